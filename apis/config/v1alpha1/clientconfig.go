@@ -39,6 +39,19 @@ func (c *ClientConfig) GetCurrentServer() (*Server, error) {
 	return nil, fmt.Errorf("current server %q not found", c.CurrentServer)
 }
 
+// GetCurrentContext returns the current context, or nil if no context is in use.
+func (c *ClientConfig) GetCurrentContext() (*Context, error) {
+	if c.CurrentContext == "" {
+		return nil, nil
+	}
+	for _, ctx := range c.KnownContexts {
+		if ctx.Name == c.CurrentContext {
+			return ctx, nil
+		}
+	}
+	return nil, fmt.Errorf("current context %q not found", c.CurrentContext)
+}
+
 // SetUnstableVersionSelector will help determine the unstable versions supported
 // In order of restrictiveness:
 // "all" -> "alpha" -> "experimental" -> "none"
@@ -84,6 +84,25 @@ type GlobalServerAuth struct {
 	Type string `json:"type" yaml:"type"`
 }
 
+// Context is a named way of working with a server, made of the credentials to use
+// and the default namespace.
+type Context struct {
+	// Name of the context.
+	Name string `json:"name,omitempty" yaml:"name"`
+
+	// Server is the name of the known server the context targets.
+	Server string `json:"server,omitempty" yaml:"server"`
+
+	// Kubeconfig is the path to the kubeconfig holding the credentials, defaults to the kubeconfig of the server.
+	Kubeconfig string `json:"kubeconfig,omitempty" yaml:"kubeconfig"`
+
+	// KubeContext is the kubeconfig context to use, defaults to the context of the server.
+	KubeContext string `json:"kubeContext,omitempty" yaml:"kubeContext"`
+
+	// Namespace is the default namespace of the context.
+	Namespace string `json:"namespace,omitempty" yaml:"namespace"`
+}
+
 // ClientOptions are the client specific options.
 type ClientOptions struct {
 	// CLI options specific to the CLI.
@@ -96,6 +115,16 @@ type CLIOptions struct {
 	Repositories []PluginRepository `json:"repositories,omitempty" yaml:"repositories"`
 	// UnstableVersionSelector determined which version tags are allowed
 	UnstableVersionSelector VersionSelectorLevel `json:"unstableVersionSelector,omitempty" yaml:"unstableVersionSelector"`
+	// Plugins are the options of individual plugins, by plugin name.
+	Plugins map[string]*PluginOptions `json:"plugins,omitempty" yaml:"plugins"`
+}
+
+// PluginOptions are the options the CLI applies when running a plugin.
+type PluginOptions struct {
+	// Env are environment variables set for the plugin.
+	Env map[string]string `json:"env,omitempty" yaml:"env"`
+	// Flags are default flag values of the plugin commands, by flag name.
+	Flags map[string]string `json:"flags,omitempty" yaml:"flags"`
 }
 
 // PluginRepository is a CLI plugin repository
@@ -123,12 +152,21 @@ type ClientConfig struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
+	// SchemaVersion is the version of the schema the config is persisted with.
+	SchemaVersion int `json:"schemaVersion,omitempty" yaml:"schemaVersion"`
+
 	// KnownServers available.
 	KnownServers []*Server `json:"servers,omitempty" yaml:"servers"`
 
 	// CurrentServer in use.
 	CurrentServer string `json:"current,omitempty" yaml:"current"`
 
+	// KnownContexts available.
+	KnownContexts []*Context `json:"contexts,omitempty" yaml:"contexts"`
+
+	// CurrentContext in use.
+	CurrentContext string `json:"currentContext,omitempty" yaml:"currentContext"`
+
 	// ClientOptions are client specific options.
 	ClientOptions *ClientOptions `json:"clientOptions,omitempty" yaml:"clientOptions"`
 }
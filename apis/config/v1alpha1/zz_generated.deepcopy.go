@@ -21,6 +21,21 @@ func (in *CLIOptions) DeepCopyInto(out *CLIOptions) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make(map[string]*PluginOptions, len(*in))
+		for key, val := range *in {
+			var outVal *PluginOptions
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(PluginOptions)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CLIOptions.
@@ -49,6 +64,17 @@ func (in *ClientConfig) DeepCopyInto(out *ClientConfig) {
 			}
 		}
 	}
+	if in.KnownContexts != nil {
+		in, out := &in.KnownContexts, &out.KnownContexts
+		*out = make([]*Context, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Context)
+				**out = **in
+			}
+		}
+	}
 	if in.ClientOptions != nil {
 		in, out := &in.ClientOptions, &out.ClientOptions
 		*out = new(ClientOptions)
@@ -126,6 +152,21 @@ func (in *ClientOptions) DeepCopy() *ClientOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Context) DeepCopyInto(out *Context) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Context.
+func (in *Context) DeepCopy() *Context {
+	if in == nil {
+		return nil
+	}
+	out := new(Context)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Feature) DeepCopyInto(out *Feature) {
 	*out = *in
@@ -412,6 +453,35 @@ func (in *ManagementClusterServer) DeepCopy() *ManagementClusterServer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginOptions) DeepCopyInto(out *PluginOptions) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginOptions.
+func (in *PluginOptions) DeepCopy() *PluginOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PluginRepository) DeepCopyInto(out *PluginRepository) {
 	*out = *in
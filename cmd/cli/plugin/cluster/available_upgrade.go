@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 
 	configv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
@@ -46,6 +47,8 @@ func init() {
 }
 
 func availableUpgrades(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &au.namespace)
+
 	server, err := config.GetCurrentServer()
 	if err != nil {
 		return err
@@ -0,0 +1,11 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import "github.com/spf13/cobra"
+
+var certificatesCmd = &cobra.Command{
+	Use:   "certificates",
+	Short: "Cluster certificates operations",
+}
@@ -0,0 +1,94 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
+)
+
+type listCertificatesOptions struct {
+	namespace    string
+	outputFormat string
+}
+
+var lco = &listCertificatesOptions{}
+
+var certificatesListCmd = &cobra.Command{
+	Use:   "list CLUSTER_NAME",
+	Short: "List the certificates of a cluster with their expiry",
+	Long: `List the certificate authorities of a cluster and the client certificate of its admin kubeconfig,
+read from the cluster secrets on the management cluster, and the serving certificate of the API server
+of each control plane machine, read with a TLS handshake to the machine, with their expiry.
+The machines which cannot be reached from the CLI are skipped with a warning.`,
+	Args: cobra.ExactArgs(1),
+	RunE: listCertificates,
+}
+
+func init() {
+	certificatesListCmd.Flags().StringVarP(&lco.namespace, "namespace", "n", "", "The namespace where the cluster was created. Assumes 'default' if not specified.")
+	certificatesListCmd.Flags().StringVarP(&lco.outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+
+	certificatesCmd.AddCommand(certificatesListCmd)
+}
+
+func listCertificates(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &lco.namespace)
+
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return err
+	}
+
+	if server.IsGlobal() {
+		return errors.New("listing the cluster certificates with a global server is not implemented yet")
+	}
+
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	certificates, err := tkgctlClient.ListClusterCertificates(tkgctl.ListClusterCertificatesOptions{
+		ClusterName: args[0],
+		Namespace:   lco.namespace,
+	})
+	if err != nil {
+		return err
+	}
+
+	if lco.outputFormat == string(component.JSONOutputType) || lco.outputFormat == string(component.YAMLOutputType) {
+		component.NewObjectWriter(cmd.OutOrStdout(), lco.outputFormat, certificates).Render()
+		return nil
+	}
+	t := component.NewOutputWriter(cmd.OutOrStdout(), lco.outputFormat, "NAME", "SECRET", "MACHINE", "SUBJECT", "EXPIRES", "RESIDUAL TIME")
+	for i := range certificates {
+		t.AddRow(certificates[i].Name, certificates[i].Secret, certificates[i].Machine, certificates[i].Subject,
+			certificates[i].NotAfter.UTC().Format(time.RFC3339), residualTime(certificates[i].NotAfter))
+	}
+	t.Render()
+	return nil
+}
+
+// residualTime returns the time left until the expiry in days, or "expired"
+func residualTime(notAfter time.Time) string {
+	remaining := time.Until(notAfter)
+	if remaining <= 0 {
+		return "expired"
+	}
+	days := int(remaining.Hours() / 24)
+	if days == 0 {
+		return "<1d"
+	}
+	return fmt.Sprintf("%dd", days)
+}
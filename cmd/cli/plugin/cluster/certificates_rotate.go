@@ -0,0 +1,68 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
+)
+
+type rotateCertificatesOptions struct {
+	namespace  string
+	unattended bool
+	timeout    time.Duration
+}
+
+var rco = &rotateCertificatesOptions{}
+
+var certificatesRotateCmd = &cobra.Command{
+	Use:   "rotate CLUSTER_NAME",
+	Short: "Rotate the certificates of the control plane nodes of a cluster",
+	Long: `Rotate the kubeadm certificates of the control plane nodes of a cluster by rolling out its control plane:
+each control plane machine is replaced with a machine bootstrapped with new certificates. The command
+waits until all the control plane machines are replaced and the cluster is ready.`,
+	Args: cobra.ExactArgs(1),
+	RunE: rotateCertificates,
+}
+
+func init() {
+	certificatesRotateCmd.Flags().StringVarP(&rco.namespace, "namespace", "n", "", "The namespace where the cluster was created. Assumes 'default' if not specified.")
+	certificatesRotateCmd.Flags().BoolVarP(&rco.unattended, "yes", "y", false, "Rotate the certificates without asking for confirmation")
+	certificatesRotateCmd.Flags().DurationVarP(&rco.timeout, "timeout", "t", constants.DefaultLongRunningOperationTimeout, "Time duration to wait for the control plane rollout before timeout. Timeout duration in hours(h)/minutes(m)/seconds(s) units or as some combination of them (e.g. 2h, 30m, 2h30m10s)")
+
+	certificatesCmd.AddCommand(certificatesRotateCmd)
+}
+
+func rotateCertificates(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &rco.namespace)
+
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return err
+	}
+
+	if server.IsGlobal() {
+		return errors.New("rotating the cluster certificates with a global server is not implemented yet")
+	}
+
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	return tkgctlClient.RotateClusterCertificates(tkgctl.RotateClusterCertificatesOptions{
+		ClusterName: args[0],
+		Namespace:   rco.namespace,
+		SkipPrompt:  rco.unattended,
+		Timeout:     rco.timeout,
+	})
+}
@@ -0,0 +1,13 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var clusterConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Cluster configuration file operations",
+}
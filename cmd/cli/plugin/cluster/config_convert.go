@@ -0,0 +1,77 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterspec"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+)
+
+type convertClusterConfigOptions struct {
+	clusterConfigFile string
+	outputFile        string
+}
+
+var ccc = &convertClusterConfigOptions{}
+
+var convertClusterConfigCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Convert a flat cluster configuration file into a cluster spec file",
+	Long: `Convert a flat cluster configuration file into a cluster spec file.
+Config variables without a cluster spec setting are kept in spec.variables.`,
+	Example: `
+    # Print the cluster spec of a cluster configuration file
+    tanzu cluster config convert -f cluster-config.yaml
+
+    # Write the cluster spec to a file and create a cluster from it
+    tanzu cluster config convert -f cluster-config.yaml --output-file cluster-spec.yaml
+    tanzu cluster create -f cluster-spec.yaml`,
+	Args: cobra.NoArgs,
+	RunE: convertClusterConfig,
+}
+
+func init() {
+	convertClusterConfigCmd.Flags().StringVarP(&ccc.clusterConfigFile, "file", "f", "", "Cluster configuration file to convert")
+	convertClusterConfigCmd.Flags().StringVar(&ccc.outputFile, "output-file", "", "File to write the cluster spec to, the cluster spec is printed if not specified")
+	convertClusterConfigCmd.MarkFlagRequired("file") //nolint
+
+	clusterConfigCmd.AddCommand(convertClusterConfigCmd)
+}
+
+func convertClusterConfig(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(ccc.clusterConfigFile)
+	if err != nil {
+		return errors.Wrap(err, "unable to read cluster configuration file")
+	}
+	if clusterspec.IsClusterSpec(data) {
+		return errors.Errorf("'%s' is already a cluster spec file", ccc.clusterConfigFile)
+	}
+
+	variables, err := clusterspec.ParseConfigVariables(data)
+	if err != nil {
+		return err
+	}
+	spec := clusterspec.FromConfigVariables(variables)
+	if err := spec.Validate(); err != nil {
+		return errors.Wrap(err, "unable to convert cluster configuration file")
+	}
+	specBytes, err := clusterspec.Marshal(spec)
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal cluster spec")
+	}
+
+	if ccc.outputFile == "" {
+		_, err = cmd.OutOrStdout().Write(specBytes)
+		return err
+	}
+	if err := os.WriteFile(ccc.outputFile, specBytes, constants.ConfigFilePermissions); err != nil {
+		return errors.Wrap(err, "unable to write cluster spec file")
+	}
+	return nil
+}
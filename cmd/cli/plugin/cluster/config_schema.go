@@ -0,0 +1,45 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
+)
+
+type clusterConfigSchemaOptions struct {
+	outputFormat string
+}
+
+var ccs = &clusterConfigSchemaOptions{}
+
+var clusterConfigSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the schema of the cluster configuration variables",
+	Long: `Print the schema of the cluster configuration variables supported by the installed providers,
+including their type, default value, allowed values, infrastructure providers and deprecation.`,
+	Args: cobra.NoArgs,
+	RunE: printClusterConfigSchema,
+}
+
+func init() {
+	clusterConfigSchemaCmd.Flags().StringVarP(&ccs.outputFormat, "output", "o", string(component.YAMLOutputType), "Output format (yaml|json)")
+
+	clusterConfigCmd.AddCommand(clusterConfigSchemaCmd)
+}
+
+func printClusterConfigSchema(cmd *cobra.Command, args []string) error {
+	tkgctlClient, err := createTKGClient("", "")
+	if err != nil {
+		return err
+	}
+
+	schema, err := tkgctlClient.GetClusterConfigSchema()
+	if err != nil {
+		return err
+	}
+	component.NewObjectWriter(cmd.OutOrStdout(), ccs.outputFormat, schema).Render()
+	return nil
+}
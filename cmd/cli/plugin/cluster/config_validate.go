@@ -0,0 +1,84 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgconfigschema"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
+)
+
+type validateClusterConfigOptions struct {
+	clusterConfigFile string
+	outputFormat      string
+}
+
+var vcc = &validateClusterConfigOptions{}
+
+var validateClusterConfigCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate a cluster configuration file",
+	Long: `Validate a cluster configuration file or cluster spec file against the config variables
+supported by the installed providers, reporting unknown variables, invalid values,
+variables of other infrastructure providers and deprecated variables at once.`,
+	Example: `
+    # Validate a cluster configuration file
+    tanzu cluster config validate -f cluster-config.yaml`,
+	Args: cobra.NoArgs,
+	RunE: validateClusterConfig,
+}
+
+func init() {
+	validateClusterConfigCmd.Flags().StringVarP(&vcc.clusterConfigFile, "file", "f", "", "Cluster configuration file to validate")
+	validateClusterConfigCmd.Flags().StringVarP(&vcc.outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	validateClusterConfigCmd.MarkFlagRequired("file") //nolint
+
+	clusterConfigCmd.AddCommand(validateClusterConfigCmd)
+}
+
+func validateClusterConfig(cmd *cobra.Command, args []string) error {
+	tkgctlClient, err := createTKGClient("", "")
+	if err != nil {
+		return err
+	}
+
+	issues, err := tkgctlClient.ValidateClusterConfig(tkgctl.ValidateClusterConfigOptions{
+		ClusterConfigFile: vcc.clusterConfigFile,
+	})
+	if err != nil {
+		return err
+	}
+	printConfigIssues(cmd.OutOrStdout(), issues, vcc.outputFormat)
+
+	errorCount := 0
+	for i := range issues {
+		if issues[i].Severity == tkgconfigschema.SeverityError {
+			errorCount++
+		}
+	}
+	if errorCount != 0 {
+		return errors.Errorf("cluster configuration file '%s' has %d errors", vcc.clusterConfigFile, errorCount)
+	}
+	return nil
+}
+
+func printConfigIssues(out io.Writer, issues []tkgconfigschema.Issue, outputFormat string) {
+	if outputFormat == string(component.JSONOutputType) || outputFormat == string(component.YAMLOutputType) {
+		component.NewObjectWriter(out, outputFormat, issues).Render()
+		return
+	}
+	if len(issues) == 0 {
+		return
+	}
+	t := component.NewOutputWriter(out, outputFormat, "VARIABLE", "SEVERITY", "MESSAGE")
+	for i := range issues {
+		t.AddRow(issues[i].Variable, issues[i].Severity, issues[i].Message)
+	}
+	t.Render()
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -22,6 +23,7 @@ import (
 	tkr "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkr/controllers/source"
 	tkrutils "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkr/pkg/utils"
 
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
 )
 
@@ -39,6 +41,8 @@ type createClusterOptions struct {
 	enableClusterOptions        string
 	vsphereControlPlaneEndpoint string
 	clusterConfigFile           string
+	profile                     string
+	configOverrides             []string
 	tkrName                     string
 	controlPlaneMachineCount    int
 	workerMachineCount          int
@@ -57,6 +61,9 @@ var createClusterCmd = &cobra.Command{
 
 func init() {
 	createClusterCmd.Flags().StringVarP(&cc.clusterConfigFile, "file", "f", "", "Configuration file from which to create a cluster")
+	createClusterCmd.Flags().StringVarP(&cc.profile, "profile", "", "", "Name of the cluster profile from which to create a cluster, cannot be used with --file")
+	createClusterCmd.Flags().StringArrayVarP(&cc.configOverrides, "set", "", nil, "Configuration variable overriding the configuration file or profile, in the KEY=VALUE format. Can be specified multiple times")
+	createClusterCmd.Flags().StringVar(&progressFormat, "progress-format", "", "Format of the progress of the cluster creation (text|json). With json, typed progress events are written to stdout as newline-delimited JSON")
 	createClusterCmd.Flags().StringVarP(&cc.tkrName, "tkr", "", "", "TanzuKubernetesRelease(TKr) to be used for creating the workload cluster. If TKr name prefix is provided, the latest compatible TKr matching the TKr name prefix would be used")
 
 	createClusterCmd.Flags().StringVarP(&cc.plan, "plan", "p", "", "The plan to be used for creating the workload cluster")
@@ -100,6 +107,12 @@ func aliasNormalizeFunc(f *pflag.FlagSet, name string) pflag.NormalizedName {
 }
 
 func create(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &cc.namespace)
+
+	if err := tkgctl.ValidateProgressFormat(progressFormat); err != nil {
+		return err
+	}
+
 	server, err := config.GetCurrentServer()
 	if err != nil {
 		// if current server does not exist and user is using generate only
@@ -133,6 +146,11 @@ func createCluster(clusterName string, server *v1alpha1.Server) error {
 		return err
 	}
 
+	configOverrides, err := parseConfigOverrides(cc.configOverrides)
+	if err != nil {
+		return err
+	}
+
 	tkrVersion := ""
 	if cc.tkrName != "" {
 		clusterClientOptions := clusterclient.Options{GetClientInterval: 2 * time.Second, GetClientTimeout: 5 * time.Second}
@@ -149,6 +167,8 @@ func createCluster(clusterName string, server *v1alpha1.Server) error {
 
 	ccOptions := tkgctl.CreateClusterOptions{
 		ClusterConfigFile:           cc.clusterConfigFile,
+		Profile:                     cc.profile,
+		ConfigOverrides:             configOverrides,
 		TkrVersion:                  tkrVersion,
 		ClusterName:                 clusterName,
 		Namespace:                   cc.namespace,
@@ -171,6 +191,20 @@ func createCluster(clusterName string, server *v1alpha1.Server) error {
 	return tkgctlClient.CreateCluster(ccOptions)
 }
 
+// parseConfigOverrides parses the KEY=VALUE config variable overrides, the config variable names are upper case
+func parseConfigOverrides(overrides []string) (map[string]string, error) {
+	configOverrides := map[string]string{}
+	for _, override := range overrides {
+		parts := strings.SplitN(override, "=", 2)
+		name := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || name == "" {
+			return nil, errors.Errorf("invalid config variable override '%s', expected KEY=VALUE", override)
+		}
+		configOverrides[strings.ToUpper(name)] = parts[1]
+	}
+	return configOverrides, nil
+}
+
 func getTkrVersionForMatchingTkr(clusterClient clusterclient.Client, tkrName string) (string, error) {
 	// get all the TKRs with tkrName prefix matching
 	tkrs, err := clusterClient.GetTanzuKubernetesReleases(tkrName)
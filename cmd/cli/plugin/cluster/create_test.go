@@ -99,3 +99,21 @@ func getFakeTKR(tkrName, k8sversion string, compatibleStatus corev1.ConditionSta
 	}
 	return tkr
 }
+
+var _ = Describe("parseConfigOverrides", func() {
+	It("should parse the KEY=VALUE overrides", func() {
+		overrides, err := parseConfigOverrides([]string{"worker_machine_count=2", "ENABLE_AUDIT_LOGGING=true", "TKG_NO_PROXY=a=b,c", "CNI="})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(overrides).To(Equal(map[string]string{
+			"WORKER_MACHINE_COUNT": "2",
+			"ENABLE_AUDIT_LOGGING": "true",
+			"TKG_NO_PROXY":         "a=b,c",
+			"CNI":                  "",
+		}))
+	})
+
+	It("should return an error if an override is not in the KEY=VALUE format", func() {
+		_, err := parseConfigOverrides([]string{"WORKER_MACHINE_COUNT"})
+		Expect(err).To(MatchError("invalid config variable override 'WORKER_MACHINE_COUNT', expected KEY=VALUE"))
+	})
+})
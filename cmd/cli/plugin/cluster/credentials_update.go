@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
 
@@ -39,6 +40,8 @@ func init() {
 }
 
 func updateCredentials(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &updateCredentialsOpts.namespace)
+
 	server, err := config.GetCurrentServer()
 	if err != nil {
 		return err
@@ -5,18 +5,25 @@ package main
 
 import (
 	"errors"
+	"fmt"
+	"io"
 
 	"github.com/spf13/cobra"
 
 	"github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
 
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
 )
 
 type deleteClustersOptions struct {
-	namespace  string
-	unattended bool
+	namespace    string
+	outputFormat string
+	unattended   bool
+	dryRun       bool
 }
 
 var dc = &deleteClustersOptions{}
@@ -31,9 +38,13 @@ var deleteClusterCmd = &cobra.Command{
 func init() {
 	deleteClusterCmd.Flags().StringVarP(&dc.namespace, "namespace", "n", "", "The namespace where the workload cluster was created. Assumes 'default' if not specified.")
 	deleteClusterCmd.Flags().BoolVarP(&dc.unattended, "yes", "y", false, "Delete workload cluster without asking for confirmation")
+	deleteClusterCmd.Flags().BoolVar(&dc.dryRun, "dry-run", false, "List the objects the deletion would remove without deleting the cluster")
+	deleteClusterCmd.Flags().StringVarP(&dc.outputFormat, "output", "o", "", "Output format of the dry run (yaml|json|table)")
 }
 
 func deleteCmd(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &dc.namespace)
+
 	server, err := config.GetCurrentServer()
 	if err != nil {
 		return err
@@ -42,10 +53,10 @@ func deleteCmd(cmd *cobra.Command, args []string) error {
 	if server.IsGlobal() {
 		return errors.New("deleting cluster with a global server is not implemented yet")
 	}
-	return deleteCluster(server, args[0])
+	return deleteCluster(cmd.OutOrStdout(), server, args[0])
 }
 
-func deleteCluster(server *v1alpha1.Server, clusterName string) error {
+func deleteCluster(out io.Writer, server *v1alpha1.Server, clusterName string) error {
 	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
 	if err != nil {
 		return err
@@ -57,5 +68,37 @@ func deleteCluster(server *v1alpha1.Server, clusterName string) error {
 		SkipPrompt:  dc.unattended,
 	}
 
+	if dc.dryRun {
+		plan, err := tkgctlClient.PlanClusterDeletion(deleteClusterOptions)
+		if err != nil {
+			return err
+		}
+		printDeletePlan(out, plan, dc.outputFormat)
+		return nil
+	}
+
 	return tkgctlClient.DeleteCluster(deleteClusterOptions)
 }
+
+// printDeletePlan prints the objects the deletion removes, or the plan itself in yaml and json output formats.
+func printDeletePlan(out io.Writer, plan *client.DeleteClusterPlan, outputFormat string) {
+	if outputFormat == string(component.JSONOutputType) || outputFormat == string(component.YAMLOutputType) {
+		component.NewObjectWriter(out, outputFormat, plan).Render()
+		return
+	}
+
+	if plan.Protected {
+		fmt.Fprintf(out, "Cluster '%s' in namespace '%s' is protected from deletion, the following objects would be removed once the protection is disabled\n\n", plan.ClusterName, plan.Namespace)
+	} else {
+		fmt.Fprintf(out, "Deleting cluster '%s' in namespace '%s' would remove the following objects\n\n", plan.ClusterName, plan.Namespace)
+	}
+	t := component.NewOutputWriter(out, outputFormat, "LOCATION", "KIND", "NAMESPACE", "NAME")
+	for _, obj := range plan.Objects {
+		t.AddRow(obj.Location, obj.Kind, obj.Namespace, obj.Name)
+	}
+	t.Render()
+
+	for _, warning := range plan.Warnings {
+		fmt.Fprintf(out, "\nWarning: %s\n", warning)
+	}
+}
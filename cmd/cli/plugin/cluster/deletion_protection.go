@@ -0,0 +1,77 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
+)
+
+type deletionProtectionOptions struct {
+	namespace string
+}
+
+var dpo = &deletionProtectionOptions{}
+
+var deletionProtectionCmd = &cobra.Command{
+	Use:   "deletion-protection",
+	Short: "Deletion protection operations for a cluster",
+	Long: `Enable or disable the deletion protection of a cluster. A protected cluster cannot be
+deleted, as a workload cluster or as a management cluster, until its protection is disabled.`,
+}
+
+var enableDeletionProtectionCmd = &cobra.Command{
+	Use:   "enable CLUSTER_NAME",
+	Short: "Protect a cluster from deletion",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setDeletionProtection(cmd, args[0], true)
+	},
+}
+
+var disableDeletionProtectionCmd = &cobra.Command{
+	Use:   "disable CLUSTER_NAME",
+	Short: "Remove the deletion protection of a cluster",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setDeletionProtection(cmd, args[0], false)
+	},
+}
+
+func init() {
+	deletionProtectionCmd.PersistentFlags().StringVarP(&dpo.namespace, "namespace", "n", "", "The namespace where the cluster was created. Assumes 'default' if not specified.")
+
+	deletionProtectionCmd.AddCommand(enableDeletionProtectionCmd)
+	deletionProtectionCmd.AddCommand(disableDeletionProtectionCmd)
+}
+
+func setDeletionProtection(cmd *cobra.Command, clusterName string, enabled bool) error {
+	cli.ApplyContextNamespace(cmd, &dpo.namespace)
+
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return err
+	}
+
+	if server.IsGlobal() {
+		return errors.New("setting the deletion protection with a global server is not implemented yet")
+	}
+
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	return tkgctlClient.SetClusterDeletionProtection(tkgctl.SetClusterDeletionProtectionOptions{
+		ClusterName: clusterName,
+		Namespace:   dpo.namespace,
+		Enabled:     enabled,
+	})
+}
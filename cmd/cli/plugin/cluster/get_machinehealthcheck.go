@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
 
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
@@ -38,6 +39,8 @@ func init() {
 }
 
 func getMachineHealthCheck(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &getMHC.namespace)
+
 	server, err := config.GetCurrentServer()
 	if err != nil {
 		return err
@@ -0,0 +1,78 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
+)
+
+type clusterHealthOptions struct {
+	namespace    string
+	outputFormat string
+}
+
+var cheo = &clusterHealthOptions{}
+
+var clusterHealthCmd = &cobra.Command{
+	Use:   "health CLUSTER_NAME",
+	Short: "Show a health report of a cluster",
+	Long: `Show a health report of a cluster combining the conditions of the cluster and of its control plane,
+the readiness of its machines and nodes, the remediations of its machine health checks, the reconciliation
+of its addons and package installs, and the expiry of its certificates. Each check passes, warns or fails,
+and the cluster health is the worst status of its checks.`,
+	Args: cobra.ExactArgs(1),
+	RunE: clusterHealth,
+}
+
+func init() {
+	clusterHealthCmd.Flags().StringVarP(&cheo.namespace, "namespace", "n", "", "The namespace where the cluster was created. Assumes 'default' if not specified.")
+	clusterHealthCmd.Flags().StringVarP(&cheo.outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+}
+
+func clusterHealth(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &cheo.namespace)
+
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return err
+	}
+
+	if server.IsGlobal() {
+		return errors.New("getting the cluster health with a global server is not implemented yet")
+	}
+
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	report, err := tkgctlClient.GetClusterHealth(tkgctl.GetClusterHealthOptions{
+		ClusterName: args[0],
+		Namespace:   cheo.namespace,
+	})
+	if err != nil {
+		return err
+	}
+
+	if cheo.outputFormat == string(component.JSONOutputType) || cheo.outputFormat == string(component.YAMLOutputType) {
+		component.NewObjectWriter(cmd.OutOrStdout(), cheo.outputFormat, report).Render()
+		return nil
+	}
+	t := component.NewOutputWriter(cmd.OutOrStdout(), cheo.outputFormat, "CATEGORY", "NAME", "STATUS", "MESSAGE")
+	for i := range report.Checks {
+		t.AddRow(report.Checks[i].Category, report.Checks[i].Name, report.Checks[i].Status, report.Checks[i].Message)
+	}
+	t.Render()
+	fmt.Fprintf(cmd.OutOrStdout(), "\nCluster %s/%s health: %s\n", report.Namespace, report.ClusterName, report.Status)
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
+)
+
+type hibernateClusterOptions struct {
+	namespace  string
+	unattended bool
+}
+
+var hco = &hibernateClusterOptions{}
+
+var hibernateClusterCmd = &cobra.Command{
+	Use:   "hibernate CLUSTER_NAME",
+	Short: "Scale the workers of a cluster to zero until it is resumed",
+	Long: `Scale the workers of a cluster to zero and pause its cluster autoscaler. The replicas of its node pools
+and of its cluster autoscaler are recorded on the cluster for 'tanzu cluster resume' to restore them.
+The control plane of the cluster keeps running.`,
+	Args: cobra.ExactArgs(1),
+	RunE: hibernate,
+}
+
+func init() {
+	hibernateClusterCmd.Flags().StringVarP(&hco.namespace, "namespace", "n", "", "The namespace where the workload cluster was created. Assumes 'default' if not specified.")
+	hibernateClusterCmd.Flags().BoolVarP(&hco.unattended, "yes", "y", false, "Hibernate the cluster without asking for confirmation")
+}
+
+func hibernate(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &hco.namespace)
+
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return err
+	}
+
+	if server.IsGlobal() {
+		return errors.New("hibernating cluster with a global server is not implemented yet")
+	}
+
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	return tkgctlClient.HibernateCluster(tkgctl.HibernateClusterOptions{
+		ClusterName: args[0],
+		Namespace:   hco.namespace,
+		SkipPrompt:  hco.unattended,
+	})
+}
@@ -0,0 +1,74 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
+)
+
+type clusterHistoryOptions struct {
+	namespace    string
+	outputFormat string
+}
+
+var cho = &clusterHistoryOptions{}
+
+var clusterHistoryCmd = &cobra.Command{
+	Use:   "history CLUSTER_NAME",
+	Short: "Show the lifecycle operations run on a cluster",
+	Long: `Show the most recent lifecycle operations (create, scale, upgrade, credentials update,
+machine health check changes) run on a cluster, with the user and CLI version that ran them.`,
+	Args: cobra.ExactArgs(1),
+	RunE: clusterHistory,
+}
+
+func init() {
+	clusterHistoryCmd.Flags().StringVarP(&cho.namespace, "namespace", "n", "", "The namespace where the cluster was created. Assumes 'default' if not specified.")
+	clusterHistoryCmd.Flags().StringVarP(&cho.outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+}
+
+func clusterHistory(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &cho.namespace)
+
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return err
+	}
+
+	if server.IsGlobal() {
+		return errors.New("getting the cluster history with a global server is not implemented yet")
+	}
+
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	history, err := tkgctlClient.GetClusterHistory(tkgctl.GetClusterHistoryOptions{
+		ClusterName: args[0],
+		Namespace:   cho.namespace,
+	})
+	if err != nil {
+		return err
+	}
+
+	if cho.outputFormat == string(component.JSONOutputType) || cho.outputFormat == string(component.YAMLOutputType) {
+		component.NewObjectWriter(cmd.OutOrStdout(), cho.outputFormat, history).Render()
+		return nil
+	}
+	t := component.NewOutputWriter(cmd.OutOrStdout(), cho.outputFormat, "OPERATION", "USER", "CLI VERSION", "STARTED", "ENDED", "RESULT", "MESSAGE")
+	for i := range history {
+		t.AddRow(history[i].Operation, history[i].User, history[i].CLIVersion, history[i].StartTimestamp, history[i].EndTimestamp, history[i].Result, history[i].Message)
+	}
+	t.Render()
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
 	tkgauth "github.com/vmware-tanzu/tanzu-framework/pkg/v1/auth/tkg"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
 
 	tkgclient "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
@@ -49,6 +50,8 @@ func init() {
 }
 
 func getKubeconfig(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &getKCOptions.namespace)
+
 	workloadClusterName := args[0]
 
 	server, err := config.GetCurrentServer()
@@ -5,7 +5,9 @@ package main
 
 import (
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -13,13 +15,17 @@ import (
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
 
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/region"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
 )
 
 type listClusterOptions struct {
-	namespace    string
-	includeMC    bool
-	outputFormat string
+	namespace             string
+	includeMC             bool
+	outputFormat          string
+	allManagementClusters bool
+	timeout               time.Duration
 }
 
 var lc = &listClusterOptions{}
@@ -34,9 +40,15 @@ func init() {
 	listClustersCmd.Flags().StringVarP(&lc.namespace, "namespace", "n", "", "The namespace from which to list workload clusters. If not provided clusters from all namespaces will be returned")
 	listClustersCmd.Flags().BoolVarP(&lc.includeMC, "include-management-cluster", "", false, "Show active management cluster information as well")
 	listClustersCmd.Flags().StringVarP(&lc.outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	listClustersCmd.Flags().BoolVarP(&lc.allManagementClusters, "all-management-clusters", "", false, "List the clusters of all the management clusters")
+	listClustersCmd.Flags().DurationVarP(&lc.timeout, "timeout", "", client.DefaultListClustersRegionTimeout, "Time duration to wait for the clusters of each management cluster with --all-management-clusters")
 }
 
 func list(cmd *cobra.Command, args []string) error {
+	if lc.allManagementClusters {
+		return listClustersInAllManagementClusters(cmd)
+	}
+
 	server, err := config.GetCurrentServer()
 	if err != nil {
 		return err
@@ -66,20 +78,75 @@ func listClusters(cmd *cobra.Command, server *v1alpha1.Server) error {
 		return err
 	}
 
+	renderClusters(cmd, clusters, false)
+	return nil
+}
+
+// listClustersInAllManagementClusters lists the clusters of the management clusters of the tanzu config,
+// the management clusters which cannot be reached are reported without failing the listing
+func listClustersInAllManagementClusters(cmd *cobra.Command) error {
+	cfg, err := config.GetClientConfig()
+	if err != nil {
+		return err
+	}
+	managementClusters := []region.RegionContext{}
+	for _, server := range cfg.KnownServers {
+		if server.IsManagementCluster() {
+			managementClusters = append(managementClusters, region.RegionContext{
+				ClusterName:      server.Name,
+				ContextName:      server.ManagementClusterOpts.Context,
+				SourceFilePath:   server.ManagementClusterOpts.Path,
+				IsCurrentContext: server.Name == cfg.CurrentServer,
+			})
+		}
+	}
+	if len(managementClusters) == 0 {
+		return errors.New("no management cluster found in the tanzu config")
+	}
+
+	tkgctlClient, err := createTKGClient("", "")
+	if err != nil {
+		return err
+	}
+
+	clusters, unreachable, err := tkgctlClient.GetClustersInAllManagementClusters(tkgctl.ListAllTKGClustersOptions{
+		Namespace:          lc.namespace,
+		IncludeMC:          lc.includeMC,
+		ManagementClusters: managementClusters,
+		Timeout:            lc.timeout,
+	})
+	if err != nil {
+		return err
+	}
+
+	renderClusters(cmd, clusters, true)
+	for _, r := range unreachable {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: unable to list the clusters of management cluster '%s': %s\n", r.ManagementCluster, r.Error)
+	}
+	return nil
+}
+
+func renderClusters(cmd *cobra.Command, clusters []client.ClusterInfo, showManagementCluster bool) {
 	var t component.OutputWriter
 	if lc.outputFormat == string(component.JSONOutputType) || lc.outputFormat == string(component.YAMLOutputType) {
 		t = component.NewObjectWriter(cmd.OutOrStdout(), lc.outputFormat, clusters)
 	} else {
-		t = component.NewOutputWriter(cmd.OutOrStdout(), lc.outputFormat, "NAME", "NAMESPACE", "STATUS", "CONTROLPLANE", "WORKERS", "KUBERNETES", "ROLES", "PLAN")
+		columns := []string{"NAME", "NAMESPACE", "STATUS", "CONTROLPLANE", "WORKERS", "KUBERNETES", "ROLES", "PLAN"}
+		if showManagementCluster {
+			columns = append(columns, "MANAGEMENT-CLUSTER")
+		}
+		t = component.NewOutputWriter(cmd.OutOrStdout(), lc.outputFormat, columns...)
 		for _, cl := range clusters {
 			clusterRoles := "<none>"
 			if len(cl.Roles) != 0 {
 				clusterRoles = strings.Join(cl.Roles, ",")
 			}
-			t.AddRow(cl.Name, cl.Namespace, cl.Status, cl.ControlPlaneCount, cl.WorkerCount, cl.K8sVersion, clusterRoles, cl.Plan)
+			row := []interface{}{cl.Name, cl.Namespace, cl.Status, cl.ControlPlaneCount, cl.WorkerCount, cl.K8sVersion, clusterRoles, cl.Plan}
+			if showManagementCluster {
+				row = append(row, cl.ManagementCluster)
+			}
+			t.AddRow(row...)
 		}
 	}
 	t.Render()
-
-	return nil
 }
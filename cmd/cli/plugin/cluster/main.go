@@ -30,6 +30,10 @@ var descriptor = cliv1alpha1.PluginDescriptor{
 
 var logLevel int32
 var logFile string
+var logFormat string
+var logFileMaxSize int
+var logFileMaxBackups int
+var progressFormat string
 
 func main() {
 	p, err := plugin.NewPlugin(&descriptor)
@@ -39,6 +43,9 @@ func main() {
 
 	p.Cmd.PersistentFlags().Int32VarP(&logLevel, "verbose", "v", 0, "Number for the log level verbosity(0-9)")
 	p.Cmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Log file path")
+	p.Cmd.PersistentFlags().StringVar(&logFormat, "log-format", "", "Format of the log file, 'text' or 'json'")
+	p.Cmd.PersistentFlags().IntVar(&logFileMaxSize, "log-file-max-size", 0, "Size in megabytes after which the log file is rotated, 0 disables the rotation")
+	p.Cmd.PersistentFlags().IntVar(&logFileMaxBackups, "log-file-max-backups", 0, "Number of rotated log files to keep")
 
 	p.AddCommands(
 		createClusterCmd,
@@ -52,6 +59,15 @@ func main() {
 		getClustersCmd,
 		availableUpgradesCmd,
 		clusterNodePoolCmd,
+		clusterConfigCmd,
+		clusterProfileCmd,
+		deletionProtectionCmd,
+		clusterHistoryCmd,
+		clusterHealthCmd,
+		certificatesCmd,
+		hibernateClusterCmd,
+		resumeClusterCmd,
+		preflightClusterCmd,
 	)
 	if err := p.Execute(); err != nil {
 		os.Exit(1)
@@ -71,10 +87,25 @@ func createTKGClient(kubeconfig, kubecontext string) (tkgctl.TKGClient, error) {
 	if err != nil {
 		return nil, err
 	}
+	logOptions := getLoggingOptions()
+	if err := tkgctl.ValidateLoggingOptions(logOptions); err != nil {
+		return nil, err
+	}
 	return tkgctl.New(tkgctl.Options{
 		ConfigDir:   configDir,
 		KubeConfig:  kubeconfig,
 		KubeContext: kubecontext,
-		LogOptions:  tkgctl.LoggingOptions{Verbosity: logLevel, File: logFile},
+		LogOptions:  logOptions,
 	})
 }
+
+func getLoggingOptions() tkgctl.LoggingOptions {
+	return tkgctl.LoggingOptions{
+		Verbosity:         logLevel,
+		File:              logFile,
+		ProgressFormat:    progressFormat,
+		LogFormat:         logFormat,
+		LogFileMaxSize:    logFileMaxSize,
+		LogFileMaxBackups: logFileMaxBackups,
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
+)
+
+type preflightClusterOptions struct {
+	clusterConfigFile string
+	profile           string
+	configOverrides   []string
+	tkrName           string
+	namespace         string
+	skipChecks        []string
+	outputFormat      string
+}
+
+var pco = &preflightClusterOptions{}
+
+var preflightClusterCmd = &cobra.Command{
+	Use:   "preflight CLUSTER_NAME",
+	Short: "Run the preflight checks of a cluster",
+	Long: `Run the preflight checks of a workload cluster without creating it
+
+Checks the configuration, the infrastructure provider credentials, quotas and resources,
+the network reachability and the availability of the images, and reports the result of
+every check. The checks not depending on each other run concurrently, and a check is
+skipped if a check it depends on fails.`,
+	Example: `
+    # Run the preflight checks of a cluster
+    tanzu cluster preflight my-cluster --file cluster-config.yaml
+
+    # Run the preflight checks except the one of the images
+    tanzu cluster preflight my-cluster --profile small --skip-check kubernetes-images`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: preflightCluster,
+}
+
+func init() {
+	preflightClusterCmd.Flags().StringVarP(&pco.clusterConfigFile, "file", "f", "", "Configuration file of the cluster to check")
+	preflightClusterCmd.Flags().StringVarP(&pco.profile, "profile", "", "", "Name of the cluster profile of the cluster to check, cannot be used with --file")
+	preflightClusterCmd.Flags().StringArrayVarP(&pco.configOverrides, "set", "", nil, "Configuration variable overriding the configuration file or profile, in the KEY=VALUE format. Can be specified multiple times")
+	preflightClusterCmd.Flags().StringVarP(&pco.tkrName, "tkr", "", "", "TanzuKubernetesRelease(TKr) of the cluster to check. If TKr name prefix is provided, the latest compatible TKr matching the TKr name prefix would be used")
+	preflightClusterCmd.Flags().StringVarP(&pco.namespace, "namespace", "n", "", "The namespace where the cluster should be deployed. Assumes 'default' if not specified")
+	preflightClusterCmd.Flags().StringSliceVar(&pco.skipChecks, "skip-check", []string{}, "Names of the preflight checks to skip")
+	preflightClusterCmd.Flags().StringVarP(&pco.outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+}
+
+func preflightCluster(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &pco.namespace)
+
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return err
+	}
+	if server.IsGlobal() {
+		return errors.New("running the preflight checks of a cluster with a global server is not implemented yet")
+	}
+	clusterName := ""
+	if len(args) > 0 {
+		clusterName = args[0]
+	}
+
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	configOverrides, err := parseConfigOverrides(pco.configOverrides)
+	if err != nil {
+		return err
+	}
+
+	tkrVersion := ""
+	if pco.tkrName != "" {
+		clusterClientOptions := clusterclient.Options{GetClientInterval: 2 * time.Second, GetClientTimeout: 5 * time.Second}
+		clusterClient, err := clusterclient.NewClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context, clusterClientOptions)
+		if err != nil {
+			return err
+		}
+
+		tkrVersion, err = getTkrVersionForMatchingTkr(clusterClient, pco.tkrName)
+		if err != nil {
+			return err
+		}
+	}
+
+	report, err := tkgctlClient.PreflightCluster(tkgctl.PreflightClusterOptions{
+		ClusterConfigFile: pco.clusterConfigFile,
+		Profile:           pco.profile,
+		ConfigOverrides:   configOverrides,
+		ClusterName:       clusterName,
+		Namespace:         pco.namespace,
+		TkrVersion:        tkrVersion,
+		Edition:           BuildEdition,
+		SkipChecks:        pco.skipChecks,
+	})
+	if err != nil {
+		return err
+	}
+
+	if pco.outputFormat == string(component.JSONOutputType) || pco.outputFormat == string(component.YAMLOutputType) {
+		component.NewObjectWriter(cmd.OutOrStdout(), pco.outputFormat, report).Render()
+	} else {
+		t := component.NewOutputWriter(cmd.OutOrStdout(), pco.outputFormat, "CATEGORY", "NAME", "STATUS", "MESSAGE")
+		for i := range report.Checks {
+			t.AddRow(report.Checks[i].Category, report.Checks[i].Name, report.Checks[i].Status, report.Checks[i].Message)
+		}
+		t.Render()
+		fmt.Fprintf(cmd.OutOrStdout(), "\nPreflight checks: %s\n", report.Status)
+	}
+
+	if report.Status == client.PreflightStatusFail {
+		return errors.New("preflight checks failed")
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var clusterProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage named cluster profiles",
+	Long: `Manage named cluster profiles. A cluster profile is a saved cluster configuration file
+or cluster spec file which can be used to create clusters with 'tanzu cluster create --profile'.`,
+}
@@ -0,0 +1,49 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
+)
+
+type deleteClusterProfileOptions struct {
+	unattended bool
+}
+
+var dcp = &deleteClusterProfileOptions{}
+
+var deleteClusterProfileCmd = &cobra.Command{
+	Use:   "delete PROFILE_NAME",
+	Short: "Delete a cluster profile",
+	Args:  cobra.ExactArgs(1),
+	RunE:  deleteClusterProfile,
+}
+
+func init() {
+	deleteClusterProfileCmd.Flags().BoolVarP(&dcp.unattended, "yes", "y", false, "Delete the cluster profile without asking for confirmation")
+
+	clusterProfileCmd.AddCommand(deleteClusterProfileCmd)
+}
+
+func deleteClusterProfile(cmd *cobra.Command, args []string) error {
+	tkgctlClient, err := createTKGClient("", "")
+	if err != nil {
+		return err
+	}
+
+	if !dcp.unattended {
+		if err := cli.AskForConfirmation(fmt.Sprintf("Deleting cluster profile '%s'. Are you sure?", args[0])); err != nil {
+			return err
+		}
+	}
+	if err := tkgctlClient.DeleteClusterProfile(args[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Cluster profile '%s' deleted\n", args[0])
+	return nil
+}
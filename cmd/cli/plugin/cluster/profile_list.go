@@ -0,0 +1,54 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
+)
+
+type listClusterProfilesOptions struct {
+	outputFormat string
+}
+
+var lcp = &listClusterProfilesOptions{}
+
+var listClusterProfilesCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the saved cluster profiles",
+	Args:  cobra.NoArgs,
+	RunE:  listClusterProfiles,
+}
+
+func init() {
+	listClusterProfilesCmd.Flags().StringVarP(&lcp.outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+
+	clusterProfileCmd.AddCommand(listClusterProfilesCmd)
+}
+
+func listClusterProfiles(cmd *cobra.Command, args []string) error {
+	tkgctlClient, err := createTKGClient("", "")
+	if err != nil {
+		return err
+	}
+
+	profiles, err := tkgctlClient.ListClusterProfiles()
+	if err != nil {
+		return err
+	}
+
+	if lcp.outputFormat == string(component.JSONOutputType) || lcp.outputFormat == string(component.YAMLOutputType) {
+		component.NewObjectWriter(cmd.OutOrStdout(), lcp.outputFormat, profiles).Render()
+		return nil
+	}
+	t := component.NewOutputWriter(cmd.OutOrStdout(), lcp.outputFormat, "NAME", "PLAN", "INFRASTRUCTURE", "LAST MODIFIED")
+	for i := range profiles {
+		t.AddRow(profiles[i].Name, profiles[i].Plan, profiles[i].InfrastructureProvider, profiles[i].LastModified.Format(time.RFC3339))
+	}
+	t.Render()
+	return nil
+}
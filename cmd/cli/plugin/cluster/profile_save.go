@@ -0,0 +1,58 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
+)
+
+type saveClusterProfileOptions struct {
+	clusterConfigFile string
+	force             bool
+}
+
+var scp = &saveClusterProfileOptions{}
+
+var saveClusterProfileCmd = &cobra.Command{
+	Use:   "save PROFILE_NAME",
+	Short: "Save a cluster configuration file as a cluster profile",
+	Example: `
+    # Save a cluster configuration file as the profile 'small-dev'
+    tanzu cluster profile save small-dev -f cluster-config.yaml
+
+    # Create a cluster from the profile
+    tanzu cluster create my-cluster --profile small-dev --set WORKER_MACHINE_COUNT=2`,
+	Args: cobra.ExactArgs(1),
+	RunE: saveClusterProfile,
+}
+
+func init() {
+	saveClusterProfileCmd.Flags().StringVarP(&scp.clusterConfigFile, "file", "f", "", "Cluster configuration file or cluster spec file to save")
+	saveClusterProfileCmd.Flags().BoolVarP(&scp.force, "force", "", false, "Replace the profile if it already exists")
+	saveClusterProfileCmd.MarkFlagRequired("file") //nolint
+
+	clusterProfileCmd.AddCommand(saveClusterProfileCmd)
+}
+
+func saveClusterProfile(cmd *cobra.Command, args []string) error {
+	tkgctlClient, err := createTKGClient("", "")
+	if err != nil {
+		return err
+	}
+
+	profile, err := tkgctlClient.SaveClusterProfile(tkgctl.SaveClusterProfileOptions{
+		Name:              args[0],
+		ClusterConfigFile: scp.clusterConfigFile,
+		Overwrite:         scp.force,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Cluster profile '%s' saved\n", profile.Name)
+	return nil
+}
@@ -0,0 +1,33 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var showClusterProfileCmd = &cobra.Command{
+	Use:   "show PROFILE_NAME",
+	Short: "Print the content of a cluster profile",
+	Args:  cobra.ExactArgs(1),
+	RunE:  showClusterProfile,
+}
+
+func init() {
+	clusterProfileCmd.AddCommand(showClusterProfileCmd)
+}
+
+func showClusterProfile(cmd *cobra.Command, args []string) error {
+	tkgctlClient, err := createTKGClient("", "")
+	if err != nil {
+		return err
+	}
+
+	data, err := tkgctlClient.GetClusterProfile(args[0])
+	if err != nil {
+		return err
+	}
+	_, err = cmd.OutOrStdout().Write(data)
+	return err
+}
@@ -0,0 +1,62 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
+)
+
+type resumeClusterOptions struct {
+	namespace string
+	timeout   time.Duration
+}
+
+var rso = &resumeClusterOptions{}
+
+var resumeClusterCmd = &cobra.Command{
+	Use:   "resume CLUSTER_NAME",
+	Short: "Resume a hibernated cluster",
+	Long: `Restore the replicas of the node pools and of the cluster autoscaler of a cluster hibernated
+with 'tanzu cluster hibernate', and wait for its nodes to be ready.`,
+	Args: cobra.ExactArgs(1),
+	RunE: resume,
+}
+
+func init() {
+	resumeClusterCmd.Flags().StringVarP(&rso.namespace, "namespace", "n", "", "The namespace where the workload cluster was created. Assumes 'default' if not specified.")
+	resumeClusterCmd.Flags().DurationVarP(&rso.timeout, "timeout", "t", constants.DefaultLongRunningOperationTimeout, "Time duration to wait for the nodes to be ready. Timeout duration in hours(h)/minutes(m)/seconds(s) units or as some combination of them (e.g. 2h, 30m, 2h30m10s)")
+}
+
+func resume(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &rso.namespace)
+
+	server, err := config.GetCurrentServer()
+	if err != nil {
+		return err
+	}
+
+	if server.IsGlobal() {
+		return errors.New("resuming cluster with a global server is not implemented yet")
+	}
+
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	return tkgctlClient.ResumeCluster(tkgctl.ResumeClusterOptions{
+		ClusterName: args[0],
+		Namespace:   rso.namespace,
+		Timeout:     rso.timeout,
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
 )
@@ -26,7 +27,34 @@ var setNodePoolOptions clusterSetNodePoolCmdOptions
 var clusterSetNodePoolCmd = &cobra.Command{
 	Use:   "set CLUSTER_NAME",
 	Short: "Set node pool for cluster",
-	RunE:  runSetNodePool,
+	Long: `Create or update a node pool of a cluster from a file describing it.
+The minSize and maxSize of the node pool can only be set if the cluster autoscaler is enabled
+for the cluster, and its replicas must be within them. Setting the taints of an existing node pool,
+or its machine health check overrides for the first time, rolls out its machines.`,
+	Example: `
+    # Node pool file
+    name: gpu-pool
+    replicas: 2
+    nodeMachineType: p3.2xlarge
+    labels:
+      workload: gpu
+    taints:
+    - key: nvidia.com/gpu
+      value: "true"
+      effect: NoSchedule
+    minSize: 1
+    maxSize: 5
+    maxSurge: 1
+    maxUnavailable: 0
+    machineHealthCheck:
+      nodeStartupTimeout: 30m
+      unhealthyConditions:
+      - Ready:False:10m
+      - Ready:Unknown:10m
+
+    # Create or update the node pool
+    tanzu cluster node-pool set my-cluster -f gpu-pool.yaml`,
+	RunE: runSetNodePool,
 }
 
 func init() {
@@ -37,6 +65,8 @@ func init() {
 }
 
 func runSetNodePool(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &setNodePoolOptions.Namespace)
+
 	server, err := config.GetCurrentServer()
 	if err != nil {
 		return err
@@ -5,18 +5,23 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/utils"
 
 	"github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
 	runv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
 	tkrutils "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkr/pkg/utils"
@@ -31,6 +36,12 @@ type upgradeClustersOptions struct {
 	osVersion           string
 	osArch              string
 	vSphereTemplateName string
+	dryRun              bool
+	status              bool
+	outputFormat        string
+	selector            string
+	maxConcurrent       int
+	failureThreshold    int
 }
 
 var uc = &upgradeClustersOptions{}
@@ -38,7 +49,12 @@ var uc = &upgradeClustersOptions{}
 var upgradeClusterCmd = &cobra.Command{
 	Use:   "upgrade CLUSTER_NAME",
 	Short: "Upgrade a cluster",
-	Args:  cobra.ExactArgs(1),
+	Args: func(cmd *cobra.Command, args []string) error {
+		if uc.selector != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	Example: `
   # Upgrade a workload cluster
   tanzu cluster upgrade wc-1
@@ -49,6 +65,15 @@ var upgradeClusterCmd = &cobra.Command{
   # Upgrade a workload cluster with tkr prefix v1.20.1
   tanzu cluster upgrade wc-1 --tkr v1.20.1
 
+  # Show the changes an upgrade of a workload cluster would make without upgrading it
+  tanzu cluster upgrade wc-1 --tkr v1.20.1 --dry-run
+
+  # Upgrade all workload clusters labeled env=staging, 3 at a time
+  tanzu cluster upgrade --selector env=staging --max-concurrent 3
+
+  # Show the progress of the last upgrade of a workload cluster
+  tanzu cluster upgrade wc-1 --status
+
   # Upgrade a workload cluster using specific os name (vsphere)
   tanzu cluster upgrade wc-1 --os-name photon
 
@@ -77,6 +102,13 @@ func init() {
 	upgradeClusterCmd.Flags().StringVarP(&uc.namespace, "namespace", "n", "", "The namespace where the workload cluster was created. Assumes 'default' if not specified")
 	upgradeClusterCmd.Flags().DurationVarP(&uc.timeout, "timeout", "t", constants.DefaultLongRunningOperationTimeout, "Time duration to wait for an operation before timeout. Timeout duration in hours(h)/minutes(m)/seconds(s) units or as some combination of them (e.g. 2h, 30m, 2h30m10s)")
 	upgradeClusterCmd.Flags().BoolVarP(&uc.unattended, "yes", "y", false, "Upgrade workload cluster without asking for confirmation")
+	upgradeClusterCmd.Flags().BoolVar(&uc.dryRun, "dry-run", false, "Show the changes the upgrade would make to the cluster without upgrading it")
+	upgradeClusterCmd.Flags().BoolVar(&uc.status, "status", false, "Show the progress of the last upgrade of the cluster. An interrupted upgrade is resumed by upgrading the cluster to the same TKr again")
+	upgradeClusterCmd.Flags().StringVarP(&uc.outputFormat, "output", "o", "", "Output format of the dry run, the status and the batch upgrade report (yaml|json|table)")
+	upgradeClusterCmd.Flags().StringVar(&progressFormat, "progress-format", "", "Format of the progress of the upgrade (text|json). With json, typed progress events are written to stdout as newline-delimited JSON")
+	upgradeClusterCmd.Flags().StringVar(&uc.selector, "selector", "", "Upgrade all the workload clusters matching the label selector instead of a single cluster (e.g. env=staging)")
+	upgradeClusterCmd.Flags().IntVar(&uc.maxConcurrent, "max-concurrent", 1, "Number of clusters upgraded at the same time when upgrading clusters matching --selector")
+	upgradeClusterCmd.Flags().IntVar(&uc.failureThreshold, "failure-threshold", 1, "Number of failed cluster upgrades after which no more clusters are upgraded when upgrading clusters matching --selector")
 
 	upgradeClusterCmd.Flags().StringVar(&uc.osName, "os-name", "", "OS name to use during cluster upgrade. Discovered automatically if not provided (See [+])")
 	upgradeClusterCmd.Flags().StringVar(&uc.osVersion, "os-version", "", "OS version to use during cluster upgrade. Discovered automatically if not provided (See [+])")
@@ -87,6 +119,12 @@ func init() {
 }
 
 func upgrade(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &uc.namespace)
+
+	if err := tkgctl.ValidateProgressFormat(progressFormat); err != nil {
+		return err
+	}
+
 	server, err := config.GetCurrentServer()
 	if err != nil {
 		return err
@@ -95,15 +133,86 @@ func upgrade(cmd *cobra.Command, args []string) error {
 	if server.IsGlobal() {
 		return errors.New("upgrading cluster with a global server is not implemented yet")
 	}
-	return upgradeCluster(server, args[0])
+	if uc.selector != "" {
+		return upgradeClusters(cmd, server)
+	}
+	return upgradeCluster(cmd, server, args[0])
 }
 
-func upgradeCluster(server *v1alpha1.Server, clusterName string) error {
+func upgradeClusters(cmd *cobra.Command, server *v1alpha1.Server) error {
+	if uc.dryRun || uc.status {
+		return errors.New("--dry-run and --status are not supported with --selector")
+	}
+	if progressFormat == log.ProgressFormatJSON {
+		return errors.New("--progress-format json is not supported with --selector")
+	}
+
 	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
 	if err != nil {
 		return err
 	}
 
+	upgradeClustersOptions := tkgctl.UpgradeClustersOptions{
+		UpgradeClusterOptions: tkgctl.UpgradeClusterOptions{
+			Namespace:           uc.namespace,
+			SkipPrompt:          uc.unattended,
+			Timeout:             uc.timeout,
+			OSName:              uc.osName,
+			OSVersion:           uc.osVersion,
+			OSArch:              uc.osArch,
+			VSphereTemplateName: uc.vSphereTemplateName,
+			Edition:             BuildEdition,
+		},
+		Selector:         uc.selector,
+		MaxConcurrent:    uc.maxConcurrent,
+		FailureThreshold: uc.failureThreshold,
+	}
+
+	if uc.tkrName != "" {
+		clusterClientOptions := clusterclient.Options{GetClientInterval: 2 * time.Second, GetClientTimeout: 5 * time.Second}
+		clusterClient, err := clusterclient.NewClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context, clusterClientOptions)
+		if err != nil {
+			return err
+		}
+		upgradeClustersOptions.ResolveTkrVersion = func(cluster client.ClusterInfo) (string, error) {
+			return getValidTkrVersionFromTkrForUpgrade(tkgctlClient, clusterClient, cluster.Name, cluster.Namespace)
+		}
+	}
+
+	results, err := tkgctlClient.UpgradeClusters(upgradeClustersOptions)
+	if len(results) != 0 {
+		printUpgradeReport(cmd.OutOrStdout(), results, uc.outputFormat)
+	}
+	return err
+}
+
+// printUpgradeReport prints the outcome of the upgrade of each cluster of a batch upgrade
+func printUpgradeReport(out io.Writer, results []tkgctl.ClusterUpgradeResult, outputFormat string) {
+	t := component.NewOutputWriter(out, outputFormat, "NAME", "NAMESPACE", "WAVE", "STATUS", "KUBERNETES", "DURATION", "ERROR")
+	for i := range results {
+		t.AddRow(results[i].Name, results[i].Namespace, results[i].Wave, results[i].Status, results[i].KubernetesVersion, results[i].Duration, results[i].Error)
+	}
+	t.Render()
+}
+
+func upgradeCluster(cmd *cobra.Command, server *v1alpha1.Server, clusterName string) error {
+	tkgctlClient, err := createTKGClient(server.ManagementClusterOpts.Path, server.ManagementClusterOpts.Context)
+	if err != nil {
+		return err
+	}
+
+	if uc.status {
+		status, err := tkgctlClient.GetClusterUpgradeStatus(tkgctl.UpgradeClusterOptions{
+			ClusterName: clusterName,
+			Namespace:   uc.namespace,
+		})
+		if err != nil {
+			return err
+		}
+		printUpgradeStatus(cmd.OutOrStdout(), clusterName, status, uc.outputFormat)
+		return nil
+	}
+
 	tkrVersion := ""
 	if uc.tkrName != "" {
 		clusterClientOptions := clusterclient.Options{GetClientInterval: 2 * time.Second, GetClientTimeout: 5 * time.Second}
@@ -112,7 +221,7 @@ func upgradeCluster(server *v1alpha1.Server, clusterName string) error {
 			return err
 		}
 
-		tkrVersion, err = getValidTkrVersionFromTkrForUpgrade(tkgctlClient, clusterClient, clusterName)
+		tkrVersion, err = getValidTkrVersionFromTkrForUpgrade(tkgctlClient, clusterClient, clusterName, uc.namespace)
 		if err != nil {
 			return err
 		}
@@ -131,13 +240,79 @@ func upgradeCluster(server *v1alpha1.Server, clusterName string) error {
 		Edition:             BuildEdition,
 	}
 
+	if uc.dryRun {
+		plan, err := tkgctlClient.PlanClusterUpgrade(upgradeClusterOptions)
+		if err != nil {
+			return err
+		}
+		printUpgradePlan(cmd.OutOrStdout(), plan, uc.outputFormat)
+		return nil
+	}
+
 	return tkgctlClient.UpgradeCluster(upgradeClusterOptions)
 }
 
-func getValidTkrVersionFromTkrForUpgrade(tkgctlClient tkgctl.TKGClient, clusterClient clusterclient.Client, clusterName string) (string, error) {
+// printUpgradeStatus prints the step the last upgrade of the cluster reached
+func printUpgradeStatus(out io.Writer, clusterName string, status *client.UpgradeStatus, outputFormat string) {
+	if status == nil {
+		fmt.Fprintf(out, "No upgrade has been recorded for cluster '%s'\n", clusterName)
+		return
+	}
+	if outputFormat == string(component.JSONOutputType) || outputFormat == string(component.YAMLOutputType) {
+		component.NewObjectWriter(out, outputFormat, status).Render()
+		return
+	}
+
+	t := component.NewOutputWriter(out, outputFormat, "NAME", "NAMESPACE", "TKR", "KUBERNETES", "PHASE", "STEP", "STARTED", "LAST UPDATED")
+	t.AddRow(status.ClusterName, status.Namespace, status.TkrVersion, status.KubernetesVersion, status.Phase, status.Step, status.StartTimestamp, status.LastUpdatedTimestamp)
+	t.Render()
+}
+
+// printUpgradePlan prints the changes of the upgrade plan, or the plan itself in yaml and json output formats.
+func printUpgradePlan(out io.Writer, plan *client.UpgradeClusterPlan, outputFormat string) {
+	if outputFormat == string(component.JSONOutputType) || outputFormat == string(component.YAMLOutputType) {
+		component.NewObjectWriter(out, outputFormat, plan).Render()
+		return
+	}
+
+	fromTkr := plan.FromTkrVersion
+	if fromTkr == "" {
+		fromTkr = "<unknown>"
+	}
+	fmt.Fprintf(out, "Cluster '%s' in namespace '%s' would be upgraded from kubernetes version '%s' (TKr %s) to '%s' (TKr %s)\n\n",
+		plan.ClusterName, plan.Namespace, plan.FromKubernetesVersion, fromTkr, plan.ToKubernetesVersion, plan.ToTkrVersion)
+
+	t := component.NewOutputWriter(out, outputFormat, "KIND", "NAME", "ACTION", "FIELD", "FROM", "TO")
+	for _, obj := range plan.Objects {
+		if len(obj.Changes) == 0 {
+			t.AddRow(obj.Kind, obj.Name, obj.Action, "", "", "")
+			continue
+		}
+		for i, change := range obj.Changes {
+			if i == 0 {
+				t.AddRow(obj.Kind, obj.Name, obj.Action, change.Path, change.From, change.To)
+			} else {
+				t.AddRow("", "", "", change.Path, change.From, change.To)
+			}
+		}
+	}
+	t.Render()
+
+	if len(plan.Components)+len(plan.Addons) == 0 {
+		return
+	}
+	fmt.Fprintln(out)
+	t = component.NewOutputWriter(out, outputFormat, "COMPONENT", "FROM", "TO")
+	for _, c := range append(plan.Components, plan.Addons...) {
+		t.AddRow(c.Name, c.From, c.To)
+	}
+	t.Render()
+}
+
+func getValidTkrVersionFromTkrForUpgrade(tkgctlClient tkgctl.TKGClient, clusterClient clusterclient.Client, clusterName, namespace string) (string, error) {
 	result, err := tkgctlClient.DescribeCluster(tkgctl.DescribeTKGClustersOptions{
 		ClusterName: clusterName,
-		Namespace:   uc.namespace,
+		Namespace:   namespace,
 	})
 	if err != nil {
 		return "", err
@@ -151,9 +326,9 @@ func getValidTkrVersionFromTkrForUpgrade(tkgctlClient tkgctl.TKGClient, clusterC
 	tkrForUpgrade, err := getMatchingTkrForTkrName(tkrs, uc.tkrName)
 	// If the complete TKR name is provided, use it
 	if err == nil {
-		return getValidTKRVersionForUpgradeGivenFullTKRName(clusterName, uc.namespace, result.ClusterInfo.Labels, &tkrForUpgrade, tkrs)
+		return getValidTKRVersionForUpgradeGivenFullTKRName(clusterName, namespace, result.ClusterInfo.Labels, &tkrForUpgrade, tkrs)
 	}
-	return getValidTKRVersionForUpgradeGivenTKRNamePrefix(clusterName, uc.namespace, uc.tkrName, result.ClusterInfo.K8sVersion, result.ClusterInfo.Labels, tkrs)
+	return getValidTKRVersionForUpgradeGivenTKRNamePrefix(clusterName, namespace, uc.tkrName, result.ClusterInfo.K8sVersion, result.ClusterInfo.Labels, tkrs)
 }
 
 func getValidTKRVersionForUpgradeGivenFullTKRName(clusterName, namespace string, clusterLabels map[string]string,
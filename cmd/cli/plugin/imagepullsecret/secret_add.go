@@ -66,6 +66,8 @@ func secretGenAvailabilityCheck(cmd *cobra.Command, _ []string) error {
 }
 
 func imagePullSecretAdd(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &imagePullSecretOp.Namespace)
+
 	imagePullSecretOp.SecretName = args[0]
 
 	password, err := extractPassword()
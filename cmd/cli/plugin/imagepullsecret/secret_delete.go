@@ -33,6 +33,8 @@ func init() {
 }
 
 func imagePullSecretDelete(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &imagePullSecretOp.Namespace)
+
 	imagePullSecretOp.SecretName = args[0]
 
 	pkgClient, err := tkgpackageclient.NewTKGPackageClient(imagePullSecretOp.KubeConfig)
@@ -13,6 +13,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgpackageclient"
 )
@@ -42,6 +43,8 @@ func init() {
 }
 
 func imagePullSecretList(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &imagePullSecretOp.Namespace)
+
 	var t component.OutputWriterSpinner
 	var exported string
 	var registry string
@@ -44,6 +44,8 @@ func init() {
 }
 
 func imagePullSecretUpdate(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &imagePullSecretOp.Namespace)
+
 	imagePullSecretOp.SecretName = args[0]
 
 	password, err := extractPassword()
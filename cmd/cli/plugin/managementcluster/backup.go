@@ -0,0 +1,52 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
+
+	"github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+)
+
+// backupRegionCmd represents the backup command
+var backupRegionCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up the workload clusters of the management cluster",
+	Long: `Back up the workload clusters of the management cluster
+
+Exports the Cluster API, provider and Tanzu objects of the workload clusters managed by the
+current management cluster, with their related secrets and config maps, to a tar.gz archive.
+The backup can be restored to a new management cluster with 'tanzu management-cluster restore'.`,
+	Example: `
+  # Back up the workload clusters of the current management cluster
+  tanzu management-cluster backup --to mc-1-backup.tar.gz`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runForCurrentMC(runBackupRegion)
+	},
+}
+
+type backupRegionOptions struct {
+	file string
+}
+
+var br = &backupRegionOptions{}
+
+func init() {
+	backupRegionCmd.Flags().StringVarP(&br.file, "to", "", "", "The tar.gz file to write the backup to")
+	_ = backupRegionCmd.MarkFlagRequired("to")
+}
+
+func runBackupRegion(server *v1alpha1.Server) error {
+	forceUpdateTKGCompatibilityImage := false
+	tkgClient, err := newTKGCtlClient(forceUpdateTKGCompatibilityImage)
+	if err != nil {
+		return err
+	}
+
+	return tkgClient.BackupRegion(tkgctl.BackupRegionOptions{
+		File: br.file,
+	})
+}
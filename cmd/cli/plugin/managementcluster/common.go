@@ -30,6 +30,10 @@ func newTKGCtlClient(forceUpdateTKGCompatibilityImage bool) (tkgctl.TKGClient, e
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get default TKG config directory")
 	}
+	logOptions := getLoggingOptions()
+	if err := tkgctl.ValidateLoggingOptions(logOptions); err != nil {
+		return nil, err
+	}
 
 	return tkgctl.New(tkgctl.Options{
 		ConfigDir: tkgConfigDir,
@@ -37,11 +41,22 @@ func newTKGCtlClient(forceUpdateTKGCompatibilityImage bool) (tkgctl.TKGClient, e
 			RegionManagerFactory: NewFactory(),
 		},
 
-		LogOptions:                       tkgctl.LoggingOptions{Verbosity: logLevel, File: logFile},
+		LogOptions:                       logOptions,
 		ForceUpdateTKGCompatibilityImage: forceUpdateTKGCompatibilityImage,
 	})
 }
 
+func getLoggingOptions() tkgctl.LoggingOptions {
+	return tkgctl.LoggingOptions{
+		Verbosity:         logLevel,
+		File:              logFile,
+		ProgressFormat:    progressFormat,
+		LogFormat:         logFormat,
+		LogFileMaxSize:    logFileMaxSize,
+		LogFileMaxBackups: logFileMaxBackups,
+	}
+}
+
 func getTKGConfigDir() (string, error) {
 	tanzuConfigDir, err := config.LocalDir()
 	if err != nil {
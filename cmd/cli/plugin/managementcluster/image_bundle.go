@@ -0,0 +1,16 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import "github.com/spf13/cobra"
+
+var imageBundleCmd = &cobra.Command{
+	Use:   "image-bundle",
+	Short: "Export and import the images of a release for internet-restricted environments",
+}
+
+func init() {
+	imageBundleCmd.AddCommand(exportImageBundleCmd)
+	imageBundleCmd.AddCommand(importImageBundleCmd)
+}
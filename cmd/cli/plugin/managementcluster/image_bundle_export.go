@@ -0,0 +1,54 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
+)
+
+type exportImageBundleOptions struct {
+	tkrVersion string
+	file       string
+}
+
+var eib = &exportImageBundleOptions{}
+
+var exportImageBundleCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the images of a release to an image bundle",
+	Long: `Export the images of a release to an image bundle
+
+Pulls the images of the TKG BOM and of the BOM of the TKr and the TKG compatibility image, and
+writes them to a tar archive along with the BOM, compatibility and provider files, to be imported
+to a private registry with 'tanzu management-cluster image-bundle import'.`,
+	Example: `
+  # Export the images of the default TKr
+  tanzu management-cluster image-bundle export --to bundle.tar
+
+  # Export the images of a TKr
+  tanzu management-cluster image-bundle export --tkr v1.20.5+vmware.1-tkg.1 --to bundle.tar`,
+	Args: cobra.NoArgs,
+	RunE: runExportImageBundle,
+}
+
+func init() {
+	exportImageBundleCmd.Flags().StringVarP(&eib.tkrVersion, "tkr", "", "", "Version of the TanzuKubernetesRelease(TKr) whose images are exported. Assumes the default TKr if not specified")
+	exportImageBundleCmd.Flags().StringVarP(&eib.file, "to", "", "", "The tar file to export the image bundle to")
+	_ = exportImageBundleCmd.MarkFlagRequired("to")
+}
+
+func runExportImageBundle(cmd *cobra.Command, args []string) error {
+	forceUpdateTKGCompatibilityImage := false
+	tkgClient, err := newTKGCtlClient(forceUpdateTKGCompatibilityImage)
+	if err != nil {
+		return err
+	}
+
+	return tkgClient.ExportImageBundle(tkgctl.ExportImageBundleOptions{
+		TkrVersion: eib.tkrVersion,
+		File:       eib.file,
+	})
+}
@@ -0,0 +1,57 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
+)
+
+type importImageBundleOptions struct {
+	file       string
+	repository string
+	unattended bool
+}
+
+var iib = &importImageBundleOptions{}
+
+var importImageBundleCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import an image bundle to a private registry",
+	Long: `Import an image bundle to a private registry
+
+Pushes the images of an image bundle made with 'tanzu management-cluster image-bundle export'
+to the image repository of a private registry, and writes the BOM files, with their image
+references rewritten to the repository, and the compatibility and provider files of the
+bundle to the TKG configuration directory. The registry is accessed with the
+TKG_CUSTOM_IMAGE_REPOSITORY_SKIP_TLS_VERIFY and TKG_CUSTOM_IMAGE_REPOSITORY_CA_CERTIFICATE settings.`,
+	Example: `
+  # Import an image bundle to a private registry
+  tanzu management-cluster image-bundle import --from bundle.tar --to-registry registry.example.com/tkg`,
+	Args: cobra.NoArgs,
+	RunE: runImportImageBundle,
+}
+
+func init() {
+	importImageBundleCmd.Flags().StringVarP(&iib.file, "from", "", "", "The tar file to import the image bundle from")
+	_ = importImageBundleCmd.MarkFlagRequired("from")
+	importImageBundleCmd.Flags().StringVarP(&iib.repository, "to-registry", "", "", "The image repository of the private registry to push the images to, e.g. registry.example.com/tkg")
+	_ = importImageBundleCmd.MarkFlagRequired("to-registry")
+	importImageBundleCmd.Flags().BoolVarP(&iib.unattended, "yes", "y", false, "Import the image bundle without asking for confirmation")
+}
+
+func runImportImageBundle(cmd *cobra.Command, args []string) error {
+	forceUpdateTKGCompatibilityImage := false
+	tkgClient, err := newTKGCtlClient(forceUpdateTKGCompatibilityImage)
+	if err != nil {
+		return err
+	}
+
+	return tkgClient.ImportImageBundle(tkgctl.ImportImageBundleOptions{
+		File:       iib.file,
+		Repository: iib.repository,
+		SkipPrompt: iib.unattended,
+	})
+}
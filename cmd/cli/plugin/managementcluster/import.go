@@ -63,11 +63,15 @@ func runImport(importFile string) error {
 	if err != nil {
 		return errors.Wrap(err, "unable to get default TKG config directory")
 	}
+	logOptions := getLoggingOptions()
+	if err := tkgctl.ValidateLoggingOptions(logOptions); err != nil {
+		return err
+	}
 
 	tkgClient, err := tkgctl.New(tkgctl.Options{
 		ConfigDir:    tkgConfigDir,
 		SettingsFile: importFile,
-		LogOptions:   tkgctl.LoggingOptions{Verbosity: logLevel, File: logFile},
+		LogOptions:   logOptions,
 	})
 	if err != nil {
 		return errors.Wrap(err, "unable to create tkgctl client")
@@ -29,9 +29,13 @@ var descriptor = cliv1alpha1.PluginDescriptor{
 }
 
 var (
-	logLevel     int32
-	logFile      string
-	outputFormat string
+	logLevel          int32
+	logFile           string
+	logFormat         string
+	logFileMaxSize    int
+	logFileMaxBackups int
+	outputFormat      string
+	progressFormat    string
 )
 
 func main() {
@@ -42,6 +46,9 @@ func main() {
 
 	p.Cmd.PersistentFlags().Int32VarP(&logLevel, "verbose", "v", 0, "Number for the log level verbosity(0-9)")
 	p.Cmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Log file path")
+	p.Cmd.PersistentFlags().StringVar(&logFormat, "log-format", "", "Format of the log file, 'text' or 'json'")
+	p.Cmd.PersistentFlags().IntVar(&logFileMaxSize, "log-file-max-size", 0, "Size in megabytes after which the log file is rotated, 0 disables the rotation")
+	p.Cmd.PersistentFlags().IntVar(&logFileMaxBackups, "log-file-max-backups", 0, "Number of rotated log files to keep")
 
 	p.AddCommands(
 		createCmd,
@@ -54,6 +61,11 @@ func main() {
 		importCmd,
 		clusterKubeconfigCmd,
 		registerCmd,
+		backupRegionCmd,
+		moveRegionCmd,
+		restoreRegionCmd,
+		preflightRegionCmd,
+		imageBundleCmd,
 	)
 
 	if err = p.Execute(); err != nil {
@@ -0,0 +1,71 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
+
+	"github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+)
+
+type moveRegionOptions struct {
+	toKubeconfig  string
+	toContext     string
+	toClusterName string
+	unattended    bool
+	timeout       time.Duration
+}
+
+var mr = &moveRegionOptions{}
+
+var moveRegionCmd = &cobra.Command{
+	Use:   "move",
+	Short: "Move the management cluster to an existing cluster",
+	Long: `Move the management cluster to an existing cluster
+
+Installs the Cluster API providers of the current management cluster on an existing cluster,
+moves the Cluster API objects of all the workload clusters to it and waits for the workload
+clusters to be reconciled from it. The existing cluster then becomes the current management
+cluster, while the former management cluster keeps managing only itself.`,
+	Example: `
+    # Move the management cluster of the current server to an existing cluster
+    tanzu management-cluster move --to-kubeconfig ~/.kube/new-mc.yaml
+
+    # Move the management cluster using a specific context of the kubeconfig
+    tanzu management-cluster move --to-kubeconfig ~/.kube/config --to-context new-mc-admin@new-mc`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runForCurrentMC(runMoveRegion)
+	},
+}
+
+func init() {
+	moveRegionCmd.Flags().StringVar(&mr.toKubeconfig, "to-kubeconfig", "", "The kubeconfig of the existing cluster to move the management cluster to")
+	_ = moveRegionCmd.MarkFlagRequired("to-kubeconfig")
+	moveRegionCmd.Flags().StringVar(&mr.toContext, "to-context", "", "The context of the kubeconfig to use, the current context of the kubeconfig if not provided")
+	moveRegionCmd.Flags().StringVar(&mr.toClusterName, "to-name", "", "The name of the new management cluster, the cluster name of the context if not provided")
+	moveRegionCmd.Flags().BoolVarP(&mr.unattended, "yes", "y", false, "Move management cluster without asking for confirmation")
+	moveRegionCmd.Flags().DurationVarP(&mr.timeout, "timeout", "t", constants.DefaultLongRunningOperationTimeout, "Time duration to wait for an operation before timeout. Timeout duration in hours(h)/minutes(m)/seconds(s) units or as some combination of them (e.g. 2h, 30m, 2h30m10s)")
+}
+
+func runMoveRegion(server *v1alpha1.Server) error {
+	forceUpdateTKGCompatibilityImage := false
+	tkgClient, err := newTKGCtlClient(forceUpdateTKGCompatibilityImage)
+	if err != nil {
+		return err
+	}
+
+	return tkgClient.MoveRegion(tkgctl.MoveRegionOptions{
+		ClusterName:   server.Name,
+		ToKubeconfig:  mr.toKubeconfig,
+		ToContext:     mr.toContext,
+		ToClusterName: mr.toClusterName,
+		SkipPrompt:    mr.unattended,
+		Timeout:       mr.timeout,
+	})
+}
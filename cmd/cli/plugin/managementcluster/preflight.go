@@ -0,0 +1,84 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
+)
+
+type preflightRegionOptions struct {
+	clusterConfigFile  string
+	useExistingCluster bool
+	skipChecks         []string
+	outputFormat       string
+}
+
+var pr = &preflightRegionOptions{}
+
+var preflightRegionCmd = &cobra.Command{
+	Use:   "preflight",
+	Short: "Run the preflight checks of a management cluster",
+	Long: `Run the preflight checks of a management cluster without creating it
+
+Checks the configuration, the infrastructure provider credentials, quotas and resources,
+the network reachability, the availability of the images and the local Docker engine,
+and reports the result of every check. The checks not depending on each other run
+concurrently, and a check is skipped if a check it depends on fails.`,
+	Example: `
+    # Run the preflight checks of a management cluster
+    tanzu management-cluster preflight --file ~/clusterconfigs/aws-mc-1.yaml
+
+    # Run the preflight checks except the ones of the Docker engine
+    tanzu management-cluster preflight --file vsphere-mc-1.yaml --skip-check docker-daemon,docker-resources`,
+	Args: cobra.NoArgs,
+	RunE: runPreflightRegion,
+}
+
+func init() {
+	preflightRegionCmd.Flags().StringVarP(&pr.clusterConfigFile, "file", "f", "", "Configuration file of the management cluster to check")
+	preflightRegionCmd.Flags().BoolVarP(&pr.useExistingCluster, "use-existing-bootstrap-cluster", "e", false, "Skip the checks of the local Docker engine as an existing bootstrap cluster is to be used")
+	preflightRegionCmd.Flags().StringSliceVar(&pr.skipChecks, "skip-check", []string{}, "Names of the preflight checks to skip")
+	preflightRegionCmd.Flags().StringVarP(&pr.outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+}
+
+func runPreflightRegion(cmd *cobra.Command, args []string) error {
+	forceUpdateTKGCompatibilityImage := false
+	tkgClient, err := newTKGCtlClient(forceUpdateTKGCompatibilityImage)
+	if err != nil {
+		return err
+	}
+
+	report, err := tkgClient.PreflightRegion(tkgctl.PreflightRegionOptions{
+		ClusterConfigFile:  pr.clusterConfigFile,
+		UseExistingCluster: pr.useExistingCluster,
+		Edition:            BuildEdition,
+		SkipChecks:         pr.skipChecks,
+	})
+	if err != nil {
+		return err
+	}
+
+	if pr.outputFormat == string(component.JSONOutputType) || pr.outputFormat == string(component.YAMLOutputType) {
+		component.NewObjectWriter(cmd.OutOrStdout(), pr.outputFormat, report).Render()
+	} else {
+		t := component.NewOutputWriter(cmd.OutOrStdout(), pr.outputFormat, "CATEGORY", "NAME", "STATUS", "MESSAGE")
+		for i := range report.Checks {
+			t.AddRow(report.Checks[i].Category, report.Checks[i].Name, report.Checks[i].Status, report.Checks[i].Message)
+		}
+		t.Render()
+		fmt.Fprintf(cmd.OutOrStdout(), "\nPreflight checks: %s\n", report.Status)
+	}
+
+	if report.Status == client.PreflightStatusFail {
+		return errors.New("preflight checks failed")
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgctl"
+
+	"github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+)
+
+// restoreRegionCmd represents the restore command
+var restoreRegionCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore a management cluster backup",
+	Long: `Restore a management cluster backup
+
+Creates the objects of a backup made with 'tanzu management-cluster backup' on the current
+management cluster, which is expected to be a freshly created management cluster on the same
+infrastructure. The restored clusters are paused until all their objects are restored.`,
+	Example: `
+  # Restore a backup to the current management cluster
+  tanzu management-cluster restore --from mc-1-backup.tar.gz`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runForCurrentMC(runRestoreRegion)
+	},
+}
+
+type restoreRegionOptions struct {
+	file       string
+	unattended bool
+}
+
+var rr = &restoreRegionOptions{}
+
+func init() {
+	restoreRegionCmd.Flags().StringVarP(&rr.file, "from", "", "", "The tar.gz file to restore the backup from")
+	_ = restoreRegionCmd.MarkFlagRequired("from")
+	restoreRegionCmd.Flags().BoolVarP(&rr.unattended, "yes", "y", false, "Restore the backup without asking for confirmation")
+}
+
+func runRestoreRegion(server *v1alpha1.Server) error {
+	forceUpdateTKGCompatibilityImage := false
+	tkgClient, err := newTKGCtlClient(forceUpdateTKGCompatibilityImage)
+	if err != nil {
+		return err
+	}
+
+	return tkgClient.RestoreRegion(tkgctl.RestoreRegionOptions{
+		File:       rr.file,
+		SkipPrompt: rr.unattended,
+	})
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/kappclient"
 )
@@ -31,6 +32,8 @@ func init() {
 }
 
 func packageAvailableList(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &packageAvailableOp.Namespace)
+
 	kc, err := kappclient.NewKappClient(packageAvailableOp.KubeConfig)
 	if err != nil {
 		return err
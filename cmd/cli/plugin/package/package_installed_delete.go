@@ -34,6 +34,8 @@ func init() {
 }
 
 func packageUninstall(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &packageInstalledOp.Namespace)
+
 	packageInstalledOp.PkgInstallName = args[0]
 
 	cmd.SilenceUsage = true
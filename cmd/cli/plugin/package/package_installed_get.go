@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/kappclient"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
@@ -35,6 +36,8 @@ func init() {
 }
 
 func packageInstalledGet(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &packageInstalledOp.Namespace)
+
 	cmd.SilenceUsage = true
 
 	kc, err := kappclient.NewKappClient(packageInstalledOp.KubeConfig)
@@ -6,6 +6,7 @@ package main
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/kappclient"
 )
@@ -31,6 +32,8 @@ func init() {
 }
 
 func packageInstalledList(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &packageInstalledOp.Namespace)
+
 	cmd.SilenceUsage = true
 
 	kc, err := kappclient.NewKappClient(packageInstalledOp.KubeConfig)
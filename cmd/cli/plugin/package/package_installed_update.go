@@ -8,6 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgpackageclient"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgpackagedatamodel"
@@ -37,6 +38,8 @@ func init() {
 }
 
 func packageUpdate(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &packageInstalledOp.Namespace)
+
 	packageInstalledOp.PkgInstallName = args[0]
 
 	cmd.SilenceUsage = true
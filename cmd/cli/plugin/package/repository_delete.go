@@ -35,6 +35,8 @@ func init() {
 }
 
 func repositoryDelete(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &repoOp.Namespace)
+
 	if len(args) == 1 {
 		repoOp.RepositoryName = args[0]
 	} else {
@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgpackageclient"
 )
@@ -29,6 +30,8 @@ func init() {
 }
 
 func repositoryGet(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &repoOp.Namespace)
+
 	if len(args) == 1 {
 		repoOp.RepositoryName = args[0]
 	} else {
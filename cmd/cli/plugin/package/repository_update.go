@@ -8,6 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgpackageclient"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgpackagedatamodel"
@@ -35,6 +36,8 @@ func init() {
 }
 
 func repositoryUpdate(cmd *cobra.Command, args []string) error {
+	cli.ApplyContextNamespace(cmd, &repoOp.Namespace)
+
 	repoOp.RepositoryName = args[0]
 
 	cmd.SilenceUsage = true
@@ -38,6 +38,7 @@ type loginOIDCOptions struct {
 	skipBrowser                bool
 	debugSessionCache          bool
 	conciergeEnabled           bool
+	refresh                    bool
 }
 
 //go:embed asset/pinniped
@@ -54,7 +55,10 @@ var loCmd = &cobra.Command{
     tanzu pinniped-auth login  --issuer https://issuer.example.com --client-id tanzu-cli
 
     # pinniped-auth login using OpenID Connect provider with TCP port for local host listener (authorization code flow only)
-    tanzu pinniped-auth login  --issuer https://issuer.example.com --client-id tanzu-cli --listen-port=48095`,
+    tanzu pinniped-auth login  --issuer https://issuer.example.com --client-id tanzu-cli --listen-port=48095
+
+    # pinniped-auth login renewing the cached session before a long-running operation
+    tanzu pinniped-auth login  --issuer https://issuer.example.com --client-id tanzu-cli --refresh`,
 }
 
 func init() {
@@ -74,12 +78,23 @@ func init() {
 	loCmd.Flags().StringVar(&lo.conciergeAuthenticatorName, "concierge-authenticator-name", "", "Concierge authenticator name")
 	loCmd.Flags().StringVar(&lo.conciergeEndpoint, "concierge-endpoint", "", "API base for the Pinniped concierge endpoint")
 	loCmd.Flags().StringVar(&lo.conciergeCABundle, "concierge-ca-bundle-data", "", "CA bundle to use when connecting to the concierge")
+	loCmd.Flags().BoolVar(&lo.refresh, "refresh", false, "Renew the cached session even if it has not expired yet")
 	loCmd.Flags().MarkHidden("debug-session-cache") //nolint
 	loCmd.MarkFlagRequired("issuer")                //nolint
 }
 
 func loginoidcCmd(pinnipedloginCliExec func(args []string) error) *cobra.Command {
 	loCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if lo.refresh {
+			refreshable, err := expireSessions(lo.sessionCachePath, lo.issuer, lo.clientID)
+			if err != nil {
+				return errors.Wrapf(err, "pinniped-auth login failed")
+			}
+			if refreshable == 0 {
+				fmt.Fprintln(cmd.ErrOrStderr(), "No refreshable session found, a new login is required")
+			}
+		}
+
 		oidcLoginArgs := []string{
 			"login", "oidc",
 			fmt.Sprintf("--issuer=%s", lo.issuer),
@@ -27,6 +27,7 @@ func main() {
 	}
 	p.AddCommands(
 		loginoidcCmd(pinnipedLoginExec),
+		sessionCmd,
 	)
 	if err := p.Execute(); err != nil {
 		os.Exit(1)
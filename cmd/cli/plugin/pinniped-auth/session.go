@@ -0,0 +1,164 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
+)
+
+type sessionOptions struct {
+	sessionCachePath string
+	issuer           string
+	clientID         string
+	audience         string
+	outputFormat     string
+	all              bool
+}
+
+var so = &sessionOptions{}
+
+var sessionCmd = &cobra.Command{
+	Use:   "session",
+	Short: "Manage the cached login sessions",
+}
+
+var sessionListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List the cached login sessions",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	Example: `
+    # List all cached login sessions
+    tanzu pinniped-auth session list
+
+    # List the cached login sessions of an issuer in json output format
+    tanzu pinniped-auth session list --issuer https://issuer.example.com -o json`,
+	RunE: sessionList,
+}
+
+var sessionDeleteCmd = &cobra.Command{
+	Use:          "delete",
+	Short:        "Delete cached login sessions",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	Example: `
+    # Delete the cached login sessions of an issuer
+    tanzu pinniped-auth session delete --issuer https://issuer.example.com
+
+    # Delete the cached login sessions of an issuer for a single audience
+    tanzu pinniped-auth session delete --issuer https://issuer.example.com --audience my-cluster
+
+    # Delete all cached login sessions
+    tanzu pinniped-auth session delete --all`,
+	RunE: sessionDelete,
+}
+
+func init() {
+	sessionCmd.PersistentFlags().StringVar(&so.sessionCachePath, "session-cache", filepath.Join(mustGetConfigDir(), "sessions.yaml"), "Path to session cache file.")
+	sessionCmd.PersistentFlags().StringVar(&so.issuer, "issuer", "", "Only include sessions of this OpenID Connect issuer URL.")
+	sessionCmd.PersistentFlags().StringVar(&so.clientID, "client-id", "", "Only include sessions of this OpenID Connect client ID.")
+	sessionCmd.PersistentFlags().StringVar(&so.audience, "audience", "", "Only include sessions whose ID token has this audience.")
+	sessionListCmd.Flags().StringVarP(&so.outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	sessionDeleteCmd.Flags().BoolVar(&so.all, "all", false, "Delete all cached sessions")
+
+	sessionCmd.AddCommand(sessionListCmd, sessionDeleteCmd)
+}
+
+func sessionList(cmd *cobra.Command, _ []string) error {
+	cache, err := readSessionCache(so.sessionCachePath)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	t := component.NewOutputWriter(cmd.OutOrStdout(), so.outputFormat, "ISSUER", "CLIENT ID", "AUDIENCE", "EXPIRES", "STATUS", "LAST USED")
+	for i := range cache.Sessions {
+		s := &cache.Sessions[i]
+		if !s.matches(so.issuer, so.clientID, so.audience) {
+			continue
+		}
+		t.AddRow(s.Key.Issuer, s.Key.ClientID, strings.Join(s.audiences(), ","), formatExpiry(s.expiry(), now), s.status(now), formatTimestamp(s.LastUsedTimestamp.Time))
+	}
+	t.Render()
+	return nil
+}
+
+func sessionDelete(cmd *cobra.Command, _ []string) error {
+	if !so.all && so.issuer == "" && so.clientID == "" && so.audience == "" {
+		return errors.New("specify the sessions to delete with --issuer, --client-id or --audience, or use --all")
+	}
+
+	deleted := 0
+	err := updateSessionCache(so.sessionCachePath, func(cache *sessionCache) bool {
+		kept := cache.Sessions[:0]
+		for i := range cache.Sessions {
+			if so.all || cache.Sessions[i].matches(so.issuer, so.clientID, so.audience) {
+				deleted++
+				continue
+			}
+			kept = append(kept, cache.Sessions[i])
+		}
+		cache.Sessions = kept
+		return deleted > 0
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No matching sessions found")
+		return nil
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Deleted %d session(s)\n", deleted)
+	return nil
+}
+
+// expireSessions marks the cached sessions of the issuer and client ID as expired,
+// so the following login renews them with their refresh token. It returns the
+// number of sessions that can be renewed without a new interactive login.
+func expireSessions(sessionCachePath, issuer, clientID string) (int, error) {
+	now := time.Now()
+	refreshable := 0
+	err := updateSessionCache(sessionCachePath, func(cache *sessionCache) bool {
+		matched := false
+		for i := range cache.Sessions {
+			s := &cache.Sessions[i]
+			if !s.matches(issuer, clientID, "") {
+				continue
+			}
+			matched = true
+			s.expire(now)
+			if s.refreshable() {
+				refreshable++
+			}
+		}
+		return matched
+	})
+	return refreshable, err
+}
+
+func formatExpiry(expiry, now time.Time) string {
+	if expiry.IsZero() {
+		return ""
+	}
+	if now.Before(expiry) {
+		return fmt.Sprintf("%s (in %s)", formatTimestamp(expiry), duration.HumanDuration(expiry.Sub(now)))
+	}
+	return fmt.Sprintf("%s (%s ago)", formatTimestamp(expiry), duration.HumanDuration(now.Sub(expiry)))
+}
+
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format(time.RFC3339)
+}
@@ -0,0 +1,240 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/utils"
+)
+
+const (
+	sessionCacheAPIVersion = "config.supervisor.pinniped.dev/v1alpha1"
+	sessionCacheKind       = "SessionCache"
+	// sessionCacheLockSuffix is the suffix of the lock file the pinniped cli holds while it updates the session cache
+	sessionCacheLockSuffix  = ".lock"
+	sessionCacheLockTimeout = 10 * time.Second
+)
+
+// sessionCache is the on-disk session cache written by the pinniped cli.
+type sessionCache struct {
+	metav1.TypeMeta `json:",inline"`
+	Sessions        []sessionEntry `json:"sessions"`
+}
+
+// sessionEntry is a single cached login session.
+type sessionEntry struct {
+	Key               sessionCacheKey `json:"key"`
+	CreationTimestamp metav1.Time     `json:"creationTimestamp"`
+	LastUsedTimestamp metav1.Time     `json:"lastUsedTimestamp"`
+	Tokens            sessionTokens   `json:"tokens"`
+}
+
+// sessionCacheKey identifies a cached login session.
+type sessionCacheKey struct {
+	Issuer               string   `json:"issuer"`
+	ClientID             string   `json:"clientID"`
+	Scopes               []string `json:"scopes"`
+	RedirectURI          string   `json:"redirect_uri"`
+	UpstreamProviderName string   `json:"upstream_provider_name,omitempty"`
+}
+
+// sessionTokens holds the tokens of a cached login session.
+type sessionTokens struct {
+	AccessToken  *accessToken  `json:"access,omitempty"`
+	RefreshToken *refreshToken `json:"refresh,omitempty"`
+	IDToken      *idToken      `json:"id,omitempty"`
+}
+
+type accessToken struct {
+	Token  string      `json:"token"`
+	Type   string      `json:"type,omitempty"`
+	Expiry metav1.Time `json:"expiryTimestamp"`
+}
+
+type refreshToken struct {
+	Token string `json:"token"`
+}
+
+type idToken struct {
+	Token  string                 `json:"token"`
+	Expiry metav1.Time            `json:"expiryTimestamp"`
+	Claims map[string]interface{} `json:"claims,omitempty"`
+}
+
+// readSessionCache reads the session cache file, an absent file is an empty cache.
+func readSessionCache(path string) (*sessionCache, error) {
+	cache := &sessionCache{TypeMeta: metav1.TypeMeta{APIVersion: sessionCacheAPIVersion, Kind: sessionCacheKind}}
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read session cache %q", path)
+	}
+	if err := yaml.Unmarshal(b, cache); err != nil {
+		return nil, errors.Wrapf(err, "could not parse session cache %q", path)
+	}
+	if cache.APIVersion != sessionCacheAPIVersion || cache.Kind != sessionCacheKind {
+		return nil, errors.Errorf("session cache %q has unsupported type %s/%s", path, cache.APIVersion, cache.Kind)
+	}
+	return cache, nil
+}
+
+// updateSessionCache applies the update to the session cache while holding the lock of the pinniped cli,
+// the file is only written when the update reports a change.
+func updateSessionCache(path string, update func(*sessionCache) bool) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Wrap(err, "could not make session cache directory")
+	}
+	lock, err := utils.GetFileLockWithTimeOut(path+sessionCacheLockSuffix, sessionCacheLockTimeout)
+	if err != nil {
+		return errors.Wrapf(err, "could not lock session cache %q", path)
+	}
+	defer lock.Unlock() //nolint:errcheck
+
+	cache, err := readSessionCache(path)
+	if err != nil {
+		return err
+	}
+	if !update(cache) {
+		return nil
+	}
+	return writeSessionCache(path, cache)
+}
+
+// writeSessionCache replaces the session cache file with the given cache.
+// The caller holds the lock of the session cache.
+func writeSessionCache(path string, cache *sessionCache) error {
+	b, err := yaml.Marshal(cache)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal session cache")
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return errors.Wrapf(err, "could not write session cache %q", path)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	_, err = tmp.Write(b)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrapf(err, "could not write session cache %q", path)
+	}
+	return errors.Wrapf(os.Rename(tmp.Name(), path), "could not write session cache %q", path)
+}
+
+// expiry returns the expiry of the session, which is that of its ID token
+// or of its access token when no ID token is cached.
+func (s *sessionEntry) expiry() time.Time {
+	if s.Tokens.IDToken != nil {
+		return s.Tokens.IDToken.Expiry.Time
+	}
+	if s.Tokens.AccessToken != nil {
+		return s.Tokens.AccessToken.Expiry.Time
+	}
+	return time.Time{}
+}
+
+// refreshable reports whether the session can be renewed without a new login.
+func (s *sessionEntry) refreshable() bool {
+	return s.Tokens.RefreshToken != nil && s.Tokens.RefreshToken.Token != ""
+}
+
+// status returns the state of the session at the given time.
+func (s *sessionEntry) status(now time.Time) string {
+	switch {
+	case now.Before(s.expiry()):
+		return "valid"
+	case s.refreshable():
+		return "expired (refreshable)"
+	default:
+		return "expired"
+	}
+}
+
+// expire marks the cached tokens as expired so the next login has to renew them.
+func (s *sessionEntry) expire(now time.Time) {
+	expired := metav1.NewTime(now.Add(-time.Second))
+	if s.Tokens.IDToken != nil {
+		s.Tokens.IDToken.Expiry = expired
+	}
+	if s.Tokens.AccessToken != nil {
+		s.Tokens.AccessToken.Expiry = expired
+	}
+}
+
+// audiences returns the audiences of the session's ID token.
+func (s *sessionEntry) audiences() []string {
+	if s.Tokens.IDToken == nil {
+		return nil
+	}
+	claims := s.Tokens.IDToken.Claims
+	if len(claims) == 0 {
+		claims = jwtClaims(s.Tokens.IDToken.Token)
+	}
+	switch aud := claims["aud"].(type) {
+	case string:
+		return []string{aud}
+	case []interface{}:
+		audiences := make([]string, 0, len(aud))
+		for _, a := range aud {
+			if str, ok := a.(string); ok {
+				audiences = append(audiences, str)
+			}
+		}
+		return audiences
+	default:
+		return nil
+	}
+}
+
+// matches reports whether the session belongs to the issuer, client ID and audience.
+// Empty values match any session.
+func (s *sessionEntry) matches(issuer, clientID, audience string) bool {
+	if issuer != "" && strings.TrimSuffix(s.Key.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return false
+	}
+	if clientID != "" && s.Key.ClientID != clientID {
+		return false
+	}
+	if audience == "" {
+		return true
+	}
+	for _, aud := range s.audiences() {
+		if aud == audience {
+			return true
+		}
+	}
+	return false
+}
+
+// jwtClaims decodes the claims of a JWT without verifying it, the token
+// was already verified by the pinniped cli when it was cached.
+func jwtClaims(token string) map[string]interface{} {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil
+	}
+	claims := map[string]interface{}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil
+	}
+	return claims
+}
@@ -0,0 +1,158 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func fakeIDToken(t *testing.T, audience string) string {
+	payload, err := json.Marshal(map[string]interface{}{"aud": audience})
+	require.NoError(t, err)
+	return "header." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
+
+func writeTestSessionCache(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "sessions.yaml")
+	now := time.Now()
+	cache := &sessionCache{
+		TypeMeta: metav1.TypeMeta{APIVersion: sessionCacheAPIVersion, Kind: sessionCacheKind},
+		Sessions: []sessionEntry{
+			{
+				Key: sessionCacheKey{Issuer: "https://issuer-a.example.com", ClientID: "pinniped-cli"},
+				Tokens: sessionTokens{
+					IDToken:      &idToken{Token: fakeIDToken(t, "cluster-a"), Expiry: metav1.NewTime(now.Add(time.Hour))},
+					RefreshToken: &refreshToken{Token: "refresh-a"},
+				},
+			},
+			{
+				Key: sessionCacheKey{Issuer: "https://issuer-b.example.com", ClientID: "pinniped-cli"},
+				Tokens: sessionTokens{
+					IDToken: &idToken{Token: fakeIDToken(t, "cluster-b"), Expiry: metav1.NewTime(now.Add(-time.Hour))},
+				},
+			},
+		},
+	}
+	require.NoError(t, writeSessionCache(path, cache))
+	return path
+}
+
+func runSessionCmd(t *testing.T, args ...string) (string, error) {
+	*so = sessionOptions{}
+	var stdout bytes.Buffer
+	sessionCmd.SetOut(&stdout)
+	sessionCmd.SetErr(&stdout)
+	sessionCmd.SetArgs(args)
+	err := sessionCmd.Execute()
+	return stdout.String(), err
+}
+
+func Test_sessionList(t *testing.T) {
+	path := writeTestSessionCache(t)
+
+	out, err := runSessionCmd(t, "list", "--session-cache", path, "-o", "json")
+	require.NoError(t, err)
+
+	var rows []map[string]string
+	require.NoError(t, json.Unmarshal([]byte(out), &rows))
+	require.Len(t, rows, 2)
+	require.Equal(t, "https://issuer-a.example.com", rows[0]["issuer"])
+	require.Equal(t, "cluster-a", rows[0]["audience"])
+	require.Equal(t, "valid", rows[0]["status"])
+	require.Equal(t, "expired", rows[1]["status"])
+
+	out, err = runSessionCmd(t, "list", "--session-cache", path, "--audience", "cluster-b", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(out), &rows))
+	require.Len(t, rows, 1)
+	require.Equal(t, "https://issuer-b.example.com", rows[0]["issuer"])
+}
+
+func Test_sessionDelete(t *testing.T) {
+	path := writeTestSessionCache(t)
+
+	_, err := runSessionCmd(t, "delete", "--session-cache", path)
+	require.Error(t, err)
+
+	out, err := runSessionCmd(t, "delete", "--session-cache", path, "--issuer", "https://issuer-b.example.com/")
+	require.NoError(t, err)
+	require.Equal(t, "Deleted 1 session(s)\n", out)
+
+	cache, err := readSessionCache(path)
+	require.NoError(t, err)
+	require.Len(t, cache.Sessions, 1)
+	require.Equal(t, "https://issuer-a.example.com", cache.Sessions[0].Key.Issuer)
+
+	_, err = runSessionCmd(t, "delete", "--session-cache", path, "--all")
+	require.NoError(t, err)
+	cache, err = readSessionCache(path)
+	require.NoError(t, err)
+	require.Empty(t, cache.Sessions)
+}
+
+func Test_expireSessions(t *testing.T) {
+	path := writeTestSessionCache(t)
+
+	refreshable, err := expireSessions(path, "https://issuer-a.example.com", "pinniped-cli")
+	require.NoError(t, err)
+	require.Equal(t, 1, refreshable)
+
+	cache, err := readSessionCache(path)
+	require.NoError(t, err)
+	require.Equal(t, "expired (refreshable)", cache.Sessions[0].status(time.Now()))
+
+	refreshable, err = expireSessions(path, "https://issuer-b.example.com", "pinniped-cli")
+	require.NoError(t, err)
+	require.Equal(t, 0, refreshable)
+}
+
+func Test_expireSessionsNoMatch(t *testing.T) {
+	path := writeTestSessionCache(t)
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(path, past, past))
+
+	refreshable, err := expireSessions(path, "https://issuer-c.example.com", "pinniped-cli")
+	require.NoError(t, err)
+	require.Equal(t, 0, refreshable)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.True(t, info.ModTime().Equal(past), "the session cache should not be rewritten")
+}
+
+func Test_updateSessionCacheConcurrently(t *testing.T) {
+	path := writeTestSessionCache(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			require.NoError(t, updateSessionCache(path, func(cache *sessionCache) bool {
+				cache.Sessions = append(cache.Sessions, sessionEntry{Key: sessionCacheKey{Issuer: fmt.Sprintf("https://issuer-%d.example.com", i)}})
+				return true
+			}))
+		}(i)
+	}
+	wg.Wait()
+
+	cache, err := readSessionCache(path)
+	require.NoError(t, err)
+	require.Len(t, cache.Sessions, 12)
+
+	tmpFiles, err := filepath.Glob(filepath.Join(filepath.Dir(path), "*.tmp"))
+	require.NoError(t, err)
+	require.Empty(t, tmpFiles)
+}
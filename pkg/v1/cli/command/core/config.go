@@ -8,6 +8,7 @@ import (
 	"os"
 
 	"github.com/aunum/log"
+	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -26,15 +27,21 @@ func init() {
 		getConfigCmd,
 		initConfigCmd,
 		setConfigCmd,
+		unsetConfigCmd,
 		serversCmd,
+		migrateConfigCmd,
 	)
+	migrateConfigCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "Show the pending migrations and the changes they make without applying them")
 	setConfigCmd.AddCommand(setUnstableVersionsOptionCmd)
 	serversCmd.AddCommand(listServersCmd)
 	addDeleteServersCmd()
 	cli.DeprecateCommandWithAlternative(showConfigCmd, "1.5.0", "get")
 }
 
-var unattended bool
+var (
+	unattended    bool
+	migrateDryRun bool
+)
 
 func addDeleteServersCmd() {
 	listServersCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
@@ -84,9 +91,78 @@ var getConfigCmd = &cobra.Command{
 	},
 }
 
+var migrateConfigCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate the configuration to the current schema version",
+	Long: `Migrate the configuration to the current schema version.
+
+Pending migrations are otherwise applied when the configuration is loaded. The configuration
+is backed up before each migration is written.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var (
+			plan *config.MigrationPlan
+			err  error
+		)
+		if migrateDryRun {
+			plan, err = config.PlanMigration()
+		} else {
+			plan, err = config.MigrateClientConfig()
+		}
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if len(plan.Migrations) == 0 {
+			fmt.Fprintf(out, "Configuration is up to date at schema version %d\n", plan.FromVersion)
+			return nil
+		}
+		if migrateDryRun {
+			fmt.Fprintf(out, "Would migrate configuration from schema version %d to %d:\n", plan.FromVersion, plan.ToVersion)
+		} else {
+			fmt.Fprintf(out, "Migrated configuration from schema version %d to %d:\n", plan.FromVersion, plan.ToVersion)
+		}
+		for _, m := range plan.Migrations {
+			fmt.Fprintf(out, "  v%d: %s\n", m.Version, m.Description)
+		}
+		if migrateDryRun {
+			fmt.Fprintf(out, "\nChanges (-before +after):\n%s", cmp.Diff(plan.Before, plan.After))
+		}
+		return nil
+	},
+}
+
 var setConfigCmd = &cobra.Command{
 	Use:   "set <key> <value>",
-	Short: "Set config option key values. Options: [unstableversions]",
+	Short: "Set config option key values. Options: [unstableversions, plugins.<name>.env.<KEY>, plugins.<name>.flags.<flag>]",
+	Example: `
+    # Always pass --namespace tkg-prod to the cluster plugin commands that take it
+    tanzu config set plugins.cluster.flags.namespace tkg-prod
+
+    # Set an environment variable for the management-cluster plugin
+    tanzu config set plugins.management-cluster.env.TKG_CUSTOM_IMAGE_REPOSITORY registry.example.com/tkg`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return errors.Errorf("key and value required")
+		}
+		if !config.IsPluginOptionKey(args[0]) {
+			return errors.Errorf("unknown config option %q", args[0])
+		}
+		return config.SetPluginOption(args[0], args[1])
+	},
+}
+
+var unsetConfigCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Unset config option keys. Options: [plugins.<name>.env.<KEY>, plugins.<name>.flags.<flag>]",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !config.IsPluginOptionKey(args[0]) {
+			return errors.Errorf("unknown config option %q", args[0])
+		}
+		return config.UnsetPluginOption(args[0])
+	},
 }
 
 var setUnstableVersionsOptionCmd = &cobra.Command{
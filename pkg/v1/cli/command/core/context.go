@@ -0,0 +1,142 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	cliv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/cli/v1alpha1"
+	configv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
+)
+
+var (
+	ctxServer, ctxKubeconfig, ctxKubeContext, ctxNamespace string
+	ctxUse                                                 bool
+)
+
+var contextCmd = &cobra.Command{
+	Use:   "context",
+	Short: "Configure and manage contexts for the Tanzu CLI",
+	Annotations: map[string]string{
+		"group": string(cliv1alpha1.SystemCmdGroup),
+	},
+}
+
+func init() {
+	contextCmd.SetUsageFunc(cli.SubCmdUsageFunc)
+	contextCmd.AddCommand(
+		listContextCmd,
+		createContextCmd,
+		useContextCmd,
+		deleteContextCmd,
+	)
+
+	listContextCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+
+	createContextCmd.Flags().StringVar(&ctxServer, "server", "", "Name of the server the context targets, defaults to the current server")
+	createContextCmd.Flags().StringVar(&ctxKubeconfig, "kubeconfig", "", "Path to the kubeconfig holding the credentials, defaults to the kubeconfig of the server")
+	createContextCmd.Flags().StringVar(&ctxKubeContext, "kube-context", "", "Kubeconfig context to use, defaults to the context of the server")
+	createContextCmd.Flags().StringVarP(&ctxNamespace, "namespace", "n", "", "Default namespace of the context")
+	createContextCmd.Flags().BoolVar(&ctxUse, "use", false, "Make the context the current context")
+
+	deleteContextCmd.Flags().BoolVarP(&unattended, "yes", "y", false, "Delete the context without confirmation")
+}
+
+var listContextCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List contexts",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.GetClientConfig()
+		if err != nil {
+			return err
+		}
+
+		output := component.NewOutputWriter(cmd.OutOrStdout(), outputFormat, "Name", "Server", "Namespace", "Kubeconfig", "Kube Context", "Current")
+		for _, ctx := range cfg.KnownContexts {
+			output.AddRow(ctx.Name, ctx.Server, ctx.Namespace, ctx.Kubeconfig, ctx.KubeContext, ctx.Name == cfg.CurrentContext)
+		}
+		output.Render()
+		return nil
+	},
+}
+
+var createContextCmd = &cobra.Command{
+	Use:   "create CONTEXT_NAME",
+	Short: "Create a context for a server",
+	Args:  cobra.ExactArgs(1),
+	Example: `
+    # Create a context for the current server with a default namespace
+    tanzu context create prod-admin --namespace tkg-prod
+
+    # Create a context using another user of the management cluster and make it current
+    tanzu context create prod-viewer --server mc-prod --kubeconfig ~/.kube/viewer --kube-context viewer@mc-prod --use`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		server := ctxServer
+		if server == "" {
+			cfg, err := config.GetClientConfig()
+			if err != nil {
+				return err
+			}
+			if cfg.CurrentServer == "" {
+				return errors.New("no current server, specify the server of the context with --server")
+			}
+			server = cfg.CurrentServer
+		}
+
+		ctx := &configv1alpha1.Context{
+			Name:        args[0],
+			Server:      server,
+			Kubeconfig:  ctxKubeconfig,
+			KubeContext: ctxKubeContext,
+			Namespace:   ctxNamespace,
+		}
+		if err := config.AddContext(ctx, ctxUse); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Created context %q for server %q\n", ctx.Name, ctx.Server)
+		return nil
+	},
+}
+
+var useContextCmd = &cobra.Command{
+	Use:   "use CONTEXT_NAME",
+	Short: "Set the current context",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.SetCurrentContext(args[0]); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Switched to context %q\n", args[0])
+		return nil
+	},
+}
+
+var deleteContextCmd = &cobra.Command{
+	Use:   "delete CONTEXT_NAME",
+	Short: "Delete a context from the config",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		exists, err := config.ContextExists(args[0])
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return errors.Errorf("context %s not found in list of known contexts", args[0])
+		}
+
+		if !unattended {
+			if err := cli.AskForConfirmation(fmt.Sprintf("Deleting context %s. Are you sure you want to continue?", args[0])); err != nil {
+				return nil
+			}
+		}
+		return config.RemoveContext(args[0])
+	},
+}
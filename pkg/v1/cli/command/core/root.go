@@ -55,6 +55,7 @@ func NewRootCmd() (*cobra.Command, error) {
 		versionCmd,
 		completionCmd,
 		configCmd,
+		contextCmd,
 		genAllDocsCmd,
 	)
 
@@ -0,0 +1,73 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"os"
+	"strings"
+
+	"github.com/aunum/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli"
+)
+
+// applyFlagDefaults sets the default flag values configured for the plugin, which the CLI
+// passes in the plugin state, on the command the args resolve to. Flags given in the args
+// and flags the command does not have are left alone.
+func applyFlagDefaults(root *cobra.Command, args []string) error {
+	statePath := os.Getenv(cli.EnvPluginStateKey)
+	if statePath == "" {
+		return nil
+	}
+	state, err := cli.ReadPluginStateFromPath(statePath)
+	if err != nil {
+		log.Debugf("unable to read plugin state: %v", err)
+		return nil
+	}
+	return setFlagDefaults(root, args, state.Flags)
+}
+
+// setFlagDefaults sets the flag values on the command the args resolve to.
+func setFlagDefaults(root *cobra.Command, args []string, flags map[string]string) error {
+	if len(flags) == 0 {
+		return nil
+	}
+	cmd, _, err := root.Find(args)
+	if err != nil {
+		return nil
+	}
+	for name, value := range flags {
+		f := cmd.LocalFlags().Lookup(name)
+		if f == nil {
+			f = cmd.InheritedFlags().Lookup(name)
+		}
+		if f == nil || flagInArgs(f, args) {
+			continue
+		}
+		if err := f.Value.Set(value); err != nil {
+			return errors.Wrapf(err, "invalid default value %q of flag --%s configured for the plugin", value, name)
+		}
+		f.Changed = true
+	}
+	return nil
+}
+
+// flagInArgs tells whether the flag is given in the args.
+func flagInArgs(f *pflag.Flag, args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			return false
+		}
+		if arg == "--"+f.Name || strings.HasPrefix(arg, "--"+f.Name+"=") {
+			return true
+		}
+		if f.Shorthand != "" && !strings.HasPrefix(arg, "--") && strings.HasPrefix(arg, "-"+f.Shorthand) {
+			return true
+		}
+	}
+	return false
+}
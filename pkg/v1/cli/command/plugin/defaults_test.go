@@ -0,0 +1,39 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetFlagDefaults(t *testing.T) {
+	assert := assert.New(t)
+
+	var namespace, output string
+	root := &cobra.Command{Use: "cluster"}
+	root.PersistentFlags().StringVarP(&output, "output", "o", "", "")
+	listCmd := &cobra.Command{Use: "list", RunE: func(cmd *cobra.Command, args []string) error { return nil }}
+	listCmd.Flags().StringVarP(&namespace, "namespace", "n", "", "")
+	root.AddCommand(listCmd)
+
+	flags := map[string]string{"namespace": "tkg-prod", "output": "json", "unknown": "x"}
+	err := setFlagDefaults(root, []string{"list"}, flags)
+	assert.Nil(err)
+	assert.Equal("tkg-prod", namespace)
+	assert.Equal("json", output)
+	assert.True(listCmd.Flags().Lookup("namespace").Changed)
+
+	namespace, output = "", ""
+	listCmd.Flags().Lookup("namespace").Changed = false
+	root.SetArgs([]string{"list", "-n", "default", "--output=yaml"})
+	err = setFlagDefaults(root, []string{"list", "-n", "default", "--output=yaml"}, flags)
+	assert.Nil(err)
+	assert.Equal("", namespace)
+	assert.Nil(root.Execute())
+	assert.Equal("default", namespace)
+	assert.Equal("yaml", output)
+}
@@ -4,6 +4,8 @@
 package plugin
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
 
 	cliv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/cli/v1alpha1"
@@ -51,5 +53,8 @@ func (p *Plugin) AddCommands(commands ...*cobra.Command) {
 
 // Execute executes the plugin.
 func (p *Plugin) Execute() error {
+	if err := applyFlagDefaults(p.Cmd, os.Args[1:]); err != nil {
+		return err
+	}
 	return p.Cmd.Execute()
 }
@@ -0,0 +1,22 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
+)
+
+const namespaceFlag = "namespace"
+
+// ApplyContextNamespace sets namespace to the default namespace of the current context
+// when the namespace flag of the command was not set on the command line.
+func ApplyContextNamespace(cmd *cobra.Command, namespace *string) {
+	f := cmd.Flags().Lookup(namespaceFlag)
+	if f == nil || f.Changed {
+		return
+	}
+	*namespace = config.DefaultNamespace(*namespace)
+}
@@ -13,6 +13,9 @@ import (
 	"path/filepath"
 
 	"github.com/aunum/log"
+
+	configv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/config"
 )
 
 // Runner is a plugin runner.
@@ -90,8 +93,15 @@ func (r *Runner) run(ctx context.Context, pluginPath string, stdout, stderr *byt
 		}
 	}()
 
+	pluginOpts, err := config.GetPluginOptions(r.name)
+	if err != nil {
+		log.Warningf("unable to read options of plugin %q: %v", r.name, err)
+		pluginOpts = &configv1alpha1.PluginOptions{}
+	}
+
 	state := &PluginState{
-		Auth: "auth",
+		Auth:  "auth",
+		Flags: pluginOpts.Flags,
 	}
 
 	if err := json.NewEncoder(stateFile).Encode(state); err != nil {
@@ -102,7 +112,14 @@ func (r *Runner) run(ctx context.Context, pluginPath string, stdout, stderr *byt
 		return fmt.Errorf("close state file: %w", err)
 	}
 
-	env := append(os.Environ(), fmt.Sprintf("%s=%s", EnvPluginStateKey, stateFile.Name()))
+	// Variables set in the environment of the CLI take precedence over those configured for the plugin.
+	env := os.Environ()
+	for key, value := range pluginOpts.Env {
+		if _, ok := os.LookupEnv(key); !ok {
+			env = append(env, fmt.Sprintf("%s=%s", key, value))
+		}
+	}
+	env = append(env, fmt.Sprintf("%s=%s", EnvPluginStateKey, stateFile.Name()))
 
 	log.Debugf("running command path %s args: %+v", pluginPath, r.args)
 	cmd := exec.CommandContext(ctx, pluginPath, r.args...)
@@ -18,6 +18,9 @@ const (
 // PluginState is state that will be passed to plugins.
 type PluginState struct {
 	Auth string `json:"auth" yaml:"auth"`
+
+	// Flags are the default flag values configured for the plugin, by flag name.
+	Flags map[string]string `json:"flags,omitempty" yaml:"flags"`
 }
 
 // ReadPluginStateFromPath read states from a path on disk.
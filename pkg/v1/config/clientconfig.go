@@ -159,6 +159,10 @@ func GetClientConfig() (cfg *configv1alpha1.ClientConfig, err error) {
 		}
 		return cfg, nil
 	}
+	_, b, err = migrateConfigFile(cfgPath, b)
+	if err != nil {
+		return nil, err
+	}
 	scheme, err := configv1alpha1.SchemeBuilder.Build()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create scheme")
@@ -232,6 +236,9 @@ func StoreClientConfig(cfg *configv1alpha1.ClientConfig) error {
 
 	s := json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme, scheme,
 		json.SerializerOptions{Yaml: true, Pretty: false, Strict: false})
+	if cfg.SchemaVersion == 0 {
+		cfg.SchemaVersion = CurrentSchemaVersion()
+	}
 	// Set GVK explicitly as encoder does not do it.
 	cfg.GetObjectKind().SetGroupVersionKind(configv1alpha1.GroupVersionKind)
 	buf := new(bytes.Buffer)
@@ -344,6 +351,16 @@ func RemoveServer(name string) error {
 		cfg.CurrentServer = ""
 	}
 
+	newContexts := []*configv1alpha1.Context{}
+	for _, ctx := range cfg.KnownContexts {
+		if ctx.Server != name {
+			newContexts = append(newContexts, ctx)
+		} else if cfg.CurrentContext == ctx.Name {
+			cfg.CurrentContext = ""
+		}
+	}
+	cfg.KnownContexts = newContexts
+
 	err = StoreClientConfig(cfg)
 	if err != nil {
 		return err
@@ -367,6 +384,9 @@ func SetCurrentServer(name string) error {
 		return fmt.Errorf("could not set current server; %q is not a known server", name)
 	}
 	cfg.CurrentServer = name
+	if ctx, err := cfg.GetCurrentContext(); err != nil || (ctx != nil && ctx.Server != name) {
+		cfg.CurrentContext = ""
+	}
 	err = StoreClientConfig(cfg)
 	if err != nil {
 		return err
@@ -374,15 +394,20 @@ func SetCurrentServer(name string) error {
 	return nil
 }
 
-// GetCurrentServer sets the current server.
+// GetCurrentServer gets the current server. If the current context targets the current
+// server, the returned server uses the kubeconfig and kubeconfig context of the current context.
 func GetCurrentServer() (s *configv1alpha1.Server, err error) {
 	cfg, err := GetClientConfig()
 	if err != nil {
 		return s, err
 	}
+	ctx, err := cfg.GetCurrentContext()
+	if err != nil {
+		return s, err
+	}
 	for _, server := range cfg.KnownServers {
 		if server.Name == cfg.CurrentServer {
-			return server, nil
+			return applyContext(server, ctx), nil
 		}
 	}
 	return s, fmt.Errorf("current server %q not found in tanzu config", cfg.CurrentServer)
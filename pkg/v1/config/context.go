@@ -0,0 +1,181 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+
+	configv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+)
+
+// GetContext by name.
+func GetContext(name string) (c *configv1alpha1.Context, err error) {
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return c, err
+	}
+	for _, ctx := range cfg.KnownContexts {
+		if ctx.Name == name {
+			return ctx, nil
+		}
+	}
+	return c, fmt.Errorf("could not find context %q", name)
+}
+
+// ContextExists tells whether the context by the given name exists.
+func ContextExists(name string) (bool, error) {
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return false, err
+	}
+	for _, ctx := range cfg.KnownContexts {
+		if ctx.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// AddContext adds a context to the config.
+func AddContext(c *configv1alpha1.Context, setCurrent bool) error {
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return err
+	}
+	for _, ctx := range cfg.KnownContexts {
+		if ctx.Name == c.Name {
+			return fmt.Errorf("context %q already exists", c.Name)
+		}
+	}
+	if err := validateContext(cfg, c); err != nil {
+		return err
+	}
+	cfg.KnownContexts = append(cfg.KnownContexts, c)
+	if setCurrent {
+		cfg.CurrentContext = c.Name
+		cfg.CurrentServer = c.Server
+	}
+	return StoreClientConfig(cfg)
+}
+
+// PutContext adds or updates the context.
+func PutContext(c *configv1alpha1.Context, setCurrent bool) error {
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return err
+	}
+	if err := validateContext(cfg, c); err != nil {
+		return err
+	}
+	newContexts := []*configv1alpha1.Context{c}
+	for _, ctx := range cfg.KnownContexts {
+		if ctx.Name == c.Name {
+			continue
+		}
+		newContexts = append(newContexts, ctx)
+	}
+	cfg.KnownContexts = newContexts
+	if setCurrent || cfg.CurrentContext == c.Name {
+		cfg.CurrentContext = c.Name
+		cfg.CurrentServer = c.Server
+	}
+	return StoreClientConfig(cfg)
+}
+
+// RemoveContext removes a context from the config.
+func RemoveContext(name string) error {
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return err
+	}
+
+	newContexts := []*configv1alpha1.Context{}
+	for _, ctx := range cfg.KnownContexts {
+		if ctx.Name != name {
+			newContexts = append(newContexts, ctx)
+		}
+	}
+	cfg.KnownContexts = newContexts
+
+	if cfg.CurrentContext == name {
+		cfg.CurrentContext = ""
+	}
+
+	return StoreClientConfig(cfg)
+}
+
+// SetCurrentContext sets the current context and makes its server the current server.
+func SetCurrentContext(name string) error {
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return err
+	}
+	var ctx *configv1alpha1.Context
+	for _, c := range cfg.KnownContexts {
+		if c.Name == name {
+			ctx = c
+		}
+	}
+	if ctx == nil {
+		return fmt.Errorf("could not set current context; %q is not a known context", name)
+	}
+	if err := validateContext(cfg, ctx); err != nil {
+		return err
+	}
+	cfg.CurrentContext = name
+	cfg.CurrentServer = ctx.Server
+	return StoreClientConfig(cfg)
+}
+
+// GetCurrentContext returns the current context, or nil if no context is in use.
+func GetCurrentContext() (c *configv1alpha1.Context, err error) {
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return c, err
+	}
+	return cfg.GetCurrentContext()
+}
+
+// DefaultNamespace returns the namespace of the current context, or fallback if
+// there is no current context or it has no namespace.
+func DefaultNamespace(fallback string) string {
+	ctx, err := GetCurrentContext()
+	if err != nil || ctx == nil || ctx.Namespace == "" {
+		return fallback
+	}
+	return ctx.Namespace
+}
+
+// validateContext checks that the context targets a known server.
+func validateContext(cfg *configv1alpha1.ClientConfig, c *configv1alpha1.Context) error {
+	if c.Name == "" {
+		return fmt.Errorf("context name is required")
+	}
+	for _, server := range cfg.KnownServers {
+		if server.Name != c.Server {
+			continue
+		}
+		if !server.IsManagementCluster() && (c.Kubeconfig != "" || c.KubeContext != "") {
+			return fmt.Errorf("context %q sets a kubeconfig but server %q is not a management cluster", c.Name, c.Server)
+		}
+		return nil
+	}
+	return fmt.Errorf("context %q refers to unknown server %q", c.Name, c.Server)
+}
+
+// applyContext returns a copy of the server with the kubeconfig and kubeconfig
+// context of the given context, if the context targets the server.
+func applyContext(s *configv1alpha1.Server, c *configv1alpha1.Context) *configv1alpha1.Server {
+	if c == nil || c.Server != s.Name || s.ManagementClusterOpts == nil {
+		return s
+	}
+	s = s.DeepCopy()
+	if c.Kubeconfig != "" {
+		s.ManagementClusterOpts.Path = c.Kubeconfig
+	}
+	if c.KubeContext != "" {
+		s.ManagementClusterOpts.Context = c.KubeContext
+	}
+	return s
+}
@@ -0,0 +1,108 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	configv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+)
+
+func TestContexts(t *testing.T) {
+	LocalDirName = fmt.Sprintf(".tanzu-test-%s", randString())
+	testCfg := &configv1alpha1.ClientConfig{
+		KnownServers: []*configv1alpha1.Server{
+			{
+				Name: "mc",
+				Type: configv1alpha1.ManagementClusterServerType,
+				ManagementClusterOpts: &configv1alpha1.ManagementClusterServer{
+					Path:    "admin-kubeconfig",
+					Context: "admin@mc",
+				},
+			},
+			{
+				Name: "other",
+				Type: configv1alpha1.ManagementClusterServerType,
+				ManagementClusterOpts: &configv1alpha1.ManagementClusterServer{
+					Path:    "other-kubeconfig",
+					Context: "admin@other",
+				},
+			},
+		},
+		CurrentServer: "mc",
+	}
+
+	err := StoreClientConfig(testCfg)
+	require.NoError(t, err)
+
+	defer cleanupDir(LocalDirName)
+
+	require.Equal(t, "fallback", DefaultNamespace("fallback"))
+
+	err = AddContext(&configv1alpha1.Context{Name: "bad", Server: "unknown"}, false)
+	require.Error(t, err)
+
+	viewer := &configv1alpha1.Context{
+		Name:        "viewer",
+		Server:      "mc",
+		Kubeconfig:  "viewer-kubeconfig",
+		KubeContext: "viewer@mc",
+		Namespace:   "tkg-prod",
+	}
+	err = AddContext(viewer, false)
+	require.NoError(t, err)
+
+	err = AddContext(viewer, false)
+	require.Error(t, err)
+
+	e, err := ContextExists("viewer")
+	require.NoError(t, err)
+	require.True(t, e)
+
+	s, err := GetCurrentServer()
+	require.NoError(t, err)
+	require.Equal(t, "admin-kubeconfig", s.ManagementClusterOpts.Path)
+
+	err = SetCurrentContext("viewer")
+	require.NoError(t, err)
+
+	s, err = GetCurrentServer()
+	require.NoError(t, err)
+	require.Equal(t, "viewer-kubeconfig", s.ManagementClusterOpts.Path)
+	require.Equal(t, "viewer@mc", s.ManagementClusterOpts.Context)
+	require.Equal(t, "tkg-prod", DefaultNamespace("default"))
+
+	// The stored server is not changed by the context.
+	s, err = GetServer("mc")
+	require.NoError(t, err)
+	require.Equal(t, "admin-kubeconfig", s.ManagementClusterOpts.Path)
+
+	// Switching to another server leaves the context.
+	err = SetCurrentServer("other")
+	require.NoError(t, err)
+	ctx, err := GetCurrentContext()
+	require.NoError(t, err)
+	require.Nil(t, ctx)
+	require.Equal(t, "default", DefaultNamespace("default"))
+
+	err = SetCurrentContext("viewer")
+	require.NoError(t, err)
+	c, err := GetClientConfig()
+	require.NoError(t, err)
+	require.Equal(t, "mc", c.CurrentServer)
+
+	// Removing the server removes its contexts.
+	err = RemoveServer("mc")
+	require.NoError(t, err)
+	c, err = GetClientConfig()
+	require.NoError(t, err)
+	require.Len(t, c.KnownContexts, 0)
+	require.Equal(t, "", c.CurrentContext)
+
+	err = DeleteClientConfig()
+	require.NoError(t, err)
+}
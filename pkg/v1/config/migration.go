@@ -0,0 +1,237 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/aunum/log"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/utils"
+)
+
+// schemaVersionKey is the key of the schema version in the persisted config.
+const schemaVersionKey = "schemaVersion"
+
+// Migration is a change of the schema of the persisted client config.
+type Migration struct {
+	// Version is the schema version the migration upgrades the config to.
+	Version int
+
+	// Description of the change made by the migration.
+	Description string
+
+	// Migrate changes the config, in its unstructured form, to the schema version.
+	Migrate func(cfg map[string]interface{}) error
+}
+
+// MigrationPlan describes the migrations of the persisted client config.
+type MigrationPlan struct {
+	// FromVersion is the schema version of the persisted config.
+	FromVersion int
+
+	// ToVersion is the schema version after the migrations.
+	ToVersion int
+
+	// Migrations to apply in order.
+	Migrations []*Migration
+
+	// Before is the persisted config.
+	Before map[string]interface{}
+
+	// After is the config once the migrations are applied.
+	After map[string]interface{}
+}
+
+// migrations are the registered migrations, ordered by version.
+var migrations []*Migration
+
+func init() {
+	_ = RegisterMigration(&Migration{
+		Version:     1,
+		Description: "Add explicit schema version",
+		Migrate: func(cfg map[string]interface{}) error {
+			return nil
+		},
+	})
+}
+
+// RegisterMigration registers a migration of the client config schema. Migrations
+// are applied in the order of their versions when the config is loaded.
+func RegisterMigration(m *Migration) error {
+	if m.Version <= 0 {
+		return errors.Errorf("migration %q has invalid version %d", m.Description, m.Version)
+	}
+	for _, existing := range migrations {
+		if existing.Version == m.Version {
+			return errors.Errorf("migration to version %d is already registered", m.Version)
+		}
+	}
+	migrations = append(migrations, m)
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return nil
+}
+
+// CurrentSchemaVersion returns the latest schema version of the client config.
+func CurrentSchemaVersion() int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// PlanMigration returns the migrations that would be applied to the persisted config, without changing it.
+func PlanMigration() (*MigrationPlan, error) {
+	cfgPath, err := ClientConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(cfgPath)
+	if err != nil {
+		return nil, NewConfigNotExistError(err)
+	}
+	return planMigration(b)
+}
+
+// MigrateClientConfig applies the pending migrations to the persisted config.
+func MigrateClientConfig() (*MigrationPlan, error) {
+	cfgPath, err := ClientConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(cfgPath)
+	if err != nil {
+		return nil, NewConfigNotExistError(err)
+	}
+	plan, _, err := migrateConfigFile(cfgPath, b)
+	return plan, err
+}
+
+// migrateConfigFile applies the pending migrations to the config file read as b. The config file is
+// read again and migrated under the tanzu file lock, so that concurrent CLI invocations migrate it once.
+// It returns the migrated config data.
+func migrateConfigFile(cfgPath string, b []byte) (*MigrationPlan, []byte, error) {
+	plan, err := planMigration(b)
+	if err != nil || len(plan.Migrations) == 0 {
+		return plan, b, err
+	}
+
+	localDir, err := LocalDir()
+	if err != nil {
+		return nil, nil, err
+	}
+	lock, err := utils.GetFileLockWithTimeOut(filepath.Join(localDir, constants.LocalTanzuFileLock), utils.DefaultLockTimeout)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot acquire lock for migrating the config file")
+	}
+	defer func() {
+		if err := lock.Unlock(); err != nil {
+			log.Warningf("cannot release lock for migrating the config file, reason: %v", err)
+		}
+	}()
+
+	b, err = os.ReadFile(cfgPath)
+	if err != nil {
+		return nil, nil, NewConfigNotExistError(err)
+	}
+	return migrateConfigData(cfgPath, b)
+}
+
+// planMigration computes the migrations for the config data.
+func planMigration(b []byte) (*MigrationPlan, error) {
+	before := map[string]interface{}{}
+	if err := yaml.Unmarshal(b, &before); err != nil {
+		return nil, errors.Wrap(err, "could not decode config file")
+	}
+	after := map[string]interface{}{}
+	if err := yaml.Unmarshal(b, &after); err != nil {
+		return nil, errors.Wrap(err, "could not decode config file")
+	}
+
+	version, err := schemaVersion(before)
+	if err != nil {
+		return nil, err
+	}
+	plan := &MigrationPlan{FromVersion: version, ToVersion: version, Before: before, After: after}
+	if version > CurrentSchemaVersion() {
+		return nil, errors.Errorf("config schema version %d is newer than the supported version %d, please upgrade the CLI",
+			version, CurrentSchemaVersion())
+	}
+	for _, m := range migrations {
+		if m.Version <= version {
+			continue
+		}
+		if err := m.Migrate(after); err != nil {
+			return nil, errors.Wrapf(err, "could not migrate config to schema version %d", m.Version)
+		}
+		after[schemaVersionKey] = m.Version
+		plan.Migrations = append(plan.Migrations, m)
+		plan.ToVersion = m.Version
+	}
+	return plan, nil
+}
+
+// migrateConfigData applies the pending migrations to the config data one by one, backing up
+// the config before each migration is written. It returns the migrated config data.
+func migrateConfigData(cfgPath string, b []byte) (*MigrationPlan, []byte, error) {
+	plan, err := planMigration(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(plan.Migrations) == 0 {
+		return plan, b, nil
+	}
+
+	cfg := map[string]interface{}{}
+	if err := yaml.Unmarshal(b, &cfg); err != nil {
+		return nil, nil, errors.Wrap(err, "could not decode config file")
+	}
+	version := plan.FromVersion
+	for _, m := range plan.Migrations {
+		backupPath := fmt.Sprintf("%s.v%d.bak", cfgPath, version)
+		if err := os.WriteFile(backupPath, b, 0644); err != nil {
+			return nil, nil, errors.Wrapf(err, "could not back up config before migrating to schema version %d", m.Version)
+		}
+		if err := m.Migrate(cfg); err != nil {
+			return nil, nil, errors.Wrapf(err, "could not migrate config to schema version %d", m.Version)
+		}
+		cfg[schemaVersionKey] = m.Version
+		b, err = yaml.Marshal(cfg)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to encode config file")
+		}
+		if err := os.WriteFile(cfgPath, b, 0644); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to write config file")
+		}
+		storeConfigToLegacyDir(b)
+		fmt.Fprintf(os.Stderr, "Migrated config to schema version %d: %s, backup stored in %s\n", m.Version, m.Description, backupPath)
+		version = m.Version
+	}
+	return plan, b, nil
+}
+
+// schemaVersion returns the schema version of the unstructured config, configs
+// persisted before the schema was versioned are at version 0.
+func schemaVersion(cfg map[string]interface{}) (int, error) {
+	v, ok := cfg[schemaVersionKey]
+	if !ok {
+		return 0, nil
+	}
+	switch version := v.(type) {
+	case float64:
+		return int(version), nil
+	case int64:
+		return int(version), nil
+	case int:
+		return version, nil
+	default:
+		return 0, errors.Errorf("invalid config schema version %v", v)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const legacyClientConfig = `apiVersion: config.tanzu.vmware.com/v1alpha1
+kind: ClientConfig
+metadata:
+  creationTimestamp: null
+current: mc
+servers:
+- managementClusterOpts:
+    context: admin@mc
+    path: admin-kubeconfig
+  name: mc
+  type: managementcluster
+`
+
+func TestMigrateClientConfig(t *testing.T) {
+	LocalDirName = fmt.Sprintf(".tanzu-test-%s", randString())
+	defer cleanupDir(LocalDirName)
+
+	localDir, err := LocalDir()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(localDir, 0755))
+	cfgPath, err := ClientConfigPath()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cfgPath, []byte(legacyClientConfig), 0644))
+
+	plan, err := PlanMigration()
+	require.NoError(t, err)
+	require.Equal(t, 0, plan.FromVersion)
+	require.Equal(t, CurrentSchemaVersion(), plan.ToVersion)
+	require.Len(t, plan.Migrations, CurrentSchemaVersion())
+	require.NotContains(t, plan.Before, schemaVersionKey)
+	require.Equal(t, CurrentSchemaVersion(), plan.After[schemaVersionKey])
+
+	// Planning does not change the config.
+	b, err := os.ReadFile(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, legacyClientConfig, string(b))
+
+	cfg, err := GetClientConfig()
+	require.NoError(t, err)
+	require.Equal(t, CurrentSchemaVersion(), cfg.SchemaVersion)
+	require.Equal(t, "mc", cfg.CurrentServer)
+
+	backup, err := os.ReadFile(cfgPath + ".v0.bak")
+	require.NoError(t, err)
+	require.Equal(t, legacyClientConfig, string(backup))
+
+	plan, err = MigrateClientConfig()
+	require.NoError(t, err)
+	require.Len(t, plan.Migrations, 0)
+
+	// Configs written by a newer CLI are refused.
+	cfg.SchemaVersion = CurrentSchemaVersion() + 1
+	require.NoError(t, StoreClientConfig(cfg))
+	_, err = GetClientConfig()
+	require.Error(t, err)
+}
+
+func TestMigrateClientConfigOutput(t *testing.T) {
+	LocalDirName = fmt.Sprintf(".tanzu-test-%s", randString())
+	defer cleanupDir(LocalDirName)
+
+	localDir, err := LocalDir()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(localDir, 0755))
+	cfgPath, err := ClientConfigPath()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cfgPath, []byte(legacyClientConfig), 0644))
+
+	// The migration notice must not end up in the output of commands, e.g. with -o json.
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout = w
+	_, err = GetClientConfig()
+	os.Stdout = stdout
+	require.NoError(t, w.Close())
+	require.NoError(t, err)
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Empty(t, string(out))
+
+	_, err = os.Stat(cfgPath + ".v0.bak")
+	require.NoError(t, err)
+}
+
+func TestRegisterMigration(t *testing.T) {
+	require.Error(t, RegisterMigration(&Migration{Version: 0}))
+	require.Error(t, RegisterMigration(&Migration{Version: CurrentSchemaVersion()}))
+}
@@ -0,0 +1,119 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	configv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+)
+
+const (
+	pluginsConfigKey = "plugins"
+	pluginEnvKey     = "env"
+	pluginFlagsKey   = "flags"
+)
+
+// GetPluginOptions returns the options of the plugin by the given name. The options are
+// empty if none are configured.
+func GetPluginOptions(name string) (*configv1alpha1.PluginOptions, error) {
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ClientOptions == nil || cfg.ClientOptions.CLI == nil || cfg.ClientOptions.CLI.Plugins[name] == nil {
+		return &configv1alpha1.PluginOptions{}, nil
+	}
+	return cfg.ClientOptions.CLI.Plugins[name].DeepCopy(), nil
+}
+
+// SetPluginOption sets a plugin option by its key, either plugins.<name>.env.<KEY>
+// or plugins.<name>.flags.<flag>.
+func SetPluginOption(key, value string) error {
+	name, kind, option, err := parsePluginOptionKey(key)
+	if err != nil {
+		return err
+	}
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.ClientOptions == nil {
+		cfg.ClientOptions = &configv1alpha1.ClientOptions{}
+	}
+	if cfg.ClientOptions.CLI == nil {
+		cfg.ClientOptions.CLI = &configv1alpha1.CLIOptions{}
+	}
+	if cfg.ClientOptions.CLI.Plugins == nil {
+		cfg.ClientOptions.CLI.Plugins = map[string]*configv1alpha1.PluginOptions{}
+	}
+	opts := cfg.ClientOptions.CLI.Plugins[name]
+	if opts == nil {
+		opts = &configv1alpha1.PluginOptions{}
+		cfg.ClientOptions.CLI.Plugins[name] = opts
+	}
+	switch kind {
+	case pluginEnvKey:
+		if opts.Env == nil {
+			opts.Env = map[string]string{}
+		}
+		opts.Env[option] = value
+	case pluginFlagsKey:
+		if opts.Flags == nil {
+			opts.Flags = map[string]string{}
+		}
+		opts.Flags[option] = value
+	}
+	return StoreClientConfig(cfg)
+}
+
+// UnsetPluginOption removes a plugin option by its key, either plugins.<name>.env.<KEY>
+// or plugins.<name>.flags.<flag>.
+func UnsetPluginOption(key string) error {
+	name, kind, option, err := parsePluginOptionKey(key)
+	if err != nil {
+		return err
+	}
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.ClientOptions == nil || cfg.ClientOptions.CLI == nil || cfg.ClientOptions.CLI.Plugins[name] == nil {
+		return nil
+	}
+	opts := cfg.ClientOptions.CLI.Plugins[name]
+	switch kind {
+	case pluginEnvKey:
+		delete(opts.Env, option)
+	case pluginFlagsKey:
+		delete(opts.Flags, option)
+	}
+	if len(opts.Env) == 0 && len(opts.Flags) == 0 {
+		delete(cfg.ClientOptions.CLI.Plugins, name)
+	}
+	return StoreClientConfig(cfg)
+}
+
+// IsPluginOptionKey tells whether the config key is a plugin option.
+func IsPluginOptionKey(key string) bool {
+	return strings.HasPrefix(key, pluginsConfigKey+".")
+}
+
+// parsePluginOptionKey splits a plugins.<name>.<env|flags>.<option> key.
+func parsePluginOptionKey(key string) (name, kind, option string, err error) {
+	parts := strings.SplitN(key, ".", 4)
+	if len(parts) != 4 || parts[0] != pluginsConfigKey || parts[1] == "" || parts[3] == "" {
+		return "", "", "", errors.Errorf("invalid plugin option %q, expected plugins.<name>.env.<KEY> or plugins.<name>.flags.<flag>", key)
+	}
+	if parts[2] != pluginEnvKey && parts[2] != pluginFlagsKey {
+		return "", "", "", errors.Errorf("invalid plugin option %q, unknown option kind %q, expected env or flags", key, parts[2])
+	}
+	option = parts[3]
+	if parts[2] == pluginFlagsKey {
+		option = strings.TrimLeft(option, "-")
+	}
+	return parts[1], parts[2], option, nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginOptions(t *testing.T) {
+	LocalDirName = fmt.Sprintf(".tanzu-test-%s", randString())
+	defer cleanupDir(LocalDirName)
+
+	opts, err := GetPluginOptions("cluster")
+	require.NoError(t, err)
+	require.Empty(t, opts.Env)
+	require.Empty(t, opts.Flags)
+
+	require.Error(t, SetPluginOption("plugins.cluster", "x"))
+	require.Error(t, SetPluginOption("plugins.cluster.args.namespace", "x"))
+
+	err = SetPluginOption("plugins.cluster.flags.--namespace", "tkg-prod")
+	require.NoError(t, err)
+	err = SetPluginOption("plugins.management-cluster.env.TKG_CUSTOM_IMAGE_REPOSITORY", "registry.example.com/tkg")
+	require.NoError(t, err)
+
+	opts, err = GetPluginOptions("cluster")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"namespace": "tkg-prod"}, opts.Flags)
+
+	opts, err = GetPluginOptions("management-cluster")
+	require.NoError(t, err)
+	require.Equal(t, "registry.example.com/tkg", opts.Env["TKG_CUSTOM_IMAGE_REPOSITORY"])
+
+	err = UnsetPluginOption("plugins.cluster.flags.namespace")
+	require.NoError(t, err)
+	cfg, err := GetClientConfig()
+	require.NoError(t, err)
+	require.NotContains(t, cfg.ClientOptions.CLI.Plugins, "cluster")
+	require.Contains(t, cfg.ClientOptions.CLI.Plugins, "management-cluster")
+}
@@ -0,0 +1,111 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package proto
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"                  // nolint
+	structpb "github.com/golang/protobuf/ptypes/struct" // nolint
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
+)
+
+func newStruct() proto.Message {
+	return &structpb.Struct{}
+}
+
+func nameOf(t *testing.T, message proto.Message) string {
+	s, ok := message.(*structpb.Struct)
+	require.True(t, ok)
+	return s.Fields["name"].GetStringValue()
+}
+
+func TestBufferToProtoListYAML(t *testing.T) {
+	input := `# leading comment
+---
+name: one
+---
+# empty document
+---
+name: two
+`
+	messages, err := BufferToProtoList(bytes.NewBufferString(input), newStruct, "yaml")
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	require.Equal(t, "one", nameOf(t, messages[0]))
+	require.Equal(t, "two", nameOf(t, messages[1]))
+}
+
+func TestBufferToProtoListJSONArray(t *testing.T) {
+	input := `[
+  {"name": "one"},
+  {"name": "two"}
+]`
+	messages, err := BufferToProtoList(bytes.NewBufferString(input), newStruct, "")
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	require.Equal(t, "one", nameOf(t, messages[0]))
+	require.Equal(t, "two", nameOf(t, messages[1]))
+}
+
+func TestBufferToProtoListErrorPositions(t *testing.T) {
+	input := `name: one
+---
+- not
+- an
+- object
+---
+name: three
+---
+[broken
+`
+	_, err := BufferToProtoList(bytes.NewBufferString(input), newStruct, "yml")
+	require.Error(t, err)
+
+	var docErrs DocumentErrors
+	require.True(t, errors.As(err, &docErrs))
+	require.Len(t, docErrs, 2)
+	require.Equal(t, 1, docErrs[0].Index)
+	require.Equal(t, 3, docErrs[0].Line)
+	require.Equal(t, 3, docErrs[1].Index)
+	require.Equal(t, 9, docErrs[1].Line)
+}
+
+func TestBufferToProtoListEmpty(t *testing.T) {
+	_, err := BufferToProtoList(bytes.NewBufferString("# nothing here\n---\n"), newStruct, "yaml")
+	require.Error(t, err)
+}
+
+func TestProtoToOutput(t *testing.T) {
+	messages, err := BufferToProtoList(bytes.NewBufferString("name: one\n---\nname: two\n"), newStruct, "yaml")
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, ProtoToOutput(&b, component.YAMLOutputType, messages...))
+	require.Equal(t, "name: one\n---\nname: two\n", b.String())
+
+	roundTrip, err := BufferToProtoList(&b, newStruct, "yaml")
+	require.NoError(t, err)
+	require.Len(t, roundTrip, 2)
+	require.True(t, proto.Equal(messages[1], roundTrip[1]))
+
+	b.Reset()
+	require.NoError(t, ProtoToOutput(&b, component.JSONOutputType, messages...))
+	roundTrip, err = BufferToProtoList(&b, newStruct, "json")
+	require.NoError(t, err)
+	require.Len(t, roundTrip, 2)
+	require.True(t, proto.Equal(messages[0], roundTrip[0]))
+
+	b.Reset()
+	require.NoError(t, ProtoToOutput(&b, component.JSONOutputType, messages[0]))
+	require.Equal(t, "{\n  \"name\": \"one\"\n}\n", b.String())
+
+	b.Reset()
+	require.NoError(t, ProtoToOutput(&b, component.TableOutputType, messages...))
+	require.Equal(t, "name: one\n---\nname: two\n", b.String())
+}
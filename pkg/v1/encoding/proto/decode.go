@@ -0,0 +1,197 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package proto
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto" // nolint
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
+)
+
+// DocumentError is the error for a single document of a multi-document input.
+type DocumentError struct {
+	// Index is the zero based position of the document in the input.
+	Index int
+	// Line is the line on which the document starts in the input.
+	Line int
+	// Err is the underlying decoding error.
+	Err error
+}
+
+// Error returns the error message including the document position.
+func (e *DocumentError) Error() string {
+	return fmt.Sprintf("document %d (line %d): %v", e.Index, e.Line, e.Err)
+}
+
+// Unwrap returns the underlying decoding error.
+func (e *DocumentError) Unwrap() error {
+	return e.Err
+}
+
+// DocumentErrors is the list of errors for all documents that failed to decode.
+type DocumentErrors []*DocumentError
+
+// Error returns the error messages of all failed documents.
+func (e DocumentErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, docErr := range e {
+		msgs = append(msgs, docErr.Error())
+	}
+	return fmt.Sprintf("unable to decode %d document(s): %s", len(e), strings.Join(msgs, "; "))
+}
+
+// document is a single raw document of a multi-document input.
+type document struct {
+	data []byte
+	line int
+	json bool
+}
+
+// InputFileToProtoList reads a json/yaml/stdin input holding one or more resources
+// and converts each of them to a protocol buffer message created by newMessage.
+func InputFileToProtoList(filePath string, newMessage func() proto.Message) ([]proto.Message, error) {
+	b, err := component.ReadInput(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return BufferToProtoList(bytes.NewBuffer(b), newMessage, strings.TrimPrefix(filepath.Ext(filePath), "."))
+}
+
+// BufferToProtoList splits a multi-document yaml buffer or a json array into documents
+// and unmarshals each of them to a protocol buffer message created by newMessage.
+// Documents that fail to decode are reported together as DocumentErrors.
+func BufferToProtoList(buf *bytes.Buffer, newMessage func() proto.Message, format string) ([]proto.Message, error) {
+	var docs []document
+	var err error
+	switch format {
+	case "json":
+		docs, err = splitJSON(buf.Bytes())
+	case "yaml", "yml":
+		docs = splitYAML(buf.Bytes())
+	default:
+		if isJSON(buf.Bytes()) {
+			docs, err = splitJSON(buf.Bytes())
+		} else {
+			docs = splitYAML(buf.Bytes())
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("no resources found in input")
+	}
+
+	var docErrs DocumentErrors
+	messages := make([]proto.Message, 0, len(docs))
+	for i, doc := range docs {
+		message := newMessage()
+		unmarshal := yamlUnmarshal
+		if doc.json {
+			unmarshal = jsonUnmarshal
+		}
+		if err := unmarshal(bytes.NewBuffer(doc.data), message); err != nil {
+			docErrs = append(docErrs, &DocumentError{Index: i, Line: doc.line, Err: err})
+			continue
+		}
+		messages = append(messages, message)
+	}
+	if len(docErrs) != 0 {
+		return nil, docErrs
+	}
+	return messages, nil
+}
+
+// isJSON reports whether the content looks like a json object or array.
+func isJSON(b []byte) bool {
+	trimmed := bytes.TrimSpace(b)
+	return bytes.HasPrefix(trimmed, []byte("{")) || bytes.HasPrefix(trimmed, []byte("["))
+}
+
+// splitJSON splits a json array, or a stream of json objects, into documents.
+func splitJSON(b []byte) ([]document, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	trimmed := bytes.TrimSpace(b)
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	}
+
+	var docs []document
+	for dec.More() {
+		line := lineAt(b, int(dec.InputOffset()))
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, &DocumentError{Index: len(docs), Line: line, Err: err}
+		}
+		docs = append(docs, document{data: raw, line: line, json: true})
+	}
+	return docs, nil
+}
+
+// lineAt returns the line number of the first non-space character at or after offset.
+func lineAt(b []byte, offset int) int {
+	for offset < len(b) && strings.ContainsRune(" \t\r\n,", rune(b[offset])) {
+		offset++
+	}
+	return bytes.Count(b[:offset], []byte("\n")) + 1
+}
+
+// splitYAML splits a multi-document yaml stream into documents, skipping empty ones.
+func splitYAML(b []byte) []document {
+	var docs []document
+	var current []string
+	start := 0
+	flush := func() {
+		content := strings.Join(current, "\n")
+		if hasYAMLContent(current) {
+			docs = append(docs, document{data: []byte(content), line: start})
+		}
+		current = nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), len(b)+1)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if isYAMLDocSeparator(line) {
+			flush()
+			continue
+		}
+		if !hasYAMLContent(current) && hasYAMLContent([]string{line}) {
+			start = lineNum
+		}
+		current = append(current, line)
+	}
+	flush()
+	return docs
+}
+
+// isYAMLDocSeparator reports whether the line starts a new yaml document.
+func isYAMLDocSeparator(line string) bool {
+	trimmed := strings.TrimRight(line, " \t\r")
+	return trimmed == yamlDocSeparator || strings.HasPrefix(trimmed, yamlDocSeparator+" ")
+}
+
+// hasYAMLContent reports whether the lines contain anything other than blanks and comments.
+func hasYAMLContent(lines []string) bool {
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			return true
+		}
+	}
+	return false
+}
@@ -1,5 +1,5 @@
 // Copyright 2021 VMware, Inc. All Rights Reserved.
 // SPDX-License-Identifier: Apache-2.0
 
-// Package proto provides conversion functions to protobuf format.
+// Package proto provides conversion functions to and from protobuf format.
 package proto
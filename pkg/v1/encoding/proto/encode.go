@@ -0,0 +1,105 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package proto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/jsonpb" // nolint
+	"github.com/golang/protobuf/proto"  // nolint
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/cli/component"
+)
+
+const (
+	indentation      = "  "
+	yamlDocSeparator = "---"
+)
+
+// ProtoToJSON converts a protocol buffer message to indented json bytes.
+func ProtoToJSON(message proto.Message) ([]byte, error) {
+	m := jsonpb.Marshaler{}
+	compact, err := m.MarshalToString(message)
+	if err != nil {
+		return nil, err
+	}
+
+	// The indentation of the marshaler is not consistent for well known types,
+	// so the compact output is indented separately.
+	buf := bytes.NewBuffer(nil)
+	if err := json.Indent(buf, []byte(compact), "", indentation); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ProtoToYAML converts a protocol buffer message to yaml bytes.
+func ProtoToYAML(message proto.Message) ([]byte, error) {
+	jsonBytes, err := ProtoToJSON(message)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.JSONToYAML(jsonBytes)
+}
+
+// ProtoToOutput writes the protocol buffer messages to out in the given output type.
+// A single message is written as a plain object, multiple messages are written
+// as a json array or as a multi-document yaml stream. Resources have no table
+// columns, so the table and any other output type fall back to yaml.
+func ProtoToOutput(out io.Writer, outputType component.OutputType, messages ...proto.Message) error {
+	if outputType == component.JSONOutputType {
+		return writeJSON(out, messages)
+	}
+	return writeYAML(out, messages)
+}
+
+// writeJSON writes the messages as a json object, or as a json array when there is more than one.
+func writeJSON(out io.Writer, messages []proto.Message) error {
+	if len(messages) == 1 {
+		b, err := ProtoToJSON(messages[0])
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(out, "%s\n", b)
+		return err
+	}
+
+	items := make([]json.RawMessage, 0, len(messages))
+	for i, message := range messages {
+		b, err := ProtoToJSON(message)
+		if err != nil {
+			return fmt.Errorf("unable to encode resource %d: %w", i, err)
+		}
+		items = append(items, b)
+	}
+	b, err := json.MarshalIndent(items, "", indentation)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "%s\n", b)
+	return err
+}
+
+// writeYAML writes the messages as yaml documents separated by the document separator.
+func writeYAML(out io.Writer, messages []proto.Message) error {
+	for i, message := range messages {
+		b, err := ProtoToYAML(message)
+		if err != nil {
+			return fmt.Errorf("unable to encode resource %d: %w", i, err)
+		}
+		if i > 0 {
+			if _, err := fmt.Fprintln(out, yamlDocSeparator); err != nil {
+				return err
+			}
+		}
+		if _, err := out.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
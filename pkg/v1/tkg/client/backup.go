@@ -0,0 +1,557 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	extensionsV1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+)
+
+const (
+	backupMetadataFile        = "metadata.yaml"
+	backupResourcesDir        = "resources"
+	backupCoreGroup           = "core"
+	tanzuAPIGroupSuffix       = ".tanzu.vmware.com"
+	backupFilePermissions     = 0o600
+	kubernetesNamespacePrefix = "kube-"
+)
+
+// BackupManagementClusterOptions contains options supported by BackupManagementCluster
+type BackupManagementClusterOptions struct {
+	// File is the path of the tar.gz archive to write the backup to
+	File string
+}
+
+// RestoreManagementClusterOptions contains options supported by RestoreManagementCluster
+type RestoreManagementClusterOptions struct {
+	// File is the path of the tar.gz archive to restore the backup from
+	File string
+}
+
+// ManagementClusterBackupMetadata describes a management cluster backup
+type ManagementClusterBackupMetadata struct {
+	ManagementCluster string    `json:"managementCluster"`
+	CreatedAt         time.Time `json:"createdAt"`
+	Objects           int       `json:"objects"`
+}
+
+// ManagementClusterBackup is the content of a management cluster backup archive
+type ManagementClusterBackup struct {
+	Metadata ManagementClusterBackupMetadata
+	Objects  []*unstructured.Unstructured
+}
+
+// BackupManagementCluster exports the cluster api, provider and tkg objects of the workload clusters of the current
+// management cluster, with their related secrets and config maps, to a tar.gz archive
+func (c *TkgClient) BackupManagementCluster(options BackupManagementClusterOptions) error {
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		return errors.Wrap(err, "cannot get current management cluster context")
+	}
+	clusterClient, err := c.getBackupClusterClient(currentRegion.SourceFilePath, currentRegion.ContextName)
+	if err != nil {
+		return err
+	}
+
+	backup, err := DoBackupManagementCluster(clusterClient, currentRegion.ClusterName)
+	if err != nil {
+		return err
+	}
+	if err := WriteManagementClusterBackup(options.File, backup); err != nil {
+		return err
+	}
+	log.Infof("Successfully backed up %d objects of management cluster %s to %s", len(backup.Objects), currentRegion.ClusterName, options.File)
+	return nil
+}
+
+// RestoreManagementCluster creates the objects of a management cluster backup on the current management cluster,
+// which is expected to be a freshly bootstrapped management cluster
+func (c *TkgClient) RestoreManagementCluster(options RestoreManagementClusterOptions) error {
+	backup, err := ReadManagementClusterBackup(options.File)
+	if err != nil {
+		return err
+	}
+
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		return errors.Wrap(err, "cannot get current management cluster context")
+	}
+	clusterClient, err := c.getBackupClusterClient(currentRegion.SourceFilePath, currentRegion.ContextName)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Restoring %d objects of management cluster %s backed up at %s...", len(backup.Objects),
+		backup.Metadata.ManagementCluster, backup.Metadata.CreatedAt.Format(time.RFC3339))
+	if err := DoRestoreManagementCluster(clusterClient, backup); err != nil {
+		return err
+	}
+	log.Infof("Successfully restored the backup of management cluster %s to management cluster %s", backup.Metadata.ManagementCluster, currentRegion.ClusterName)
+	return nil
+}
+
+func (c *TkgClient) getBackupClusterClient(kubeconfig, context string) (clusterclient.Client, error) {
+	clusterclientOptions := clusterclient.Options{
+		GetClientInterval: 1 * time.Second,
+		GetClientTimeout:  3 * time.Second,
+	}
+	clusterClient, err := clusterclient.NewClient(kubeconfig, context, clusterclientOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get cluster client while backing up or restoring management cluster")
+	}
+
+	isPacific, err := clusterClient.IsPacificRegionalCluster()
+	if err != nil {
+		return nil, errors.Wrap(err, "error determining 'Tanzu Kubernetes Cluster service for vSphere' management cluster")
+	}
+	if isPacific {
+		return nil, errors.New("backup and restore of 'Tanzu Kubernetes Cluster service for vSphere' management clusters is not yet supported")
+	}
+	if err := clusterClient.IsRegionalCluster(); err != nil {
+		return nil, errors.Wrap(err, "the current cluster is not a management cluster")
+	}
+	return clusterClient, nil
+}
+
+// DoBackupManagementCluster collects the objects to back up from the management cluster, following the semantics
+// of clusterctl move: the objects of the namespaced cluster api and provider types, the objects of the namespaced
+// tkg types, and the secrets and config maps which belong to them. The objects of the management cluster itself
+// and of the kubernetes namespaces are left out as they are created when bootstrapping a management cluster.
+func DoBackupManagementCluster(clusterClient clusterclient.Client, managementClusterName string) (*ManagementClusterBackup, error) {
+	resourceTypes, err := getBackupResourceTypes(clusterClient)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []*unstructured.Unstructured{}
+	for _, gvk := range resourceTypes {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := clusterClient.ListResources(list); err != nil {
+			return nil, errors.Wrapf(err, "unable to list the %s objects", gvk.Kind)
+		}
+		for i := range list.Items {
+			if isBackupNamespace(list.Items[i].GetNamespace()) {
+				objects = append(objects, &list.Items[i])
+			}
+		}
+	}
+
+	related, err := getBackupRelatedObjects(clusterClient, objects)
+	if err != nil {
+		return nil, err
+	}
+	objects = append(objects, related...)
+
+	for _, obj := range objects {
+		cleanupBackupObject(obj)
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return backupObjectPath(objects[i]) < backupObjectPath(objects[j])
+	})
+	return &ManagementClusterBackup{
+		Metadata: ManagementClusterBackupMetadata{
+			ManagementCluster: managementClusterName,
+			CreatedAt:         time.Now().UTC(),
+			Objects:           len(objects),
+		},
+		Objects: objects,
+	}, nil
+}
+
+// getBackupResourceTypes returns the namespaced types of the cluster api providers and of tkg,
+// listed at their storage version
+func getBackupResourceTypes(clusterClient clusterclient.Client) ([]schema.GroupVersionKind, error) {
+	crds := &extensionsV1.CustomResourceDefinitionList{}
+	if err := clusterClient.ListResources(crds); err != nil {
+		return nil, errors.Wrap(err, "unable to list the custom resource definitions")
+	}
+
+	resourceTypes := []schema.GroupVersionKind{}
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		if crd.Spec.Scope != extensionsV1.NamespaceScoped {
+			continue
+		}
+		_, isProviderType := crd.Labels[clusterctlv1.ClusterctlLabelName]
+		// the inventory of the providers of clusterctl is not moved
+		isProviderType = isProviderType && crd.Spec.Group != clusterctlv1.GroupVersion.Group
+		if !isProviderType && !strings.HasSuffix(crd.Spec.Group, tanzuAPIGroupSuffix) {
+			continue
+		}
+		for _, version := range crd.Spec.Versions {
+			if version.Storage {
+				resourceTypes = append(resourceTypes, schema.GroupVersionKind{Group: crd.Spec.Group, Version: version.Name, Kind: crd.Spec.Names.Kind})
+				break
+			}
+		}
+	}
+	sort.Slice(resourceTypes, func(i, j int) bool {
+		return resourceTypes[i].String() < resourceTypes[j].String()
+	})
+	return resourceTypes, nil
+}
+
+// getBackupRelatedObjects returns the secrets and config maps which belong to the backed up objects, like
+// clusterctl move they are the ones owned by a backed up object, the ones labeled to be moved, and the
+// secrets named after a backed up cluster
+func getBackupRelatedObjects(clusterClient clusterclient.Client, objects []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	namespaces := map[string]bool{}
+	owners := map[types.UID]bool{}
+	clusterNames := map[string][]string{}
+	for _, obj := range objects {
+		namespaces[obj.GetNamespace()] = true
+		owners[obj.GetUID()] = true
+		if isCAPICluster(obj) {
+			clusterNames[obj.GetNamespace()] = append(clusterNames[obj.GetNamespace()], obj.GetName())
+		}
+	}
+
+	isRelated := func(obj metav1.Object, isSecret bool) bool {
+		if _, moved := obj.GetLabels()[clusterctlv1.ClusterctlMoveLabelName]; moved {
+			return true
+		}
+		for _, ownerRef := range obj.GetOwnerReferences() {
+			if owners[ownerRef.UID] {
+				return true
+			}
+		}
+		if isSecret {
+			for _, clusterName := range clusterNames[obj.GetNamespace()] {
+				if strings.HasPrefix(obj.GetName(), clusterName+"-") {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	related := []*unstructured.Unstructured{}
+	for namespace := range namespaces {
+		secrets := &corev1.SecretList{}
+		if err := clusterClient.ListResources(secrets, crtclient.InNamespace(namespace)); err != nil {
+			return nil, errors.Wrapf(err, "unable to list the secrets of namespace '%s'", namespace)
+		}
+		for i := range secrets.Items {
+			if !isRelated(&secrets.Items[i], true) {
+				continue
+			}
+			secrets.Items[i].SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+			obj, err := toUnstructured(&secrets.Items[i])
+			if err != nil {
+				return nil, err
+			}
+			related = append(related, obj)
+		}
+
+		configMaps := &corev1.ConfigMapList{}
+		if err := clusterClient.ListResources(configMaps, crtclient.InNamespace(namespace)); err != nil {
+			return nil, errors.Wrapf(err, "unable to list the config maps of namespace '%s'", namespace)
+		}
+		for i := range configMaps.Items {
+			if !isRelated(&configMaps.Items[i], false) {
+				continue
+			}
+			configMaps.Items[i].SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+			obj, err := toUnstructured(&configMaps.Items[i])
+			if err != nil {
+				return nil, err
+			}
+			related = append(related, obj)
+		}
+	}
+	return related, nil
+}
+
+// isBackupNamespace returns true if the objects of the namespace are to be backed up
+func isBackupNamespace(namespace string) bool {
+	return namespace != TKGsystemNamespace && !strings.HasPrefix(namespace, kubernetesNamespacePrefix)
+}
+
+// cleanupBackupObject removes the fields set by the api server, which cannot be restored
+func cleanupBackupObject(obj *unstructured.Unstructured) {
+	for _, field := range []string{"uid", "resourceVersion", "creationTimestamp", "managedFields", "generation", "selfLink"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(obj.Object, "status")
+}
+
+func toUnstructured(obj runtime.Object) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to convert %T to unstructured", obj)
+	}
+	return &unstructured.Unstructured{Object: content}, nil
+}
+
+// backupObjectKey identifies an object of a backup by its group, kind, namespace and name
+func backupObjectKey(group, kind, namespace, name string) string {
+	if group == "" {
+		group = backupCoreGroup
+	}
+	return path.Join(group, kind, namespace, name)
+}
+
+func backupObjectPath(obj *unstructured.Unstructured) string {
+	key := backupObjectKey(obj.GroupVersionKind().Group, obj.GetKind(), obj.GetNamespace(), obj.GetName())
+	return path.Join(backupResourcesDir, key) + ".yaml"
+}
+
+// WriteManagementClusterBackup writes the backup to a tar.gz archive holding its metadata
+// and a yaml file per object
+func WriteManagementClusterBackup(file string, backup *ManagementClusterBackup) error {
+	buf := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	addFile := func(name string, content []byte) error {
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     backupFilePermissions,
+			Size:     int64(len(content)),
+			ModTime:  backup.Metadata.CreatedAt,
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := tarWriter.Write(content)
+		return err
+	}
+
+	metadata, err := yaml.Marshal(backup.Metadata)
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal the backup metadata")
+	}
+	if err := addFile(backupMetadataFile, metadata); err != nil {
+		return errors.Wrap(err, "unable to write the backup metadata")
+	}
+	for _, obj := range backup.Objects {
+		content, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return errors.Wrapf(err, "unable to marshal %s", backupObjectPath(obj))
+		}
+		if err := addFile(backupObjectPath(obj), content); err != nil {
+			return errors.Wrapf(err, "unable to write %s", backupObjectPath(obj))
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return errors.Wrap(err, "unable to write the backup archive")
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return errors.Wrap(err, "unable to write the backup archive")
+	}
+
+	if err := os.WriteFile(file, buf.Bytes(), backupFilePermissions); err != nil {
+		return errors.Wrapf(err, "unable to write the backup to '%s'", file)
+	}
+	return nil
+}
+
+// ReadManagementClusterBackup reads a backup from a tar.gz archive written by WriteManagementClusterBackup
+func ReadManagementClusterBackup(file string) (*ManagementClusterBackup, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to open the backup '%s'", file)
+	}
+	defer f.Close()
+	gzipReader, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "'%s' is not a tar.gz archive", file)
+	}
+	tarReader := tar.NewReader(gzipReader)
+
+	backup := &ManagementClusterBackup{}
+	hasMetadata := false
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read the backup '%s'", file)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read %s from the backup", header.Name)
+		}
+
+		switch {
+		case header.Name == backupMetadataFile:
+			if err := yaml.Unmarshal(content, &backup.Metadata); err != nil {
+				return nil, errors.Wrap(err, "unable to parse the backup metadata")
+			}
+			hasMetadata = true
+		case strings.HasPrefix(header.Name, backupResourcesDir+"/") && strings.HasSuffix(header.Name, ".yaml"):
+			obj := &unstructured.Unstructured{}
+			if err := yaml.Unmarshal(content, &obj.Object); err != nil {
+				return nil, errors.Wrapf(err, "unable to parse %s from the backup", header.Name)
+			}
+			backup.Objects = append(backup.Objects, obj)
+		}
+	}
+	if !hasMetadata {
+		return nil, errors.Errorf("'%s' is not a management cluster backup, %s is missing", file, backupMetadataFile)
+	}
+	if len(backup.Objects) != backup.Metadata.Objects {
+		return nil, errors.Errorf("the backup '%s' is incomplete, it holds %d objects out of %d", file, len(backup.Objects), backup.Metadata.Objects)
+	}
+	return backup, nil
+}
+
+// DoRestoreManagementCluster creates the objects of the backup on the management cluster. Like clusterctl move,
+// the objects are created after their owners so that the owner references can be updated with the uids of the
+// restored owners, and the clusters are created paused so that their controllers do not act on them before
+// all their objects are restored.
+func DoRestoreManagementCluster(clusterClient clusterclient.Client, backup *ManagementClusterBackup) error {
+	namespaces := map[string]bool{}
+	pausedClusters := map[string]bool{}
+	for _, obj := range backup.Objects {
+		namespaces[obj.GetNamespace()] = true
+		if !isCAPICluster(obj) {
+			continue
+		}
+		err := clusterClient.GetResource(&capi.Cluster{}, obj.GetName(), obj.GetNamespace(), nil, nil)
+		if err == nil {
+			return errors.Errorf("cluster %s already exists in namespace '%s', backups can only be restored to a freshly created management cluster", obj.GetName(), obj.GetNamespace())
+		}
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "unable to get cluster '%s' in namespace '%s'", obj.GetName(), obj.GetNamespace())
+		}
+		paused, _, _ := unstructured.NestedBool(obj.Object, "spec", "paused")
+		pausedClusters[backupObjectPath(obj)] = paused
+	}
+
+	for namespace := range namespaces {
+		if namespace == "" {
+			continue
+		}
+		if err := clusterClient.CreateNamespace(namespace); err != nil {
+			return errors.Wrapf(err, "unable to create namespace '%s'", namespace)
+		}
+	}
+
+	inBackup := map[string]bool{}
+	for _, obj := range backup.Objects {
+		inBackup[backupObjectPath(obj)] = true
+	}
+	ownerPath := func(obj *unstructured.Unstructured, ownerRef metav1.OwnerReference) string {
+		gv, _ := schema.ParseGroupVersion(ownerRef.APIVersion)
+		return path.Join(backupResourcesDir, backupObjectKey(gv.Group, ownerRef.Kind, obj.GetNamespace(), ownerRef.Name)) + ".yaml"
+	}
+
+	restored := map[string]types.UID{}
+	pending := backup.Objects
+	for len(pending) != 0 {
+		remaining := []*unstructured.Unstructured{}
+		for _, obj := range pending {
+			ready := true
+			for _, ownerRef := range obj.GetOwnerReferences() {
+				owner := ownerPath(obj, ownerRef)
+				if _, done := restored[owner]; inBackup[owner] && !done {
+					ready = false
+					break
+				}
+			}
+			if !ready {
+				remaining = append(remaining, obj)
+				continue
+			}
+			uid, err := restoreBackupObject(clusterClient, obj, func(ownerRef metav1.OwnerReference) (types.UID, bool) {
+				uid, ok := restored[ownerPath(obj, ownerRef)]
+				return uid, ok
+			})
+			if err != nil {
+				return err
+			}
+			restored[backupObjectPath(obj)] = uid
+		}
+		if len(remaining) == len(pending) {
+			return errors.Errorf("unable to restore %d objects of the backup, their owner references form a cycle", len(remaining))
+		}
+		pending = remaining
+	}
+
+	for _, obj := range backup.Objects {
+		if !isCAPICluster(obj) || pausedClusters[backupObjectPath(obj)] {
+			continue
+		}
+		patch := `{"spec":{"paused":false}}`
+		if err := clusterClient.PatchResource(&capi.Cluster{}, obj.GetName(), obj.GetNamespace(), patch, types.MergePatchType, nil); err != nil {
+			return errors.Wrapf(err, "unable to resume the reconciliation of cluster %s", obj.GetName())
+		}
+	}
+	return nil
+}
+
+// restoreBackupObject creates the object with its owner references updated with the uids of the restored owners,
+// the references to owners which are not part of the backup are dropped. It returns the uid of the created object.
+func restoreBackupObject(clusterClient clusterclient.Client, backupObj *unstructured.Unstructured,
+	restoredOwnerUID func(metav1.OwnerReference) (types.UID, bool)) (types.UID, error) {
+	obj := backupObj.DeepCopy()
+	ownerRefs := []metav1.OwnerReference{}
+	for _, ownerRef := range obj.GetOwnerReferences() {
+		uid, ok := restoredOwnerUID(ownerRef)
+		if !ok {
+			log.V(3).Infof("Dropping the reference of %s to its owner %s %s which is not part of the backup", backupObjectPath(obj), ownerRef.Kind, ownerRef.Name)
+			continue
+		}
+		ownerRef.UID = uid
+		ownerRefs = append(ownerRefs, ownerRef)
+	}
+	obj.SetOwnerReferences(ownerRefs)
+	if isCAPICluster(obj) {
+		if err := unstructured.SetNestedField(obj.Object, true, "spec", "paused"); err != nil {
+			return "", errors.Wrapf(err, "unable to pause cluster %s", obj.GetName())
+		}
+	}
+
+	log.V(3).Infof("Restoring %s", backupObjectPath(obj))
+	err := clusterClient.CreateResource(obj, obj.GetName(), obj.GetNamespace())
+	if err == nil {
+		return obj.GetUID(), nil
+	}
+	if !apierrors.IsAlreadyExists(errors.Cause(err)) {
+		return "", errors.Wrapf(err, "unable to restore %s %s in namespace '%s'", obj.GetKind(), obj.GetName(), obj.GetNamespace())
+	}
+
+	log.Warningf("%s %s already exists in namespace '%s', it is left unchanged", obj.GetKind(), obj.GetName(), obj.GetNamespace())
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := clusterClient.GetResource(existing, obj.GetName(), obj.GetNamespace(), nil, nil); err != nil {
+		return "", errors.Wrapf(err, "unable to get %s %s in namespace '%s'", obj.GetKind(), obj.GetName(), obj.GetNamespace())
+	}
+	return existing.GetUID(), nil
+}
+
+func isCAPICluster(obj *unstructured.Unstructured) bool {
+	return obj.GetKind() == "Cluster" && obj.GroupVersionKind().Group == capi.GroupVersion.Group
+}
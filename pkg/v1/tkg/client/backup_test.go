@@ -0,0 +1,177 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	extensionsV1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	capav1alpha3 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" // nolint:staticcheck
+
+	. "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/fakes"
+	fakehelper "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/fakes/helper"
+)
+
+var _ = Describe("Management cluster backup", func() {
+	var (
+		sourceClientSet crtclient.Client
+		targetClientSet crtclient.Client
+		backupDir       string
+		backupFile      string
+	)
+
+	newClusterClient := func(clientSet crtclient.Client) clusterclient.Client {
+		crtClientFactory := &fakes.CrtClientFactory{}
+		crtClientFactory.NewClientReturns(clientSet, nil)
+		kubeconfig := fakehelper.GetFakeKubeConfigFilePath(testingDir, "../fakes/config/kubeconfig/config1.yaml")
+		clusterClient, err := clusterclient.NewClient(kubeconfig, "", clusterclient.NewOptions(getFakePoller(), crtClientFactory, getFakeDiscoveryFactory(), nil))
+		Expect(err).NotTo(HaveOccurred())
+		return clusterClient
+	}
+	newCRD := func(group, version, kind string, scope extensionsV1.ResourceScope, labels map[string]string) *extensionsV1.CustomResourceDefinition {
+		return &extensionsV1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: kind + "." + group, Labels: labels},
+			Spec: extensionsV1.CustomResourceDefinitionSpec{
+				Group:    group,
+				Scope:    scope,
+				Names:    extensionsV1.CustomResourceDefinitionNames{Kind: kind},
+				Versions: []extensionsV1.CustomResourceDefinitionVersion{{Name: version, Storage: true}},
+			},
+		}
+	}
+	ownedBy := func(apiVersion, kind, name, uid string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{APIVersion: apiVersion, Kind: kind, Name: name, UID: k8stypes.UID(uid)}}
+	}
+	backupAndRestore := func() error {
+		backup, err := DoBackupManagementCluster(newClusterClient(sourceClientSet), "my-mc")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(WriteManagementClusterBackup(backupFile, backup)).To(Succeed())
+		restored, err := ReadManagementClusterBackup(backupFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restored.Metadata.ManagementCluster).To(Equal("my-mc"))
+		Expect(restored.Objects).To(HaveLen(len(backup.Objects)))
+		return DoRestoreManagementCluster(newClusterClient(targetClientSet), restored)
+	}
+
+	BeforeEach(func() {
+		providerLabels := map[string]string{clusterctlv1.ClusterctlLabelName: ""}
+		sourceObjects := []runtime.Object{
+			newCRD(capi.GroupVersion.Group, "v1alpha3", "Cluster", extensionsV1.NamespaceScoped, providerLabels),
+			newCRD(capav1alpha3.GroupVersion.Group, "v1alpha3", "AWSCluster", extensionsV1.NamespaceScoped, providerLabels),
+			newCRD(clusterctlv1.GroupVersion.Group, "v1alpha3", "Provider", extensionsV1.NamespaceScoped, providerLabels),
+			&capi.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "my-namespace", UID: "uid-cluster", ResourceVersion: "10"},
+			},
+			&capi.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-mc", Namespace: TKGsystemNamespace, UID: "uid-mc"},
+			},
+			&capav1alpha3.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "my-namespace", UID: "uid-awscluster",
+					OwnerReferences: ownedBy(capi.GroupVersion.String(), "Cluster", "my-cluster", "uid-cluster")},
+				Spec: capav1alpha3.AWSClusterSpec{Region: "us-east-1"},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-kubeconfig", Namespace: "my-namespace"},
+				Data:       map[string][]byte{"value": []byte("kubeconfig")},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "my-namespace"},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "aws-settings", Namespace: "my-namespace",
+					OwnerReferences: ownedBy(capav1alpha3.GroupVersion.String(), "AWSCluster", "my-cluster", "uid-awscluster")},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "moved", Namespace: "my-namespace", Labels: map[string]string{clusterctlv1.ClusterctlMoveLabelName: ""}},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "my-namespace"},
+			},
+		}
+		sourceClientSet = fake.NewFakeClientWithScheme(scheme, sourceObjects...)
+		targetClientSet = fake.NewFakeClientWithScheme(scheme)
+
+		var err error
+		backupDir, err = os.MkdirTemp("", "backup_test")
+		Expect(err).NotTo(HaveOccurred())
+		backupFile = filepath.Join(backupDir, "backup.tar.gz")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(backupDir)
+	})
+
+	It("restores the workload cluster objects and their related secrets and config maps", func() {
+		Expect(backupAndRestore()).To(Succeed())
+
+		get := func(obj runtime.Object, name string) error {
+			return targetClientSet.Get(context.Background(), crtclient.ObjectKey{Name: name, Namespace: "my-namespace"}, obj)
+		}
+		cluster := &capi.Cluster{}
+		Expect(get(cluster, "my-cluster")).To(Succeed())
+		Expect(cluster.Spec.Paused).To(BeFalse())
+
+		awsCluster := &capav1alpha3.AWSCluster{}
+		Expect(get(awsCluster, "my-cluster")).To(Succeed())
+		Expect(awsCluster.Spec.Region).To(Equal("us-east-1"))
+		Expect(awsCluster.OwnerReferences).To(HaveLen(1))
+		Expect(awsCluster.OwnerReferences[0].Name).To(Equal("my-cluster"))
+		Expect(awsCluster.OwnerReferences[0].UID).To(Equal(cluster.UID))
+
+		secret := &corev1.Secret{}
+		Expect(get(secret, "my-cluster-kubeconfig")).To(Succeed())
+		Expect(secret.Data["value"]).To(Equal([]byte("kubeconfig")))
+		Expect(get(&corev1.ConfigMap{}, "aws-settings")).To(Succeed())
+		Expect(get(&corev1.ConfigMap{}, "moved")).To(Succeed())
+
+		Expect(get(&corev1.Secret{}, "unrelated")).NotTo(Succeed())
+		Expect(get(&corev1.ConfigMap{}, "unrelated")).NotTo(Succeed())
+		Expect(targetClientSet.Get(context.Background(), crtclient.ObjectKey{Name: "my-mc", Namespace: TKGsystemNamespace}, &capi.Cluster{})).NotTo(Succeed())
+	})
+
+	It("keeps the clusters paused in the backup paused", func() {
+		cluster := &capi.Cluster{}
+		Expect(sourceClientSet.Get(context.Background(), crtclient.ObjectKey{Name: "my-cluster", Namespace: "my-namespace"}, cluster)).To(Succeed())
+		cluster.Spec.Paused = true
+		Expect(sourceClientSet.Update(context.Background(), cluster)).To(Succeed())
+
+		Expect(backupAndRestore()).To(Succeed())
+
+		restored := &capi.Cluster{}
+		Expect(targetClientSet.Get(context.Background(), crtclient.ObjectKey{Name: "my-cluster", Namespace: "my-namespace"}, restored)).To(Succeed())
+		Expect(restored.Spec.Paused).To(BeTrue())
+	})
+
+	It("fails if a cluster of the backup already exists", func() {
+		targetClientSet = fake.NewFakeClientWithScheme(scheme, &capi.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "my-namespace"},
+		})
+
+		err := backupAndRestore()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("cluster my-cluster already exists"))
+	})
+
+	It("fails to read a file which is not a backup", func() {
+		Expect(os.WriteFile(backupFile, []byte("not a backup"), 0o600)).To(Succeed())
+
+		_, err := ReadManagementClusterBackup(backupFile)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("is not a tar.gz archive"))
+	})
+})
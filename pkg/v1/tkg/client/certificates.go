@@ -0,0 +1,360 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+)
+
+// cluster api secrets holding the certificates of a cluster, named after the cluster
+const (
+	clusterCASecretSuffix         = "-ca"
+	clusterEtcdCASecretSuffix     = "-etcd"
+	clusterProxyCASecretSuffix    = "-proxy"
+	clusterKubeconfigSecretSuffix = "-kubeconfig"
+
+	tlsCertificateKey = "tls.crt"
+	kubeconfigKey     = "value"
+
+	apiServerCertificateName = "apiserver"
+	defaultAPIServerPort     = 6443
+)
+
+// apiServerDialTimeout is the timeout of the TLS handshake with the API server of a control plane machine
+var apiServerDialTimeout = 5 * time.Second
+
+// ClusterCertificate is a certificate of a cluster, either stored in a secret on the management cluster or
+// served by the API server of a control plane machine
+type ClusterCertificate struct {
+	Name      string    `json:"name" yaml:"name"`
+	Secret    string    `json:"secret,omitempty" yaml:"secret,omitempty"`
+	Machine   string    `json:"machine,omitempty" yaml:"machine,omitempty"`
+	Subject   string    `json:"subject" yaml:"subject"`
+	NotBefore time.Time `json:"notBefore" yaml:"notBefore"`
+	NotAfter  time.Time `json:"notAfter" yaml:"notAfter"`
+}
+
+// ListClusterCertificatesOptions contains options supported by ListClusterCertificates
+type ListClusterCertificatesOptions struct {
+	ClusterName string
+	Namespace   string
+}
+
+// RotateClusterCertificatesOptions contains options supported by RotateClusterCertificates
+type RotateClusterCertificatesOptions struct {
+	ClusterName string
+	Namespace   string
+}
+
+// clusterCertificateSecrets are the secrets holding the certificate authorities of the cluster, by certificate name
+var clusterCertificateSecrets = []struct {
+	name   string
+	suffix string
+}{
+	{name: "cluster-ca", suffix: clusterCASecretSuffix},
+	{name: "etcd-ca", suffix: clusterEtcdCASecretSuffix},
+	{name: "front-proxy-ca", suffix: clusterProxyCASecretSuffix},
+}
+
+// getClusterCertificates reads the certificate authorities of the cluster and the client certificate of its
+// admin kubeconfig from the cluster api secrets, and the serving certificate of the API server of each control
+// plane machine. The secrets which do not exist and the machines which cannot be reached are skipped.
+func getClusterCertificates(regionalClusterClient clusterclient.Client, clusterName, namespace string) ([]ClusterCertificate, error) {
+	certificates, err := getClusterSecretCertificates(regionalClusterClient, clusterName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	servingCertificates, err := getAPIServerCertificates(regionalClusterClient, clusterName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return append(certificates, servingCertificates...), nil
+}
+
+// getClusterSecretCertificates reads the certificate authorities of the cluster and the client certificate of
+// its admin kubeconfig from the cluster api secrets
+func getClusterSecretCertificates(regionalClusterClient clusterclient.Client, clusterName, namespace string) ([]ClusterCertificate, error) {
+	certificates := []ClusterCertificate{}
+	for _, certificateSecret := range clusterCertificateSecrets {
+		secretName := clusterName + certificateSecret.suffix
+		secret := &corev1.Secret{}
+		if err := regionalClusterClient.GetResource(secret, secretName, namespace, nil, nil); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "unable to get secret '%s'", secretName)
+		}
+		certificate, err := parseCertificate(certificateSecret.name, secretName, secret.Data[tlsCertificateKey])
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, *certificate)
+	}
+
+	secretName := clusterName + clusterKubeconfigSecretSuffix
+	secret := &corev1.Secret{}
+	if err := regionalClusterClient.GetResource(secret, secretName, namespace, nil, nil); err != nil {
+		if apierrors.IsNotFound(err) {
+			return certificates, nil
+		}
+		return nil, errors.Wrapf(err, "unable to get secret '%s'", secretName)
+	}
+	kubeconfig, err := clientcmd.Load(secret.Data[kubeconfigKey])
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to load the kubeconfig of secret '%s'", secretName)
+	}
+	for _, authInfo := range kubeconfig.AuthInfos {
+		if len(authInfo.ClientCertificateData) == 0 {
+			continue
+		}
+		certificate, err := parseCertificate("admin-client", secretName, authInfo.ClientCertificateData)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, *certificate)
+		break
+	}
+	return certificates, nil
+}
+
+// getAPIServerCertificates returns the serving certificate of the API server of each control plane machine
+// of the cluster, read with a TLS handshake to the address of the machine on the port of the control plane endpoint
+func getAPIServerCertificates(regionalClusterClient clusterclient.Client, clusterName, namespace string) ([]ClusterCertificate, error) {
+	cluster := &capi.Cluster{}
+	if err := regionalClusterClient.GetResource(cluster, clusterName, namespace, nil, nil); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "unable to get cluster '%s'", clusterName)
+	}
+	port := int(cluster.Spec.ControlPlaneEndpoint.Port)
+	if port == 0 {
+		port = defaultAPIServerPort
+	}
+
+	machines := &capi.MachineList{}
+	if err := regionalClusterClient.GetResourceList(machines, clusterName, namespace, nil, nil); err != nil {
+		return nil, errors.Wrapf(err, "unable to list the machines of cluster '%s'", clusterName)
+	}
+	certificates := []ClusterCertificate{}
+	for i := range machines.Items {
+		machine := &machines.Items[i]
+		if _, isControlPlane := machine.Labels[capi.MachineControlPlaneLabelName]; !isControlPlane {
+			continue
+		}
+		address := machineAddress(machine)
+		if address == "" {
+			log.V(3).Infof("Skipping the API server certificate of machine %s which has no address", machine.Name)
+			continue
+		}
+		certificate, err := getServingCertificate(net.JoinHostPort(address, strconv.Itoa(port)))
+		if err != nil {
+			log.Warningf("unable to get the API server certificate of machine %s: %v", machine.Name, err)
+			continue
+		}
+		certificates = append(certificates, ClusterCertificate{
+			Name:      apiServerCertificateName,
+			Machine:   machine.Name,
+			Subject:   certificate.Subject.CommonName,
+			NotBefore: certificate.NotBefore,
+			NotAfter:  certificate.NotAfter,
+		})
+	}
+	return certificates, nil
+}
+
+// machineAddress returns the internal IP of the machine, or its external IP if it has no internal IP
+func machineAddress(machine *capi.Machine) string {
+	for _, addressType := range []capi.MachineAddressType{capi.MachineInternalIP, capi.MachineExternalIP} {
+		for _, address := range machine.Status.Addresses {
+			if address.Type == addressType && address.Address != "" {
+				return address.Address
+			}
+		}
+	}
+	return ""
+}
+
+// getServingCertificate returns the leaf certificate served at the address
+func getServingCertificate(address string) (*x509.Certificate, error) {
+	dialer := &net.Dialer{Timeout: apiServerDialTimeout}
+	// the certificate is only read to report its expiry, it is not trusted
+	conn, err := tls.DialWithDialer(dialer, "tcp", address, &tls.Config{InsecureSkipVerify: true}) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	peerCertificates := conn.ConnectionState().PeerCertificates
+	if len(peerCertificates) == 0 {
+		return nil, errors.Errorf("no certificate served at %s", address)
+	}
+	return peerCertificates[0], nil
+}
+
+// parseCertificate parses the first PEM encoded certificate of data
+func parseCertificate(name, secretName string, data []byte) (*ClusterCertificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.Errorf("no PEM encoded certificate found in secret '%s'", secretName)
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse the certificate of secret '%s'", secretName)
+	}
+	return &ClusterCertificate{
+		Name:      name,
+		Secret:    secretName,
+		Subject:   certificate.Subject.CommonName,
+		NotBefore: certificate.NotBefore,
+		NotAfter:  certificate.NotAfter,
+	}, nil
+}
+
+// ListClusterCertificates returns the certificates of the cluster stored on the management cluster and the serving
+// certificates of its control plane machines with their expiry
+func (c *TkgClient) ListClusterCertificates(options ListClusterCertificatesOptions) ([]ClusterCertificate, error) {
+	clusterClient, err := c.getCertificatesClusterClient(&options.ClusterName, &options.Namespace, 0)
+	if err != nil {
+		return nil, err
+	}
+	cluster := &capi.Cluster{}
+	if err := clusterClient.GetResource(cluster, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return nil, errors.Wrapf(err, "unable to get cluster '%s' in namespace '%s'", options.ClusterName, options.Namespace)
+	}
+	return getClusterCertificates(clusterClient, options.ClusterName, options.Namespace)
+}
+
+// RotateClusterCertificates renews the kubeadm certificates of the control plane nodes of the cluster by
+// rolling out its control plane, and waits for the new control plane machines to be ready
+func (c *TkgClient) RotateClusterCertificates(options RotateClusterCertificatesOptions) error {
+	clusterClient, err := c.getCertificatesClusterClient(&options.ClusterName, &options.Namespace, c.timeout)
+	if err != nil {
+		return err
+	}
+
+	recorder := recordOperationStarted(clusterClient, options.ClusterName, options.Namespace, clusterclient.OperationTypeRotateCertificates)
+	err = DoRotateClusterCertificates(clusterClient, options, c.timeout)
+	recorder.finished(err)
+	return err
+}
+
+// getCertificatesClusterClient returns a client of the current management cluster, and defaults the namespace
+// of the cluster if it is not given
+func (c *TkgClient) getCertificatesClusterClient(clusterName, namespace *string, operationTimeout time.Duration) (clusterclient.Client, error) {
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get current management cluster context")
+	}
+	clusterclientOptions := clusterclient.Options{
+		GetClientInterval: 1 * time.Second,
+		GetClientTimeout:  3 * time.Second,
+		OperationTimeout:  operationTimeout,
+	}
+	clusterClient, err := clusterclient.NewClient(currentRegion.SourceFilePath, currentRegion.ContextName, clusterclientOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get cluster client while managing cluster certificates")
+	}
+
+	isPacific, err := clusterClient.IsPacificRegionalCluster()
+	if err != nil {
+		return nil, errors.Wrap(err, "error determining 'Tanzu Kubernetes Cluster service for vSphere' management cluster")
+	}
+	if isPacific {
+		return nil, errors.New("certificate management for 'Tanzu Kubernetes Cluster service for vSphere' clusters is not yet supported")
+	}
+
+	*namespace = defaultCertificatesClusterNamespace(currentRegion.ClusterName, *clusterName, *namespace)
+	return clusterClient, nil
+}
+
+// defaultCertificatesClusterNamespace returns the namespace of the cluster if it is given. Otherwise, it returns
+// the namespace of the management cluster objects if the cluster is the management cluster, or the default namespace.
+func defaultCertificatesClusterNamespace(managementClusterName, clusterName, namespace string) string {
+	if namespace != "" {
+		return namespace
+	}
+	if clusterName == managementClusterName {
+		return TKGsystemNamespace
+	}
+	return constants.DefaultNamespace
+}
+
+// DoRotateClusterCertificates sets the upgradeAfter field of the KubeadmControlPlane of the cluster, which makes
+// the control plane machines created before it to be replaced with machines bootstrapped with new certificates.
+// It then waits until all the control plane machines are replaced and the cluster is ready.
+func DoRotateClusterCertificates(clusterClient clusterclient.Client, options RotateClusterCertificatesOptions, timeout time.Duration) error {
+	kcp, err := clusterClient.GetKCPObjectForCluster(options.ClusterName, options.Namespace)
+	if err != nil {
+		return errors.Wrapf(err, "unable to find control plane object for cluster %s", options.ClusterName)
+	}
+
+	// the creation timestamps of the machines have a second precision
+	rolloutAfter := metav1.NewTime(time.Now().Truncate(time.Second))
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"upgradeAfter": rolloutAfter,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal the control plane patch")
+	}
+	log.V(3).Infof("Patching KubeadmControlPlane with the patch: %s", string(patch))
+	if err := clusterClient.PatchResource(kcp, kcp.Name, kcp.Namespace, string(patch), types.MergePatchType, nil); err != nil {
+		return errors.Wrapf(err, "unable to trigger the rollout of the control plane of cluster %s", options.ClusterName)
+	}
+
+	log.Infof("Waiting for the control plane machines of cluster %s to be replaced...", options.ClusterName)
+	pollOptions := &clusterclient.PollOptions{Interval: clusterclient.CheckClusterInterval, Timeout: timeout}
+	if err := clusterClient.GetResourceList(&capi.MachineList{}, options.ClusterName, options.Namespace, verifyControlPlaneMachinesCreatedAfter(rolloutAfter), pollOptions); err != nil {
+		return errors.Wrap(err, "timed out waiting for the control plane machines to be replaced")
+	}
+	if err := clusterClient.WaitForClusterReady(options.ClusterName, options.Namespace, false); err != nil {
+		return errors.Wrap(err, "error waiting for the cluster to be ready")
+	}
+	return nil
+}
+
+// verifyControlPlaneMachinesCreatedAfter verifies that all the control plane machines of the list were
+// created after the given time and have a node
+func verifyControlPlaneMachinesCreatedAfter(after metav1.Time) clusterclient.PostVerifyrFunc {
+	return func(obj runtime.Object) error {
+		machines, ok := obj.(*capi.MachineList)
+		if !ok {
+			return errors.Errorf("invalid type: %T during verifyControlPlaneMachinesCreatedAfter", obj)
+		}
+		errList := []error{}
+		for i := range machines.Items {
+			machine := &machines.Items[i]
+			if _, isControlPlane := machine.Labels[capi.MachineControlPlaneLabelName]; !isControlPlane {
+				continue
+			}
+			if !after.Before(&machine.CreationTimestamp) {
+				errList = append(errList, errors.Errorf("control plane machine %s is still to be replaced", machine.Name))
+			} else if machine.Status.NodeRef == nil {
+				errList = append(errList, errors.Errorf("control plane machine %s is still being provisioned", machine.Name))
+			}
+		}
+		return kerrors.NewAggregate(errList)
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+)
+
+var _ = Describe("defaultCertificatesClusterNamespace", func() {
+	It("keeps the given namespace", func() {
+		Expect(defaultCertificatesClusterNamespace("my-mgmt", "my-cluster", "my-namespace")).To(Equal("my-namespace"))
+		Expect(defaultCertificatesClusterNamespace("my-mgmt", "my-mgmt", "my-namespace")).To(Equal("my-namespace"))
+	})
+	It("defaults the namespace of the management cluster to the namespace of the management cluster objects", func() {
+		Expect(defaultCertificatesClusterNamespace("my-mgmt", "my-mgmt", "")).To(Equal(TKGsystemNamespace))
+	})
+	It("defaults the namespace of a workload cluster to the default namespace", func() {
+		Expect(defaultCertificatesClusterNamespace("my-mgmt", "my-cluster", "")).To(Equal(constants.DefaultNamespace))
+	})
+})
@@ -0,0 +1,90 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" // nolint:staticcheck
+
+	. "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/fakes"
+	fakehelper "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/fakes/helper"
+)
+
+var _ = Describe("Cluster certificates rotation", func() {
+	var (
+		clusterClient     clusterclient.Client
+		clientSet         crtclient.Client
+		controlPlaneLabel map[string]string
+		machine           *capi.Machine
+	)
+
+	BeforeEach(func() {
+		controlPlaneLabel = map[string]string{capi.ClusterLabelName: "my-cluster", capi.MachineControlPlaneLabelName: ""}
+		machine = &capi.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "my-cluster-control-plane-abcde",
+				Namespace:         "my-namespace",
+				Labels:            controlPlaneLabel,
+				CreationTimestamp: metav1.NewTime(time.Now().Add(time.Hour)),
+			},
+			Status: capi.MachineStatus{NodeRef: &corev1.ObjectReference{Name: "my-cluster-control-plane-abcde"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		objects := []runtime.Object{
+			&capi.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "my-namespace"},
+				Status: capi.ClusterStatus{Conditions: capi.Conditions{
+					{Type: capi.ControlPlaneReadyCondition, Status: corev1.ConditionTrue},
+					{Type: capi.InfrastructureReadyCondition, Status: corev1.ConditionTrue},
+				}},
+			},
+			&controlplanev1.KubeadmControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-control-plane", Namespace: "my-namespace", Labels: map[string]string{capi.ClusterLabelName: "my-cluster"}},
+			},
+			machine,
+		}
+		crtClientFactory := &fakes.CrtClientFactory{}
+		clientSet = fake.NewFakeClientWithScheme(scheme, objects...)
+		crtClientFactory.NewClientReturns(clientSet, nil)
+		kubeconfig := fakehelper.GetFakeKubeConfigFilePath(testingDir, "../fakes/config/kubeconfig/config1.yaml")
+		var err error
+		clusterClient, err = clusterclient.NewClient(kubeconfig, "", clusterclient.NewOptions(getFakePoller(), crtClientFactory, getFakeDiscoveryFactory(), nil))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("rolls out the control plane and waits for the new machines", func() {
+		err := DoRotateClusterCertificates(clusterClient, RotateClusterCertificatesOptions{ClusterName: "my-cluster", Namespace: "my-namespace"}, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+
+		kcp := &controlplanev1.KubeadmControlPlane{}
+		Expect(clientSet.Get(context.Background(), crtclient.ObjectKey{Name: "my-cluster-control-plane", Namespace: "my-namespace"}, kcp)).To(Succeed())
+		Expect(kcp.Spec.UpgradeAfter).NotTo(BeNil())
+	})
+
+	Context("when a control plane machine was created before the rotation", func() {
+		BeforeEach(func() {
+			machine.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		})
+
+		It("returns an error once the wait times out", func() {
+			err := DoRotateClusterCertificates(clusterClient, RotateClusterCertificatesOptions{ClusterName: "my-cluster", Namespace: "my-namespace"}, time.Minute)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("control plane machine my-cluster-control-plane-abcde is still to be replaced"))
+		})
+	})
+})
@@ -127,12 +127,52 @@ type Client interface {
 	GetWorkloadClusterCredentials(options GetWorkloadClusterCredentialsOptions) (string, string, error)
 	// ListTKGClusters lists workload clusters managed by the management cluster
 	ListTKGClusters(options ListTKGClustersOptions) ([]ClusterInfo, error)
+	// ListTKGClustersInAllRegions lists workload clusters managed by all the management clusters,
+	// and the management clusters whose clusters could not be listed
+	ListTKGClustersInAllRegions(options ListTKGClustersInAllRegionsOptions) ([]ClusterInfo, []UnreachableRegion, error)
 	// DeleteWorkloadCluster deletes a workload cluster managed by the management cluster
 	DeleteWorkloadCluster(options DeleteWorkloadClusterOptions) error
+	// VerifyWorkloadClusterDeletion returns an error if the workload cluster is protected from deletion
+	VerifyWorkloadClusterDeletion(options DeleteWorkloadClusterOptions) error
+	// PlanWorkloadClusterDeletion lists the objects a deletion of the workload cluster would remove without deleting anything
+	PlanWorkloadClusterDeletion(options DeleteWorkloadClusterOptions) (*DeleteClusterPlan, error)
+	// SetClusterDeletionProtection enables or disables the deletion protection of the cluster
+	SetClusterDeletionProtection(options SetClusterDeletionProtectionOptions) error
+	// GetClusterOperationHistory returns the lifecycle operations recorded on the cluster
+	GetClusterOperationHistory(options GetClusterOperationHistoryOptions) ([]OperationRecord, error)
+	// GetClusterHealth checks the health of the cluster and of its components
+	GetClusterHealth(options GetClusterHealthOptions) (*ClusterHealthReport, error)
+	// ListClusterCertificates returns the certificates of the cluster with their expiry
+	ListClusterCertificates(options ListClusterCertificatesOptions) ([]ClusterCertificate, error)
+	// RotateClusterCertificates renews the certificates of the control plane nodes of the cluster
+	RotateClusterCertificates(options RotateClusterCertificatesOptions) error
 	// ScaleCluster scales the cluster
 	ScaleCluster(options ScaleClusterOptions) error
+	// HibernateCluster scales the workers of the cluster to zero and pauses its cluster autoscaler
+	HibernateCluster(options HibernateClusterOptions) error
+	// ResumeCluster restores the workers and the cluster autoscaler of a hibernated cluster
+	ResumeCluster(options ResumeClusterOptions) error
+	// BackupManagementCluster exports the objects of the workload clusters of the management cluster to an archive
+	BackupManagementCluster(options BackupManagementClusterOptions) error
+	// MoveManagementCluster moves the workload clusters of the management cluster to an existing cluster
+	// which becomes the management cluster
+	MoveManagementCluster(options MoveManagementClusterOptions) error
+	// RunManagementClusterPreflight runs the preflight checks of a management cluster and reports their results
+	RunManagementClusterPreflight(options *InitRegionOptions, skipChecks []string) (*PreflightReport, error)
+	// RunClusterPreflight runs the preflight checks of a workload cluster and reports their results
+	RunClusterPreflight(options *CreateClusterOptions, skipChecks []string) (*PreflightReport, error)
+	// ExportImageBundle writes the images of a TKr and the BOM, compatibility and provider files to an image bundle
+	ExportImageBundle(options ExportImageBundleOptions) error
+	// ImportImageBundle pushes the images of an image bundle to a private registry and rewrites the image references of its BOM files
+	ImportImageBundle(options ImportImageBundleOptions) error
+	// RestoreManagementCluster creates the objects of a management cluster backup on the management cluster
+	RestoreManagementCluster(options RestoreManagementClusterOptions) error
 	// UpgradeCluster upgrades tkg cluster to specific kubernetes version
 	UpgradeCluster(options *UpgradeClusterOptions) error
+	// PlanClusterUpgrade computes the changes an upgrade of the cluster would make without changing anything
+	PlanClusterUpgrade(options *UpgradeClusterOptions) (*UpgradeClusterPlan, error)
+	// GetClusterUpgradeStatus returns the status of the last upgrade of the cluster, or nil if it was never upgraded
+	GetClusterUpgradeStatus(options *UpgradeClusterOptions) (*UpgradeStatus, error)
 	// ConfigureAndValidateManagementClusterConfiguration validates the management cluster configuration
 	// User is expected to validate the configuration before creating management cluster using init operation
 	ConfigureAndValidateManagementClusterConfiguration(options *InitRegionOptions, skipValidation bool) *ValidationError
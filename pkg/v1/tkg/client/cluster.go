@@ -47,6 +47,24 @@ const (
 	TkgLabelClusterRoleWorkload = "workload"
 )
 
+// workload cluster create step constants
+const (
+	StepCreateClusterObjects         = "Create cluster objects"
+	StepWaitForClusterInitialization = "Wait for cluster initialization"
+	StepWaitForClusterNodes          = "Wait for cluster nodes"
+	StepWaitForAddons                = "Wait for addons and packages"
+)
+
+// CreateClusterSteps workload cluster create step sequence
+var CreateClusterSteps = []string{
+	StepValidateConfiguration,
+	StepGenerateClusterConfiguration,
+	StepCreateClusterObjects,
+	StepWaitForClusterInitialization,
+	StepWaitForClusterNodes,
+	StepWaitForAddons,
+}
+
 type waitForAddonsOptions struct {
 	regionalClusterClient clusterclient.Client
 	workloadClusterClient clusterclient.Client
@@ -62,10 +80,23 @@ type waitForAddonsOptions struct {
 var TKGSupportedClusterOptions string
 
 // CreateCluster create workload cluster
-func (c *TkgClient) CreateCluster(options *CreateClusterOptions, waitForCluster bool) error { //nolint:gocyclo,funlen
+func (c *TkgClient) CreateCluster(options *CreateClusterOptions, waitForCluster bool) (err error) { //nolint:gocyclo,funlen
+	createSteps := CreateClusterSteps
+	if !waitForCluster {
+		createSteps = CreateClusterSteps[:3]
+	}
+	defer func() {
+		if err != nil {
+			log.SendProgressUpdate(statusFailed, "", createSteps)
+		} else {
+			log.SendProgressUpdate(statusSuccessful, "", createSteps)
+		}
+	}()
+
 	if err := checkClusterNameFormat(options.ClusterName); err != nil {
 		return NewValidationError(ValidationErrorCode, err.Error())
 	}
+	log.SendProgressUpdate(statusRunning, StepValidateConfiguration, createSteps)
 	log.Info("Validating configuration...")
 	// validate kubectl only since we need only kubectl for create cluster
 	if err := c.ValidatePrerequisites(false, true); err != nil {
@@ -126,6 +157,7 @@ func (c *TkgClient) CreateCluster(options *CreateClusterOptions, waitForCluster
 	if err != nil {
 		return err
 	}
+	log.SendProgressUpdate(statusRunning, StepGenerateClusterConfiguration, createSteps)
 	bytes, err = c.getClusterConfiguration(&options.ClusterConfigOptions, isManagementCluster, infraProviderName)
 	if err != nil {
 		return errors.Wrap(err, "unable to get cluster configuration")
@@ -141,6 +173,7 @@ func (c *TkgClient) CreateCluster(options *CreateClusterOptions, waitForCluster
 		}
 	}
 
+	log.SendProgressUpdate(statusRunning, StepCreateClusterObjects, createSteps)
 	log.Infof("Creating workload cluster '%s'...", options.ClusterName)
 	err = c.DoCreateCluster(regionalClusterClient, options.ClusterName, options.TargetNamespace, string(bytes))
 	if err != nil {
@@ -148,13 +181,18 @@ func (c *TkgClient) CreateCluster(options *CreateClusterOptions, waitForCluster
 	}
 	// If user opts not to wait for the cluster to be provisioned, return
 	if !waitForCluster {
+		recordOperationSubmitted(regionalClusterClient, options.ClusterName, options.TargetNamespace, clusterclient.OperationTypeCreate)
 		return nil
 	}
-	return c.waitForClusterCreation(regionalClusterClient, options)
+	recorder := recordOperationStarted(regionalClusterClient, options.ClusterName, options.TargetNamespace, clusterclient.OperationTypeCreate)
+	err = c.waitForClusterCreation(regionalClusterClient, options)
+	recorder.finished(err)
+	return err
 }
 
 func (c *TkgClient) waitForClusterCreation(regionalClusterClient clusterclient.Client, options *CreateClusterOptions) error {
-	log.Info("Waiting for cluster to be initialized...")
+	log.SendProgressUpdate(statusRunning, StepWaitForClusterInitialization, CreateClusterSteps)
+	logWaitingFor("Cluster", options.TargetNamespace, options.ClusterName, "Waiting for cluster to be initialized...")
 	kubeConfigBytes, err := c.WaitForClusterInitializedAndGetKubeConfig(regionalClusterClient, options.ClusterName, options.TargetNamespace)
 	if err != nil {
 		return errors.Wrap(err, "unable to wait for cluster and get the cluster kubeconfig")
@@ -173,14 +211,15 @@ func (c *TkgClient) waitForClusterCreation(regionalClusterClient clusterclient.C
 		return errors.Wrap(err, "unable to save management cluster kubeconfig to TKG managed kubeconfig")
 	}
 
-	log.Info("Waiting for cluster nodes to be available...")
+	log.SendProgressUpdate(statusRunning, StepWaitForClusterNodes, CreateClusterSteps)
+	logWaitingFor("Cluster", options.TargetNamespace, options.ClusterName, "Waiting for cluster nodes to be available...")
 	if err := c.WaitForClusterReadyAfterCreate(regionalClusterClient, options.ClusterName, options.TargetNamespace); err != nil {
 		return errors.Wrap(err, "unable to wait for cluster nodes to be available")
 	}
 
 	if _, err := c.TKGConfigReaderWriter().Get(constants.ConfigVariableEnableAutoscaler); err == nil {
-		log.Infof("Waiting for cluster autoscaler to be available...")
 		autoscalerDeploymentName := options.ClusterName + "-cluster-autoscaler"
+		logWaitingFor("Deployment", options.TargetNamespace, autoscalerDeploymentName, "Waiting for cluster autoscaler to be available...")
 		if err := regionalClusterClient.WaitForAutoscalerDeployment(autoscalerDeploymentName, options.TargetNamespace); err != nil {
 			log.Warningf("Unable to wait for autoscaler deployment to be ready. reason: %v", err)
 		}
@@ -191,7 +230,8 @@ func (c *TkgClient) waitForClusterCreation(regionalClusterClient clusterclient.C
 		return errors.Wrap(err, "unable to create workload cluster client")
 	}
 
-	log.Info("Waiting for addons installation...")
+	log.SendProgressUpdate(statusRunning, StepWaitForAddons, CreateClusterSteps)
+	logWaitingFor("Cluster", options.TargetNamespace, options.ClusterName, "Waiting for addons installation...")
 	if err := c.WaitForAddons(waitForAddonsOptions{
 		regionalClusterClient: regionalClusterClient,
 		workloadClusterClient: workloadClusterClient,
@@ -202,7 +242,7 @@ func (c *TkgClient) waitForClusterCreation(regionalClusterClient clusterclient.C
 		return errors.Wrap(err, "error waiting for addons to get installed")
 	}
 
-	log.Info("Waiting for packages to be up and running...")
+	logWaitingFor("PackageInstall", "", "", "Waiting for packages to be up and running...")
 	if err := c.WaitForPackages(regionalClusterClient, workloadClusterClient, options.ClusterName, options.TargetNamespace); err != nil {
 		log.Warningf("Warning: Cluster is created successfully, but some packages are failing. %v", err)
 	}
@@ -210,6 +250,12 @@ func (c *TkgClient) waitForClusterCreation(regionalClusterClient clusterclient.C
 	return nil
 }
 
+// logWaitingFor logs the message and sends the resource waiting event
+func logWaitingFor(kind, namespace, name, message string) {
+	log.Info(message)
+	log.SendWaitingEvent(kind, namespace, name, message)
+}
+
 // DoCreateCluster performs steps to create cluster
 func (c *TkgClient) DoCreateCluster(clusterClient clusterclient.Client, name, namespace, manifest string) error {
 	var err error
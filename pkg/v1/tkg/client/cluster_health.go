@@ -0,0 +1,370 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	kappctrl "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	kappipkg "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+)
+
+// Statuses of the cluster health checks, ordered from the healthiest
+const (
+	HealthStatusPass = "pass"
+	HealthStatusWarn = "warn"
+	HealthStatusFail = "fail"
+)
+
+// Categories of the cluster health checks
+const (
+	HealthCategoryCluster            = "Cluster"
+	HealthCategoryControlPlane       = "ControlPlane"
+	HealthCategoryMachine            = "Machine"
+	HealthCategoryMachineHealthCheck = "MachineHealthCheck"
+	HealthCategoryNode               = "Node"
+	HealthCategoryAddon              = "Addon"
+	HealthCategoryPackageInstall     = "PackageInstall"
+	HealthCategoryCertificate        = "Certificate"
+)
+
+// CertificateExpiryWarningPeriod is the time before the expiry of a certificate from which its health check warns
+const CertificateExpiryWarningPeriod = 30 * 24 * time.Hour
+
+// ClusterHealthReport is the health of a cluster and of its components
+type ClusterHealthReport struct {
+	ClusterName string               `json:"clusterName" yaml:"clusterName"`
+	Namespace   string               `json:"namespace" yaml:"namespace"`
+	Status      string               `json:"status" yaml:"status"`
+	Checks      []ClusterHealthCheck `json:"checks" yaml:"checks"`
+}
+
+// ClusterHealthCheck is the health of a component of a cluster
+type ClusterHealthCheck struct {
+	Category string `json:"category" yaml:"category"`
+	Name     string `json:"name" yaml:"name"`
+	Status   string `json:"status" yaml:"status"`
+	Message  string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// GetClusterHealthOptions contains options supported by GetClusterHealth
+type GetClusterHealthOptions struct {
+	ClusterName string
+	Namespace   string
+}
+
+// GetClusterHealth checks the health of a workload cluster. The nodes and package installs are checked on
+// the workload cluster, their checks fail if it is unreachable.
+func (c *TkgClient) GetClusterHealth(options GetClusterHealthOptions) (*ClusterHealthReport, error) {
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get current management cluster context")
+	}
+	clusterclientOptions := clusterclient.Options{
+		GetClientInterval: 1 * time.Second,
+		GetClientTimeout:  3 * time.Second,
+	}
+	clusterClient, err := clusterclient.NewClient(currentRegion.SourceFilePath, currentRegion.ContextName, clusterclientOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get cluster client while checking cluster health")
+	}
+
+	isPacific, err := clusterClient.IsPacificRegionalCluster()
+	if err != nil {
+		return nil, errors.Wrap(err, "error determining 'Tanzu Kubernetes Cluster service for vSphere' management cluster")
+	}
+	if isPacific {
+		return nil, errors.New("health report for 'Tanzu Kubernetes Cluster service for vSphere' clusters is not yet supported")
+	}
+
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+
+	workloadClusterClient, workloadErr := c.getWorkloadClusterClient(options.ClusterName, options.Namespace)
+	report, err := DoGetClusterHealth(clusterClient, workloadClusterClient, options, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if workloadErr != nil {
+		report.addCheck(HealthCategoryCluster, "workload-cluster-access", HealthStatusFail, workloadErr.Error())
+	}
+	return report, nil
+}
+
+// DoGetClusterHealth checks the health of the cluster objects on the management cluster, and of the nodes and
+// package installs on the workload cluster if the workload cluster client is not nil. now is used to evaluate
+// the expiry of the certificates.
+func DoGetClusterHealth(regionalClusterClient, workloadClusterClient clusterclient.Client, options GetClusterHealthOptions, now time.Time) (*ClusterHealthReport, error) { //nolint:gocyclo,funlen
+	cluster := &capi.Cluster{}
+	if err := regionalClusterClient.GetResource(cluster, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return nil, errors.Wrapf(err, "unable to get cluster '%s' in namespace '%s'", options.ClusterName, options.Namespace)
+	}
+
+	report := &ClusterHealthReport{
+		ClusterName: options.ClusterName,
+		Namespace:   options.Namespace,
+		Checks:      []ClusterHealthCheck{},
+	}
+	checkConditions := func(category string, getter conditions.Getter) {
+		status, message := conditionsHealth(getter)
+		report.addCheck(category, getter.GetName(), status, message)
+	}
+
+	checkConditions(HealthCategoryCluster, cluster)
+
+	kcpList := &controlplanev1.KubeadmControlPlaneList{}
+	if err := regionalClusterClient.GetResourceList(kcpList, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return nil, errors.Wrap(err, "unable to list the control plane of the cluster")
+	}
+	for i := range kcpList.Items {
+		checkConditions(HealthCategoryControlPlane, &kcpList.Items[i])
+	}
+
+	machineList := &capi.MachineList{}
+	if err := regionalClusterClient.GetResourceList(machineList, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return nil, errors.Wrap(err, "unable to list the machines of the cluster")
+	}
+	for i := range machineList.Items {
+		status, message := machineHealth(&machineList.Items[i])
+		report.addCheck(HealthCategoryMachine, machineList.Items[i].Name, status, message)
+	}
+
+	mhcList := &capi.MachineHealthCheckList{}
+	if err := regionalClusterClient.GetResourceList(mhcList, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return nil, errors.Wrap(err, "unable to list the machine health checks of the cluster")
+	}
+	for i := range mhcList.Items {
+		status, message := machineHealthCheckHealth(&mhcList.Items[i])
+		report.addCheck(HealthCategoryMachineHealthCheck, mhcList.Items[i].Name, status, message)
+	}
+
+	secretList := &corev1.SecretList{}
+	if err := regionalClusterClient.ListResources(secretList, &crtclient.ListOptions{Namespace: options.Namespace}); err != nil {
+		return nil, errors.Wrap(err, "unable to list the addon secrets of the cluster")
+	}
+	for i := range secretList.Items {
+		secret := &secretList.Items[i]
+		if secret.Type != constants.AddonSecretType || secret.Labels[constants.ClusterNameLabel] != options.ClusterName {
+			continue
+		}
+		status, message := addonHealth(regionalClusterClient, workloadClusterClient, secret)
+		report.addCheck(HealthCategoryAddon, secret.Labels[constants.AddonNameLabel], status, message)
+	}
+
+	if workloadClusterClient != nil {
+		nodeList := &corev1.NodeList{}
+		if err := workloadClusterClient.ListResources(nodeList, &crtclient.ListOptions{}); err != nil {
+			report.addCheck(HealthCategoryNode, "nodes", HealthStatusFail, fmt.Sprintf("unable to list the nodes of the workload cluster: %v", err))
+		} else {
+			for i := range nodeList.Items {
+				status, message := nodeHealth(&nodeList.Items[i])
+				report.addCheck(HealthCategoryNode, nodeList.Items[i].Name, status, message)
+			}
+		}
+
+		packageInstallList := &kappipkg.PackageInstallList{}
+		if err := workloadClusterClient.ListResources(packageInstallList, &crtclient.ListOptions{}); err != nil {
+			report.addCheck(HealthCategoryPackageInstall, "package-installs", HealthStatusFail, fmt.Sprintf("unable to list the package installs of the workload cluster: %v", err))
+		} else {
+			for i := range packageInstallList.Items {
+				packageInstall := &packageInstallList.Items[i]
+				status, message := reconcileHealth(packageInstall.Status.GenericStatus)
+				report.addCheck(HealthCategoryPackageInstall, packageInstall.Namespace+"/"+packageInstall.Name, status, message)
+			}
+		}
+	}
+
+	certificates, err := getClusterCertificates(regionalClusterClient, options.ClusterName, options.Namespace)
+	if err != nil {
+		report.addCheck(HealthCategoryCertificate, "certificates", HealthStatusFail, err.Error())
+	}
+	for i := range certificates {
+		status, message := certificateHealth(&certificates[i], now)
+		name := certificates[i].Name
+		if certificates[i].Machine != "" {
+			name += "/" + certificates[i].Machine
+		}
+		report.addCheck(HealthCategoryCertificate, name, status, message)
+	}
+
+	return report, nil
+}
+
+// addCheck adds the check to the report, the status of the report is the worst status of its checks
+func (r *ClusterHealthReport) addCheck(category, name, status, message string) {
+	r.Checks = append(r.Checks, ClusterHealthCheck{Category: category, Name: name, Status: status, Message: message})
+	r.Status = worstHealthStatus(r.Status, status)
+}
+
+func worstHealthStatus(status1, status2 string) string {
+	severity := map[string]int{"": 0, HealthStatusPass: 1, HealthStatusWarn: 2, HealthStatusFail: 3}
+	if severity[status2] > severity[status1] {
+		return status2
+	}
+	return status1
+}
+
+// conditionsHealth evaluates the Ready condition of a cluster api object. The object fails if it is not ready
+// with an error severity, and warns if its readiness is unknown or not ready with a lower severity.
+func conditionsHealth(getter conditions.Getter) (string, string) {
+	notTrue := []string{}
+	for _, condition := range getter.GetConditions() {
+		if condition.Status == corev1.ConditionTrue {
+			continue
+		}
+		description := string(condition.Type)
+		if condition.Reason != "" {
+			description += fmt.Sprintf(" (%s)", condition.Reason)
+		}
+		if condition.Message != "" {
+			description += ": " + condition.Message
+		}
+		notTrue = append(notTrue, description)
+	}
+	message := strings.Join(notTrue, ", ")
+
+	ready := conditions.Get(getter, capi.ReadyCondition)
+	switch {
+	case ready == nil:
+		return HealthStatusWarn, "no Ready condition reported"
+	case ready.Status == corev1.ConditionTrue:
+		return HealthStatusPass, message
+	case ready.Status == corev1.ConditionFalse && ready.Severity == capi.ConditionSeverityError:
+		return HealthStatusFail, message
+	default:
+		return HealthStatusWarn, message
+	}
+}
+
+// machineHealth evaluates the phase, the node and the Ready condition of the machine
+func machineHealth(machine *capi.Machine) (string, string) {
+	if machine.Status.GetTypedPhase() == capi.MachinePhaseFailed {
+		message := "machine failed"
+		if machine.Status.FailureMessage != nil {
+			message += ": " + *machine.Status.FailureMessage
+		}
+		return HealthStatusFail, message
+	}
+	status, message := conditionsHealth(machine)
+	if machine.Status.NodeRef == nil {
+		return worstHealthStatus(status, HealthStatusWarn), joinMessages("no node", message)
+	}
+	return status, message
+}
+
+// machineHealthCheckHealth evaluates the healthy machines and the remediations allowed by the machine health check
+func machineHealthCheckHealth(mhc *capi.MachineHealthCheck) (string, string) {
+	message := fmt.Sprintf("%d/%d machines healthy, %d remediations allowed",
+		mhc.Status.CurrentHealthy, mhc.Status.ExpectedMachines, mhc.Status.RemediationsAllowed)
+	if conditions.IsFalse(mhc, capi.RemediationAllowedCondition) {
+		return HealthStatusFail, joinMessages(message, "remediation blocked: "+conditions.GetMessage(mhc, capi.RemediationAllowedCondition))
+	}
+	if mhc.Status.CurrentHealthy < mhc.Status.ExpectedMachines {
+		return HealthStatusWarn, message
+	}
+	return HealthStatusPass, message
+}
+
+// nodeHealth evaluates the Ready and the pressure conditions of the node
+func nodeHealth(node *corev1.Node) (string, string) {
+	status := HealthStatusPass
+	messages := []string{}
+	for _, condition := range node.Status.Conditions {
+		switch condition.Type {
+		case corev1.NodeReady:
+			if condition.Status != corev1.ConditionTrue {
+				status = HealthStatusFail
+				messages = append(messages, joinMessages("not ready", condition.Message))
+			}
+		case corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure:
+			if condition.Status == corev1.ConditionTrue {
+				status = worstHealthStatus(status, HealthStatusWarn)
+				messages = append(messages, string(condition.Type))
+			}
+		}
+	}
+	return status, strings.Join(messages, ", ")
+}
+
+// addonHealth evaluates the reconciliation of the kapp App of the addon, which is on the management
+// cluster for the remote apps and on the workload cluster otherwise
+func addonHealth(regionalClusterClient, workloadClusterClient clusterclient.Client, secret *corev1.Secret) (string, string) {
+	if _, paused := secret.Annotations[constants.AddonPausedAnnotation]; paused {
+		return HealthStatusWarn, "addon reconciliation paused"
+	}
+
+	addonName := secret.Labels[constants.AddonNameLabel]
+	appName, appNamespace, appClusterClient := addonName, constants.TkgNamespace, workloadClusterClient
+	if secret.Annotations[constants.AddonRemoteAppAnnotation] == "true" {
+		appName = secret.Labels[constants.ClusterNameLabel] + "-" + addonName
+		appNamespace, appClusterClient = secret.Namespace, regionalClusterClient
+	}
+	if appClusterClient == nil {
+		return HealthStatusWarn, "unable to get the addon App, the workload cluster is unreachable"
+	}
+
+	app := &kappctrl.App{}
+	if err := appClusterClient.GetResource(app, appName, appNamespace, nil, nil); err != nil {
+		if apierrors.IsNotFound(err) {
+			return HealthStatusWarn, fmt.Sprintf("App '%s/%s' not found", appNamespace, appName)
+		}
+		return HealthStatusWarn, fmt.Sprintf("unable to get App '%s/%s': %v", appNamespace, appName, err)
+	}
+	return reconcileHealth(app.Status.GenericStatus)
+}
+
+// reconcileHealth evaluates the reconcile conditions of a kapp-controller App or PackageInstall
+func reconcileHealth(status kappctrl.GenericStatus) (string, string) {
+	for _, condition := range status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case kappctrl.ReconcileSucceeded:
+			return HealthStatusPass, status.FriendlyDescription
+		case kappctrl.ReconcileFailed, kappctrl.DeleteFailed:
+			return HealthStatusFail, joinMessages(status.FriendlyDescription, status.UsefulErrorMessage)
+		}
+	}
+	if status.FriendlyDescription == "" {
+		return HealthStatusWarn, "reconciliation not reported"
+	}
+	return HealthStatusWarn, status.FriendlyDescription
+}
+
+// certificateHealth fails if the certificate is expired and warns if it expires within CertificateExpiryWarningPeriod
+func certificateHealth(certificate *ClusterCertificate, now time.Time) (string, string) {
+	expiry := certificate.NotAfter.UTC().Format(time.RFC3339)
+	switch {
+	case !now.Before(certificate.NotAfter):
+		return HealthStatusFail, fmt.Sprintf("expired on %s", expiry)
+	case certificate.NotAfter.Sub(now) < CertificateExpiryWarningPeriod:
+		return HealthStatusWarn, fmt.Sprintf("expires on %s, in %d days", expiry, int(certificate.NotAfter.Sub(now).Hours()/24))
+	default:
+		return HealthStatusPass, fmt.Sprintf("expires on %s", expiry)
+	}
+}
+
+func joinMessages(messages ...string) string {
+	nonEmpty := []string{}
+	for _, message := range messages {
+		if message != "" {
+			nonEmpty = append(nonEmpty, message)
+		}
+	}
+	return strings.Join(nonEmpty, "; ")
+}
@@ -0,0 +1,260 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	stdlog "log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	kappctrl "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	kappipkg "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" // nolint:staticcheck
+
+	. "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/fakes"
+	fakehelper "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/fakes/helper"
+)
+
+var _ = Describe("Cluster health", func() {
+	var (
+		regionalClusterClient clusterclient.Client
+		workloadClusterClient clusterclient.Client
+		regionalObjects       []runtime.Object
+		workloadObjects       []runtime.Object
+		now                   time.Time
+	)
+
+	newClusterClient := func(objects []runtime.Object) clusterclient.Client {
+		crtClientFactory := &fakes.CrtClientFactory{}
+		crtClientFactory.NewClientReturns(fake.NewFakeClientWithScheme(scheme, objects...), nil)
+		kubeconfig := fakehelper.GetFakeKubeConfigFilePath(testingDir, "../fakes/config/kubeconfig/config1.yaml")
+		clusterClient, err := clusterclient.NewClient(kubeconfig, "", clusterclient.NewOptions(getFakePoller(), crtClientFactory, getFakeDiscoveryFactory(), nil))
+		Expect(err).NotTo(HaveOccurred())
+		return clusterClient
+	}
+
+	selfSignedCertificate := func(commonName string, notAfter time.Time) ([]byte, *ecdsa.PrivateKey) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: commonName},
+			NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+			NotAfter:     notAfter,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		Expect(err).NotTo(HaveOccurred())
+		return der, key
+	}
+
+	certificateSecret := func(name string, notAfter time.Time) *corev1.Secret {
+		der, _ := selfSignedCertificate("kubernetes", notAfter)
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "my-namespace"},
+			Data:       map[string][]byte{"tls.crt": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})},
+		}
+	}
+
+	findCheck := func(report *ClusterHealthReport, category, name string) ClusterHealthCheck {
+		for _, check := range report.Checks {
+			if check.Category == category && check.Name == name {
+				return check
+			}
+		}
+		Fail("no check " + category + "/" + name)
+		return ClusterHealthCheck{}
+	}
+
+	BeforeEach(func() {
+		now = time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)
+		clusterLabels := map[string]string{capi.ClusterLabelName: "my-cluster"}
+		readyCondition := capi.Conditions{{Type: capi.ReadyCondition, Status: corev1.ConditionTrue}}
+		regionalObjects = []runtime.Object{
+			&capi.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "my-namespace"},
+				Status:     capi.ClusterStatus{Conditions: readyCondition},
+			},
+			&capi.Machine{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-md-0-abcde", Namespace: "my-namespace", Labels: clusterLabels},
+				Status: capi.MachineStatus{
+					NodeRef:    &corev1.ObjectReference{Name: "my-cluster-md-0-abcde"},
+					Conditions: readyCondition,
+				},
+			},
+			&capi.Machine{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-md-0-fghij", Namespace: "my-namespace", Labels: clusterLabels},
+				Status: capi.MachineStatus{
+					Conditions: capi.Conditions{{Type: capi.ReadyCondition, Status: corev1.ConditionFalse, Severity: capi.ConditionSeverityInfo, Reason: "WaitingForInfrastructure"}},
+				},
+			},
+			&capi.MachineHealthCheck{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "my-namespace", Labels: clusterLabels},
+				Status: capi.MachineHealthCheckStatus{
+					ExpectedMachines:    2,
+					CurrentHealthy:      0,
+					RemediationsAllowed: 0,
+					Conditions:          capi.Conditions{{Type: capi.RemediationAllowedCondition, Status: corev1.ConditionFalse, Reason: capi.TooManyUnhealthyReason}},
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-cluster-antrea-addon",
+					Namespace:   "my-namespace",
+					Labels:      map[string]string{constants.ClusterNameLabel: "my-cluster", constants.AddonNameLabel: "antrea"},
+					Annotations: map[string]string{constants.AddonRemoteAppAnnotation: "true"},
+				},
+				Type: constants.AddonSecretType,
+			},
+			&kappctrl.App{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-antrea", Namespace: "my-namespace"},
+				Status: kappctrl.AppStatus{GenericStatus: kappctrl.GenericStatus{
+					Conditions:          []kappctrl.AppCondition{{Type: kappctrl.ReconcileSucceeded, Status: corev1.ConditionTrue}},
+					FriendlyDescription: "Reconcile succeeded",
+				}},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-cluster-metrics-server-addon",
+					Namespace:   "my-namespace",
+					Labels:      map[string]string{constants.ClusterNameLabel: "my-cluster", constants.AddonNameLabel: "metrics-server"},
+					Annotations: map[string]string{constants.AddonPausedAnnotation: ""},
+				},
+				Type: constants.AddonSecretType,
+			},
+			certificateSecret("my-cluster-ca", now.Add(5*365*24*time.Hour)),
+			certificateSecret("my-cluster-etcd", now.Add(10*24*time.Hour)),
+			certificateSecret("my-cluster-proxy", now.Add(-time.Hour)),
+		}
+		workloadObjects = []runtime.Object{
+			&corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-md-0-abcde"},
+				Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+					{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+					{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue},
+				}},
+			},
+			&kappipkg.PackageInstall{
+				ObjectMeta: metav1.ObjectMeta{Name: "fluent-bit", Namespace: "my-packages"},
+				Status: kappipkg.PackageInstallStatus{GenericStatus: kappctrl.GenericStatus{
+					Conditions:         []kappctrl.AppCondition{{Type: kappctrl.ReconcileFailed, Status: corev1.ConditionTrue}},
+					UsefulErrorMessage: "template failed",
+				}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		regionalClusterClient = newClusterClient(regionalObjects)
+		workloadClusterClient = newClusterClient(workloadObjects)
+	})
+
+	It("reports the status of each component of the cluster", func() {
+		report, err := DoGetClusterHealth(regionalClusterClient, workloadClusterClient, GetClusterHealthOptions{ClusterName: "my-cluster", Namespace: "my-namespace"}, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Status).To(Equal(HealthStatusFail))
+
+		Expect(findCheck(report, HealthCategoryCluster, "my-cluster").Status).To(Equal(HealthStatusPass))
+		Expect(findCheck(report, HealthCategoryMachine, "my-cluster-md-0-abcde").Status).To(Equal(HealthStatusPass))
+		Expect(findCheck(report, HealthCategoryMachine, "my-cluster-md-0-fghij").Status).To(Equal(HealthStatusWarn))
+		Expect(findCheck(report, HealthCategoryMachineHealthCheck, "my-cluster").Status).To(Equal(HealthStatusFail))
+		Expect(findCheck(report, HealthCategoryAddon, "antrea").Status).To(Equal(HealthStatusPass))
+		Expect(findCheck(report, HealthCategoryAddon, "metrics-server").Status).To(Equal(HealthStatusWarn))
+		Expect(findCheck(report, HealthCategoryNode, "my-cluster-md-0-abcde").Status).To(Equal(HealthStatusWarn))
+		packageInstallCheck := findCheck(report, HealthCategoryPackageInstall, "my-packages/fluent-bit")
+		Expect(packageInstallCheck.Status).To(Equal(HealthStatusFail))
+		Expect(packageInstallCheck.Message).To(ContainSubstring("template failed"))
+		Expect(findCheck(report, HealthCategoryCertificate, "cluster-ca").Status).To(Equal(HealthStatusPass))
+		Expect(findCheck(report, HealthCategoryCertificate, "etcd-ca").Status).To(Equal(HealthStatusWarn))
+		Expect(findCheck(report, HealthCategoryCertificate, "front-proxy-ca").Status).To(Equal(HealthStatusFail))
+	})
+
+	Context("when the API servers of the control plane machines are reachable", func() {
+		var apiServer *httptest.Server
+
+		BeforeEach(func() {
+			der, key := selfSignedCertificate("kube-apiserver", now.Add(20*24*time.Hour))
+			apiServer = httptest.NewUnstartedServer(http.NotFoundHandler())
+			apiServer.TLS = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}} //nolint:gosec
+			// the connections closed right after the handshake are logged as handshake errors
+			apiServer.Config.ErrorLog = stdlog.New(io.Discard, "", 0)
+			apiServer.StartTLS()
+			host, port, err := net.SplitHostPort(apiServer.Listener.Addr().String())
+			Expect(err).NotTo(HaveOccurred())
+			endpointPort, err := strconv.Atoi(port)
+			Expect(err).NotTo(HaveOccurred())
+
+			regionalObjects[0].(*capi.Cluster).Spec.ControlPlaneEndpoint = capi.APIEndpoint{Host: "my-cluster.example.com", Port: int32(endpointPort)}
+			controlPlaneLabels := map[string]string{capi.ClusterLabelName: "my-cluster", capi.MachineControlPlaneLabelName: ""}
+			regionalObjects = append(regionalObjects,
+				&capi.Machine{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-control-plane-abcde", Namespace: "my-namespace", Labels: controlPlaneLabels},
+					Status: capi.MachineStatus{
+						Addresses: capi.MachineAddresses{
+							{Type: capi.MachineExternalIP, Address: "192.0.2.1"},
+							{Type: capi.MachineInternalIP, Address: host},
+						},
+						Conditions: capi.Conditions{{Type: capi.ReadyCondition, Status: corev1.ConditionTrue}},
+					},
+				},
+				&capi.Machine{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-control-plane-fghij", Namespace: "my-namespace", Labels: controlPlaneLabels},
+					Status:     capi.MachineStatus{Conditions: capi.Conditions{{Type: capi.ReadyCondition, Status: corev1.ConditionTrue}}},
+				},
+			)
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+		})
+
+		It("reports the expiry of the serving certificate of each control plane machine with an address", func() {
+			report, err := DoGetClusterHealth(regionalClusterClient, workloadClusterClient, GetClusterHealthOptions{ClusterName: "my-cluster", Namespace: "my-namespace"}, now)
+			Expect(err).NotTo(HaveOccurred())
+
+			check := findCheck(report, HealthCategoryCertificate, "apiserver/my-cluster-control-plane-abcde")
+			Expect(check.Status).To(Equal(HealthStatusWarn))
+			Expect(check.Message).To(Equal("expires on 2021-08-21T00:00:00Z, in 20 days"))
+			for _, check := range report.Checks {
+				if check.Category == HealthCategoryCertificate {
+					Expect(check.Name).NotTo(ContainSubstring("my-cluster-control-plane-fghij"))
+				}
+			}
+		})
+	})
+
+	It("skips the workload cluster checks without a workload cluster client", func() {
+		report, err := DoGetClusterHealth(regionalClusterClient, nil, GetClusterHealthOptions{ClusterName: "my-cluster", Namespace: "my-namespace"}, now)
+		Expect(err).NotTo(HaveOccurred())
+		for _, check := range report.Checks {
+			Expect(check.Category).NotTo(Equal(HealthCategoryNode))
+			Expect(check.Category).NotTo(Equal(HealthCategoryPackageInstall))
+		}
+	})
+
+	It("returns an error if the cluster does not exist", func() {
+		_, err := DoGetClusterHealth(regionalClusterClient, workloadClusterClient, GetClusterHealthOptions{ClusterName: "unknown", Namespace: "my-namespace"}, now)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,204 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	kappipkg "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+	addonsv1 "sigs.k8s.io/cluster-api/exp/addons/api/v1alpha3"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+)
+
+// Locations of the objects in a delete plan
+const (
+	DeleteLocationManagementCluster = "management-cluster"
+	DeleteLocationWorkloadCluster   = "workload-cluster"
+)
+
+// DeleteClusterPlan lists the objects the deletion of a workload cluster removes
+type DeleteClusterPlan struct {
+	ClusterName string                `json:"clusterName" yaml:"clusterName"`
+	Namespace   string                `json:"namespace" yaml:"namespace"`
+	Protected   bool                  `json:"protected" yaml:"protected"`
+	Objects     []DeleteClusterObject `json:"objects" yaml:"objects"`
+	Warnings    []string              `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// DeleteClusterObject is an object removed by the deletion of a workload cluster
+type DeleteClusterObject struct {
+	Kind      string `json:"kind" yaml:"kind"`
+	Name      string `json:"name" yaml:"name"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Location  string `json:"location" yaml:"location"`
+}
+
+// PlanWorkloadClusterDeletion lists the objects the deletion of the workload cluster would remove, without deleting anything.
+// The package installs are listed from the workload cluster, they are omitted with a warning if it is unreachable.
+func (c *TkgClient) PlanWorkloadClusterDeletion(options DeleteWorkloadClusterOptions) (*DeleteClusterPlan, error) {
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get current management cluster context")
+	}
+	clusterclientOptions := clusterclient.Options{
+		GetClientInterval: 1 * time.Second,
+		GetClientTimeout:  3 * time.Second,
+	}
+	clusterClient, err := clusterclient.NewClient(currentRegion.SourceFilePath, currentRegion.ContextName, clusterclientOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get cluster client while planning cluster deletion")
+	}
+
+	isPacific, err := clusterClient.IsPacificRegionalCluster()
+	if err != nil {
+		return nil, errors.Wrap(err, "error determining 'Tanzu Kubernetes Cluster service for vSphere' management cluster")
+	}
+	if isPacific {
+		return nil, errors.New("delete plan for 'Tanzu Kubernetes Cluster service for vSphere' clusters is not yet supported")
+	}
+
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+
+	var warnings []string
+	workloadClusterClient, err := c.getWorkloadClusterClient(options.ClusterName, options.Namespace)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("the package installs of the workload cluster are not listed: %v", err))
+	}
+
+	plan, err := DoPlanWorkloadClusterDeletion(clusterClient, workloadClusterClient, options)
+	if err != nil {
+		return nil, err
+	}
+	plan.Warnings = append(warnings, plan.Warnings...)
+	return plan, nil
+}
+
+// DoPlanWorkloadClusterDeletion lists the objects of the cluster on the management cluster, and the package
+// installs on the workload cluster if the workload cluster client is not nil
+func DoPlanWorkloadClusterDeletion(regionalClusterClient, workloadClusterClient clusterclient.Client, options DeleteWorkloadClusterOptions) (*DeleteClusterPlan, error) { //nolint:gocyclo
+	cluster := &capi.Cluster{}
+	if err := regionalClusterClient.GetResource(cluster, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return nil, errors.Wrapf(err, "unable to get cluster '%s' in namespace '%s'", options.ClusterName, options.Namespace)
+	}
+
+	plan := &DeleteClusterPlan{
+		ClusterName: options.ClusterName,
+		Namespace:   options.Namespace,
+		Protected:   isDeletionProtected(cluster),
+		Objects:     []DeleteClusterObject{},
+	}
+	planned := map[string]bool{}
+	add := func(kind, name, namespace, location string) {
+		key := fmt.Sprintf("%s/%s/%s/%s", location, kind, namespace, name)
+		if name == "" || planned[key] {
+			return
+		}
+		planned[key] = true
+		plan.Objects = append(plan.Objects, DeleteClusterObject{Kind: kind, Name: name, Namespace: namespace, Location: location})
+	}
+	addReference := func(ref *corev1.ObjectReference) {
+		if ref == nil {
+			return
+		}
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = options.Namespace
+		}
+		add(ref.Kind, ref.Name, namespace, DeleteLocationManagementCluster)
+	}
+
+	add("Cluster", cluster.Name, cluster.Namespace, DeleteLocationManagementCluster)
+	addReference(cluster.Spec.InfrastructureRef)
+	addReference(cluster.Spec.ControlPlaneRef)
+
+	kcpList := &controlplanev1.KubeadmControlPlaneList{}
+	if err := regionalClusterClient.GetResourceList(kcpList, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return nil, errors.Wrap(err, "unable to list the control plane of the cluster")
+	}
+	for i := range kcpList.Items {
+		add("KubeadmControlPlane", kcpList.Items[i].Name, kcpList.Items[i].Namespace, DeleteLocationManagementCluster)
+		addReference(&kcpList.Items[i].Spec.InfrastructureTemplate)
+	}
+
+	mdList := &capi.MachineDeploymentList{}
+	if err := regionalClusterClient.GetResourceList(mdList, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return nil, errors.Wrap(err, "unable to list the machine deployments of the cluster")
+	}
+	for i := range mdList.Items {
+		add("MachineDeployment", mdList.Items[i].Name, mdList.Items[i].Namespace, DeleteLocationManagementCluster)
+		addReference(&mdList.Items[i].Spec.Template.Spec.InfrastructureRef)
+		addReference(mdList.Items[i].Spec.Template.Spec.Bootstrap.ConfigRef)
+	}
+
+	machineList := &capi.MachineList{}
+	if err := regionalClusterClient.GetResourceList(machineList, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return nil, errors.Wrap(err, "unable to list the machines of the cluster")
+	}
+	for i := range machineList.Items {
+		add("Machine", machineList.Items[i].Name, machineList.Items[i].Namespace, DeleteLocationManagementCluster)
+		addReference(&machineList.Items[i].Spec.InfrastructureRef)
+		addReference(machineList.Items[i].Spec.Bootstrap.ConfigRef)
+	}
+
+	mhcList := &capi.MachineHealthCheckList{}
+	if err := regionalClusterClient.GetResourceList(mhcList, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return nil, errors.Wrap(err, "unable to list the machine health checks of the cluster")
+	}
+	for i := range mhcList.Items {
+		add("MachineHealthCheck", mhcList.Items[i].Name, mhcList.Items[i].Namespace, DeleteLocationManagementCluster)
+	}
+
+	secretList := &corev1.SecretList{}
+	if err := regionalClusterClient.ListResources(secretList, &crtclient.ListOptions{Namespace: options.Namespace}); err != nil {
+		return nil, errors.Wrap(err, "unable to list the addon secrets of the cluster")
+	}
+	for i := range secretList.Items {
+		if secretList.Items[i].Type == constants.AddonSecretType && secretList.Items[i].Labels[constants.ClusterNameLabel] == options.ClusterName {
+			add("Secret", secretList.Items[i].Name, secretList.Items[i].Namespace, DeleteLocationManagementCluster)
+		}
+	}
+
+	crsList := &addonsv1.ClusterResourceSetList{}
+	if err := regionalClusterClient.GetResourceList(crsList, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return nil, errors.Wrap(err, "unable to list the ClusterResourceSets of the cluster")
+	}
+	for i := range crsList.Items {
+		add("ClusterResourceSet", crsList.Items[i].Name, crsList.Items[i].Namespace, DeleteLocationManagementCluster)
+	}
+
+	autoscalerDeployment := &appsv1.Deployment{}
+	autoscalerDeploymentName := options.ClusterName + "-cluster-autoscaler"
+	err := regionalClusterClient.GetResource(autoscalerDeployment, autoscalerDeploymentName, options.Namespace, nil, nil)
+	if err == nil {
+		add("Deployment", autoscalerDeploymentName, options.Namespace, DeleteLocationManagementCluster)
+	} else if !apierrors.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "unable to get the autoscaler deployment '%s'", autoscalerDeploymentName)
+	}
+
+	if workloadClusterClient != nil {
+		packageInstallList := &kappipkg.PackageInstallList{}
+		if err := workloadClusterClient.ListResources(packageInstallList, &crtclient.ListOptions{}); err != nil {
+			log.V(3).Infof("unable to list the package installs of the workload cluster: %v", err)
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("the package installs of the workload cluster are not listed: %v", err))
+		} else {
+			for i := range packageInstallList.Items {
+				add("PackageInstall", packageInstallList.Items[i].Name, packageInstallList.Items[i].Namespace, DeleteLocationWorkloadCluster)
+			}
+		}
+	}
+	return plan, nil
+}
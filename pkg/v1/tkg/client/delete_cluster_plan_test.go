@@ -0,0 +1,148 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	kappipkg "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" // nolint:staticcheck
+
+	. "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/fakes"
+	fakehelper "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/fakes/helper"
+)
+
+var _ = Describe("Cluster deletion", func() {
+	var (
+		err                   error
+		regionalClusterClient clusterclient.Client
+		workloadClusterClient clusterclient.Client
+		regionalClientSet     crtclient.Client
+		regionalObjects       []runtime.Object
+		workloadObjects       []runtime.Object
+		cluster               *capi.Cluster
+	)
+
+	newClusterClient := func(objects []runtime.Object) (clusterclient.Client, crtclient.Client) {
+		crtClientFactory := &fakes.CrtClientFactory{}
+		clientSet := fake.NewFakeClientWithScheme(scheme, objects...)
+		crtClientFactory.NewClientReturns(clientSet, nil)
+		kubeconfig := fakehelper.GetFakeKubeConfigFilePath(testingDir, "../fakes/config/kubeconfig/config1.yaml")
+		clusterClient, err := clusterclient.NewClient(kubeconfig, "", clusterclient.NewOptions(getFakePoller(), crtClientFactory, getFakeDiscoveryFactory(), nil))
+		Expect(err).NotTo(HaveOccurred())
+		return clusterClient, clientSet
+	}
+
+	BeforeEach(func() {
+		cluster = &capi.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "my-namespace"},
+			Spec: capi.ClusterSpec{
+				InfrastructureRef: &corev1.ObjectReference{Kind: "AWSCluster", Name: "my-cluster"},
+				ControlPlaneRef:   &corev1.ObjectReference{Kind: "KubeadmControlPlane", Name: "my-cluster-control-plane", Namespace: "my-namespace"},
+			},
+		}
+		clusterLabels := map[string]string{capi.ClusterLabelName: "my-cluster"}
+		regionalObjects = []runtime.Object{
+			cluster,
+			&capi.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-md-0", Namespace: "my-namespace", Labels: clusterLabels},
+				Spec: capi.MachineDeploymentSpec{
+					Template: capi.MachineTemplateSpec{
+						Spec: capi.MachineSpec{
+							InfrastructureRef: corev1.ObjectReference{Kind: "AWSMachineTemplate", Name: "my-cluster-md-0"},
+							Bootstrap:         capi.Bootstrap{ConfigRef: &corev1.ObjectReference{Kind: "KubeadmConfigTemplate", Name: "my-cluster-md-0"}},
+						},
+					},
+				},
+			},
+			&capi.Machine{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-md-0-abcde", Namespace: "my-namespace", Labels: clusterLabels},
+				Spec: capi.MachineSpec{
+					InfrastructureRef: corev1.ObjectReference{Kind: "AWSMachine", Name: "my-cluster-md-0-fghij"},
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-antrea-addon", Namespace: "my-namespace", Labels: map[string]string{constants.ClusterNameLabel: "my-cluster"}},
+				Type:       constants.AddonSecretType,
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-cluster-antrea-addon", Namespace: "my-namespace", Labels: map[string]string{constants.ClusterNameLabel: "other-cluster"}},
+				Type:       constants.AddonSecretType,
+			},
+		}
+		workloadObjects = []runtime.Object{
+			&kappipkg.PackageInstall{ObjectMeta: metav1.ObjectMeta{Name: "antrea", Namespace: constants.TkgNamespace}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		regionalClusterClient, regionalClientSet = newClusterClient(regionalObjects)
+		workloadClusterClient, _ = newClusterClient(workloadObjects)
+	})
+
+	Describe("DoPlanWorkloadClusterDeletion", func() {
+		It("lists the objects the deletion removes", func() {
+			plan, err := DoPlanWorkloadClusterDeletion(regionalClusterClient, workloadClusterClient, DeleteWorkloadClusterOptions{ClusterName: "my-cluster", Namespace: "my-namespace"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Protected).To(BeFalse())
+			Expect(plan.Objects).To(Equal([]DeleteClusterObject{
+				{Kind: "Cluster", Name: "my-cluster", Namespace: "my-namespace", Location: DeleteLocationManagementCluster},
+				{Kind: "AWSCluster", Name: "my-cluster", Namespace: "my-namespace", Location: DeleteLocationManagementCluster},
+				{Kind: "KubeadmControlPlane", Name: "my-cluster-control-plane", Namespace: "my-namespace", Location: DeleteLocationManagementCluster},
+				{Kind: "MachineDeployment", Name: "my-cluster-md-0", Namespace: "my-namespace", Location: DeleteLocationManagementCluster},
+				{Kind: "AWSMachineTemplate", Name: "my-cluster-md-0", Namespace: "my-namespace", Location: DeleteLocationManagementCluster},
+				{Kind: "KubeadmConfigTemplate", Name: "my-cluster-md-0", Namespace: "my-namespace", Location: DeleteLocationManagementCluster},
+				{Kind: "Machine", Name: "my-cluster-md-0-abcde", Namespace: "my-namespace", Location: DeleteLocationManagementCluster},
+				{Kind: "AWSMachine", Name: "my-cluster-md-0-fghij", Namespace: "my-namespace", Location: DeleteLocationManagementCluster},
+				{Kind: "Secret", Name: "my-cluster-antrea-addon", Namespace: "my-namespace", Location: DeleteLocationManagementCluster},
+				{Kind: "PackageInstall", Name: "antrea", Namespace: constants.TkgNamespace, Location: DeleteLocationWorkloadCluster},
+			}))
+		})
+
+		It("omits the package installs without a workload cluster client", func() {
+			plan, err := DoPlanWorkloadClusterDeletion(regionalClusterClient, nil, DeleteWorkloadClusterOptions{ClusterName: "my-cluster", Namespace: "my-namespace"})
+			Expect(err).NotTo(HaveOccurred())
+			for _, obj := range plan.Objects {
+				Expect(obj.Kind).NotTo(Equal("PackageInstall"))
+			}
+		})
+
+		It("returns an error if the cluster does not exist", func() {
+			_, err = DoPlanWorkloadClusterDeletion(regionalClusterClient, workloadClusterClient, DeleteWorkloadClusterOptions{ClusterName: "unknown", Namespace: "my-namespace"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("DoSetClusterDeletionProtection", func() {
+		getAnnotations := func() map[string]string {
+			updated := &capi.Cluster{}
+			Expect(regionalClientSet.Get(context.Background(), crtclient.ObjectKey{Name: "my-cluster", Namespace: "my-namespace"}, updated)).To(Succeed())
+			return updated.Annotations
+		}
+
+		It("annotates the cluster and reports it as protected", func() {
+			err = DoSetClusterDeletionProtection(regionalClusterClient, SetClusterDeletionProtectionOptions{ClusterName: "my-cluster", Namespace: "my-namespace", Enabled: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getAnnotations()).To(HaveKeyWithValue(constants.DeletionProtectionAnnotation, "true"))
+
+			plan, err := DoPlanWorkloadClusterDeletion(regionalClusterClient, nil, DeleteWorkloadClusterOptions{ClusterName: "my-cluster", Namespace: "my-namespace"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Protected).To(BeTrue())
+
+			err = DoSetClusterDeletionProtection(regionalClusterClient, SetClusterDeletionProtectionOptions{ClusterName: "my-cluster", Namespace: "my-namespace", Enabled: false})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getAnnotations()).NotTo(HaveKey(constants.DeletionProtectionAnnotation))
+		})
+	})
+})
@@ -0,0 +1,93 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+)
+
+// ErrorDeletionProtected is returned when deleting a cluster protected from deletion
+const ErrorDeletionProtected = "cluster '%s' in namespace '%s' is protected from deletion by the '%s' annotation. Disable the deletion protection of the cluster to delete it"
+
+// SetClusterDeletionProtectionOptions contains options supported by SetClusterDeletionProtection
+type SetClusterDeletionProtectionOptions struct {
+	ClusterName string
+	Namespace   string
+	Enabled     bool
+}
+
+// SetClusterDeletionProtection enables or disables the deletion protection of the cluster. A protected
+// cluster cannot be deleted, as a workload cluster or as a management cluster, until the protection is disabled.
+func (c *TkgClient) SetClusterDeletionProtection(options SetClusterDeletionProtectionOptions) error {
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		return errors.Wrap(err, "cannot get current management cluster context")
+	}
+	clusterclientOptions := clusterclient.Options{
+		GetClientInterval: 1 * time.Second,
+		GetClientTimeout:  3 * time.Second,
+	}
+	clusterClient, err := clusterclient.NewClient(currentRegion.SourceFilePath, currentRegion.ContextName, clusterclientOptions)
+	if err != nil {
+		return errors.Wrap(err, "unable to get cluster client while setting the cluster deletion protection")
+	}
+
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+	return DoSetClusterDeletionProtection(clusterClient, options)
+}
+
+// DoSetClusterDeletionProtection annotates the cluster object with the deletion protection
+func DoSetClusterDeletionProtection(clusterClient clusterclient.Client, options SetClusterDeletionProtectionOptions) error {
+	cluster := &capi.Cluster{}
+	if err := clusterClient.GetResource(cluster, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return errors.Wrapf(err, "unable to get cluster '%s' in namespace '%s'", options.ClusterName, options.Namespace)
+	}
+
+	// a null value removes the annotation with a merge patch
+	value := "null"
+	if options.Enabled {
+		value = `"true"`
+	}
+	patchJSONString := fmt.Sprintf(`{"metadata":{"annotations":{%q:%s}}}`, constants.DeletionProtectionAnnotation, value)
+	if err := clusterClient.PatchResource(&capi.Cluster{}, options.ClusterName, options.Namespace, patchJSONString, types.MergePatchType, nil); err != nil {
+		return errors.Wrapf(err, "unable to update the deletion protection of cluster '%s'", options.ClusterName)
+	}
+	return nil
+}
+
+// verifyClusterNotDeletionProtected returns an error if the cluster is protected from deletion
+func verifyClusterNotDeletionProtected(clusterClient clusterclient.Client, clusterName, namespace string) error {
+	cluster := &capi.Cluster{}
+	err := clusterClient.GetResource(cluster, clusterName, namespace, nil, nil)
+	if apierrors.IsNotFound(err) {
+		// leave it to the deletion to report the missing cluster
+		log.V(4).Infof("cluster '%s' not found in namespace '%s' while checking its deletion protection", clusterName, namespace)
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "unable to get cluster '%s' in namespace '%s'", clusterName, namespace)
+	}
+	if isDeletionProtected(cluster) {
+		return errors.Errorf(ErrorDeletionProtected, clusterName, namespace, constants.DeletionProtectionAnnotation)
+	}
+	return nil
+}
+
+func isDeletionProtected(cluster *capi.Cluster) bool {
+	protected, _ := strconv.ParseBool(cluster.Annotations[constants.DeletionProtectionAnnotation])
+	return protected
+}
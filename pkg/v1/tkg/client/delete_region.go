@@ -4,7 +4,6 @@
 package client
 
 import (
-	"fmt"
 	"path/filepath"
 	"strings"
 	"time"
@@ -39,6 +38,10 @@ const (
 You need to delete these clusters first before deleting the management cluster.
 
 Alternatively, you can use the -f/--force flag to force the deletion of the management cluster but doing so will orphan the above-mentioned clusters and leave them unmanaged.`
+	ErrorDeleteAbortProtected = `Deletion is aborted because the following workload clusters managed by the management cluster are protected from deletion by the '%s' annotation:
+
+%s
+Disable the deletion protection of these clusters and delete them before deleting the management cluster.`
 )
 
 // DeleteRegion delete management cluster
@@ -282,7 +285,6 @@ func (c *TkgClient) waitForClusterDeletion(kubeconfig, clusterName, clusterNames
 }
 
 func (c *TkgClient) verifyDeleteRegion(clusterClient clusterclient.Client, options DeleteRegionOptions) (string, error) {
-	var errMsg string
 	var regionalClusterNamespace string
 
 	isPacific, err := clusterClient.IsPacificRegionalCluster()
@@ -300,17 +302,31 @@ func (c *TkgClient) verifyDeleteRegion(clusterClient clusterclient.Client, optio
 		return regionalClusterNamespace, errors.Errorf(ErrorGettingClusterObjects, err.Error())
 	}
 
-	if len(clusters.Items) == 0 {
+	return verifyDeleteRegionClusters(clusters.Items, options)
+}
+
+// verifyDeleteRegionClusters verifies the management cluster can be deleted given the clusters it manages, and
+// returns the namespace of the management cluster. Workload clusters protected from deletion prevent the deletion
+// of the management cluster even when forced, as they would be left unmanaged.
+func verifyDeleteRegionClusters(clusters []capi.Cluster, options DeleteRegionOptions) (string, error) {
+	var regionalClusterNamespace string
+	if len(clusters) == 0 {
 		return regionalClusterNamespace, errors.New(ErrorNoClusterObject)
 	}
 	regionalClusterObjectPresent := false
-	var workloadClusters string
-	for i := range clusters.Items {
-		if clusters.Items[i].Name == options.ClusterName {
+	var workloadClusters, protectedWorkloadClusters string
+	for i := range clusters {
+		if clusters[i].Name == options.ClusterName {
 			regionalClusterObjectPresent = true
-			regionalClusterNamespace = clusters.Items[i].Namespace
+			regionalClusterNamespace = clusters[i].Namespace
+			if isDeletionProtected(&clusters[i]) {
+				return regionalClusterNamespace, errors.Errorf(ErrorDeletionProtected, options.ClusterName, regionalClusterNamespace, constants.DeletionProtectionAnnotation)
+			}
 		} else {
-			workloadClusters += "- " + clusters.Items[i].Name + "\n"
+			workloadClusters += "- " + clusters[i].Name + "\n"
+			if isDeletionProtected(&clusters[i]) {
+				protectedWorkloadClusters += "- " + clusters[i].Namespace + "/" + clusters[i].Name + "\n"
+			}
 		}
 	}
 
@@ -318,12 +334,12 @@ func (c *TkgClient) verifyDeleteRegion(clusterClient clusterclient.Client, optio
 		return regionalClusterNamespace, errors.New(ErrorMissingRegionalClusterObject)
 	}
 
-	if len(workloadClusters) > 0 {
-		errMsg = fmt.Sprintf(ErrorDeleteAbort, workloadClusters)
+	if protectedWorkloadClusters != "" {
+		return regionalClusterNamespace, errors.Errorf(ErrorDeleteAbortProtected, constants.DeletionProtectionAnnotation, protectedWorkloadClusters)
 	}
 
-	if !options.Force && errMsg != "" {
-		return regionalClusterNamespace, errors.New(errMsg)
+	if !options.Force && workloadClusters != "" {
+		return regionalClusterNamespace, errors.Errorf(ErrorDeleteAbort, workloadClusters)
 	}
 
 	return regionalClusterNamespace, nil
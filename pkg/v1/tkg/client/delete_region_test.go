@@ -0,0 +1,62 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+)
+
+var _ = Describe("verifyDeleteRegionClusters", func() {
+	var clusters []capi.Cluster
+
+	newCluster := func(name, namespace string, protected bool) capi.Cluster {
+		cluster := capi.Cluster{}
+		cluster.Name = name
+		cluster.Namespace = namespace
+		if protected {
+			cluster.Annotations = map[string]string{constants.DeletionProtectionAnnotation: "true"}
+		}
+		return cluster
+	}
+
+	BeforeEach(func() {
+		clusters = []capi.Cluster{
+			newCluster("mc", "tkg-system", false),
+			newCluster("wc-1", "default", false),
+			newCluster("wc-2", "prod", true),
+			newCluster("wc-3", "prod", true),
+		}
+	})
+
+	It("refuses to delete a management cluster managing protected workload clusters even when forced", func() {
+		namespace, err := verifyDeleteRegionClusters(clusters, DeleteRegionOptions{ClusterName: "mc", Force: true})
+		Expect(namespace).To(Equal("tkg-system"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("protected from deletion by the '" + constants.DeletionProtectionAnnotation + "' annotation"))
+		Expect(err.Error()).To(ContainSubstring("- prod/wc-2\n- prod/wc-3\n"))
+		Expect(err.Error()).NotTo(ContainSubstring("wc-1"))
+	})
+
+	It("refuses to delete a management cluster managing workload clusters unless forced", func() {
+		clusters = clusters[:2]
+		_, err := verifyDeleteRegionClusters(clusters, DeleteRegionOptions{ClusterName: "mc"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Deletion is aborted because management cluster is currently managing the following workload clusters"))
+
+		namespace, err := verifyDeleteRegionClusters(clusters, DeleteRegionOptions{ClusterName: "mc", Force: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(namespace).To(Equal("tkg-system"))
+	})
+
+	It("refuses to delete a protected management cluster", func() {
+		clusters[0] = newCluster("mc", "tkg-system", true)
+		_, err := verifyDeleteRegionClusters(clusters[:1], DeleteRegionOptions{ClusterName: "mc", Force: true})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("cluster 'mc' in namespace 'tkg-system' is protected from deletion"))
+	})
+})
@@ -13,6 +13,7 @@ import (
 
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/region"
 )
 
 // ListTKGClustersOptions contains options supported by ListClusters
@@ -32,6 +33,8 @@ type ClusterInfo struct {
 	K8sVersion        string            `json:"kubernetes" yaml:"kubernetes"`
 	Roles             []string          `json:"roles" yaml:"roles"`
 	Labels            map[string]string `json:"labels" yaml:"labels"`
+	// ManagementCluster is only set when listing the clusters of all the management clusters
+	ManagementCluster string `json:"managementCluster,omitempty" yaml:"managementCluster,omitempty"`
 }
 
 // ListTKGClusters lists tkg cluster information
@@ -40,6 +43,11 @@ func (c *TkgClient) ListTKGClusters(options ListTKGClustersOptions) ([]ClusterIn
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot get current management cluster context")
 	}
+	return c.listTKGClustersInRegion(currentRegion, options)
+}
+
+// listTKGClustersInRegion lists tkg cluster information of the management cluster of the region context
+func (c *TkgClient) listTKGClustersInRegion(currentRegion region.RegionContext, options ListTKGClustersOptions) ([]ClusterInfo, error) {
 	clusterclientOptions := clusterclient.Options{
 		GetClientInterval: 1 * time.Second,
 		GetClientTimeout:  3 * time.Second,
@@ -0,0 +1,103 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/region"
+)
+
+// DefaultListClustersRegionTimeout is the time the listing waits for the clusters of each management cluster
+const DefaultListClustersRegionTimeout = 30 * time.Second
+
+// ListTKGClustersInAllRegionsOptions contains options supported by ListTKGClustersInAllRegions
+type ListTKGClustersInAllRegionsOptions struct {
+	ListTKGClustersOptions
+	// Regions are the management clusters to list the clusters of, all the region contexts of the
+	// region manager are listed if empty
+	Regions []region.RegionContext
+	// Timeout is the time to wait for the clusters of each management cluster
+	Timeout time.Duration
+}
+
+// UnreachableRegion is a management cluster whose clusters could not be listed
+type UnreachableRegion struct {
+	ManagementCluster string `json:"managementCluster" yaml:"managementCluster"`
+	Error             string `json:"error" yaml:"error"`
+}
+
+// ListTKGClustersInAllRegions lists the tkg clusters of all the management clusters in parallel. The management
+// clusters which cannot be listed before the timeout are returned as unreachable rather than failing the listing.
+func (c *TkgClient) ListTKGClustersInAllRegions(options ListTKGClustersInAllRegionsOptions) ([]ClusterInfo, []UnreachableRegion, error) {
+	regions := options.Regions
+	if len(regions) == 0 {
+		var err error
+		if regions, err = c.regionManager.ListRegionContexts(); err != nil {
+			return nil, nil, errors.Wrap(err, "unable to get the management cluster contexts")
+		}
+	}
+	if options.Timeout == 0 {
+		options.Timeout = DefaultListClustersRegionTimeout
+	}
+
+	clusters, unreachable := listClustersInRegions(regions, options.Timeout, func(regionContext region.RegionContext) ([]ClusterInfo, error) {
+		return c.listTKGClustersInRegion(regionContext, options.ListTKGClustersOptions)
+	})
+	return clusters, unreachable, nil
+}
+
+// listClustersInRegions calls listFunc for every region in parallel, waiting at most timeout for each one, and
+// returns the clusters of the regions which could be listed tagged with their management cluster
+func listClustersInRegions(regions []region.RegionContext, timeout time.Duration,
+	listFunc func(region.RegionContext) ([]ClusterInfo, error)) ([]ClusterInfo, []UnreachableRegion) {
+	type regionResult struct {
+		clusters []ClusterInfo
+		err      error
+	}
+	results := make([]regionResult, len(regions))
+
+	var wg sync.WaitGroup
+	for i := range regions {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			regionContext := regions[i]
+			if regionContext.Status == region.Failed {
+				results[i].err = errors.Errorf("deployment failed for management cluster %s", regionContext.ClusterName)
+				return
+			}
+
+			// the listing goroutine is abandoned on timeout, its result channel is buffered so that it can still complete
+			done := make(chan regionResult, 1)
+			go func() {
+				clusters, err := listFunc(regionContext)
+				done <- regionResult{clusters: clusters, err: err}
+			}()
+			select {
+			case results[i] = <-done:
+			case <-time.After(timeout):
+				results[i].err = errors.Errorf("timed out after %s listing the clusters", timeout)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	clusters := []ClusterInfo{}
+	unreachable := []UnreachableRegion{}
+	for i := range regions {
+		if results[i].err != nil {
+			unreachable = append(unreachable, UnreachableRegion{ManagementCluster: regions[i].ClusterName, Error: results[i].err.Error()})
+			continue
+		}
+		for j := range results[i].clusters {
+			results[i].clusters[j].ManagementCluster = regions[i].ClusterName
+			clusters = append(clusters, results[i].clusters[j])
+		}
+	}
+	return clusters, unreachable
+}
@@ -0,0 +1,60 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/region"
+)
+
+var _ = Describe("listClustersInRegions", func() {
+	var (
+		regions     []region.RegionContext
+		clusters    []ClusterInfo
+		unreachable []UnreachableRegion
+	)
+
+	BeforeEach(func() {
+		regions = []region.RegionContext{
+			{ClusterName: "mc-1", ContextName: "mc-1-admin@mc-1"},
+			{ClusterName: "mc-2", ContextName: "mc-2-admin@mc-2"},
+			{ClusterName: "mc-3", ContextName: "mc-3-admin@mc-3"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		clusters, unreachable = listClustersInRegions(regions, 100*time.Millisecond, func(regionContext region.RegionContext) ([]ClusterInfo, error) {
+			switch regionContext.ClusterName {
+			case "mc-2":
+				return nil, errors.New("connection refused")
+			case "mc-3":
+				time.Sleep(time.Second)
+			}
+			return []ClusterInfo{{Name: "wc-" + regionContext.ClusterName, Namespace: "default"}}, nil
+		})
+	})
+
+	It("tags the clusters with their management cluster and reports the failed and timed out management clusters", func() {
+		Expect(clusters).To(Equal([]ClusterInfo{{Name: "wc-mc-1", Namespace: "default", ManagementCluster: "mc-1"}}))
+		Expect(unreachable).To(Equal([]UnreachableRegion{
+			{ManagementCluster: "mc-2", Error: "connection refused"},
+			{ManagementCluster: "mc-3", Error: "timed out after 100ms listing the clusters"},
+		}))
+	})
+
+	Context("when the deployment of a management cluster failed", func() {
+		BeforeEach(func() {
+			regions = []region.RegionContext{{ClusterName: "mc-1", Status: region.Failed}}
+		})
+		It("reports it without listing it", func() {
+			Expect(clusters).To(BeEmpty())
+			Expect(unreachable).To(Equal([]UnreachableRegion{{ManagementCluster: "mc-1", Error: "deployment failed for management cluster mc-1"}}))
+		})
+	})
+})
@@ -16,6 +16,7 @@ import (
 	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
 )
 
@@ -259,6 +260,10 @@ func getClusterStatus(clusterInfo *clusterObjects) TKGClusterPhase {
 		return TKGClusterPhaseDeleting
 	}
 
+	if _, hibernated := clusterInfo.cluster.Annotations[constants.HibernationAnnotation]; hibernated {
+		return TKGClusterPhaseHibernated
+	}
+
 	readyReplicas, specReplicas, replicas, updatedReplicas := getClusterReplicas(clusterInfo.mds)
 
 	creationCompleteCondition := clusterInfo.cluster.Status.InfrastructureReady &&
@@ -0,0 +1,265 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+)
+
+// HibernateClusterOptions contains options supported by HibernateCluster
+type HibernateClusterOptions struct {
+	ClusterName string
+	Namespace   string
+}
+
+// ResumeClusterOptions contains options supported by ResumeCluster
+type ResumeClusterOptions struct {
+	ClusterName string
+	Namespace   string
+}
+
+// hibernationState is recorded on the Cluster object of a hibernated cluster to restore its workers on resume
+type hibernationState struct {
+	// MachineDeployments are the replicas of the machine deployments of the cluster, by name
+	MachineDeployments map[string]int32 `json:"machineDeployments"`
+	// AutoscalerReplicas are the replicas of the cluster autoscaler deployment, nil if the autoscaler is not enabled
+	AutoscalerReplicas *int32 `json:"autoscalerReplicas,omitempty"`
+}
+
+// HibernateCluster scales the workers of the cluster to zero and pauses its cluster autoscaler,
+// recording their replicas on the Cluster object for ResumeCluster to restore them
+func (c *TkgClient) HibernateCluster(options HibernateClusterOptions) error {
+	clusterClient, err := c.getHibernationClusterClient(options.ClusterName, 0)
+	if err != nil {
+		return err
+	}
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+
+	recorder := recordOperationStarted(clusterClient, options.ClusterName, options.Namespace, clusterclient.OperationTypeHibernate)
+	err = DoHibernateCluster(clusterClient, options)
+	recorder.finished(err)
+	return err
+}
+
+// ResumeCluster restores the workers and the cluster autoscaler of a hibernated cluster and waits for its nodes to be ready
+func (c *TkgClient) ResumeCluster(options ResumeClusterOptions) error {
+	clusterClient, err := c.getHibernationClusterClient(options.ClusterName, c.timeout)
+	if err != nil {
+		return err
+	}
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+
+	recorder := recordOperationStarted(clusterClient, options.ClusterName, options.Namespace, clusterclient.OperationTypeResume)
+	err = DoResumeCluster(clusterClient, options)
+	recorder.finished(err)
+	return err
+}
+
+func (c *TkgClient) getHibernationClusterClient(clusterName string, operationTimeout time.Duration) (clusterclient.Client, error) {
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get current management cluster context")
+	}
+	if currentRegion.ClusterName == clusterName {
+		return nil, errors.New("hibernation of the management cluster is not supported")
+	}
+	clusterclientOptions := clusterclient.Options{
+		GetClientInterval: 1 * time.Second,
+		GetClientTimeout:  3 * time.Second,
+		OperationTimeout:  operationTimeout,
+	}
+	clusterClient, err := clusterclient.NewClient(currentRegion.SourceFilePath, currentRegion.ContextName, clusterclientOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get cluster client while hibernating or resuming cluster")
+	}
+
+	isPacific, err := clusterClient.IsPacificRegionalCluster()
+	if err != nil {
+		return nil, errors.Wrap(err, "error determining 'Tanzu Kubernetes Cluster service for vSphere' management cluster")
+	}
+	if isPacific {
+		return nil, errors.New("hibernation of 'Tanzu Kubernetes Cluster service for vSphere' clusters is not yet supported")
+	}
+	return clusterClient, nil
+}
+
+// DoHibernateCluster records the replicas of the machine deployments and of the cluster autoscaler of the cluster
+// on its Cluster object, then scales them to zero. The replicas are recorded first so that a cluster whose
+// hibernation fails midway can still be resumed.
+func DoHibernateCluster(clusterClient clusterclient.Client, options HibernateClusterOptions) error {
+	cluster := &capi.Cluster{}
+	if err := clusterClient.GetResource(cluster, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return errors.Wrapf(err, "unable to get cluster '%s' in namespace '%s'", options.ClusterName, options.Namespace)
+	}
+	if _, hibernated := cluster.Annotations[constants.HibernationAnnotation]; hibernated {
+		return errors.Errorf("cluster %s is already hibernated", options.ClusterName)
+	}
+
+	machineDeployments, err := clusterClient.GetMDObjectForCluster(options.ClusterName, options.Namespace)
+	if err != nil {
+		return errors.Wrapf(err, "unable to find worker node machine deployment object for cluster %s", options.ClusterName)
+	}
+	state := &hibernationState{MachineDeployments: map[string]int32{}}
+	for i := range machineDeployments {
+		state.MachineDeployments[machineDeployments[i].Name] = replicasOrDefault(machineDeployments[i].Spec.Replicas)
+	}
+
+	autoscalerDeployment := &appsv1.Deployment{}
+	autoscalerDeploymentName := options.ClusterName + "-cluster-autoscaler"
+	err = clusterClient.GetResource(autoscalerDeployment, autoscalerDeploymentName, options.Namespace, nil, nil)
+	if err == nil {
+		autoscalerReplicas := replicasOrDefault(autoscalerDeployment.Spec.Replicas)
+		state.AutoscalerReplicas = &autoscalerReplicas
+	} else if !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "unable to get autoscaler deployment '%s' from management cluster", autoscalerDeploymentName)
+	}
+
+	if err := setHibernationState(clusterClient, options.ClusterName, options.Namespace, state); err != nil {
+		return err
+	}
+
+	// the autoscaler is paused first so that it does not scale the workers back up
+	if state.AutoscalerReplicas != nil {
+		if err := clusterClient.UpdateReplicas(autoscalerDeployment, autoscalerDeploymentName, options.Namespace, 0); err != nil {
+			return errors.Wrapf(err, "unable to pause the cluster autoscaler of cluster %s", options.ClusterName)
+		}
+		log.Infof("Paused the cluster autoscaler of cluster %s", options.ClusterName)
+	}
+
+	errList := []error{}
+	for i := range machineDeployments {
+		if err := clusterClient.UpdateReplicas(&machineDeployments[i], machineDeployments[i].Name, machineDeployments[i].Namespace, 0); err != nil {
+			errList = append(errList, errors.Wrapf(err, "unable to scale machine deployment %s to zero", machineDeployments[i].Name))
+		}
+	}
+	if len(errList) != 0 {
+		return kerrors.NewAggregate(errList)
+	}
+	log.Infof("Successfully scaled the workers of cluster %s to zero", options.ClusterName)
+	return nil
+}
+
+// DoResumeCluster restores the replicas recorded on the Cluster object of a hibernated cluster
+// and waits for its nodes to be ready
+func DoResumeCluster(clusterClient clusterclient.Client, options ResumeClusterOptions) error {
+	cluster := &capi.Cluster{}
+	if err := clusterClient.GetResource(cluster, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return errors.Wrapf(err, "unable to get cluster '%s' in namespace '%s'", options.ClusterName, options.Namespace)
+	}
+	value, hibernated := cluster.Annotations[constants.HibernationAnnotation]
+	if !hibernated {
+		return errors.Errorf("cluster %s is not hibernated", options.ClusterName)
+	}
+	state := &hibernationState{}
+	if err := json.Unmarshal([]byte(value), state); err != nil {
+		return errors.Wrapf(err, "unable to parse the annotation %s of cluster %s", constants.HibernationAnnotation, options.ClusterName)
+	}
+
+	machineDeployments, err := clusterClient.GetMDObjectForCluster(options.ClusterName, options.Namespace)
+	if err != nil {
+		return errors.Wrapf(err, "unable to find worker node machine deployment object for cluster %s", options.ClusterName)
+	}
+	errList := []error{}
+	for i := range machineDeployments {
+		replicas, recorded := state.MachineDeployments[machineDeployments[i].Name]
+		if !recorded {
+			log.Warningf("Machine deployment %s was created while cluster %s was hibernated, its replicas are left unchanged", machineDeployments[i].Name, options.ClusterName)
+			continue
+		}
+		if err := clusterClient.UpdateReplicas(&machineDeployments[i], machineDeployments[i].Name, machineDeployments[i].Namespace, replicas); err != nil {
+			errList = append(errList, errors.Wrapf(err, "unable to restore the replicas of machine deployment %s", machineDeployments[i].Name))
+		}
+	}
+	if len(errList) != 0 {
+		return kerrors.NewAggregate(errList)
+	}
+
+	if state.AutoscalerReplicas != nil {
+		autoscalerDeploymentName := options.ClusterName + "-cluster-autoscaler"
+		err := clusterClient.UpdateReplicas(&appsv1.Deployment{}, autoscalerDeploymentName, options.Namespace, *state.AutoscalerReplicas)
+		if err != nil && !apierrors.IsNotFound(errors.Cause(err)) {
+			return errors.Wrapf(err, "unable to resume the cluster autoscaler of cluster %s", options.ClusterName)
+		}
+		if err != nil {
+			log.Warningf("autoscaler deployment '%s' is not present on the management cluster anymore", autoscalerDeploymentName)
+		}
+	}
+
+	if err := setHibernationState(clusterClient, options.ClusterName, options.Namespace, nil); err != nil {
+		return err
+	}
+
+	log.Infof("Waiting for the nodes of cluster %s to be ready...", options.ClusterName)
+	if err := clusterClient.WaitForClusterReady(options.ClusterName, options.Namespace, true); err != nil {
+		return errors.Wrap(err, "error waiting for the nodes of the cluster to be ready")
+	}
+	log.Infof("Successfully resumed cluster %s", options.ClusterName)
+	return nil
+}
+
+// verifyClusterNotHibernated returns an error if the cluster is hibernated, its workers must be restored by resuming it
+func verifyClusterNotHibernated(clusterClient clusterclient.Client, clusterName, namespace string) error {
+	cluster := &capi.Cluster{}
+	err := clusterClient.GetResource(cluster, clusterName, namespace, nil, nil)
+	if apierrors.IsNotFound(err) {
+		// leave it to the caller to report the missing cluster
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "unable to get cluster '%s' in namespace '%s'", clusterName, namespace)
+	}
+	if _, hibernated := cluster.Annotations[constants.HibernationAnnotation]; hibernated {
+		return errors.Errorf("cluster %s is hibernated, resume it before scaling its workers", clusterName)
+	}
+	return nil
+}
+
+// setHibernationState records the hibernation state on the Cluster object, a nil state removes it
+func setHibernationState(clusterClient clusterclient.Client, clusterName, namespace string, state *hibernationState) error {
+	var value interface{}
+	if state != nil {
+		stateBytes, err := json.Marshal(state)
+		if err != nil {
+			return errors.Wrap(err, "unable to marshal the hibernation state")
+		}
+		value = string(stateBytes)
+	}
+	// a null value removes the annotation with a merge patch
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{constants.HibernationAnnotation: value},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal the cluster patch")
+	}
+	if err := clusterClient.PatchResource(&capi.Cluster{}, clusterName, namespace, string(patch), types.MergePatchType, nil); err != nil {
+		return errors.Wrapf(err, "unable to update the hibernation state of cluster '%s'", clusterName)
+	}
+	return nil
+}
+
+// replicasOrDefault returns the replicas, or the default of one replica if they are not set
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
@@ -0,0 +1,132 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" // nolint:staticcheck
+
+	. "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/fakes"
+	fakehelper "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/fakes/helper"
+)
+
+var _ = Describe("Cluster hibernation", func() {
+	var (
+		clusterClient      clusterclient.Client
+		clientSet          crtclient.Client
+		clusterAnnotations map[string]string
+		workerReplicas     int32
+	)
+
+	int32Ptr := func(i int32) *int32 { return &i }
+
+	getCluster := func() *capi.Cluster {
+		cluster := &capi.Cluster{}
+		Expect(clientSet.Get(context.Background(), crtclient.ObjectKey{Name: "my-cluster", Namespace: "my-namespace"}, cluster)).To(Succeed())
+		return cluster
+	}
+	getObject := func(obj runtime.Object, name string) {
+		Expect(clientSet.Get(context.Background(), crtclient.ObjectKey{Name: name, Namespace: "my-namespace"}, obj)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		clusterAnnotations = map[string]string{}
+		workerReplicas = 3
+	})
+
+	JustBeforeEach(func() {
+		objects := []runtime.Object{
+			&capi.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "my-namespace", Annotations: clusterAnnotations},
+				Status: capi.ClusterStatus{Conditions: capi.Conditions{
+					{Type: capi.ControlPlaneReadyCondition, Status: corev1.ConditionTrue},
+					{Type: capi.InfrastructureReadyCondition, Status: corev1.ConditionTrue},
+				}},
+			},
+			&capi.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-md-0", Namespace: "my-namespace", Labels: map[string]string{capi.ClusterLabelName: "my-cluster"}},
+				Spec:       capi.MachineDeploymentSpec{ClusterName: "my-cluster", Replicas: int32Ptr(workerReplicas)},
+				Status:     capi.MachineDeploymentStatus{ReadyReplicas: 3},
+			},
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-cluster-autoscaler", Namespace: "my-namespace"},
+				Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(workerReplicas / 3)},
+			},
+		}
+		crtClientFactory := &fakes.CrtClientFactory{}
+		clientSet = fake.NewFakeClientWithScheme(scheme, objects...)
+		crtClientFactory.NewClientReturns(clientSet, nil)
+		kubeconfig := fakehelper.GetFakeKubeConfigFilePath(testingDir, "../fakes/config/kubeconfig/config1.yaml")
+		var err error
+		clusterClient, err = clusterclient.NewClient(kubeconfig, "", clusterclient.NewOptions(getFakePoller(), crtClientFactory, getFakeDiscoveryFactory(), nil))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("DoHibernateCluster", func() {
+		It("records the replicas and scales the workers and the autoscaler to zero", func() {
+			err := DoHibernateCluster(clusterClient, HibernateClusterOptions{ClusterName: "my-cluster", Namespace: "my-namespace"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(getCluster().Annotations).To(HaveKeyWithValue(constants.HibernationAnnotation, `{"machineDeployments":{"my-cluster-md-0":3},"autoscalerReplicas":1}`))
+			md := &capi.MachineDeployment{}
+			getObject(md, "my-cluster-md-0")
+			Expect(*md.Spec.Replicas).To(Equal(int32(0)))
+			autoscaler := &appsv1.Deployment{}
+			getObject(autoscaler, "my-cluster-cluster-autoscaler")
+			Expect(*autoscaler.Spec.Replicas).To(Equal(int32(0)))
+		})
+
+		Context("when the cluster is already hibernated", func() {
+			BeforeEach(func() {
+				clusterAnnotations[constants.HibernationAnnotation] = `{"machineDeployments":{"my-cluster-md-0":3}}`
+			})
+			It("returns an error", func() {
+				err := DoHibernateCluster(clusterClient, HibernateClusterOptions{ClusterName: "my-cluster", Namespace: "my-namespace"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("cluster my-cluster is already hibernated"))
+			})
+		})
+	})
+
+	Describe("DoResumeCluster", func() {
+		Context("when the cluster is hibernated", func() {
+			BeforeEach(func() {
+				workerReplicas = 0
+				clusterAnnotations[constants.HibernationAnnotation] = `{"machineDeployments":{"my-cluster-md-0":3},"autoscalerReplicas":1}`
+			})
+			It("restores the replicas and removes the hibernation state", func() {
+				err := DoResumeCluster(clusterClient, ResumeClusterOptions{ClusterName: "my-cluster", Namespace: "my-namespace"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(getCluster().Annotations).NotTo(HaveKey(constants.HibernationAnnotation))
+				md := &capi.MachineDeployment{}
+				getObject(md, "my-cluster-md-0")
+				Expect(*md.Spec.Replicas).To(Equal(int32(3)))
+				autoscaler := &appsv1.Deployment{}
+				getObject(autoscaler, "my-cluster-cluster-autoscaler")
+				Expect(*autoscaler.Spec.Replicas).To(Equal(int32(1)))
+			})
+		})
+
+		Context("when the cluster is not hibernated", func() {
+			It("returns an error", func() {
+				err := DoResumeCluster(clusterClient, ResumeClusterOptions{ClusterName: "my-cluster", Namespace: "my-namespace"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("cluster my-cluster is not hibernated"))
+			})
+		})
+	})
+})
@@ -0,0 +1,687 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	regname "github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/pkg/errors"
+	yamlv3 "gopkg.in/yaml.v3"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgconfigbom"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgconfigpaths"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/utils"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkr/pkg/registry"
+)
+
+const (
+	imageBundleMetadataFile      = "metadata.yaml"
+	imageBundleBOMDir            = "bom"
+	imageBundleCompatibilityDir  = "compatibility"
+	imageBundleProvidersDir      = "providers"
+	imageBundleImagesDir         = "images"
+	imageBundleFilePermissions   = 0o600
+	imageBundleImageRepoKey      = "imageRepository"
+	imageBundleKindImageRepoLine = "imageRepository:"
+)
+
+// ExportImageBundleOptions contains options supported by ExportImageBundle
+type ExportImageBundleOptions struct {
+	// TKRVersion is the version of the TKr whose images are exported, the default TKr if empty
+	TKRVersion string
+	// File is the path of the tar archive to write the image bundle to
+	File string
+}
+
+// ImportImageBundleOptions contains options supported by ImportImageBundle
+type ImportImageBundleOptions struct {
+	// File is the path of the tar archive to import the image bundle from
+	File string
+	// Repository is the image repository of the private registry to push the images to, e.g. registry.example.com/tkg
+	Repository string
+}
+
+// ImageBundleMetadata describes an image bundle
+type ImageBundleMetadata struct {
+	TKGVersion string             `json:"tkgVersion"`
+	TKRVersion string             `json:"tkrVersion"`
+	CreatedAt  time.Time          `json:"createdAt"`
+	Images     []ImageBundleImage `json:"images"`
+}
+
+// ImageBundleImage describes an image of an image bundle
+type ImageBundleImage struct {
+	// Repository is the image repository the image was exported from, e.g. projects.registry.vmware.com/tkg
+	Repository string `json:"repository"`
+	// ImagePath is the path of the image in the image repository, e.g. etcd
+	ImagePath string `json:"imagePath"`
+	Tag       string `json:"tag"`
+}
+
+// ExportImageBundle writes the images of the TKG BOM and of the BOM of the given TKr and the TKG compatibility
+// image, along with the BOM, compatibility and provider files, to a tar archive to be imported in an
+// internet-restricted environment
+func (c *TkgClient) ExportImageBundle(options ExportImageBundleOptions) error {
+	tkrVersion := options.TKRVersion
+	if tkrVersion == "" {
+		var err error
+		if tkrVersion, err = c.tkgBomClient.GetDefaultTKRVersion(); err != nil {
+			return errors.Wrap(err, "unable to get the default TKr version")
+		}
+	}
+
+	files, err := c.getImageBundleConfigFiles(tkrVersion)
+	if err != nil {
+		return err
+	}
+	tkgBOM, tkrBOM, err := parseImageBundleBOMs(files, tkrVersion)
+	if err != nil {
+		return err
+	}
+	compatibilityRepository, compatibilityImagePath := c.getCompatibilityImageRepositoryAndPath()
+	compatibilityImage, err := getImageBundleCompatibilityImage(files, compatibilityRepository, compatibilityImagePath)
+	if err != nil {
+		return err
+	}
+	metadata := &ImageBundleMetadata{
+		TKGVersion: tkgBOM.Release.Version,
+		TKRVersion: tkrVersion,
+		CreatedAt:  time.Now().UTC(),
+		Images:     getImageBundleImages(tkgBOM, tkrBOM, tkrVersion, compatibilityImage),
+	}
+
+	bomRegistry, err := c.tkgBomClient.InitBOMRegistry()
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize the BOM registry")
+	}
+	log.Infof("Exporting %d images of TKG %s and TKr %s...", len(metadata.Images), metadata.TKGVersion, tkrVersion)
+	if err := WriteImageBundle(options.File, bomRegistry, metadata, files); err != nil {
+		return err
+	}
+	log.Infof("Successfully exported the images of TKr %s to %s", tkrVersion, options.File)
+	return nil
+}
+
+// ImportImageBundle pushes the images of an image bundle to the given repository of a private registry, and writes
+// the BOM files, with their image references rewritten to the repository, and the compatibility and provider files
+// to the TKG config directory
+func (c *TkgClient) ImportImageBundle(options ImportImageBundleOptions) error {
+	if options.Repository == "" {
+		return errors.New("the image repository to push the images to is required")
+	}
+	repository := strings.TrimSuffix(options.Repository, "/")
+	if _, err := regname.NewRepository(repository, regname.WeakValidation); err != nil {
+		return errors.Wrapf(err, "invalid image repository '%s'", repository)
+	}
+
+	bomRegistry, err := c.tkgBomClient.InitBOMRegistryWithCredentials()
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize the registry")
+	}
+	metadata, files, err := ReadImageBundle(options.File, bomRegistry, repository)
+	if err != nil {
+		return err
+	}
+
+	for name, content := range files {
+		if !strings.HasPrefix(name, imageBundleBOMDir+"/") {
+			continue
+		}
+		if files[name], err = rewriteBOMImageRepository(content, repository); err != nil {
+			return errors.Wrapf(err, "unable to rewrite the image references of %s", name)
+		}
+	}
+	if err := c.writeImageBundleConfigFiles(files); err != nil {
+		return err
+	}
+	log.Infof("Successfully imported the %d images of TKr %s to %s", len(metadata.Images), metadata.TKRVersion, repository)
+	log.Infof("Set %s to %s for the management and workload clusters to pull the images from it", constants.ConfigVariableCustomImageRepository, repository)
+	return nil
+}
+
+// getImageBundleConfigFiles returns the TKG and TKr BOM files, the compatibility file and the provider files keyed by
+// their path in the image bundle
+func (c *TkgClient) getImageBundleConfigFiles(tkrVersion string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+
+	tkgBOMFile, err := c.tkgBomClient.GetDefaultBoMFilePath()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get the default BOM file")
+	}
+	tkrBOMFile, err := c.getTKRBOMFile(tkrVersion)
+	if err != nil {
+		return nil, err
+	}
+	compatibilityFile, err := c.tkgConfigPathsClient.GetTKGCompatibilityConfigPath()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get the TKG compatibility file")
+	}
+	for name, file := range map[string]string{
+		imageBundleBOMDir + "/" + filepath.Base(tkgBOMFile):                  tkgBOMFile,
+		imageBundleBOMDir + "/" + filepath.Base(tkrBOMFile):                  tkrBOMFile,
+		imageBundleCompatibilityDir + "/" + filepath.Base(compatibilityFile): compatibilityFile,
+	} {
+		if files[name], err = os.ReadFile(file); err != nil {
+			return nil, errors.Wrapf(err, "unable to read %s", file)
+		}
+	}
+
+	providersDir, err := c.tkgConfigPathsClient.GetTKGProvidersDirectory()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get the providers directory")
+	}
+	err = filepath.Walk(providersDir, func(file string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		relPath, err := filepath.Rel(providersDir, file)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		files[imageBundleProvidersDir+"/"+filepath.ToSlash(relPath)] = content
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read the providers directory %s", providersDir)
+	}
+	return files, nil
+}
+
+// getTKRBOMFile returns the path of the BOM file of the given TKr in the BOM directory
+func (c *TkgClient) getTKRBOMFile(tkrVersion string) (string, error) {
+	bomDir, err := c.tkgConfigPathsClient.GetTKGBoMDirectory()
+	if err != nil {
+		return "", errors.Wrap(err, "unable to get the BOM directory")
+	}
+	entries, err := os.ReadDir(bomDir)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to read the BOM directory %s", bomDir)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".yaml" {
+			continue
+		}
+		file := filepath.Join(bomDir, entry.Name())
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", errors.Wrapf(err, "unable to read %s", file)
+		}
+		bom := &tkgconfigbom.BOMConfiguration{}
+		if err := yamlv3.Unmarshal(content, bom); err != nil || bom.Default != nil || bom.Release == nil {
+			continue
+		}
+		if bom.Release.Version == tkrVersion {
+			return file, nil
+		}
+	}
+	return "", tkgconfigbom.NewBomNotPresent(fmt.Sprintf("No BOM file found with TKr version %s", tkrVersion))
+}
+
+// writeImageBundleConfigFiles writes the BOM, compatibility and provider files of an image bundle to the TKG config directory
+func (c *TkgClient) writeImageBundleConfigFiles(files map[string][]byte) error {
+	dirs := map[string]func() (string, error){
+		imageBundleBOMDir:           c.tkgConfigPathsClient.GetTKGBoMDirectory,
+		imageBundleCompatibilityDir: c.tkgConfigPathsClient.GetTKGCompatibilityDirectory,
+		imageBundleProvidersDir:     c.tkgConfigPathsClient.GetTKGProvidersDirectory,
+	}
+	// the paths of all the files are checked before any of them is written
+	paths := map[string]string{}
+	for name := range files {
+		bundleDir := strings.SplitN(name, "/", 2)[0]
+		getDir, found := dirs[bundleDir]
+		if !found {
+			continue
+		}
+		dir, err := getDir()
+		if err != nil {
+			return errors.Wrapf(err, "unable to get the %s directory", bundleDir)
+		}
+		if paths[name], err = getImageBundleConfigFilePath(dir, bundleDir, name); err != nil {
+			return err
+		}
+	}
+
+	lock, err := utils.GetFileLockWithTimeOut(filepath.Join(c.tkgConfigDir, constants.LocalTanzuFileLock), utils.DefaultLockTimeout)
+	if err != nil {
+		return errors.Wrap(err, "cannot acquire lock for writing the image bundle files")
+	}
+	defer func() {
+		if err := lock.Unlock(); err != nil {
+			log.Warningf("cannot release lock for writing the image bundle files, reason: %v", err)
+		}
+	}()
+
+	for name, file := range paths {
+		if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+			return errors.Wrapf(err, "unable to create the directory of %s", file)
+		}
+		if err := os.WriteFile(file, files[name], imageBundleFilePermissions); err != nil {
+			return errors.Wrapf(err, "unable to write %s", file)
+		}
+	}
+	return nil
+}
+
+// getImageBundleConfigFilePath returns the path in the directory of the file of an image bundle, refusing the file
+// names which are absolute or would be written outside of the directory
+func getImageBundleConfigFilePath(dir, bundleDir, name string) (string, error) {
+	relPath := filepath.FromSlash(strings.TrimPrefix(name, bundleDir+"/"))
+	if path.IsAbs(name) || filepath.IsAbs(relPath) || containsDotDot(name) {
+		return "", errors.Errorf("invalid file name %s in the image bundle", name)
+	}
+	file := filepath.Join(dir, relPath)
+	if rel, err := filepath.Rel(dir, file); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("invalid file name %s in the image bundle", name)
+	}
+	return file, nil
+}
+
+// containsDotDot returns true when one of the elements of the slash separated name is ".."
+func containsDotDot(name string) bool {
+	for _, element := range strings.FieldsFunc(name, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if element == ".." {
+			return true
+		}
+	}
+	return false
+}
+
+// parseImageBundleBOMs parses the TKG BOM and the BOM of the given TKr among the files of an image bundle
+func parseImageBundleBOMs(files map[string][]byte, tkrVersion string) (*tkgconfigbom.BOMConfiguration, *tkgconfigbom.BOMConfiguration, error) {
+	var tkgBOM, tkrBOM *tkgconfigbom.BOMConfiguration
+	for name, content := range files {
+		if !strings.HasPrefix(name, imageBundleBOMDir+"/") {
+			continue
+		}
+		bom := &tkgconfigbom.BOMConfiguration{}
+		if err := yamlv3.Unmarshal(content, bom); err != nil {
+			return nil, nil, errors.Wrapf(err, "unable to parse the BOM file %s", name)
+		}
+		if bom.ImageConfig == nil || bom.Release == nil {
+			return nil, nil, errors.Errorf("the BOM file %s has no release or image config", name)
+		}
+		if bom.Default != nil {
+			tkgBOM = bom
+		} else if bom.Release.Version == tkrVersion {
+			tkrBOM = bom
+		}
+	}
+	if tkgBOM == nil || tkrBOM == nil {
+		return nil, nil, errors.Errorf("the TKG BOM file or the BOM file of TKr %s is missing", tkrVersion)
+	}
+	return tkgBOM, tkrBOM, nil
+}
+
+// getCompatibilityImageRepositoryAndPath returns the image repository and the image path the TKG compatibility
+// file is downloaded from
+func (c *TkgClient) getCompatibilityImageRepositoryAndPath() (string, string) {
+	repository := tkgconfigpaths.TKGDefaultImageRepo
+	if customRepository, err := c.TKGConfigReaderWriter().Get(constants.ConfigVariableCustomImageRepository); err == nil && customRepository != "" {
+		repository = customRepository
+	}
+	imagePath := tkgconfigpaths.TKGDefaultCompatibilityImagePath
+	if customImagePath, err := c.TKGConfigReaderWriter().Get(constants.ConfigVariableCompatibilityCustomImagePath); err == nil && customImagePath != "" {
+		imagePath = customImagePath
+	}
+	return strings.TrimSuffix(repository, "/"), imagePath
+}
+
+// getImageBundleCompatibilityImage returns the TKG compatibility image, tagged with the version of the compatibility
+// file of the image bundle, so the compatibility file downloaded from the private registry is the one of the bundle
+func getImageBundleCompatibilityImage(files map[string][]byte, repository, imagePath string) (ImageBundleImage, error) {
+	name := imageBundleCompatibilityDir + "/" + constants.TKGCompatibilityFileName
+	content, found := files[name]
+	if !found {
+		return ImageBundleImage{}, errors.Errorf("the TKG compatibility file %s is missing", name)
+	}
+	metadata := &tkgconfigbom.TKGCompatibilityMetadata{}
+	if err := yamlv3.Unmarshal(content, metadata); err != nil {
+		return ImageBundleImage{}, errors.Wrapf(err, "unable to parse the TKG compatibility file %s", name)
+	}
+	if metadata.Version == "" {
+		return ImageBundleImage{}, errors.Errorf("the TKG compatibility file %s has no version", name)
+	}
+	return ImageBundleImage{Repository: repository, ImagePath: imagePath, Tag: metadata.Version}, nil
+}
+
+// getImageBundleImages returns the images of the components of the TKG and TKr BOMs, the TKr BOM image and the
+// TKG compatibility image, sorted
+func getImageBundleImages(tkgBOM, tkrBOM *tkgconfigbom.BOMConfiguration, tkrVersion string, compatibilityImage ImageBundleImage) []ImageBundleImage {
+	images := map[string]ImageBundleImage{}
+	addImage := func(image ImageBundleImage) {
+		images[fmt.Sprintf("%s/%s:%s", image.Repository, image.ImagePath, image.Tag)] = image
+	}
+	addImage(compatibilityImage)
+	for _, bom := range []*tkgconfigbom.BOMConfiguration{tkgBOM, tkrBOM} {
+		for _, components := range bom.Components {
+			for _, component := range components {
+				for _, image := range component.Images {
+					repository := bom.ImageConfig.ImageRepository
+					if image.ImageRepository != "" {
+						repository = image.ImageRepository
+					}
+					addImage(ImageBundleImage{Repository: repository, ImagePath: image.ImagePath, Tag: image.Tag})
+				}
+			}
+		}
+	}
+	if tkgBOM.TKRBOM != nil && tkgBOM.TKRBOM.ImagePath != "" {
+		addImage(ImageBundleImage{
+			Repository: tkgBOM.ImageConfig.ImageRepository,
+			ImagePath:  tkgBOM.TKRBOM.ImagePath,
+			Tag:        tkgconfigbom.GetTKRBOMImageTagNameFromTKRVersion(tkrVersion),
+		})
+	}
+
+	keys := make([]string, 0, len(images))
+	for key := range images {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]ImageBundleImage, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, images[key])
+	}
+	return result
+}
+
+// imageBundleImageFile returns the path of the tarball of the image in the image bundle
+func imageBundleImageFile(image *ImageBundleImage) string {
+	return path.Join(imageBundleImagesDir, strings.ReplaceAll(image.ImagePath, "/", "_")+"_"+image.Tag+".tar")
+}
+
+// WriteImageBundle writes a tar archive holding the metadata and the files of the image bundle, and a tarball per
+// image pulled from the registry
+func WriteImageBundle(file string, bomRegistry registry.Registry, metadata *ImageBundleMetadata, files map[string][]byte) error {
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, imageBundleFilePermissions)
+	if err != nil {
+		return errors.Wrapf(err, "unable to create the image bundle '%s'", file)
+	}
+	defer f.Close()
+	tarWriter := tar.NewWriter(f)
+
+	addFile := func(name string, size int64, content io.Reader) error {
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     imageBundleFilePermissions,
+			Size:     size,
+			ModTime:  metadata.CreatedAt,
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := io.Copy(tarWriter, content)
+		return err
+	}
+
+	content, err := yaml.Marshal(metadata)
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal the image bundle metadata")
+	}
+	if err := addFile(imageBundleMetadataFile, int64(len(content)), bytes.NewReader(content)); err != nil {
+		return errors.Wrap(err, "unable to write the image bundle metadata")
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := addFile(name, int64(len(files[name])), bytes.NewReader(files[name])); err != nil {
+			return errors.Wrapf(err, "unable to write %s", name)
+		}
+	}
+
+	tmpDir, err := os.MkdirTemp("", "image-bundle")
+	if err != nil {
+		return errors.Wrap(err, "unable to create a temporary directory")
+	}
+	defer os.RemoveAll(tmpDir)
+	for i := range metadata.Images {
+		image := &metadata.Images[i]
+		imageName := image.Repository + "/" + image.ImagePath
+		log.V(3).Infof("Exporting image %s:%s (%d/%d)", imageName, image.Tag, i+1, len(metadata.Images))
+		if err := writeImageBundleImage(addFile, bomRegistry, image, tmpDir); err != nil {
+			return errors.Wrapf(err, "unable to export image %s:%s", imageName, image.Tag)
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return errors.Wrapf(err, "unable to write the image bundle '%s'", file)
+	}
+	return nil
+}
+
+func writeImageBundleImage(addFile func(string, int64, io.Reader) error, bomRegistry registry.Registry, image *ImageBundleImage, tmpDir string) error {
+	imageName := image.Repository + "/" + image.ImagePath
+	img, err := bomRegistry.GetImage(imageName, image.Tag)
+	if err != nil {
+		return err
+	}
+	tag, err := regname.NewTag(fmt.Sprintf("%s:%s", imageName, image.Tag), regname.WeakValidation)
+	if err != nil {
+		return err
+	}
+	tmpFile := filepath.Join(tmpDir, "image.tar")
+	if err := tarball.WriteToFile(tmpFile, tag, img); err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile)
+
+	f, err := os.Open(tmpFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return addFile(imageBundleImageFile(image), info.Size(), f)
+}
+
+// ReadImageBundle reads an image bundle written by WriteImageBundle, pushing its images to the given repository,
+// and returns its metadata and its files
+func ReadImageBundle(file string, bomRegistry registry.Registry, repository string) (*ImageBundleMetadata, map[string][]byte, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "unable to open the image bundle '%s'", file)
+	}
+	defer f.Close()
+	tarReader := tar.NewReader(f)
+
+	tmpDir, err := os.MkdirTemp("", "image-bundle")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to create a temporary directory")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	var metadata *ImageBundleMetadata
+	images := map[string]*ImageBundleImage{}
+	files := map[string][]byte{}
+	pushed := 0
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "unable to read the image bundle '%s'", file)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		switch {
+		case header.Name == imageBundleMetadataFile:
+			content, err := io.ReadAll(tarReader)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "unable to read %s from the image bundle", header.Name)
+			}
+			metadata = &ImageBundleMetadata{}
+			if err := yaml.Unmarshal(content, metadata); err != nil {
+				return nil, nil, errors.Wrap(err, "unable to parse the image bundle metadata")
+			}
+			for i := range metadata.Images {
+				images[imageBundleImageFile(&metadata.Images[i])] = &metadata.Images[i]
+			}
+		case strings.HasPrefix(header.Name, imageBundleImagesDir+"/"):
+			if metadata == nil {
+				return nil, nil, errors.Errorf("'%s' is not an image bundle, %s is missing", file, imageBundleMetadataFile)
+			}
+			image, found := images[header.Name]
+			if !found {
+				return nil, nil, errors.Errorf("the image %s is not in the image bundle metadata", header.Name)
+			}
+			imageName := repository + "/" + image.ImagePath
+			log.V(3).Infof("Importing image %s:%s (%d/%d)", imageName, image.Tag, pushed+1, len(metadata.Images))
+			if err := pushImageBundleImage(tarReader, bomRegistry, imageName, image.Tag, tmpDir); err != nil {
+				return nil, nil, errors.Wrapf(err, "unable to push image %s:%s", imageName, image.Tag)
+			}
+			pushed++
+		default:
+			content, err := io.ReadAll(tarReader)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "unable to read %s from the image bundle", header.Name)
+			}
+			files[header.Name] = content
+		}
+	}
+	if metadata == nil {
+		return nil, nil, errors.Errorf("'%s' is not an image bundle, %s is missing", file, imageBundleMetadataFile)
+	}
+	if pushed != len(metadata.Images) {
+		return nil, nil, errors.Errorf("the image bundle '%s' is incomplete, it holds %d images out of %d", file, pushed, len(metadata.Images))
+	}
+	return metadata, files, nil
+}
+
+func pushImageBundleImage(content io.Reader, bomRegistry registry.Registry, imageName, tag, tmpDir string) error {
+	tmpFile := filepath.Join(tmpDir, "image.tar")
+	f, err := os.OpenFile(tmpFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, imageBundleFilePermissions)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile)
+	if _, err := io.Copy(f, content); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	img, err := tarball.ImageFromPath(tmpFile, nil)
+	if err != nil {
+		return err
+	}
+	return bomRegistry.PushImage(imageName, tag, img)
+}
+
+// rewriteBOMImageRepository points the image references of a BOM file to the given repository: the image repository
+// of the image config, kubeadm config spec and kind kubeadm config spec are replaced, and the image repositories of
+// the images are removed so that they default to the one of the image config
+func rewriteBOMImageRepository(content []byte, repository string) ([]byte, error) {
+	doc := &yamlv3.Node{}
+	if err := yamlv3.Unmarshal(content, doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind != yamlv3.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yamlv3.MappingNode {
+		return nil, errors.New("the BOM file is not a yaml mapping")
+	}
+	root := doc.Content[0]
+
+	setYAMLMappingValue(getYAMLMappingValue(root, "imageConfig"), imageBundleImageRepoKey, repository)
+
+	kubeadmConfigSpec := getYAMLMappingValue(root, "kubeadmConfigSpec")
+	setYAMLMappingValue(kubeadmConfigSpec, imageBundleImageRepoKey, repository)
+	setYAMLMappingValue(getYAMLMappingValue(kubeadmConfigSpec, "dns"), imageBundleImageRepoKey, repository)
+	setYAMLMappingValue(getYAMLMappingValue(getYAMLMappingValue(kubeadmConfigSpec, "etcd"), "local"), imageBundleImageRepoKey, repository)
+
+	if kindKubeadmConfigSpec := getYAMLMappingValue(root, "kindKubeadmConfigSpec"); kindKubeadmConfigSpec != nil {
+		for _, line := range kindKubeadmConfigSpec.Content {
+			trimmed := strings.TrimLeft(line.Value, " ")
+			if line.Kind == yamlv3.ScalarNode && strings.HasPrefix(trimmed, imageBundleKindImageRepoLine) {
+				line.Value = line.Value[:len(line.Value)-len(trimmed)] + imageBundleKindImageRepoLine + " " + repository
+			}
+		}
+	}
+
+	if components := getYAMLMappingValue(root, "components"); components != nil && components.Kind == yamlv3.MappingNode {
+		for i := 1; i < len(components.Content); i += 2 {
+			for _, component := range components.Content[i].Content {
+				images := getYAMLMappingValue(component, "images")
+				if images == nil || images.Kind != yamlv3.MappingNode {
+					continue
+				}
+				for j := 1; j < len(images.Content); j += 2 {
+					deleteYAMLMappingKey(images.Content[j], imageBundleImageRepoKey)
+				}
+			}
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := yamlv3.NewEncoder(buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func getYAMLMappingValue(node *yamlv3.Node, key string) *yamlv3.Node {
+	if node == nil || node.Kind != yamlv3.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setYAMLMappingValue sets the value of the key of the mapping if the mapping has the key
+func setYAMLMappingValue(node *yamlv3.Node, key, value string) {
+	if valueNode := getYAMLMappingValue(node, key); valueNode != nil && valueNode.Kind == yamlv3.ScalarNode {
+		valueNode.Value = value
+		valueNode.Tag = "!!str"
+		valueNode.Style = 0
+	}
+}
+
+func deleteYAMLMappingKey(node *yamlv3.Node, key string) {
+	if node == nil || node.Kind != yamlv3.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
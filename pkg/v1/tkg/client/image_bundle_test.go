@@ -0,0 +1,308 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	regv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgconfigbom"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgconfigpaths"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkr/fakes"
+)
+
+const imageBundleTKGBOM = `default:
+  k8sVersion: v1.20.4+vmware.1-tkg.1
+release:
+  version: v1.3.1
+components:
+  cluster_api:
+  - version: v0.3.14+vmware.2
+    images:
+      capiController:
+        imagePath: cluster-api/cluster-api-controller
+        tag: v0.3.14_vmware.2
+  kube_rbac_proxy:
+  - version: v0.4.1+vmware.2
+    images:
+      kubeRbacProxyControllerImageCapi:
+        imagePath: cluster-api/kube-rbac-proxy
+        tag: v0.4.1_vmware.2
+        imageRepository: mirror.example.com/tkg
+kindKubeadmConfigSpec:
+- "kind: Cluster"
+- "kubeadmConfigPatches:"
+- "  imageRepository: registry.tkg.vmware.run"
+- "  etcd:"
+- "    local:"
+- "      imageRepository: registry.tkg.vmware.run"
+imageConfig:
+  imageRepository: projects.registry.vmware.com/tkg
+tkr-bom:
+  imagePath: tkr-bom
+`
+
+const imageBundleTKRBOM = `release:
+  version: v1.20.4+vmware.1-tkg.1
+components:
+  etcd:
+  - version: v3.4.13+vmware.7
+    images:
+      etcd:
+        imagePath: etcd
+        tag: v3.4.13_vmware.7
+  kubernetes:
+  - version: v1.20.4+vmware.1
+    images:
+      kubeAPIServer:
+        imagePath: kube-apiserver
+        tag: v1.20.4_vmware.1
+      pause:
+        imagePath: pause
+        tag: "3.2"
+kubeadmConfigSpec:
+  apiVersion: kubeadm.k8s.io/v1beta2
+  kind: ClusterConfiguration
+  imageRepository: projects.registry.vmware.com/tkg
+  kubernetesVersion: v1.20.4+vmware.1
+  etcd:
+    local:
+      dataDir: /var/lib/etcd
+      imageRepository: projects.registry.vmware.com/tkg
+      imageTag: v3.4.13_vmware.7
+  dns:
+    type: CoreDNS
+    imageRepository: projects.registry.vmware.com/tkg
+    imageTag: v1.7.0_vmware.8
+imageConfig:
+  imageRepository: projects.registry.vmware.com/tkg
+`
+
+var _ = Describe("Image bundle", func() {
+	var (
+		tkgBOM *tkgconfigbom.BOMConfiguration
+		tkrBOM *tkgconfigbom.BOMConfiguration
+	)
+
+	compatibilityImage := ImageBundleImage{Repository: "projects.registry.vmware.com/tkg", ImagePath: "framework-zshippable/tkg-compatibility", Tag: "v1"}
+
+	BeforeEach(func() {
+		tkgBOM = &tkgconfigbom.BOMConfiguration{}
+		Expect(yaml.Unmarshal([]byte(imageBundleTKGBOM), tkgBOM)).To(Succeed())
+		tkrBOM = &tkgconfigbom.BOMConfiguration{}
+		Expect(yaml.Unmarshal([]byte(imageBundleTKRBOM), tkrBOM)).To(Succeed())
+	})
+
+	Describe("getImageBundleCompatibilityImage", func() {
+		It("tags the compatibility image with the version of the compatibility file", func() {
+			image, err := getImageBundleCompatibilityImage(map[string][]byte{
+				"compatibility/tkg-compatibility.yaml": []byte(fmt.Sprintf(testTKGCompatibilityFileFmt, "v1.4.0", "v1.4.0")),
+			}, "projects.registry.vmware.com/tkg", tkgconfigpaths.TKGDefaultCompatibilityImagePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(image).To(Equal(ImageBundleImage{Repository: "projects.registry.vmware.com/tkg", ImagePath: tkgconfigpaths.TKGDefaultCompatibilityImagePath, Tag: "v1"}))
+		})
+		It("fails when the compatibility file is missing or has no version", func() {
+			_, err := getImageBundleCompatibilityImage(map[string][]byte{}, "projects.registry.vmware.com/tkg", tkgconfigpaths.TKGDefaultCompatibilityImagePath)
+			Expect(err).To(MatchError("the TKG compatibility file compatibility/tkg-compatibility.yaml is missing"))
+			_, err = getImageBundleCompatibilityImage(map[string][]byte{
+				"compatibility/tkg-compatibility.yaml": []byte("managementClusterPluginVersions: []"),
+			}, "projects.registry.vmware.com/tkg", tkgconfigpaths.TKGDefaultCompatibilityImagePath)
+			Expect(err).To(MatchError("the TKG compatibility file compatibility/tkg-compatibility.yaml has no version"))
+		})
+	})
+
+	Describe("getImageBundleImages", func() {
+		It("returns the images of the BOMs, the TKr BOM image and the compatibility image", func() {
+			Expect(getImageBundleImages(tkgBOM, tkrBOM, "v1.20.4+vmware.1-tkg.1", compatibilityImage)).To(Equal([]ImageBundleImage{
+				{Repository: "mirror.example.com/tkg", ImagePath: "cluster-api/kube-rbac-proxy", Tag: "v0.4.1_vmware.2"},
+				{Repository: "projects.registry.vmware.com/tkg", ImagePath: "cluster-api/cluster-api-controller", Tag: "v0.3.14_vmware.2"},
+				{Repository: "projects.registry.vmware.com/tkg", ImagePath: "etcd", Tag: "v3.4.13_vmware.7"},
+				{Repository: "projects.registry.vmware.com/tkg", ImagePath: "framework-zshippable/tkg-compatibility", Tag: "v1"},
+				{Repository: "projects.registry.vmware.com/tkg", ImagePath: "kube-apiserver", Tag: "v1.20.4_vmware.1"},
+				{Repository: "projects.registry.vmware.com/tkg", ImagePath: "pause", Tag: "3.2"},
+				{Repository: "projects.registry.vmware.com/tkg", ImagePath: "tkr-bom", Tag: "v1.20.4_vmware.1-tkg.1"},
+			}))
+		})
+	})
+
+	Describe("parseImageBundleBOMs", func() {
+		It("fails when the BOM of the TKr is missing", func() {
+			_, _, err := parseImageBundleBOMs(map[string][]byte{
+				"bom/tkg-bom-v1.3.1.yaml":                 []byte(imageBundleTKGBOM),
+				"bom/tkr-bom-v1.20.4+vmware.1-tkg.1.yaml": []byte(imageBundleTKRBOM),
+				"providers/config.yaml":                   []byte("providers: []"),
+				"compatibility/tkg-compatibility.yaml":    []byte("version: v1"),
+			}, "v1.19.8+vmware.1-tkg.1")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("the TKG BOM file or the BOM file of TKr v1.19.8+vmware.1-tkg.1 is missing"))
+		})
+	})
+
+	Describe("WriteImageBundle and ReadImageBundle", func() {
+		var (
+			file  string
+			files map[string][]byte
+		)
+
+		BeforeEach(func() {
+			dir, err := os.MkdirTemp("", "image-bundle-test")
+			Expect(err).NotTo(HaveOccurred())
+			file = filepath.Join(dir, "bundle.tar")
+			files = map[string][]byte{
+				"bom/tkg-bom-v1.3.1.yaml":                 []byte(imageBundleTKGBOM),
+				"bom/tkr-bom-v1.20.4+vmware.1-tkg.1.yaml": []byte(imageBundleTKRBOM),
+				"compatibility/tkg-compatibility.yaml":    []byte("version: v1"),
+				"providers/ytt/02_addons/cni.yaml":        []byte("#@ load(\"@ytt:data\", \"data\")"),
+			}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(filepath.Dir(file))
+		})
+
+		It("pushes the images of the bundle to the repository and returns its files", func() {
+			image, err := random.Image(1024, 2)
+			Expect(err).NotTo(HaveOccurred())
+			sourceRegistry := &fakes.Registry{}
+			sourceRegistry.GetImageReturns(image, nil)
+			metadata := &ImageBundleMetadata{
+				TKGVersion: "v1.3.1",
+				TKRVersion: "v1.20.4+vmware.1-tkg.1",
+				CreatedAt:  time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+				Images:     getImageBundleImages(tkgBOM, tkrBOM, "v1.20.4+vmware.1-tkg.1", compatibilityImage),
+			}
+			Expect(WriteImageBundle(file, sourceRegistry, metadata, files)).To(Succeed())
+			Expect(sourceRegistry.GetImageCallCount()).To(Equal(7))
+			imageName, tag := sourceRegistry.GetImageArgsForCall(0)
+			Expect(imageName).To(Equal("mirror.example.com/tkg/cluster-api/kube-rbac-proxy"))
+			Expect(tag).To(Equal("v0.4.1_vmware.2"))
+
+			var pushedDigests []regv1.Hash
+			targetRegistry := &fakes.Registry{}
+			targetRegistry.PushImageStub = func(_, _ string, img regv1.Image) error {
+				digest, err := img.Digest()
+				pushedDigests = append(pushedDigests, digest)
+				return err
+			}
+			readMetadata, readFiles, err := ReadImageBundle(file, targetRegistry, "registry.example.com/tkg")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(readMetadata).To(Equal(metadata))
+			Expect(readFiles).To(Equal(files))
+
+			Expect(targetRegistry.PushImageCallCount()).To(Equal(7))
+			imageName, tag, _ = targetRegistry.PushImageArgsForCall(0)
+			Expect(imageName).To(Equal("registry.example.com/tkg/cluster-api/kube-rbac-proxy"))
+			Expect(tag).To(Equal("v0.4.1_vmware.2"))
+			expectedDigest, err := image.Digest()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pushedDigests).To(ConsistOf(expectedDigest, expectedDigest, expectedDigest, expectedDigest, expectedDigest, expectedDigest, expectedDigest))
+			imageName, tag, _ = targetRegistry.PushImageArgsForCall(3)
+			Expect(imageName).To(Equal("registry.example.com/tkg/framework-zshippable/tkg-compatibility"))
+			Expect(tag).To(Equal("v1"))
+			imageName, tag, _ = targetRegistry.PushImageArgsForCall(6)
+			Expect(imageName).To(Equal("registry.example.com/tkg/tkr-bom"))
+			Expect(tag).To(Equal("v1.20.4_vmware.1-tkg.1"))
+		})
+
+		It("fails for an archive which is not an image bundle", func() {
+			Expect(WriteManagementClusterBackup(file, &ManagementClusterBackup{})).To(Succeed())
+			_, _, err := ReadImageBundle(file, &fakes.Registry{}, "registry.example.com/tkg")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to read the image bundle"))
+		})
+	})
+
+	Describe("writeImageBundleConfigFiles", func() {
+		var (
+			configDir string
+			tkgClient *TkgClient
+		)
+
+		BeforeEach(func() {
+			dir, err := os.MkdirTemp("", "image-bundle-config-test")
+			Expect(err).NotTo(HaveOccurred())
+			configDir = filepath.Join(dir, "tkg")
+			Expect(os.MkdirAll(configDir, 0o700)).To(Succeed())
+			tkgClient = &TkgClient{tkgConfigDir: configDir, tkgConfigPathsClient: tkgconfigpaths.New(configDir)}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(filepath.Dir(configDir))
+		})
+
+		It("writes the files to the BOM, compatibility and providers directories", func() {
+			Expect(tkgClient.writeImageBundleConfigFiles(map[string][]byte{
+				"bom/tkg-bom-v1.3.1.yaml":              []byte(imageBundleTKGBOM),
+				"compatibility/tkg-compatibility.yaml": []byte("version: v1"),
+				"providers/ytt/02_addons/cni.yaml":     []byte("cni"),
+				"metadata.yaml":                        []byte("tkgVersion: v1.3.1"),
+			})).To(Succeed())
+			Expect(os.ReadFile(filepath.Join(configDir, "bom", "tkg-bom-v1.3.1.yaml"))).To(Equal([]byte(imageBundleTKGBOM)))
+			Expect(os.ReadFile(filepath.Join(configDir, "compatibility", "tkg-compatibility.yaml"))).To(Equal([]byte("version: v1")))
+			Expect(os.ReadFile(filepath.Join(configDir, "providers", "ytt", "02_addons", "cni.yaml"))).To(Equal([]byte("cni")))
+			Expect(filepath.Join(configDir, "metadata.yaml")).NotTo(BeAnExistingFile())
+		})
+
+		DescribeTable("refuses the files which would be written outside of their directory",
+			func(name string) {
+				err := tkgClient.writeImageBundleConfigFiles(map[string][]byte{
+					"compatibility/tkg-compatibility.yaml": []byte("version: v1"),
+					name:                                   []byte("echo pwned"),
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("invalid file name " + name + " in the image bundle"))
+				Expect(filepath.Join(configDir, "compatibility", "tkg-compatibility.yaml")).NotTo(BeAnExistingFile())
+				Expect(filepath.Join(filepath.Dir(configDir), ".bashrc")).NotTo(BeAnExistingFile())
+				Expect(filepath.Join(filepath.Dir(configDir), "x")).NotTo(BeAnExistingFile())
+			},
+			Entry("parent directory in a provider file", "providers/../../../.bashrc"),
+			Entry("parent directory in a BOM file", "bom/../../x"),
+			Entry("parent directory at the end", "bom/.."),
+			Entry("absolute path", "providers//etc/passwd"),
+		)
+	})
+
+	Describe("rewriteBOMImageRepository", func() {
+		It("points the image references of the TKr BOM to the repository", func() {
+			content, err := rewriteBOMImageRepository([]byte(imageBundleTKRBOM), "registry.example.com/tkg")
+			Expect(err).NotTo(HaveOccurred())
+			bom := &tkgconfigbom.BOMConfiguration{}
+			Expect(yaml.Unmarshal(content, bom)).To(Succeed())
+			Expect(bom.ImageConfig.ImageRepository).To(Equal("registry.example.com/tkg"))
+			Expect(bom.KubeadmConfigSpec.ImageRepository).To(Equal("registry.example.com/tkg"))
+			Expect(bom.KubeadmConfigSpec.DNS.ImageRepository).To(Equal("registry.example.com/tkg"))
+			Expect(bom.KubeadmConfigSpec.Etcd.Local.ImageRepository).To(Equal("registry.example.com/tkg"))
+			Expect(bom.KubeadmConfigSpec.Etcd.Local.ImageTag).To(Equal("v3.4.13_vmware.7"))
+			Expect(bom.Components["kubernetes"][0].Images["pause"]).To(Equal(&tkgconfigbom.ImageInfo{ImagePath: "pause", Tag: "3.2"}))
+		})
+
+		It("removes the image repositories of the images and rewrites the kind kubeadm config spec", func() {
+			content, err := rewriteBOMImageRepository([]byte(imageBundleTKGBOM), "registry.example.com/tkg")
+			Expect(err).NotTo(HaveOccurred())
+			bom := &tkgconfigbom.BOMConfiguration{}
+			Expect(yaml.Unmarshal(content, bom)).To(Succeed())
+			Expect(bom.ImageConfig.ImageRepository).To(Equal("registry.example.com/tkg"))
+			Expect(bom.Components["kube_rbac_proxy"][0].Images["kubeRbacProxyControllerImageCapi"].ImageRepository).To(BeEmpty())
+			Expect(bom.KindKubeadmConfigSpec).To(Equal([]string{
+				"kind: Cluster",
+				"kubeadmConfigPatches:",
+				"  imageRepository: registry.example.com/tkg",
+				"  etcd:",
+				"    local:",
+				"      imageRepository: registry.example.com/tkg",
+			}))
+			Expect(bom.Default.TKRVersion).To(Equal("v1.20.4+vmware.1-tkg.1"))
+			Expect(bom.TKRBOM.ImagePath).To(Equal("tkr-bom"))
+		})
+	})
+})
@@ -263,12 +263,12 @@ func (c *TkgClient) InitRegion(options *InitRegionOptions) error { //nolint:funl
 		return err
 	}
 
-	log.Info("Waiting for the management cluster to get ready for move...")
+	logWaitingFor("Cluster", targetClusterNamespace, options.ClusterName, "Waiting for the management cluster to get ready for move...")
 	if err := c.WaitForClusterReadyForMove(bootStrapClusterClient, options.ClusterName, targetClusterNamespace); err != nil {
 		return errors.Wrap(err, "unable to wait for cluster getting ready for move")
 	}
 
-	log.Info("Waiting for addons installation...")
+	logWaitingFor("Cluster", options.Namespace, options.ClusterName, "Waiting for addons installation...")
 	if err := c.WaitForAddons(waitForAddonsOptions{
 		regionalClusterClient: bootStrapClusterClient,
 		workloadClusterClient: regionalClusterClient,
@@ -332,12 +332,12 @@ func (c *TkgClient) InitRegion(options *InitRegionOptions) error { //nolint:funl
 		}
 	}
 
-	log.Info("Waiting for additional components to be up and running...")
+	logWaitingFor("Deployment", "", "", "Waiting for additional components to be up and running...")
 	if err := c.WaitForAddonsDeployments(regionalClusterClient); err != nil {
 		return err
 	}
 
-	log.Info("Waiting for packages to be up and running...")
+	logWaitingFor("PackageInstall", "", "", "Waiting for packages to be up and running...")
 	if err := c.WaitForPackages(regionalClusterClient, regionalClusterClient, options.ClusterName, targetClusterNamespace); err != nil {
 		log.Warningf("Warning: Management cluster is created successfully, but some packages are failing. %v", err)
 	}
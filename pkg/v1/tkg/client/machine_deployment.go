@@ -9,6 +9,7 @@ import (
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	aws "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
 	azure "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 	vsphere "sigs.k8s.io/cluster-api-provider-vsphere/api/v1alpha3"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
 )
 
 // GetMachineDeploymentOptions a struct describing options for retrieving MachineDeployments
@@ -49,6 +51,22 @@ type NodePool struct {
 	NodeMachineType string            `yaml:"nodeMachineType,omitempty"`
 	Labels          map[string]string `yaml:"labels,omitempty"`
 	VSphere         VSphereNodePool   `yaml:"vsphere,omitempty"`
+	// Taints are set on the nodes of the node pool, an empty list removes the taints of an existing node pool
+	Taints []corev1.Taint `yaml:"taints,omitempty"`
+	// MinSize and MaxSize are the bounds the cluster autoscaler scales the node pool within
+	MinSize *int32 `yaml:"minSize,omitempty"`
+	MaxSize *int32 `yaml:"maxSize,omitempty"`
+	// MaxSurge and MaxUnavailable are the number or percentage of machines of the rolling updates
+	MaxSurge           string                      `yaml:"maxSurge,omitempty"`
+	MaxUnavailable     string                      `yaml:"maxUnavailable,omitempty"`
+	MachineHealthCheck *NodePoolMachineHealthCheck `yaml:"machineHealthCheck,omitempty"`
+}
+
+// NodePoolMachineHealthCheck a struct describing the machine health check overrides of a node pool
+type NodePoolMachineHealthCheck struct {
+	NodeStartupTimeout string `yaml:"nodeStartupTimeout,omitempty"`
+	// UnhealthyConditions are in the NodeConditionType:ConditionStatus:Timeout format
+	UnhealthyConditions []string `yaml:"unhealthyConditions,omitempty"`
 }
 
 // VSphereNodePool a struct describing properties necessary for a node pool on vSphere
@@ -68,11 +86,20 @@ type VSphereNodePool struct {
 }
 
 // SetMachineDeployment sets a MachineDeployment on a cluster.
-func (c *TkgClient) SetMachineDeployment(options *SetMachineDeploymentOptions) error { //nolint:funlen,gocyclo
+func (c *TkgClient) SetMachineDeployment(options *SetMachineDeploymentOptions) error {
 	clusterClient, err := c.getClusterClient()
 	if err != nil {
 		return errors.Wrap(err, "Unable to create clusterclient")
 	}
+	return c.DoSetMachineDeployment(clusterClient, options)
+}
+
+// DoSetMachineDeployment creates or updates the MachineDeployment of the node pool, with its machine health
+// check overrides, using the given management cluster client
+func (c *TkgClient) DoSetMachineDeployment(clusterClient clusterclient.Client, options *SetMachineDeploymentOptions) error { //nolint:funlen,gocyclo
+	if err := validateNodePool(&options.NodePool); err != nil {
+		return err
+	}
 
 	workers, err := clusterClient.GetMDObjectForCluster(options.ClusterName, options.Namespace)
 	if err != nil || len(workers) == 0 {
@@ -89,15 +116,30 @@ func (c *TkgClient) SetMachineDeployment(options *SetMachineDeploymentOptions) e
 		}
 	}
 
-	baseWorker.Annotations = map[string]string{}
+	// the annotations of an existing node pool hold its revision and autoscaler bounds
+	if !update {
+		baseWorker.Annotations = map[string]string{}
+	}
 	baseWorker.Name = options.Name
 	if options.Replicas != nil {
 		baseWorker.Spec.Replicas = options.Replicas
 	}
 
+	if baseWorker.Spec.Template.Labels == nil {
+		baseWorker.Spec.Template.Labels = map[string]string{}
+	}
 	for k, v := range options.Labels {
 		baseWorker.Spec.Template.Labels[k] = v
 	}
+	if options.MachineHealthCheck != nil {
+		// the machines of the node pool are checked by its own machine health check rather than the cluster one
+		baseWorker.Spec.Template.Labels[NodePoolKey] = options.Name
+	}
+
+	if err := setNodePoolAutoscalerBounds(clusterClient, &baseWorker, options); err != nil {
+		return err
+	}
+	setNodePoolRollingUpdate(&baseWorker, &options.NodePool)
 
 	kcTemplate, err := retrieveKubeadmConfigTemplate(clusterClient, baseWorker.Spec.Template.Spec.Bootstrap.ConfigRef)
 	if err != nil {
@@ -106,6 +148,7 @@ func (c *TkgClient) SetMachineDeployment(options *SetMachineDeploymentOptions) e
 	kcTemplate.Annotations = map[string]string{}
 	kcTemplate.Name = fmt.Sprintf("%s-kct", options.Name)
 	kcTemplate.ResourceVersion = ""
+	setNodePoolTaints(kcTemplate, options.Taints)
 
 	if !update {
 		if err = clusterClient.CreateResource(kcTemplate, kcTemplate.Name, options.Namespace); err != nil {
@@ -141,10 +184,31 @@ func (c *TkgClient) SetMachineDeployment(options *SetMachineDeploymentOptions) e
 			return errors.Wrap(err, "failed to create machinedeployment")
 		}
 	} else {
+		var previousKCTemplate *v1alpha3.KubeadmConfigTemplate
+		if options.Taints != nil {
+			// the kubeadmconfigtemplate is replaced rather than updated so that the machines are rolled out with the new taints
+			previousKCTemplate = &v1alpha3.KubeadmConfigTemplate{}
+			previousKCTemplate.Name = baseWorker.Spec.Template.Spec.Bootstrap.ConfigRef.Name
+			previousKCTemplate.Namespace = kcTemplate.Namespace
+			kcTemplate.Name = fmt.Sprintf("%s-kct-%s", options.Name, utilrand.String(5))
+			if err = clusterClient.CreateResource(kcTemplate, kcTemplate.Name, options.Namespace); err != nil {
+				return errors.Wrap(err, "could not create kubeadmconfigtemplate")
+			}
+			baseWorker.Spec.Template.Spec.Bootstrap.ConfigRef.Name = kcTemplate.Name
+		}
 		err = clusterClient.UpdateResource(&baseWorker, baseWorker.Name, options.Namespace)
 		if err != nil {
 			return errors.Wrap(err, "failed to create machinedeployment")
 		}
+		if previousKCTemplate != nil && !isKubeadmConfigTemplateReferenced(workers, previousKCTemplate.Name, options.Name) {
+			if err := clusterClient.DeleteResource(previousKCTemplate); err != nil {
+				log.Warningf("unable to delete the previous kubeadmconfigtemplate %s of node pool %s: %v", previousKCTemplate.Name, options.Name, err)
+			}
+		}
+	}
+
+	if options.MachineHealthCheck != nil {
+		return c.setNodePoolMachineHealthCheck(clusterClient, options)
 	}
 	return nil
 }
@@ -280,7 +344,7 @@ func createAWSMachineTemplate(clusterClient clusterclient.Client, infraTemplate
 	awsMachineTemplate.Annotations = map[string]string{}
 	awsMachineTemplate.Name = machineTemplateName
 	awsMachineTemplate.ResourceVersion = ""
-	if options.NodeMachineType == "" {
+	if options.NodeMachineType != "" {
 		awsMachineTemplate.Spec.Template.Spec.InstanceType = options.NodeMachineType
 	}
 	if err = clusterClient.CreateResource(&awsMachineTemplate, machineTemplateName, options.Namespace); err != nil {
@@ -295,7 +359,7 @@ func createAzureMachineTemplate(clusterClient clusterclient.Client, infraTemplat
 	if err != nil {
 		return err
 	}
-	if options.NodeMachineType == "" {
+	if options.NodeMachineType != "" {
 		azureMachineTemplate.Spec.Template.Spec.VMSize = options.NodeMachineType
 	}
 	azureMachineTemplate.Name = machineTemplateName
@@ -332,6 +396,18 @@ func retrieveMachineTemplate(clusterClient clusterclient.Client, infraTemplate *
 	return nil
 }
 
+// isKubeadmConfigTemplateReferenced returns true when a machine deployment other than the given one bootstraps
+// its machines with the kubeadmconfigtemplate
+func isKubeadmConfigTemplateReferenced(workers []capi.MachineDeployment, kcTemplateName, mdName string) bool {
+	for i := range workers {
+		configRef := workers[i].Spec.Template.Spec.Bootstrap.ConfigRef
+		if workers[i].Name != mdName && configRef != nil && configRef.Name == kcTemplateName {
+			return true
+		}
+	}
+	return false
+}
+
 func retrieveKubeadmConfigTemplate(clusterClient clusterclient.Client, configRef *corev1.ObjectReference) (*v1alpha3.KubeadmConfigTemplate, error) {
 	var kcTemplate v1alpha3.KubeadmConfigTemplate
 	kcTemplateName := configRef.Name
@@ -0,0 +1,303 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	aws "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
+	azure "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	bootstrapv1alpha3 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1alpha3"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" // nolint:staticcheck
+
+	. "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/fakes"
+	fakehelper "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/fakes/helper"
+)
+
+var _ = Describe("SetMachineDeployment", func() {
+	var (
+		tkgClient           *TkgClient
+		clusterClient       clusterclient.Client
+		clientSet           crtclient.Client
+		autoscalerEnabled   bool
+		machineTemplate     runtime.Object
+		machineTemplateKind string
+		otherObjects        []runtime.Object
+		options             *SetMachineDeploymentOptions
+		err                 error
+	)
+
+	int32Ptr := func(i int32) *int32 { return &i }
+
+	BeforeEach(func() {
+		autoscalerEnabled = false
+		otherObjects = nil
+		machineTemplateKind = constants.AWSMachineTemplate
+		machineTemplate = &aws.AWSMachineTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-md-0", Namespace: "my-namespace"},
+			Spec:       aws.AWSMachineTemplateSpec{Template: aws.AWSMachineTemplateResource{Spec: aws.AWSMachineSpec{InstanceType: "m5.large"}}},
+		}
+		options = &SetMachineDeploymentOptions{
+			ClusterName: "my-cluster",
+			Namespace:   "my-namespace",
+			NodePool:    NodePool{Name: "my-pool", Replicas: int32Ptr(2)},
+		}
+	})
+
+	JustBeforeEach(func() {
+		clusterLabel := map[string]string{capi.ClusterLabelName: "my-cluster"}
+		objects := []runtime.Object{
+			&capi.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-md-0", Namespace: "my-namespace", Labels: clusterLabel},
+				Spec: capi.MachineDeploymentSpec{
+					ClusterName: "my-cluster",
+					Replicas:    int32Ptr(1),
+					Template: capi.MachineTemplateSpec{
+						ObjectMeta: capi.ObjectMeta{Labels: map[string]string{NodePoolKey: "my-cluster-worker-pool"}},
+						Spec: capi.MachineSpec{
+							ClusterName:       "my-cluster",
+							Bootstrap:         capi.Bootstrap{ConfigRef: &corev1.ObjectReference{Kind: "KubeadmConfigTemplate", Name: "my-cluster-md-0", Namespace: "my-namespace"}},
+							InfrastructureRef: corev1.ObjectReference{Kind: machineTemplateKind, Name: "my-cluster-md-0", Namespace: "my-namespace"},
+						},
+					},
+				},
+			},
+			&bootstrapv1alpha3.KubeadmConfigTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-md-0", Namespace: "my-namespace"},
+			},
+			machineTemplate,
+		}
+		objects = append(objects, otherObjects...)
+		if autoscalerEnabled {
+			objects = append(objects, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-cluster-autoscaler", Namespace: "my-namespace"}})
+		}
+		crtClientFactory := &fakes.CrtClientFactory{}
+		clientSet = fake.NewFakeClientWithScheme(scheme, objects...)
+		crtClientFactory.NewClientReturns(clientSet, nil)
+		kubeconfig := fakehelper.GetFakeKubeConfigFilePath(testingDir, "../fakes/config/kubeconfig/config1.yaml")
+		clusterClient, err = clusterclient.NewClient(kubeconfig, "", clusterclient.NewOptions(getFakePoller(), crtClientFactory, getFakeDiscoveryFactory(), nil))
+		Expect(err).NotTo(HaveOccurred())
+		tkgClient, err = CreateTKGClient("../fakes/config/config.yaml", testingDir, defaultTKGBoMFileForTesting, 2*time.Second)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = tkgClient.DoSetMachineDeployment(clusterClient, options)
+	})
+
+	getMachineDeployment := func(name string) *capi.MachineDeployment {
+		md := &capi.MachineDeployment{}
+		Expect(clientSet.Get(context.Background(), crtclient.ObjectKey{Name: name, Namespace: "my-namespace"}, md)).To(Succeed())
+		return md
+	}
+
+	Context("when the node pool has taints and rolling update settings", func() {
+		BeforeEach(func() {
+			options.Taints = []corev1.Taint{{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}}
+			options.MaxSurge = "25%"
+			options.MaxUnavailable = "0"
+		})
+		It("creates the node pool with them", func() {
+			Expect(err).NotTo(HaveOccurred())
+			md := getMachineDeployment("my-pool")
+			Expect(*md.Spec.Replicas).To(Equal(int32(2)))
+			Expect(*md.Spec.Strategy.RollingUpdate.MaxSurge).To(Equal(intstr.FromString("25%")))
+			Expect(*md.Spec.Strategy.RollingUpdate.MaxUnavailable).To(Equal(intstr.FromInt(0)))
+
+			kct := &bootstrapv1alpha3.KubeadmConfigTemplate{}
+			Expect(clientSet.Get(context.Background(), crtclient.ObjectKey{Name: md.Spec.Template.Spec.Bootstrap.ConfigRef.Name, Namespace: "my-namespace"}, kct)).To(Succeed())
+			Expect(kct.Spec.Template.Spec.JoinConfiguration.NodeRegistration.Taints).To(Equal(options.Taints))
+		})
+	})
+
+	Context("when the node pool has a machine type", func() {
+		BeforeEach(func() {
+			options.NodeMachineType = "p3.2xlarge"
+		})
+		It("creates the AWSMachineTemplate with the instance type", func() {
+			Expect(err).NotTo(HaveOccurred())
+			md := getMachineDeployment("my-pool")
+			awsMachineTemplate := &aws.AWSMachineTemplate{}
+			Expect(clientSet.Get(context.Background(), crtclient.ObjectKey{Name: md.Spec.Template.Spec.InfrastructureRef.Name, Namespace: "my-namespace"}, awsMachineTemplate)).To(Succeed())
+			Expect(awsMachineTemplate.Spec.Template.Spec.InstanceType).To(Equal("p3.2xlarge"))
+		})
+	})
+
+	Context("when the node pool has no machine type", func() {
+		It("creates the AWSMachineTemplate with the instance type of the base machine deployment", func() {
+			Expect(err).NotTo(HaveOccurred())
+			md := getMachineDeployment("my-pool")
+			awsMachineTemplate := &aws.AWSMachineTemplate{}
+			Expect(clientSet.Get(context.Background(), crtclient.ObjectKey{Name: md.Spec.Template.Spec.InfrastructureRef.Name, Namespace: "my-namespace"}, awsMachineTemplate)).To(Succeed())
+			Expect(awsMachineTemplate.Spec.Template.Spec.InstanceType).To(Equal("m5.large"))
+		})
+	})
+
+	Context("when the node pool of an Azure cluster has a machine type", func() {
+		BeforeEach(func() {
+			machineTemplateKind = constants.AzureMachineTemplate
+			machineTemplate = &azure.AzureMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-md-0", Namespace: "my-namespace"},
+				Spec:       azure.AzureMachineTemplateSpec{Template: azure.AzureMachineTemplateResource{Spec: azure.AzureMachineSpec{VMSize: "Standard_D2s_v3"}}},
+			}
+			options.NodeMachineType = "Standard_NC6"
+		})
+		It("creates the AzureMachineTemplate with the VM size", func() {
+			Expect(err).NotTo(HaveOccurred())
+			md := getMachineDeployment("my-pool")
+			azureMachineTemplate := &azure.AzureMachineTemplate{}
+			Expect(clientSet.Get(context.Background(), crtclient.ObjectKey{Name: md.Spec.Template.Spec.InfrastructureRef.Name, Namespace: "my-namespace"}, azureMachineTemplate)).To(Succeed())
+			Expect(azureMachineTemplate.Spec.Template.Spec.VMSize).To(Equal("Standard_NC6"))
+		})
+	})
+
+	Context("when a taint effect is not supported", func() {
+		BeforeEach(func() {
+			options.Taints = []corev1.Taint{{Key: "dedicated", Effect: "Evict"}}
+		})
+		It("returns an error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("taint effect \"Evict\" of node pool my-pool is not supported"))
+		})
+	})
+
+	Context("when the autoscaler bounds are set and the cluster autoscaler is not enabled", func() {
+		BeforeEach(func() {
+			options.MinSize = int32Ptr(1)
+			options.MaxSize = int32Ptr(3)
+		})
+		It("returns an error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cluster autoscaler is not enabled for cluster my-cluster"))
+		})
+	})
+
+	Context("when the cluster autoscaler is enabled", func() {
+		BeforeEach(func() {
+			autoscalerEnabled = true
+			options.MinSize = int32Ptr(1)
+			options.MaxSize = int32Ptr(3)
+		})
+		It("sets the autoscaler bounds of the node pool", func() {
+			Expect(err).NotTo(HaveOccurred())
+			md := getMachineDeployment("my-pool")
+			Expect(md.Annotations).To(HaveKeyWithValue(constants.AutoscalerMinSizeAnnotation, "1"))
+			Expect(md.Annotations).To(HaveKeyWithValue(constants.AutoscalerMaxSizeAnnotation, "3"))
+		})
+
+		Context("when the replicas are out of the bounds", func() {
+			BeforeEach(func() {
+				options.Replicas = int32Ptr(5)
+			})
+			It("returns an error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("replicas 5 of node pool my-pool are out of its autoscaler bounds [1, 3]"))
+			})
+		})
+
+		Context("when only the minimum size is set on a new node pool", func() {
+			BeforeEach(func() {
+				options.MaxSize = nil
+			})
+			It("returns an error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("both minSize and maxSize of node pool my-pool are required"))
+			})
+		})
+
+		Context("when the minimum size is greater than the maximum size", func() {
+			BeforeEach(func() {
+				options.MinSize = int32Ptr(4)
+			})
+			It("returns an error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("minSize 4 of node pool my-pool is greater than its maxSize 3"))
+			})
+		})
+	})
+
+	Context("when the node pool has machine health check overrides", func() {
+		BeforeEach(func() {
+			options.MachineHealthCheck = &NodePoolMachineHealthCheck{
+				NodeStartupTimeout:  "30m",
+				UnhealthyConditions: []string{"Ready:False:10m"},
+			}
+		})
+		It("creates a machine health check for the node pool", func() {
+			Expect(err).NotTo(HaveOccurred())
+			md := getMachineDeployment("my-pool")
+			Expect(md.Spec.Template.Labels).To(HaveKeyWithValue(NodePoolKey, "my-pool"))
+
+			mhc := &capi.MachineHealthCheck{}
+			Expect(clientSet.Get(context.Background(), crtclient.ObjectKey{Name: "my-pool", Namespace: "my-namespace"}, mhc)).To(Succeed())
+			Expect(mhc.Spec.ClusterName).To(Equal("my-cluster"))
+			Expect(mhc.Spec.Selector.MatchLabels).To(Equal(map[string]string{NodePoolKey: "my-pool"}))
+			Expect(mhc.Spec.NodeStartupTimeout.Duration).To(Equal(30 * time.Minute))
+			Expect(mhc.Spec.UnhealthyConditions).To(HaveLen(1))
+			Expect(mhc.Spec.UnhealthyConditions[0].Timeout.Duration).To(Equal(10 * time.Minute))
+		})
+	})
+
+	Context("when the taints of an existing node pool are updated", func() {
+		BeforeEach(func() {
+			options.Name = "my-cluster-md-0"
+			options.Taints = []corev1.Taint{{Key: "dedicated", Effect: corev1.TaintEffectNoExecute}}
+		})
+		It("replaces its kubeadmconfigtemplate", func() {
+			Expect(err).NotTo(HaveOccurred())
+			md := getMachineDeployment("my-cluster-md-0")
+			Expect(md.Spec.Template.Spec.Bootstrap.ConfigRef.Name).To(HavePrefix("my-cluster-md-0-kct-"))
+
+			kct := &bootstrapv1alpha3.KubeadmConfigTemplate{}
+			Expect(clientSet.Get(context.Background(), crtclient.ObjectKey{Name: md.Spec.Template.Spec.Bootstrap.ConfigRef.Name, Namespace: "my-namespace"}, kct)).To(Succeed())
+			Expect(kct.Spec.Template.Spec.JoinConfiguration.NodeRegistration.Taints).To(Equal(options.Taints))
+		})
+		It("deletes the previous kubeadmconfigtemplate", func() {
+			Expect(err).NotTo(HaveOccurred())
+			kct := &bootstrapv1alpha3.KubeadmConfigTemplate{}
+			err = clientSet.Get(context.Background(), crtclient.ObjectKey{Name: "my-cluster-md-0", Namespace: "my-namespace"}, kct)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("when the taints of a node pool sharing its kubeadmconfigtemplate are updated", func() {
+		BeforeEach(func() {
+			options.Name = "my-cluster-md-0"
+			options.Taints = []corev1.Taint{{Key: "dedicated", Effect: corev1.TaintEffectNoExecute}}
+			otherObjects = []runtime.Object{
+				&capi.MachineDeployment{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-md-1", Namespace: "my-namespace", Labels: map[string]string{capi.ClusterLabelName: "my-cluster"}},
+					Spec: capi.MachineDeploymentSpec{
+						ClusterName: "my-cluster",
+						Template: capi.MachineTemplateSpec{
+							Spec: capi.MachineSpec{
+								ClusterName:       "my-cluster",
+								Bootstrap:         capi.Bootstrap{ConfigRef: &corev1.ObjectReference{Kind: "KubeadmConfigTemplate", Name: "my-cluster-md-0", Namespace: "my-namespace"}},
+								InfrastructureRef: corev1.ObjectReference{Kind: machineTemplateKind, Name: "my-cluster-md-0", Namespace: "my-namespace"},
+							},
+						},
+					},
+				},
+			}
+		})
+		It("keeps the previous kubeadmconfigtemplate", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getMachineDeployment("my-cluster-md-0").Spec.Template.Spec.Bootstrap.ConfigRef.Name).To(HavePrefix("my-cluster-md-0-kct-"))
+			kct := &bootstrapv1alpha3.KubeadmConfigTemplate{}
+			Expect(clientSet.Get(context.Background(), crtclient.ObjectKey{Name: "my-cluster-md-0", Namespace: "my-namespace"}, kct)).To(Succeed())
+		})
+	})
+})
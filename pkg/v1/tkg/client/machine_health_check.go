@@ -94,7 +94,10 @@ func (c *TkgClient) DeleteMachineHealthCheck(options MachineHealthCheckOptions)
 		log.Infof("use %s as default namespace", options.Namespace)
 	}
 
-	return c.DeleteMachineHealthCheckWithClusterClient(clusterClient, options)
+	recorder := recordOperationStarted(clusterClient, options.ClusterName, options.Namespace, clusterclient.OperationTypeDeleteMachineHealthCheck)
+	err = c.DeleteMachineHealthCheckWithClusterClient(clusterClient, options)
+	recorder.finished(err)
+	return err
 }
 
 // DeleteMachineHealthCheckWithClusterClient delete machinehealthcheck with client
@@ -212,7 +215,7 @@ func findCluster(clusterClient clusterclient.Client, clusterName, namespace stri
 }
 
 // SetMachineHealthCheck sets machinehealthcheck
-func (c *TkgClient) SetMachineHealthCheck(options *SetMachineHealthCheckOptions) error {
+func (c *TkgClient) SetMachineHealthCheck(options *SetMachineHealthCheckOptions) (err error) {
 	currentRegion, err := c.GetCurrentRegionContext()
 	if err != nil {
 		return errors.Wrap(err, "cannot get current management cluster context")
@@ -235,6 +238,11 @@ func (c *TkgClient) SetMachineHealthCheck(options *SetMachineHealthCheckOptions)
 		log.Infof("use %s as default namespace", options.Namespace)
 	}
 
+	recorder := recordOperationStarted(clusterClient, options.ClusterName, options.Namespace, clusterclient.OperationTypeSetMachineHealthCheck)
+	defer func() {
+		recorder.finished(err)
+	}()
+
 	candidates, err := getMachineHealthCheckCandidates(clusterClient, options.ClusterName, options.Namespace, options.MachineHealthCheckName, "")
 	if err != nil {
 		return err
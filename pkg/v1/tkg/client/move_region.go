@@ -0,0 +1,266 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/region"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/utils"
+)
+
+// MoveManagementClusterOptions contains options supported by MoveManagementCluster
+type MoveManagementClusterOptions struct {
+	// Kubeconfig is the kubeconfig of the existing cluster to move the management cluster to
+	Kubeconfig string
+	// Context is the context of the kubeconfig to use, the current context of the kubeconfig if empty
+	Context string
+	// ClusterName is the name of the new management cluster, the cluster name of the context if empty
+	ClusterName string
+}
+
+// MoveManagementCluster installs the providers of the current management cluster on an existing cluster, moves the
+// Cluster API objects of the workload clusters to it, makes it the current management cluster and waits for the
+// workload clusters to be reconciled from it. The current management cluster keeps managing itself.
+func (c *TkgClient) MoveManagementCluster(options MoveManagementClusterOptions) error { //nolint:funlen
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		return errors.Wrap(err, "cannot get current management cluster context")
+	}
+	clusterclientOptions := clusterclient.Options{OperationTimeout: c.timeout}
+	sourceClusterClient, err := clusterclient.NewClient(currentRegion.SourceFilePath, currentRegion.ContextName, clusterclientOptions)
+	if err != nil {
+		return errors.Wrap(err, "unable to get cluster client while moving management cluster")
+	}
+	isPacific, err := sourceClusterClient.IsPacificRegionalCluster()
+	if err != nil {
+		return errors.Wrap(err, "error determining 'Tanzu Kubernetes Cluster service for vSphere' management cluster")
+	}
+	if isPacific {
+		return errors.New("moving 'Tanzu Kubernetes Cluster service for vSphere' management cluster is not yet supported")
+	}
+
+	targetClusterClient, err := clusterclient.NewClient(options.Kubeconfig, options.Context, clusterclientOptions)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get cluster client for kubeconfig '%s'", options.Kubeconfig)
+	}
+	if err := targetClusterClient.IsRegionalCluster(); err == nil {
+		return errors.New("the target cluster already has Cluster API providers installed, it cannot become the management cluster")
+	}
+	if options.ClusterName == "" {
+		if options.ClusterName, err = targetClusterClient.GetCurrentClusterName(options.Context); err != nil {
+			return errors.Wrap(err, "unable to get the name of the target cluster")
+		}
+	}
+	if options.ClusterName == currentRegion.ClusterName {
+		return errors.Errorf("the new management cluster must have a different name than management cluster %s", currentRegion.ClusterName)
+	}
+
+	namespaces, clusters, err := GetManagementClusterMoveNamespaces(sourceClusterClient, currentRegion.ClusterName)
+	if err != nil {
+		return err
+	}
+	initOptions, err := getInstalledProviders(sourceClusterClient)
+	if err != nil {
+		return err
+	}
+
+	sourceKubeconfigPath, err := sourceClusterClient.ExportCurrentKubeconfigToFile()
+	if err != nil {
+		return errors.Wrap(err, "unable to export the kubeconfig of the management cluster")
+	}
+	defer os.Remove(sourceKubeconfigPath)
+	targetKubeconfigPath, err := targetClusterClient.ExportCurrentKubeconfigToFile()
+	if err != nil {
+		return errors.Wrap(err, "unable to export the kubeconfig of the target cluster")
+	}
+	defer os.Remove(targetKubeconfigPath)
+
+	if err := c.configureVariablesForProvidersInstallation(sourceClusterClient); err != nil {
+		return errors.Wrap(err, "unable to configure variables for provider installation")
+	}
+	log.Info("Installing providers on the target cluster...")
+	if err := c.InitializeProviders(initOptions, targetClusterClient, targetKubeconfigPath); err != nil {
+		return errors.Wrap(err, "unable to initialize providers on the target cluster")
+	}
+	if err := targetClusterClient.PatchClusterAPIAWSControllersToUseEC2Credentials(); err != nil {
+		return err
+	}
+
+	for _, namespace := range namespaces {
+		log.Infof("Moving the Cluster API objects of namespace '%s' to the target cluster...", namespace)
+		if err := c.MoveObjects(sourceKubeconfigPath, targetKubeconfigPath, namespace); err != nil {
+			return errors.Wrapf(err, "unable to move the Cluster API objects of namespace '%s' to the target cluster", namespace)
+		}
+	}
+
+	if err := c.saveMovedRegionContext(targetClusterClient, options.ClusterName); err != nil {
+		return err
+	}
+
+	log.Info("Waiting for the workload clusters to be reconciled by the new management cluster...")
+	if err := WaitForMovedClustersReconciled(targetClusterClient, clusters, c.timeout); err != nil {
+		return err
+	}
+	log.Infof("Workload clusters moved to management cluster %s, management cluster %s does not manage them anymore", options.ClusterName, currentRegion.ClusterName)
+	return nil
+}
+
+// saveMovedRegionContext saves the kubeconfig of the new management cluster into the tkg managed kubeconfig
+// and makes it the current management cluster
+func (c *TkgClient) saveMovedRegionContext(targetClusterClient clusterclient.Client, clusterName string) error {
+	kubeconfigBytes, err := targetClusterClient.LoadCurrentKubeconfigBytes()
+	if err != nil {
+		return errors.Wrap(err, "unable to load the kubeconfig of the target cluster")
+	}
+	kubeconfig, err := clientcmd.Load(kubeconfigBytes)
+	if err != nil {
+		return errors.Wrap(err, "unable to load the kubeconfig of the target cluster")
+	}
+	// only the context of the target cluster is saved
+	if err := clientcmdapi.MinifyConfig(kubeconfig); err != nil {
+		return errors.Wrap(err, "unable to extract the context of the target cluster from its kubeconfig")
+	}
+	if kubeconfigBytes, err = clientcmd.Write(*kubeconfig); err != nil {
+		return errors.Wrap(err, "unable to write the kubeconfig of the target cluster")
+	}
+	regionalClusterKubeconfigPath, err := getTKGKubeConfigPath(true)
+	if err != nil {
+		return err
+	}
+
+	filelock, err := utils.GetFileLockWithTimeOut(filepath.Join(c.tkgConfigDir, constants.LocalTanzuFileLock), utils.DefaultLockTimeout)
+	if err != nil {
+		return errors.Wrap(err, "cannot acquire lock for updating management cluster configuration")
+	}
+	defer func() {
+		if err := filelock.Unlock(); err != nil {
+			log.Warningf("cannot release lock for updating management cluster configuration, reason: %v", err)
+		}
+	}()
+
+	if err := MergeKubeConfigWithoutSwitchContext(kubeconfigBytes, regionalClusterKubeconfigPath); err != nil {
+		return errors.Wrap(err, "unable to save the kubeconfig of the new management cluster to TKG managed kubeconfig")
+	}
+	regionContext := region.RegionContext{
+		ClusterName:    clusterName,
+		ContextName:    kubeconfig.CurrentContext,
+		SourceFilePath: regionalClusterKubeconfigPath,
+		Status:         region.Success,
+	}
+	if err := c.regionManager.UpsertRegionContext(regionContext); err != nil {
+		return errors.Wrapf(err, "unable to persist management cluster %s info to tkg config", clusterName)
+	}
+	if err := c.regionManager.SetCurrentContext(regionContext.ClusterName, regionContext.ContextName); err != nil {
+		return errors.Wrapf(err, "unable to use management cluster %s as current management cluster", clusterName)
+	}
+	return nil
+}
+
+// getInstalledProviders returns the options to install the providers of the management cluster, at their current version
+func getInstalledProviders(clusterClient clusterclient.Client) (*InitRegionOptions, error) {
+	providers := &clusterctlv1.ProviderList{}
+	if err := clusterClient.ListResources(providers, &crtclient.ListOptions{}); err != nil {
+		return nil, errors.Wrap(err, "cannot get installed provider config")
+	}
+
+	options := &InitRegionOptions{}
+	for i := range providers.Items {
+		provider := &providers.Items[i]
+		providerNameVersion := provider.ProviderName + ":" + provider.Version
+		switch clusterctlv1.ProviderType(provider.Type) {
+		case clusterctlv1.CoreProviderType:
+			options.CoreProvider = providerNameVersion
+		case clusterctlv1.BootstrapProviderType:
+			options.BootstrapProvider = providerNameVersion
+		case clusterctlv1.ControlPlaneProviderType:
+			options.ControlPlaneProvider = providerNameVersion
+		case clusterctlv1.InfrastructureProviderType:
+			options.InfrastructureProvider = providerNameVersion
+		}
+	}
+	if options.CoreProvider == "" || options.InfrastructureProvider == "" {
+		return nil, errors.New("unable to find the core and infrastructure providers of the management cluster")
+	}
+	return options, nil
+}
+
+// GetManagementClusterMoveNamespaces returns the namespaces holding the workload clusters of the management cluster
+// and the workload clusters. The namespace of the management cluster itself is not moved, it must not hold workload clusters.
+func GetManagementClusterMoveNamespaces(clusterClient clusterclient.Client, managementClusterName string) ([]string, []capi.Cluster, error) {
+	clusters, err := clusterClient.ListClusters("")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to list the clusters of the management cluster")
+	}
+
+	managementClusterNamespace := ""
+	for i := range clusters {
+		if clusters[i].Name == managementClusterName {
+			managementClusterNamespace = clusters[i].Namespace
+			break
+		}
+	}
+
+	namespaces := []string{}
+	isMoved := map[string]bool{}
+	workloadClusters := []capi.Cluster{}
+	for i := range clusters {
+		if clusters[i].Name == managementClusterName && clusters[i].Namespace == managementClusterNamespace {
+			continue
+		}
+		if clusters[i].Namespace == managementClusterNamespace {
+			return nil, nil, errors.Errorf("workload cluster %s is in namespace '%s' of the management cluster, it cannot be moved without the management cluster",
+				clusters[i].Name, managementClusterNamespace)
+		}
+		if !isMoved[clusters[i].Namespace] {
+			isMoved[clusters[i].Namespace] = true
+			namespaces = append(namespaces, clusters[i].Namespace)
+		}
+		workloadClusters = append(workloadClusters, clusters[i])
+	}
+	sort.Strings(namespaces)
+	return namespaces, workloadClusters, nil
+}
+
+// WaitForMovedClustersReconciled waits until the moved clusters are unpaused, reconciled by the controllers of
+// the new management cluster, and ready
+func WaitForMovedClustersReconciled(clusterClient clusterclient.Client, clusters []capi.Cluster, timeout time.Duration) error {
+	pollOptions := &clusterclient.PollOptions{Interval: clusterclient.CheckClusterInterval, Timeout: timeout}
+	for i := range clusters {
+		if err := clusterClient.GetResource(&capi.Cluster{}, clusters[i].Name, clusters[i].Namespace, verifyClusterReconciled, pollOptions); err != nil {
+			return errors.Wrapf(err, "cluster %s is not reconciled by the new management cluster", clusters[i].Name)
+		}
+		log.Infof("Cluster %s is reconciled by the new management cluster", clusters[i].Name)
+	}
+	return nil
+}
+
+// verifyClusterReconciled verifies that the latest spec of the cluster is reconciled and that the cluster is ready
+func verifyClusterReconciled(obj runtime.Object) error {
+	cluster, ok := obj.(*capi.Cluster)
+	if !ok {
+		return errors.Errorf("invalid type: %T during verifyClusterReconciled", obj)
+	}
+	if cluster.Spec.Paused {
+		return errors.Errorf("cluster %s is still paused", cluster.Name)
+	}
+	if cluster.Status.ObservedGeneration < cluster.Generation {
+		return errors.Errorf("cluster %s is still to be reconciled", cluster.Name)
+	}
+	return clusterclient.VerifyClusterReady(cluster)
+}
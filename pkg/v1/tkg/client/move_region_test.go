@@ -0,0 +1,115 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" // nolint:staticcheck
+
+	. "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/fakes"
+	fakehelper "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/fakes/helper"
+)
+
+var _ = Describe("Management cluster move", func() {
+	var (
+		clusterClient clusterclient.Client
+		objects       []runtime.Object
+	)
+
+	newCluster := func(name, namespace string) *capi.Cluster {
+		return &capi.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Generation: 2},
+			Status: capi.ClusterStatus{
+				ObservedGeneration: 2,
+				Conditions: capi.Conditions{
+					{Type: capi.ReadyCondition, Status: corev1.ConditionTrue},
+					{Type: capi.ControlPlaneReadyCondition, Status: corev1.ConditionTrue},
+					{Type: capi.InfrastructureReadyCondition, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		objects = []runtime.Object{
+			newCluster("my-mc", TKGsystemNamespace),
+			newCluster("cluster-1", "team-b"),
+			newCluster("cluster-2", "team-a"),
+			newCluster("cluster-3", "team-a"),
+		}
+	})
+
+	JustBeforeEach(func() {
+		crtClientFactory := &fakes.CrtClientFactory{}
+		crtClientFactory.NewClientReturns(fake.NewFakeClientWithScheme(scheme, objects...), nil)
+		kubeconfig := fakehelper.GetFakeKubeConfigFilePath(testingDir, "../fakes/config/kubeconfig/config1.yaml")
+		var err error
+		clusterClient, err = clusterclient.NewClient(kubeconfig, "", clusterclient.NewOptions(getFakePoller(), crtClientFactory, getFakeDiscoveryFactory(), nil))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("GetManagementClusterMoveNamespaces", func() {
+		It("returns the namespaces of the workload clusters", func() {
+			namespaces, clusters, err := GetManagementClusterMoveNamespaces(clusterClient, "my-mc")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(namespaces).To(Equal([]string{"team-a", "team-b"}))
+			Expect(clusters).To(HaveLen(3))
+			for i := range clusters {
+				Expect(clusters[i].Name).NotTo(Equal("my-mc"))
+			}
+		})
+
+		Context("when a workload cluster is in the namespace of the management cluster", func() {
+			BeforeEach(func() {
+				objects = append(objects, newCluster("cluster-4", TKGsystemNamespace))
+			})
+			It("returns an error", func() {
+				_, _, err := GetManagementClusterMoveNamespaces(clusterClient, "my-mc")
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("cannot be moved without the management cluster"))
+			})
+		})
+	})
+
+	Describe("WaitForMovedClustersReconciled", func() {
+		It("succeeds when the clusters are reconciled and ready", func() {
+			Expect(WaitForMovedClustersReconciled(clusterClient, []capi.Cluster{*newCluster("cluster-2", "team-a")}, time.Second)).To(Succeed())
+		})
+
+		Context("when a cluster is not reconciled yet", func() {
+			BeforeEach(func() {
+				cluster := newCluster("cluster-2", "team-a")
+				cluster.Generation = 3
+				objects = append(objects[:2], cluster)
+			})
+			It("returns an error", func() {
+				err := WaitForMovedClustersReconciled(clusterClient, []capi.Cluster{*newCluster("cluster-2", "team-a")}, time.Second)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("cluster cluster-2 is still to be reconciled"))
+			})
+		})
+
+		Context("when a cluster is still paused", func() {
+			BeforeEach(func() {
+				cluster := newCluster("cluster-2", "team-a")
+				cluster.Spec.Paused = true
+				objects = append(objects[:2], cluster)
+			})
+			It("returns an error", func() {
+				err := WaitForMovedClustersReconciled(clusterClient, []capi.Cluster{*newCluster("cluster-2", "team-a")}, time.Second)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("cluster cluster-2 is still paused"))
+			})
+		})
+	})
+})
@@ -0,0 +1,238 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1alpha3"
+	kubeadmv1beta1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/v1beta1"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+)
+
+// validTaintEffects are the taint effects supported on the nodes of a node pool
+var validTaintEffects = map[corev1.TaintEffect]bool{
+	corev1.TaintEffectNoSchedule:       true,
+	corev1.TaintEffectPreferNoSchedule: true,
+	corev1.TaintEffectNoExecute:        true,
+}
+
+// validateNodePool validates the settings of the node pool which do not depend on the cluster
+func validateNodePool(nodePool *NodePool) error {
+	if nodePool.Name == "" {
+		return errors.New("the name of the node pool is required")
+	}
+	if nodePool.MinSize != nil && *nodePool.MinSize < 0 {
+		return errors.Errorf("minSize of node pool %s cannot be negative", nodePool.Name)
+	}
+	if nodePool.MaxSize != nil && *nodePool.MaxSize < 0 {
+		return errors.Errorf("maxSize of node pool %s cannot be negative", nodePool.Name)
+	}
+	if nodePool.MinSize != nil && nodePool.MaxSize != nil && *nodePool.MinSize > *nodePool.MaxSize {
+		return errors.Errorf("minSize %d of node pool %s is greater than its maxSize %d", *nodePool.MinSize, nodePool.Name, *nodePool.MaxSize)
+	}
+
+	for i := range nodePool.Taints {
+		if nodePool.Taints[i].Key == "" {
+			return errors.Errorf("the key of the taints of node pool %s is required", nodePool.Name)
+		}
+		if !validTaintEffects[nodePool.Taints[i].Effect] {
+			return errors.Errorf("taint effect %q of node pool %s is not supported, the supported effects are %s, %s and %s", nodePool.Taints[i].Effect,
+				nodePool.Name, corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute)
+		}
+	}
+
+	if err := validateRollingUpdateValue("maxSurge", nodePool.MaxSurge); err != nil {
+		return err
+	}
+	if err := validateRollingUpdateValue("maxUnavailable", nodePool.MaxUnavailable); err != nil {
+		return err
+	}
+
+	if nodePool.MachineHealthCheck != nil {
+		if nodePool.MachineHealthCheck.NodeStartupTimeout != "" {
+			if _, err := time.ParseDuration(nodePool.MachineHealthCheck.NodeStartupTimeout); err != nil {
+				return errors.Wrapf(err, "cannot parse the nodeStartupTimeout of node pool %s", nodePool.Name)
+			}
+		}
+		for _, condition := range nodePool.MachineHealthCheck.UnhealthyConditions {
+			if _, err := getUnhealthConditions(condition); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateRollingUpdateValue validates a number or percentage of machines of the rolling updates
+func validateRollingUpdateValue(name, value string) error {
+	if value == "" {
+		return nil
+	}
+	number := strings.TrimSuffix(value, "%")
+	if n, err := strconv.Atoi(number); err != nil || n < 0 {
+		return errors.Errorf("%s %q must be a non-negative number or percentage", name, value)
+	}
+	return nil
+}
+
+// setNodePoolRollingUpdate sets the rolling update strategy of the node pool machine deployment
+func setNodePoolRollingUpdate(md *capi.MachineDeployment, nodePool *NodePool) {
+	if nodePool.MaxSurge == "" && nodePool.MaxUnavailable == "" {
+		return
+	}
+	if md.Spec.Strategy == nil {
+		md.Spec.Strategy = &capi.MachineDeploymentStrategy{Type: capi.RollingUpdateMachineDeploymentStrategyType}
+	}
+	if md.Spec.Strategy.RollingUpdate == nil {
+		md.Spec.Strategy.RollingUpdate = &capi.MachineRollingUpdateDeployment{}
+	}
+	if nodePool.MaxSurge != "" {
+		maxSurge := intstr.Parse(nodePool.MaxSurge)
+		md.Spec.Strategy.RollingUpdate.MaxSurge = &maxSurge
+	}
+	if nodePool.MaxUnavailable != "" {
+		maxUnavailable := intstr.Parse(nodePool.MaxUnavailable)
+		md.Spec.Strategy.RollingUpdate.MaxUnavailable = &maxUnavailable
+	}
+}
+
+// setNodePoolTaints sets the taints the nodes of the node pool register with, nil taints are left unchanged
+func setNodePoolTaints(kcTemplate *v1alpha3.KubeadmConfigTemplate, taints []corev1.Taint) {
+	if taints == nil {
+		return
+	}
+	spec := &kcTemplate.Spec.Template.Spec
+	if spec.JoinConfiguration == nil {
+		spec.JoinConfiguration = &kubeadmv1beta1.JoinConfiguration{}
+	}
+	spec.JoinConfiguration.NodeRegistration.Taints = taints
+}
+
+// setNodePoolAutoscalerBounds sets the autoscaler annotations of the node pool machine deployment and validates them,
+// with the replicas of the node pool, against the cluster autoscaler settings. If the replicas are not set they are
+// brought within the bounds.
+func setNodePoolAutoscalerBounds(clusterClient clusterclient.Client, md *capi.MachineDeployment, options *SetMachineDeploymentOptions) error {
+	autoscalerEnabled, err := isAutoscalerEnabled(clusterClient, options.ClusterName, options.Namespace)
+	if err != nil {
+		return err
+	}
+	if !autoscalerEnabled {
+		if options.MinSize != nil || options.MaxSize != nil {
+			return errors.Errorf("cluster autoscaler is not enabled for cluster %s, the minSize and maxSize of node pool %s cannot be set", options.ClusterName, options.Name)
+		}
+		return nil
+	}
+
+	minSize, err := autoscalerSize(md, constants.AutoscalerMinSizeAnnotation, options.MinSize)
+	if err != nil {
+		return err
+	}
+	maxSize, err := autoscalerSize(md, constants.AutoscalerMaxSizeAnnotation, options.MaxSize)
+	if err != nil {
+		return err
+	}
+	if minSize == nil && maxSize == nil {
+		log.Infof("Node pool %s is not scaled by the cluster autoscaler, set its minSize and maxSize to enable it", options.Name)
+		return nil
+	}
+	if minSize == nil || maxSize == nil {
+		return errors.Errorf("both minSize and maxSize of node pool %s are required for the cluster autoscaler", options.Name)
+	}
+	if *minSize > *maxSize {
+		return errors.Errorf("minSize %d of node pool %s is greater than its maxSize %d", *minSize, options.Name, *maxSize)
+	}
+
+	replicas := int32(1)
+	if md.Spec.Replicas != nil {
+		replicas = *md.Spec.Replicas
+	}
+	if replicas < *minSize || replicas > *maxSize {
+		if options.Replicas != nil {
+			return errors.Errorf("replicas %d of node pool %s are out of its autoscaler bounds [%d, %d]", replicas, options.Name, *minSize, *maxSize)
+		}
+		if replicas < *minSize {
+			replicas = *minSize
+		} else {
+			replicas = *maxSize
+		}
+		log.Infof("Scaling node pool %s to %d replicas to be within its autoscaler bounds", options.Name, replicas)
+		md.Spec.Replicas = &replicas
+	}
+
+	if md.Annotations == nil {
+		md.Annotations = map[string]string{}
+	}
+	md.Annotations[constants.AutoscalerMinSizeAnnotation] = strconv.Itoa(int(*minSize))
+	md.Annotations[constants.AutoscalerMaxSizeAnnotation] = strconv.Itoa(int(*maxSize))
+	return nil
+}
+
+// autoscalerSize returns the size if it is set, otherwise the size of the autoscaler annotation of the machine deployment
+func autoscalerSize(md *capi.MachineDeployment, annotation string, size *int32) (*int32, error) {
+	if size != nil {
+		return size, nil
+	}
+	value, ok := md.Annotations[annotation]
+	if !ok {
+		return nil, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse the annotation %s of machine deployment %s", annotation, md.Name)
+	}
+	annotationSize := int32(n)
+	return &annotationSize, nil
+}
+
+// isAutoscalerEnabled returns true if the cluster autoscaler deployment of the cluster exists on the management cluster
+func isAutoscalerEnabled(clusterClient clusterclient.Client, clusterName, namespace string) (bool, error) {
+	var autoscalerDeployment appsv1.Deployment
+	autoscalerDeploymentName := clusterName + "-cluster-autoscaler"
+	err := clusterClient.GetResource(&autoscalerDeployment, autoscalerDeploymentName, namespace, nil, nil)
+	if err != nil && apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to get autoscaler deployment '%s' from management cluster", autoscalerDeploymentName)
+	}
+	return true, nil
+}
+
+// setNodePoolMachineHealthCheck creates or updates the machine health check of the node pool, named after it
+func (c *TkgClient) setNodePoolMachineHealthCheck(clusterClient clusterclient.Client, options *SetMachineDeploymentOptions) error {
+	mhcOptions := &SetMachineHealthCheckOptions{
+		ClusterName:            options.ClusterName,
+		Namespace:              options.Namespace,
+		MachineHealthCheckName: options.Name,
+		MatchLables:            []string{fmt.Sprintf("%s:%s", NodePoolKey, options.Name)},
+		UnhealthyConditions:    options.MachineHealthCheck.UnhealthyConditions,
+		NodeStartupTimeout:     options.MachineHealthCheck.NodeStartupTimeout,
+	}
+	candidates, err := getMachineHealthCheckCandidates(clusterClient, options.ClusterName, options.Namespace, options.Name, "")
+	if err != nil {
+		return errors.Wrap(err, "unable to get the machine health checks of the cluster")
+	}
+	if len(candidates) == 0 {
+		err = c.CreateMachineHealthCheck(clusterClient, mhcOptions)
+	} else {
+		err = c.UpdateMachineHealthCheck(&candidates[0], clusterClient, mhcOptions)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "unable to set the machine health check of node pool %s", options.Name)
+	}
+	return nil
+}
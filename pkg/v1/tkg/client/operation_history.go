@@ -0,0 +1,242 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"os"
+	"os/user"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/buildinfo"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+)
+
+// Results of the operations in the operation history
+const (
+	OperationResultRunning   = "Running"
+	OperationResultSucceeded = "Succeeded"
+	OperationResultFailed    = "Failed"
+	// OperationResultSubmitted is the result of an operation the CLI did not wait for to complete
+	OperationResultSubmitted = "Submitted"
+)
+
+const (
+	// maxOperationHistoryEntries is the number of operations kept in the history of a cluster, the oldest are dropped
+	maxOperationHistoryEntries = 10
+	// maxOperationMessageLength bounds the size of the error message of a failed operation
+	maxOperationMessageLength = 256
+)
+
+// OperationRecord is an entry of the operation history of a cluster
+type OperationRecord struct {
+	Operation      string `json:"operation" yaml:"operation"`
+	User           string `json:"user" yaml:"user"`
+	CLIVersion     string `json:"cliVersion" yaml:"cliVersion"`
+	StartTimestamp string `json:"startTimestamp" yaml:"startTimestamp"`
+	EndTimestamp   string `json:"endTimestamp,omitempty" yaml:"endTimestamp,omitempty"`
+	Result         string `json:"result" yaml:"result"`
+	Message        string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// GetClusterOperationHistoryOptions contains options supported by GetClusterOperationHistory
+type GetClusterOperationHistoryOptions struct {
+	ClusterName string
+	Namespace   string
+}
+
+// GetClusterOperationHistory returns the lifecycle operations recorded on the cluster, oldest first
+func (c *TkgClient) GetClusterOperationHistory(options GetClusterOperationHistoryOptions) ([]OperationRecord, error) {
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get current management cluster context")
+	}
+	clusterclientOptions := clusterclient.Options{
+		GetClientInterval: 1 * time.Second,
+		GetClientTimeout:  3 * time.Second,
+	}
+	clusterClient, err := clusterclient.NewClient(currentRegion.SourceFilePath, currentRegion.ContextName, clusterclientOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get cluster client while getting cluster operation history")
+	}
+
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+	return GetOperationHistory(clusterClient, options.ClusterName, options.Namespace)
+}
+
+// GetOperationHistory reads the operation history persisted on the cluster object
+func GetOperationHistory(clusterClient clusterclient.Client, clusterName, namespace string) ([]OperationRecord, error) {
+	cluster := &capi.Cluster{}
+	if err := clusterClient.GetResource(cluster, clusterName, namespace, nil, nil); err != nil {
+		return nil, errors.Wrapf(err, "unable to get cluster object '%s' in namespace '%s'", clusterName, namespace)
+	}
+	history, err := parseOperationHistory(cluster.Annotations[clusterclient.TKGOperationHistoryKey])
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse the operation history of cluster '%s'", clusterName)
+	}
+	return history, nil
+}
+
+func parseOperationHistory(historyString string) ([]OperationRecord, error) {
+	history := []OperationRecord{}
+	if historyString == "" {
+		return history, nil
+	}
+	if err := json.Unmarshal([]byte(historyString), &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// operationRecorder records a lifecycle operation in the history of the cluster. Recording is
+// best effort, a failure to update the history never fails the operation.
+type operationRecorder struct {
+	clusterClient clusterclient.Client
+	clusterName   string
+	namespace     string
+	record        OperationRecord
+}
+
+// recordOperationStarted adds the operation to the history of the cluster as running
+func recordOperationStarted(clusterClient clusterclient.Client, clusterName, namespace, operation string) *operationRecorder {
+	r := newOperationRecorder(clusterClient, clusterName, namespace, operation)
+	r.save()
+	return r
+}
+
+// recordOperationSubmitted adds the operation to the history of the cluster as submitted, for the
+// operations the CLI does not wait for and which result is therefore never recorded
+func recordOperationSubmitted(clusterClient clusterclient.Client, clusterName, namespace, operation string) {
+	r := newOperationRecorder(clusterClient, clusterName, namespace, operation)
+	r.record.EndTimestamp = r.record.StartTimestamp
+	r.record.Result = OperationResultSubmitted
+	r.save()
+}
+
+func newOperationRecorder(clusterClient clusterclient.Client, clusterName, namespace, operation string) *operationRecorder {
+	return &operationRecorder{
+		clusterClient: clusterClient,
+		clusterName:   clusterName,
+		namespace:     namespace,
+		record: OperationRecord{
+			Operation:      operation,
+			User:           currentUsername(),
+			CLIVersion:     buildinfo.Version,
+			StartTimestamp: time.Now().UTC().Format(time.RFC3339),
+			Result:         OperationResultRunning,
+		},
+	}
+}
+
+// finished records the result of the operation
+func (r *operationRecorder) finished(err error) {
+	r.record.EndTimestamp = time.Now().UTC().Format(time.RFC3339)
+	r.record.Result = OperationResultSucceeded
+	if err != nil {
+		r.record.Result = OperationResultFailed
+		r.record.Message = truncateOperationMessage(err.Error())
+	}
+	r.save()
+}
+
+// truncateOperationMessage bounds the message to maxOperationMessageLength bytes, cutting it on a rune boundary
+// so that the annotation stays valid UTF-8
+func truncateOperationMessage(message string) string {
+	if len(message) <= maxOperationMessageLength {
+		return message
+	}
+	end := maxOperationMessageLength
+	for end > 0 && !utf8.RuneStart(message[end]) {
+		end--
+	}
+	return message[:end] + "..."
+}
+
+// save records the operation in the history persisted on the cluster object, retrying when the
+// history was updated concurrently, e.g. by another CLI operating on the same cluster
+func (r *operationRecorder) save() {
+	isConflict := func(err error) bool {
+		return apierrors.IsConflict(errors.Cause(err))
+	}
+	if err := retry.OnError(retry.DefaultRetry, isConflict, r.update); err != nil {
+		log.V(6).Infof("unable to record operation '%s' on cluster '%s', %s", r.record.Operation, r.clusterName, err.Error())
+	}
+}
+
+// update replaces the entry of the operation in the history persisted on the cluster object, or appends it.
+// The patch carries the resourceVersion the history was read at, so it fails with a conflict instead of
+// overwriting a history updated in the meantime.
+func (r *operationRecorder) update() error {
+	cluster := &capi.Cluster{}
+	if err := r.clusterClient.GetResource(cluster, r.clusterName, r.namespace, nil, nil); err != nil {
+		return errors.Wrapf(err, "unable to get cluster object '%s' in namespace '%s'", r.clusterName, r.namespace)
+	}
+	history, err := parseOperationHistory(cluster.Annotations[clusterclient.TKGOperationHistoryKey])
+	if err != nil {
+		// an unreadable history is replaced rather than preventing every later operation from being recorded
+		log.V(6).Infof("unable to read the operation history, %s", err.Error())
+		history = []OperationRecord{}
+	}
+
+	history = upsertOperationRecord(history, r.record)
+	historyBytes, err := json.Marshal(history)
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal operation history")
+	}
+	metadata := map[string]interface{}{
+		"annotations": map[string]string{
+			clusterclient.TKGOperationHistoryKey: string(historyBytes),
+		},
+	}
+	if cluster.ResourceVersion != "" {
+		metadata["resourceVersion"] = cluster.ResourceVersion
+	}
+	patch, err := json.Marshal(map[string]interface{}{"metadata": metadata})
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal operation history patch")
+	}
+
+	log.V(6).Infof("patch cluster object with operation history: %s", string(patch))
+	return r.clusterClient.PatchClusterObject(r.clusterName, r.namespace, string(patch))
+}
+
+// upsertOperationRecord replaces the entry of the operation in the history or appends it, and drops
+// the oldest entries over maxOperationHistoryEntries
+func upsertOperationRecord(history []OperationRecord, record OperationRecord) []OperationRecord {
+	updated := false
+	for i := range history {
+		if history[i].Operation == record.Operation && history[i].StartTimestamp == record.StartTimestamp && history[i].User == record.User {
+			history[i] = record
+			updated = true
+		}
+	}
+	if !updated {
+		history = append(history, record)
+	}
+	if len(history) > maxOperationHistoryEntries {
+		history = history[len(history)-maxOperationHistoryEntries:]
+	}
+	return history
+}
+
+// currentUsername returns the name of the user running the CLI
+func currentUsername() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if username := os.Getenv("USER"); username != "" {
+		return username
+	}
+	return "unknown"
+}
@@ -0,0 +1,163 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+)
+
+// historyClusterClient stores the cluster object the operation history is recorded on. Patches not carrying
+// the current resourceVersion fail with a conflict, like they do on the API server.
+type historyClusterClient struct {
+	clusterclient.Client
+	cluster capi.Cluster
+	patches []string
+	// concurrentHistory is recorded by another client before the next patch is applied
+	concurrentHistory string
+}
+
+func (c *historyClusterClient) GetResource(resourceReference interface{}, _, _ string, _ clusterclient.PostVerifyrFunc, _ *clusterclient.PollOptions) error {
+	*resourceReference.(*capi.Cluster) = *c.cluster.DeepCopy()
+	return nil
+}
+
+func (c *historyClusterClient) PatchClusterObject(clusterName, _, patchJSONString string) error {
+	if c.concurrentHistory != "" {
+		c.setHistory(c.concurrentHistory)
+		c.concurrentHistory = ""
+	}
+	c.patches = append(c.patches, patchJSONString)
+	patch := capi.Cluster{}
+	if err := json.Unmarshal([]byte(patchJSONString), &patch); err != nil {
+		return err
+	}
+	if patch.ResourceVersion != c.cluster.ResourceVersion {
+		conflict := apierrors.NewConflict(schema.GroupResource{Resource: "clusters"}, clusterName, errors.New("the object has been modified"))
+		return errors.Wrap(conflict, "unable to patch the cluster object")
+	}
+	c.setHistory(patch.Annotations[clusterclient.TKGOperationHistoryKey])
+	return nil
+}
+
+func (c *historyClusterClient) setHistory(history string) {
+	c.cluster.Annotations = map[string]string{clusterclient.TKGOperationHistoryKey: history}
+	c.cluster.ResourceVersion += "0"
+}
+
+var _ = Describe("Operation history", func() {
+	Describe("upsertOperationRecord", func() {
+		It("appends a new operation and replaces it when it finishes", func() {
+			started := OperationRecord{Operation: "Scale", User: "admin", StartTimestamp: "2021-08-01T10:00:00Z", Result: OperationResultRunning}
+			history := upsertOperationRecord([]OperationRecord{}, started)
+			Expect(history).To(Equal([]OperationRecord{started}))
+
+			finished := started
+			finished.EndTimestamp = "2021-08-01T10:05:00Z"
+			finished.Result = OperationResultSucceeded
+			history = upsertOperationRecord(history, finished)
+			Expect(history).To(Equal([]OperationRecord{finished}))
+		})
+
+		It("keeps only the most recent operations", func() {
+			history := []OperationRecord{}
+			for i := 0; i < maxOperationHistoryEntries+2; i++ {
+				history = upsertOperationRecord(history, OperationRecord{Operation: "Scale", User: "admin", StartTimestamp: fmt.Sprintf("2021-08-01T10:%02d:00Z", i)})
+			}
+			Expect(history).To(HaveLen(maxOperationHistoryEntries))
+			Expect(history[0].StartTimestamp).To(Equal("2021-08-01T10:02:00Z"))
+			Expect(history[maxOperationHistoryEntries-1].StartTimestamp).To(Equal("2021-08-01T10:11:00Z"))
+		})
+	})
+
+	Describe("parseOperationHistory", func() {
+		It("returns an empty history if none was recorded", func() {
+			history, err := parseOperationHistory("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history).To(BeEmpty())
+		})
+
+		It("parses the recorded operations", func() {
+			history, err := parseOperationHistory(`[{"operation":"Create","user":"admin","cliVersion":"v1.4.0","startTimestamp":"2021-08-01T10:00:00Z","result":"Failed","message":"timeout"}]`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history).To(Equal([]OperationRecord{
+				{Operation: "Create", User: "admin", CLIVersion: "v1.4.0", StartTimestamp: "2021-08-01T10:00:00Z", Result: OperationResultFailed, Message: "timeout"},
+			}))
+		})
+
+		It("returns an error for an invalid history", func() {
+			_, err := parseOperationHistory("not-json")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("truncateOperationMessage", func() {
+		It("keeps a message within the bound", func() {
+			Expect(truncateOperationMessage("cluster creation failed")).To(Equal("cluster creation failed"))
+		})
+
+		It("truncates a long message on a rune boundary", func() {
+			message := strings.Repeat("a", maxOperationMessageLength-1) + "é is not ascii"
+			truncated := truncateOperationMessage(message)
+			Expect(utf8.ValidString(truncated)).To(BeTrue())
+			Expect(truncated).To(Equal(strings.Repeat("a", maxOperationMessageLength-1) + "..."))
+		})
+	})
+
+	Describe("operationRecorder", func() {
+		var clusterClient *historyClusterClient
+
+		BeforeEach(func() {
+			clusterClient = &historyClusterClient{}
+			clusterClient.cluster.Name = "my-cluster"
+			clusterClient.cluster.ResourceVersion = "1"
+		})
+
+		history := func() []OperationRecord {
+			history, err := GetOperationHistory(clusterClient, "my-cluster", "default")
+			Expect(err).NotTo(HaveOccurred())
+			return history
+		}
+
+		It("records the start and the result of the operation", func() {
+			recorder := recordOperationStarted(clusterClient, "my-cluster", "default", "Scale")
+			Expect(history()).To(HaveLen(1))
+			Expect(history()[0].Result).To(Equal(OperationResultRunning))
+
+			recorder.finished(errors.New("timeout"))
+			Expect(history()).To(HaveLen(1))
+			Expect(history()[0].Result).To(Equal(OperationResultFailed))
+			Expect(history()[0].Message).To(Equal("timeout"))
+		})
+
+		It("records an operation which is not waited for as submitted", func() {
+			recordOperationSubmitted(clusterClient, "my-cluster", "default", "Create")
+			Expect(history()).To(HaveLen(1))
+			Expect(history()[0].Result).To(Equal(OperationResultSubmitted))
+			Expect(history()[0].EndTimestamp).To(Equal(history()[0].StartTimestamp))
+		})
+
+		It("does not overwrite the operations recorded concurrently", func() {
+			clusterClient.concurrentHistory = `[{"operation":"Upgrade","user":"admin","startTimestamp":"2021-08-01T10:00:00Z","result":"Running"}]`
+			recordOperationStarted(clusterClient, "my-cluster", "default", "Scale")
+			Expect(clusterClient.patches).To(HaveLen(2))
+			Expect(clusterClient.patches[0]).To(ContainSubstring(`"resourceVersion":"1"`))
+			Expect(clusterClient.patches[1]).To(ContainSubstring(`"resourceVersion":"10"`))
+			Expect(history()).To(HaveLen(2))
+			Expect(history()[0].Operation).To(Equal("Upgrade"))
+			Expect(history()[1].Operation).To(Equal("Scale"))
+		})
+	})
+})
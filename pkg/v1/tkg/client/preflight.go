@@ -0,0 +1,177 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Statuses of the preflight checks, ordered from the healthiest for pass, warn and fail
+const (
+	PreflightStatusPass = "pass"
+	PreflightStatusWarn = "warn"
+	PreflightStatusFail = "fail"
+	PreflightStatusSkip = "skip"
+)
+
+// Categories of the preflight checks
+const (
+	PreflightCategoryConfig      = "Config"
+	PreflightCategoryCredentials = "Credentials"
+	PreflightCategoryQuota       = "Quota"
+	PreflightCategoryNetwork     = "Network"
+	PreflightCategoryImage       = "Image"
+	PreflightCategoryDocker      = "Docker"
+)
+
+// PreflightCheck is a named check run before creating a cluster
+type PreflightCheck struct {
+	Name     string
+	Category string
+	// Requires are the names of the checks registered before this one which must pass or warn for it to run,
+	// it is skipped otherwise
+	Requires []string
+	// Configures is set for the checks which update the config variables. The config variables are not safe
+	// for concurrent use, so these checks run first, one after the other in their registration order.
+	Configures bool
+	// Run returns an error if the check fails, or an error created with NewPreflightWarning if it only warns
+	Run func() error
+}
+
+// PreflightReport is the result of the preflight checks
+type PreflightReport struct {
+	Status string                 `json:"status" yaml:"status"`
+	Checks []PreflightCheckResult `json:"checks" yaml:"checks"`
+}
+
+// PreflightCheckResult is the result of a preflight check
+type PreflightCheckResult struct {
+	Category string `json:"category" yaml:"category"`
+	Name     string `json:"name" yaml:"name"`
+	Status   string `json:"status" yaml:"status"`
+	Message  string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// preflightWarning is returned by the preflight checks which warn instead of failing
+type preflightWarning struct {
+	message string
+}
+
+func (w *preflightWarning) Error() string {
+	return w.message
+}
+
+// NewPreflightWarning returns an error reporting a warning of a preflight check
+func NewPreflightWarning(format string, args ...interface{}) error {
+	return &preflightWarning{message: fmt.Sprintf(format, args...)}
+}
+
+// RunPreflightChecks runs the preflight checks, except the skipped ones, and reports the result of all of them.
+// The checks updating the config variables run first in their registration order, then the other checks run
+// concurrently, each one waiting for the checks it requires.
+func RunPreflightChecks(checks []PreflightCheck, skipChecks []string) (*PreflightReport, error) {
+	index := map[string]int{}
+	names := []string{}
+	for i := range checks {
+		if _, registered := index[checks[i].Name]; registered {
+			return nil, errors.Errorf("preflight check '%s' is registered more than once", checks[i].Name)
+		}
+		// requiring only checks registered before rules out dependency cycles
+		for _, required := range checks[i].Requires {
+			j, registered := index[required]
+			if !registered {
+				return nil, errors.Errorf("preflight check '%s' requires check '%s' which is not registered before it", checks[i].Name, required)
+			}
+			if checks[i].Configures && !checks[j].Configures {
+				return nil, errors.Errorf("preflight check '%s' updates the config and cannot require check '%s' which does not", checks[i].Name, required)
+			}
+		}
+		index[checks[i].Name] = i
+		names = append(names, checks[i].Name)
+	}
+
+	skipped := map[string]bool{}
+	for _, name := range skipChecks {
+		if _, registered := index[name]; !registered {
+			return nil, errors.Errorf("unknown preflight check '%s', the preflight checks are: %s", name, strings.Join(names, ", "))
+		}
+		skipped[name] = true
+	}
+
+	results := make([]PreflightCheckResult, len(checks))
+	done := make([]chan struct{}, len(checks))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	run := func(i int) {
+		defer close(done[i])
+		results[i] = runPreflightCheck(&checks[i], skipped[checks[i].Name], func(required string) string {
+			<-done[index[required]]
+			return results[index[required]].Status
+		})
+	}
+
+	for i := range checks {
+		if checks[i].Configures {
+			run(i)
+		}
+	}
+	var wg sync.WaitGroup
+	for i := range checks {
+		if checks[i].Configures {
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			run(i)
+		}(i)
+	}
+	wg.Wait()
+
+	report := &PreflightReport{Status: PreflightStatusPass, Checks: results}
+	for i := range results {
+		if results[i].Status == PreflightStatusFail {
+			report.Status = PreflightStatusFail
+		} else if results[i].Status == PreflightStatusWarn && report.Status == PreflightStatusPass {
+			report.Status = PreflightStatusWarn
+		}
+	}
+	return report, nil
+}
+
+// runPreflightCheck runs a preflight check once the checks it requires are done, waitFor waits for
+// a required check and returns its status
+func runPreflightCheck(check *PreflightCheck, skip bool, waitFor func(string) string) PreflightCheckResult {
+	result := PreflightCheckResult{Category: check.Category, Name: check.Name}
+	if skip {
+		result.Status = PreflightStatusSkip
+		result.Message = "skipped on request"
+		return result
+	}
+	for _, required := range check.Requires {
+		if status := waitFor(required); status != PreflightStatusPass && status != PreflightStatusWarn {
+			result.Status = PreflightStatusSkip
+			result.Message = fmt.Sprintf("requires check %s to pass", required)
+			return result
+		}
+	}
+
+	err := check.Run()
+	if err == nil {
+		result.Status = PreflightStatusPass
+		return result
+	}
+	result.Message = err.Error()
+	if _, warning := err.(*preflightWarning); warning {
+		result.Status = PreflightStatusWarn
+	} else {
+		result.Status = PreflightStatusFail
+	}
+	return result
+}
@@ -0,0 +1,698 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/aws"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/azure"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgconfigbom"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/vc"
+)
+
+const (
+	// preflightMinDockerCPUs and preflightMinDockerMemory are the resources the Docker engine needs to run
+	// a cluster of the Docker infrastructure provider
+	preflightMinDockerCPUs   = 4
+	preflightMinDockerMemory = 6 * 1024 * 1024 * 1024
+	// preflightAWSVPCQuota is the default quota of VPCs per AWS region
+	preflightAWSVPCQuota = 5
+	// preflightDialTimeout is the timeout of the network reachability checks
+	preflightDialTimeout = 5 * time.Second
+)
+
+// preflightState holds what the preflight checks of a cluster find for the checks which require them
+type preflightState struct {
+	providerName      string
+	tkrVersion        string
+	kubernetesVersion string
+	vcClient          vc.Client
+	awsClient         aws.Client
+	azureClient       azure.Client
+}
+
+// RunManagementClusterPreflight runs the preflight checks of a management cluster, except the skipped ones
+func (c *TkgClient) RunManagementClusterPreflight(options *InitRegionOptions, skipChecks []string) (*PreflightReport, error) {
+	providerName, _, err := ParseProviderName(options.InfrastructureProvider)
+	if err != nil {
+		return nil, err
+	}
+	return RunPreflightChecks(c.managementClusterPreflightChecks(options, providerName), skipChecks)
+}
+
+// RunClusterPreflight runs the preflight checks of a workload cluster of the current management cluster,
+// except the skipped ones
+func (c *TkgClient) RunClusterPreflight(options *CreateClusterOptions, skipChecks []string) (*PreflightReport, error) {
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get current management cluster context")
+	}
+	clusterclientOptions := clusterclient.Options{
+		GetClientInterval: 1 * time.Second,
+		GetClientTimeout:  3 * time.Second,
+		OperationTimeout:  c.timeout,
+	}
+	regionalClusterClient, err := clusterclient.NewClient(currentRegion.SourceFilePath, currentRegion.ContextName, clusterclientOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get cluster client while running preflight checks")
+	}
+
+	isPacific, err := regionalClusterClient.IsPacificRegionalCluster()
+	if err != nil {
+		return nil, errors.Wrap(err, "error determining 'Tanzu Kubernetes Cluster service for vSphere' management cluster")
+	}
+	if isPacific {
+		return nil, errors.New("preflight checks of 'Tanzu Kubernetes Cluster service for vSphere' clusters are not yet supported")
+	}
+
+	infraProvider := options.ProviderRepositorySource.InfrastructureProvider
+	if infraProvider == "" {
+		if infraProvider, err = regionalClusterClient.GetRegionalClusterDefaultProviderName(clusterctlv1.InfrastructureProviderType); err != nil {
+			return nil, errors.Wrap(err, "failed to get cluster provider information")
+		}
+	}
+	providerName, _, err := ParseProviderName(infraProvider)
+	if err != nil {
+		return nil, err
+	}
+	return RunPreflightChecks(c.clusterPreflightChecks(options, providerName, regionalClusterClient), skipChecks)
+}
+
+func (c *TkgClient) managementClusterPreflightChecks(options *InitRegionOptions, providerName string) []PreflightCheck { // nolint:funlen
+	state := &preflightState{providerName: providerName}
+	checks := []PreflightCheck{
+		{
+			Name:       "cluster-name",
+			Category:   PreflightCategoryConfig,
+			Configures: true,
+			Run: func() error {
+				if options.ClusterName == "" {
+					return nil
+				}
+				if err := checkClusterNameFormat(options.ClusterName); err != nil {
+					return err
+				}
+				regions, err := c.regionManager.ListRegionContexts()
+				if err != nil {
+					return errors.Wrap(err, "unable to verify cluster name uniqueness")
+				}
+				for i := range regions {
+					if regions[i].ClusterName == options.ClusterName {
+						return errors.Errorf("cluster name %s matches another management cluster", options.ClusterName)
+					}
+				}
+				return nil
+			},
+		},
+		c.preflightPlanCheck(options.Plan),
+		c.preflightEditionCheck(options.Edition, ManagementCluster),
+		c.preflightKubernetesReleaseCheck(state, ""),
+		{
+			Name:       "infrastructure-provider",
+			Category:   PreflightCategoryConfig,
+			Configures: true,
+			Run: func() error {
+				infraProvider, err := c.tkgConfigUpdaterClient.CheckInfrastructureVersion(options.InfrastructureProvider)
+				if err != nil {
+					return errors.Wrap(err, "unable to check infrastructure provider version")
+				}
+				options.InfrastructureProvider = infraProvider
+				return nil
+			},
+		},
+	}
+	checks = append(checks, c.preflightNetworkConfigChecks(options.CniType, providerName)...)
+
+	isProdPlan := options.Plan == constants.PlanProd
+	_, workerMachineCount := c.getMachineCountForMC(options.Plan)
+	checks = append(checks, c.preflightProviderConfigCheck(state, options.NodeSizeOptions, options.VsphereControlPlaneEndpoint, isProdPlan, int64(workerMachineCount), true, nil, "kubernetes-release", "infrastructure-provider"))
+	checks = append(checks, c.preflightProviderChecks(state, nil)...)
+
+	if providerName == VSphereProviderName {
+		checks = append(checks, PreflightCheck{
+			Name:     "control-plane-endpoint",
+			Category: PreflightCategoryNetwork,
+			Requires: []string{"provider-config"},
+			Run: func() error {
+				vip, _ := c.TKGConfigReaderWriter().Get(constants.ConfigVariableVsphereControlPlaneEndpoint)
+				if vip == "" {
+					return nil
+				}
+				if err := c.ValidateVsphereControlPlaneEndpointIP(vip); err != nil {
+					return NewPreflightWarning("the control plane endpoint '%s' might already be used by another cluster: %s", vip, err.Message)
+				}
+				return nil
+			},
+		})
+	}
+	checks = append(checks, c.preflightImageChecks(state)...)
+
+	// the bootstrap cluster runs on the local Docker engine unless an existing cluster is used
+	if !options.UseExistingCluster || providerName == DockerProviderName {
+		checks = append(checks, c.preflightDockerChecks(providerName)...)
+	}
+	return checks
+}
+
+func (c *TkgClient) clusterPreflightChecks(options *CreateClusterOptions, providerName string, regionalClusterClient clusterclient.Client) []PreflightCheck { // nolint:funlen
+	state := &preflightState{providerName: providerName}
+	if options.ClusterType == "" {
+		options.ClusterType = WorkloadCluster
+	}
+	checks := []PreflightCheck{
+		{
+			Name:       "cluster-name",
+			Category:   PreflightCategoryConfig,
+			Configures: true,
+			Run: func() error {
+				if err := checkClusterNameFormat(options.ClusterName); err != nil {
+					return err
+				}
+				clusters, err := regionalClusterClient.ListClusters("")
+				if err != nil {
+					return errors.Wrap(err, "unable to get list of workload clusters managed by current management cluster")
+				}
+				for i := range clusters {
+					if clusters[i].Name == options.ClusterName {
+						return errors.Errorf("cluster with name %s already exists", options.ClusterName)
+					}
+				}
+				return nil
+			},
+		},
+		c.preflightPlanCheck(options.ProviderRepositorySource.Flavor),
+		c.preflightEditionCheck(options.Edition, options.ClusterType),
+		{
+			Name:       "management-cluster-version",
+			Category:   PreflightCategoryConfig,
+			Configures: true,
+			Run: func() error {
+				return c.ValidateManagementClusterVersionWithCLI(regionalClusterClient)
+			},
+		},
+		c.preflightKubernetesReleaseCheck(state, options.TKRVersion),
+		{
+			Name:     "kubernetes-version-support",
+			Category: PreflightCategoryConfig,
+			Requires: []string{"management-cluster-version", "kubernetes-release"},
+			Run: func() error {
+				return c.ValidateSupportOfK8sVersionForManagmentCluster(regionalClusterClient, state.kubernetesVersion, false)
+			},
+		},
+		{
+			Name:       "cluster-options",
+			Category:   PreflightCategoryConfig,
+			Configures: true,
+			Run: func() error {
+				return c.ValidateAndConfigureClusterOptions(options)
+			},
+		},
+	}
+	if options.ProviderRepositorySource.InfrastructureProvider != "" {
+		checks = append(checks, PreflightCheck{
+			Name:       "infrastructure-provider",
+			Category:   PreflightCategoryConfig,
+			Configures: true,
+			Run: func() error {
+				infraProvider, err := c.tkgConfigUpdaterClient.CheckInfrastructureVersion(options.ProviderRepositorySource.InfrastructureProvider)
+				if err != nil {
+					return errors.Wrap(err, "unable to check infrastructure provider version")
+				}
+				options.ProviderRepositorySource.InfrastructureProvider = infraProvider
+				return nil
+			},
+		})
+	}
+	checks = append(checks, c.preflightNetworkConfigChecks(options.CniType, providerName)...)
+
+	var workerMachineCount int64
+	if options.WorkerMachineCount != nil {
+		workerMachineCount = *options.WorkerMachineCount
+	}
+	isProdPlan := options.ProviderRepositorySource.Flavor == constants.PlanProd
+	checks = append(checks, c.preflightProviderConfigCheck(state, options.NodeSizeOptions, options.VsphereControlPlaneEndpoint, isProdPlan, workerMachineCount, false, regionalClusterClient, "kubernetes-release"))
+	checks = append(checks, c.preflightProviderChecks(state, regionalClusterClient)...)
+
+	if providerName == VSphereProviderName {
+		checks = append(checks, PreflightCheck{
+			Name:     "control-plane-endpoint",
+			Category: PreflightCategoryNetwork,
+			Requires: []string{"provider-config"},
+			Run: func() error {
+				vip, _ := c.TKGConfigReaderWriter().Get(constants.ConfigVariableVsphereControlPlaneEndpoint)
+				if vip == "" {
+					return nil
+				}
+				return c.ValidateVsphereVipWorkloadCluster(regionalClusterClient, vip, false)
+			},
+		})
+	}
+	checks = append(checks, c.preflightImageChecks(state)...)
+
+	if providerName == DockerProviderName {
+		checks = append(checks, c.preflightDockerChecks(providerName)...)
+	}
+	return checks
+}
+
+func (c *TkgClient) preflightPlanCheck(plan string) PreflightCheck {
+	return PreflightCheck{
+		Name:       "plan",
+		Category:   PreflightCategoryConfig,
+		Configures: true,
+		Run: func() error {
+			if plan == "" {
+				return errors.New("required config variable 'CLUSTER_PLAN' is not set")
+			}
+			return nil
+		},
+	}
+}
+
+func (c *TkgClient) preflightEditionCheck(edition string, clusterType TKGClusterType) PreflightCheck {
+	return PreflightCheck{
+		Name:       "edition",
+		Category:   PreflightCategoryConfig,
+		Configures: true,
+		Run: func() error {
+			if edition == "" {
+				return errors.New("required config variable 'BUILD_EDITION' is not set")
+			}
+			c.SetBuildEdition(edition)
+			c.SetTKGClusterRole(clusterType)
+			c.SetTKGVersion()
+			return nil
+		},
+	}
+}
+
+func (c *TkgClient) preflightKubernetesReleaseCheck(state *preflightState, tkrVersion string) PreflightCheck {
+	return PreflightCheck{
+		Name:       "kubernetes-release",
+		Category:   PreflightCategoryConfig,
+		Configures: true,
+		Run: func() error {
+			k8sVersion, resolvedTkrVersion, err := c.ConfigureAndValidateTkrVersion(tkrVersion)
+			if err != nil {
+				return err
+			}
+			state.kubernetesVersion = k8sVersion
+			state.tkrVersion = resolvedTkrVersion
+			return nil
+		},
+	}
+}
+
+// preflightNetworkConfigChecks returns the checks of the CNI, IP family and proxy config variables
+func (c *TkgClient) preflightNetworkConfigChecks(cniType, providerName string) []PreflightCheck {
+	return []PreflightCheck{
+		{
+			Name:       "cni",
+			Category:   PreflightCategoryConfig,
+			Configures: true,
+			Run: func() error {
+				return c.ConfigureAndValidateCNIType(cniType)
+			},
+		},
+		{
+			Name:       "ip-family",
+			Category:   PreflightCategoryConfig,
+			Configures: true,
+			Run: func() error {
+				return c.configureAndValidateIPFamilyConfiguration()
+			},
+		},
+		{
+			Name:       "http-proxy",
+			Category:   PreflightCategoryConfig,
+			Configures: true,
+			Run: func() error {
+				return c.ConfigureAndValidateHTTPProxyConfiguration(providerName)
+			},
+		},
+		{
+			Name:     "http-proxy-reachability",
+			Category: PreflightCategoryNetwork,
+			Requires: []string{"http-proxy"},
+			Run: func() error {
+				proxyEnabled, err := c.TKGConfigReaderWriter().Get(constants.TKGHTTPProxyEnabled)
+				if err != nil || proxyEnabled != trueString {
+					return nil
+				}
+				httpProxy, _ := c.TKGConfigReaderWriter().Get(constants.TKGHTTPProxy)
+				httpsProxy, _ := c.TKGConfigReaderWriter().Get(constants.TKGHTTPSProxy)
+				return checkProxiesReachable(httpProxy, httpsProxy)
+			},
+		},
+	}
+}
+
+// preflightProviderConfigCheck returns the check configuring the config variables of the infrastructure provider,
+// without the validations talking to the infrastructure which are left to the other provider checks
+func (c *TkgClient) preflightProviderConfigCheck(state *preflightState, nodeSizes NodeSizeOptions, vip string, isProdPlan bool, workerMachineCount int64, isManagementCluster bool, regionalClusterClient clusterclient.Client, requires ...string) PreflightCheck {
+	return PreflightCheck{
+		Name:       "provider-config",
+		Category:   PreflightCategoryConfig,
+		Requires:   requires,
+		Configures: true,
+		Run: func() error {
+			switch state.providerName {
+			case AWSProviderName:
+				c.SetProviderType(AWSProviderName)
+				if err := c.OverrideAWSNodeSizeWithOptions(nodeSizes, nil, true); err != nil {
+					return err
+				}
+				vpcID, _ := c.TKGConfigReaderWriter().Get(constants.ConfigVariableAWSVPCID)
+				return c.ConfigureAndValidateAwsConfig(state.tkrVersion, true, isProdPlan, workerMachineCount, isManagementCluster, vpcID != "")
+			case VSphereProviderName:
+				// the vSphere credentials of a workload cluster are the ones of its management cluster
+				if regionalClusterClient != nil {
+					if err := c.configureVsphereCredentialsFromCluster(regionalClusterClient); err != nil {
+						return err
+					}
+				}
+				if err := c.ConfigureAndValidateVsphereConfig(state.tkrVersion, nodeSizes, vip, true, nil); err != nil {
+					return err
+				}
+				return nil
+			case AzureProviderName:
+				return c.ConfigureAndValidateAzureConfig(state.tkrVersion, nodeSizes, true, isProdPlan, workerMachineCount, nil, isManagementCluster)
+			case DockerProviderName:
+				return c.ConfigureAndValidateDockerConfig(state.tkrVersion, nodeSizes, true)
+			}
+			return nil
+		},
+	}
+}
+
+// preflightProviderChecks returns the checks of the credentials, quotas and resources of the infrastructure provider
+func (c *TkgClient) preflightProviderChecks(state *preflightState, regionalClusterClient clusterclient.Client) []PreflightCheck { // nolint:funlen
+	switch state.providerName {
+	case VSphereProviderName:
+		return []PreflightCheck{
+			{
+				Name:       "provider-credentials",
+				Category:   PreflightCategoryCredentials,
+				Requires:   []string{"provider-config"},
+				Configures: true,
+				Run: func() error {
+					vcClient, err := c.GetVSphereEndpoint(nil)
+					if err != nil {
+						return errors.Wrap(err, "unable to verify vSphere credentials")
+					}
+					state.vcClient = vcClient
+					return nil
+				},
+			},
+			{
+				Name:     "vsphere-resources",
+				Category: PreflightCategoryQuota,
+				Requires: []string{"provider-credentials"},
+				Run: func() error {
+					dc, err := c.TKGConfigReaderWriter().Get(constants.ConfigVariableVsphereDatacenter)
+					if err != nil {
+						return errors.Errorf("failed to get %s", constants.ConfigVariableVsphereDatacenter)
+					}
+					return c.ValidateVsphereResources(state.vcClient, dc)
+				},
+			},
+			{
+				Name:     "vsphere-template",
+				Category: PreflightCategoryImage,
+				Requires: []string{"provider-credentials", "kubernetes-release"},
+				Run: func() error {
+					dc, err := c.TKGConfigReaderWriter().Get(constants.ConfigVariableVsphereDatacenter)
+					if err != nil {
+						return errors.Errorf("failed to get %s", constants.ConfigVariableVsphereDatacenter)
+					}
+					tkrBom, err := c.tkgBomClient.GetBOMConfigurationFromTkrVersion(state.tkrVersion)
+					if err != nil {
+						return err
+					}
+					templateName, _ := c.TKGConfigReaderWriter().Get(constants.ConfigVariableVsphereTemplate)
+					vm, err := state.vcClient.GetAndValidateVirtualMachineTemplate(tkrBom.GetOVAVersions(), state.tkrVersion, templateName, dc, c.TKGConfigReaderWriter())
+					if err != nil || vm == nil {
+						return errors.Wrap(err, "unable to get or validate VM Template for given Tanzu Kubernetes release")
+					}
+					return nil
+				},
+			},
+		}
+	case AWSProviderName:
+		return []PreflightCheck{
+			{
+				Name:       "provider-credentials",
+				Category:   PreflightCategoryCredentials,
+				Requires:   []string{"provider-config"},
+				Configures: true,
+				Run: func() error {
+					awsClient, err := c.EncodeAWSCredentialsAndGetClient(nil)
+					if err != nil {
+						return err
+					}
+					if err := awsClient.VerifyAccount(); err != nil {
+						return errors.Wrap(err, "unable to verify AWS credentials")
+					}
+					state.awsClient = awsClient
+					return nil
+				},
+			},
+			{
+				Name:     "aws-instance-types",
+				Category: PreflightCategoryQuota,
+				Requires: []string{"provider-credentials"},
+				Run: func() error {
+					return c.validateAwsInstanceTypes(state.awsClient)
+				},
+			},
+			{
+				Name:     "aws-vpc-quota",
+				Category: PreflightCategoryQuota,
+				Requires: []string{"provider-credentials"},
+				Run: func() error {
+					if vpcID, _ := c.TKGConfigReaderWriter().Get(constants.ConfigVariableAWSVPCID); vpcID != "" {
+						return nil
+					}
+					vpcs, err := state.awsClient.ListVPCs()
+					if err != nil {
+						return errors.Wrap(err, "unable to list the VPCs")
+					}
+					if len(vpcs) >= preflightAWSVPCQuota {
+						return NewPreflightWarning("a new VPC is to be created and there are already %d VPCs in the region, which is the default quota", len(vpcs))
+					}
+					return nil
+				},
+			},
+		}
+	case AzureProviderName:
+		return []PreflightCheck{
+			{
+				Name:       "provider-credentials",
+				Category:   PreflightCategoryCredentials,
+				Requires:   []string{"provider-config"},
+				Configures: true,
+				Run: func() error {
+					azureClient, err := c.EncodeAzureCredentialsAndGetClient(regionalClusterClient)
+					if err != nil {
+						return errors.Wrap(err, "failed to initialize Azure client")
+					}
+					if err := azureClient.VerifyAccount(context.Background()); err != nil {
+						return errors.Wrap(err, "unable to verify Azure credentials")
+					}
+					state.azureClient = azureClient
+					return nil
+				},
+			},
+			{
+				Name:     "azure-vm-sizes",
+				Category: PreflightCategoryQuota,
+				Requires: []string{"provider-credentials"},
+				Run: func() error {
+					location, err := c.TKGConfigReaderWriter().Get(constants.ConfigVariableAzureLocation)
+					if err != nil {
+						return errors.Errorf("failed to get %s", constants.ConfigVariableAzureLocation)
+					}
+					instanceTypes, err := state.azureClient.GetAzureInstanceTypesForRegion(context.Background(), location)
+					if err != nil {
+						return errors.Wrapf(err, "unable to list the VM sizes of location %s", location)
+					}
+					available := map[string]bool{}
+					for _, instanceType := range instanceTypes {
+						available[instanceType.Name] = true
+					}
+					for _, variable := range []string{constants.ConfigVariableAzureCPMachineType, constants.ConfigVariableAzureNodeMachineType} {
+						if size, _ := c.TKGConfigReaderWriter().Get(variable); size != "" && !available[size] {
+							return errors.Errorf("VM size %s of %s is not available in location %s", size, variable, location)
+						}
+					}
+					return nil
+				},
+			},
+		}
+	}
+	return nil
+}
+
+// preflightImageChecks returns the checks of the availability of the images of the Kubernetes release
+func (c *TkgClient) preflightImageChecks(state *preflightState) []PreflightCheck {
+	return []PreflightCheck{
+		{
+			Name:     "kubernetes-images",
+			Category: PreflightCategoryImage,
+			Requires: []string{"kubernetes-release"},
+			Run: func() error {
+				tkrBom, err := c.tkgBomClient.GetBOMConfigurationFromTkrVersion(state.tkrVersion)
+				if err != nil {
+					return err
+				}
+				components := []string{"kubernetes", "etcd", "coredns"}
+				if state.providerName == DockerProviderName {
+					components = append(components, "kubernetes-sigs_kind")
+				}
+				bomRegistry, err := c.tkgBomClient.InitBOMRegistry()
+				if err != nil {
+					return errors.Wrap(err, "unable to initialize the image registry client")
+				}
+				return checkBOMImagesAvailable(bomRegistry, tkrBom, components)
+			},
+		},
+	}
+}
+
+func (c *TkgClient) preflightDockerChecks(providerName string) []PreflightCheck {
+	checks := []PreflightCheck{
+		{
+			Name:     "docker-daemon",
+			Category: PreflightCategoryDocker,
+			Run: func() error {
+				return c.validateDockerPrerequisites()
+			},
+		},
+	}
+	if providerName == DockerProviderName {
+		checks = append(checks, PreflightCheck{
+			Name:     "docker-resources",
+			Category: PreflightCategoryDocker,
+			Requires: []string{"docker-daemon"},
+			Run:      checkDockerResources,
+		})
+	}
+	return checks
+}
+
+// imageTagLister lists the tags of an image, it is implemented by the registry client of the BOM
+type imageTagLister interface {
+	ListImageTags(imageName string) ([]string, error)
+}
+
+// checkBOMImagesAvailable verifies that the images of the components of a BOM are available in their registry
+func checkBOMImagesAvailable(bomRegistry imageTagLister, bom *tkgconfigbom.BOMConfiguration, components []string) error {
+	images := map[string]string{}
+	for _, component := range components {
+		for _, componentInfo := range bom.Components[component] {
+			for _, image := range componentInfo.Images {
+				images[tkgconfigbom.GetFullImagePath(image, bom.ImageConfig.ImageRepository)] = image.Tag
+			}
+		}
+	}
+	imagePaths := make([]string, 0, len(images))
+	for imagePath := range images {
+		imagePaths = append(imagePaths, imagePath)
+	}
+	sort.Strings(imagePaths)
+
+	missing := []string{}
+	for _, imagePath := range imagePaths {
+		tags, err := bomRegistry.ListImageTags(imagePath)
+		if err != nil {
+			return errors.Wrapf(err, "unable to list the tags of image %s", imagePath)
+		}
+		found := false
+		for _, tag := range tags {
+			if tag == images[imagePath] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, imagePath+":"+images[imagePath])
+		}
+	}
+	if len(missing) != 0 {
+		return errors.Errorf("images not found: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// checkProxiesReachable verifies that a TCP connection can be opened to the proxies
+func checkProxiesReachable(proxies ...string) error {
+	for _, proxy := range proxies {
+		if proxy == "" {
+			continue
+		}
+		proxyURL, err := url.Parse(proxy)
+		if err != nil {
+			return errors.Wrap(err, "unable to parse the proxy URL")
+		}
+		port := proxyURL.Port()
+		if port == "" {
+			port = "80"
+			if proxyURL.Scheme == "https" {
+				port = "443"
+			}
+		}
+		conn, err := net.DialTimeout("tcp", net.JoinHostPort(proxyURL.Hostname(), port), preflightDialTimeout)
+		if err != nil {
+			return errors.Wrapf(err, "proxy %s is not reachable", proxyURL.Host)
+		}
+		conn.Close()
+	}
+	return nil
+}
+
+// checkDockerResources warns if the Docker engine has less CPUs or memory than a cluster needs
+func checkDockerResources() error {
+	var stdout bytes.Buffer
+	cmd := exec.Command("docker", "info", "--format", "{{.NCPU}} {{.MemTotal}}")
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(err, "failed to get the resources of the Docker engine")
+	}
+	fields := strings.Fields(stdout.String())
+	if len(fields) != 2 { // nolint:gomnd
+		return errors.Errorf("unexpected output of docker info: %s", stdout.String())
+	}
+	cpus, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return errors.Wrap(err, "unable to parse the CPUs of the Docker engine")
+	}
+	memory, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "unable to parse the memory of the Docker engine")
+	}
+	if cpus < preflightMinDockerCPUs || memory < preflightMinDockerMemory {
+		return NewPreflightWarning("the Docker engine has %d CPUs and %s of memory, at least %d CPUs and %s are recommended",
+			cpus, formatGiB(memory), preflightMinDockerCPUs, formatGiB(preflightMinDockerMemory))
+	}
+	return nil
+}
+
+func formatGiB(size int64) string {
+	return fmt.Sprintf("%.1fGiB", float64(size)/(1024*1024*1024))
+}
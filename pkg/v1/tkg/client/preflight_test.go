@@ -0,0 +1,222 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgconfigbom"
+)
+
+type fakeImageTagLister map[string][]string
+
+func (f fakeImageTagLister) ListImageTags(imageName string) ([]string, error) {
+	tags, found := f[imageName]
+	if !found {
+		return nil, errors.New("NAME_UNKNOWN")
+	}
+	return tags, nil
+}
+
+var _ = Describe("Preflight checks", func() {
+	passing := func(name string) PreflightCheck {
+		return PreflightCheck{Name: name, Category: PreflightCategoryConfig, Run: func() error { return nil }}
+	}
+	statuses := func(report *PreflightReport) map[string]string {
+		result := map[string]string{}
+		for i := range report.Checks {
+			result[report.Checks[i].Name] = report.Checks[i].Status
+		}
+		return result
+	}
+
+	Describe("RunPreflightChecks", func() {
+		It("reports the result of every check", func() {
+			checks := []PreflightCheck{
+				passing("pass"),
+				{Name: "warn", Category: PreflightCategoryQuota, Run: func() error { return NewPreflightWarning("%d VPCs", 5) }},
+				{Name: "fail", Category: PreflightCategoryNetwork, Run: func() error { return errors.New("unreachable") }},
+			}
+			report, err := RunPreflightChecks(checks, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Status).To(Equal(PreflightStatusFail))
+			Expect(report.Checks).To(Equal([]PreflightCheckResult{
+				{Category: PreflightCategoryConfig, Name: "pass", Status: PreflightStatusPass},
+				{Category: PreflightCategoryQuota, Name: "warn", Status: PreflightStatusWarn, Message: "5 VPCs"},
+				{Category: PreflightCategoryNetwork, Name: "fail", Status: PreflightStatusFail, Message: "unreachable"},
+			}))
+		})
+
+		It("warns if the worst result is a warning", func() {
+			checks := []PreflightCheck{
+				passing("pass"),
+				{Name: "warn", Run: func() error { return NewPreflightWarning("low memory") }},
+			}
+			report, err := RunPreflightChecks(checks, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Status).To(Equal(PreflightStatusWarn))
+		})
+
+		It("skips the requested checks", func() {
+			ran := false
+			checks := []PreflightCheck{
+				passing("pass"),
+				{Name: "fail", Run: func() error { ran = true; return errors.New("failed") }},
+			}
+			report, err := RunPreflightChecks(checks, []string{"fail"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ran).To(BeFalse())
+			Expect(report.Status).To(Equal(PreflightStatusPass))
+			Expect(report.Checks[1].Status).To(Equal(PreflightStatusSkip))
+			Expect(report.Checks[1].Message).To(Equal("skipped on request"))
+		})
+
+		It("skips the checks requiring a check which did not pass", func() {
+			checks := []PreflightCheck{
+				{Name: "credentials", Configures: true, Run: func() error { return errors.New("invalid credentials") }},
+				{Name: "skipped", Run: func() error { return nil }},
+				{Name: "resources", Requires: []string{"credentials"}, Run: func() error { return nil }},
+				{Name: "images", Requires: []string{"skipped"}, Run: func() error { return nil }},
+				{Name: "template", Requires: []string{"resources", "credentials"}, Run: func() error { return nil }},
+			}
+			report, err := RunPreflightChecks(checks, []string{"skipped"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statuses(report)).To(Equal(map[string]string{
+				"credentials": PreflightStatusFail,
+				"skipped":     PreflightStatusSkip,
+				"resources":   PreflightStatusSkip,
+				"images":      PreflightStatusSkip,
+				"template":    PreflightStatusSkip,
+			}))
+			Expect(report.Checks[2].Message).To(Equal("requires check credentials to pass"))
+		})
+
+		It("runs the checks updating the config first and in order", func() {
+			order := []string{}
+			record := func(name string, configures bool) PreflightCheck {
+				return PreflightCheck{Name: name, Configures: configures, Run: func() error {
+					order = append(order, name)
+					return nil
+				}}
+			}
+			checks := []PreflightCheck{record("first", true), record("second", true)}
+			checks = append(checks, PreflightCheck{Name: "last", Requires: []string{"second"}, Run: func() error {
+				Expect(order).To(Equal([]string{"first", "second"}))
+				return nil
+			}})
+			report, err := RunPreflightChecks(checks, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Status).To(Equal(PreflightStatusPass))
+		})
+
+		It("runs the other checks concurrently", func() {
+			started := map[string]chan struct{}{"a": make(chan struct{}), "b": make(chan struct{})}
+			waitFor := func(name, other string) func() error {
+				return func() error {
+					close(started[name])
+					select {
+					case <-started[other]:
+						return nil
+					case <-time.After(5 * time.Second):
+						return errors.Errorf("check %s did not run concurrently", other)
+					}
+				}
+			}
+			checks := []PreflightCheck{
+				{Name: "a", Run: waitFor("a", "b")},
+				{Name: "b", Run: waitFor("b", "a")},
+			}
+			report, err := RunPreflightChecks(checks, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Status).To(Equal(PreflightStatusPass))
+		})
+
+		It("fails for an unknown skipped check", func() {
+			_, err := RunPreflightChecks([]PreflightCheck{passing("cni"), passing("plan")}, []string{"dns"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("unknown preflight check 'dns', the preflight checks are: cni, plan"))
+		})
+
+		It("fails for a check requiring a check registered after it", func() {
+			checks := []PreflightCheck{
+				{Name: "resources", Requires: []string{"credentials"}, Run: func() error { return nil }},
+				passing("credentials"),
+			}
+			_, err := RunPreflightChecks(checks, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("requires check 'credentials' which is not registered before it"))
+		})
+
+		It("fails for a check updating the config requiring one which does not", func() {
+			checks := []PreflightCheck{
+				passing("docker"),
+				{Name: "provider-config", Configures: true, Requires: []string{"docker"}, Run: func() error { return nil }},
+			}
+			_, err := RunPreflightChecks(checks, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot require check 'docker'"))
+		})
+	})
+
+	Describe("checkBOMImagesAvailable", func() {
+		bom := &tkgconfigbom.BOMConfiguration{}
+		BeforeEach(func() {
+			Expect(yaml.Unmarshal([]byte(`
+imageConfig:
+  imageRepository: registry.example.com/tkg
+components:
+  etcd:
+  - images:
+      etcd:
+        imagePath: etcd
+        tag: v3.4.13_vmware.4
+  kubernetes:
+  - images:
+      kubeAPIServer:
+        imagePath: kube-apiserver
+        tag: v1.18.0_vmware.1
+      pause:
+        imagePath: pause
+        tag: "3.2"
+        imageRepository: mirror.example.com
+  antrea:
+  - images:
+      antrea:
+        imagePath: antrea
+        tag: v0.11.3
+`), bom)).To(Succeed())
+		})
+
+		It("succeeds when the images of the components are available", func() {
+			lister := fakeImageTagLister{
+				"registry.example.com/tkg/etcd":           {"v3.4.13_vmware.4"},
+				"registry.example.com/tkg/kube-apiserver": {"v1.17.3_vmware.2", "v1.18.0_vmware.1"},
+				"mirror.example.com/pause":                {"3.2"},
+			}
+			Expect(checkBOMImagesAvailable(lister, bom, []string{"kubernetes", "etcd"})).To(Succeed())
+		})
+
+		It("reports the missing images", func() {
+			lister := fakeImageTagLister{
+				"registry.example.com/tkg/etcd":           {"v3.4.3_vmware.11"},
+				"registry.example.com/tkg/kube-apiserver": {"v1.18.0_vmware.1"},
+				"mirror.example.com/pause":                {"3.1"},
+			}
+			err := checkBOMImagesAvailable(lister, bom, []string{"kubernetes", "etcd"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("images not found: mirror.example.com/pause:3.2, registry.example.com/tkg/etcd:v3.4.13_vmware.4"))
+		})
+
+		It("fails when an image cannot be listed", func() {
+			err := checkBOMImagesAvailable(fakeImageTagLister{}, bom, []string{"etcd"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to list the tags of image registry.example.com/tkg/etcd"))
+		})
+	})
+})
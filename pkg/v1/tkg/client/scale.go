@@ -49,7 +49,10 @@ func (c *TkgClient) ScaleCluster(options ScaleClusterOptions) error {
 		options.Namespace = constants.DefaultNamespace
 	}
 
-	return c.DoScaleCluster(clusterClient, &options)
+	recorder := recordOperationStarted(clusterClient, options.ClusterName, options.Namespace, clusterclient.OperationTypeScale)
+	err = c.DoScaleCluster(clusterClient, &options)
+	recorder.finished(err)
+	return err
 }
 
 // DoScaleCluster performs the scale operation using the given clusterclient.Client
@@ -63,6 +66,12 @@ func (c *TkgClient) DoScaleCluster(clusterClient clusterclient.Client, options *
 		return c.ScalePacificCluster(*options, clusterClient)
 	}
 
+	if options.WorkerCount > 0 {
+		if err := verifyClusterNotHibernated(clusterClient, options.ClusterName, options.Namespace); err != nil {
+			return err
+		}
+	}
+
 	errList := []error{}
 
 	controlPlaneNode, err := clusterClient.GetKCPObjectForCluster(options.ClusterName, options.Namespace)
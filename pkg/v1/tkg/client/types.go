@@ -43,6 +43,10 @@ const (
 	// stalled and user intervention is required.
 	TKGClusterPhaseUpdateStalled = TKGClusterPhase("updateStalled")
 
+	// TKGClusterPhaseHibernated indicates that the workers of the cluster
+	// are scaled to zero until the cluster is resumed.
+	TKGClusterPhaseHibernated = TKGClusterPhase("hibernated")
+
 	// TKGClusterPhaseEmpty is useful for the initial reconcile,
 	// before we even state the phase as creating.
 	TKGClusterPhaseEmpty = TKGClusterPhase("")
@@ -70,7 +70,10 @@ func (c *TkgClient) UpdateCredentialsRegion(options *UpdateCredentialsOptions) e
 
 	log.Infof("Updating credentials for management cluster %q", options.ClusterName)
 	if infraProviderName == VSphereProviderName {
-		if err := c.UpdateVSphereClusterCredentials(regionalClusterClient, options); err != nil {
+		recorder := recordOperationStarted(regionalClusterClient, options.ClusterName, options.Namespace, clusterclient.OperationTypeUpdateCredentials)
+		err := c.UpdateVSphereClusterCredentials(regionalClusterClient, options)
+		recorder.finished(err)
+		if err != nil {
 			return err
 		}
 	}
@@ -117,7 +120,10 @@ func (c *TkgClient) UpdateCredentialsCluster(options *UpdateCredentialsOptions)
 
 	log.Infof("Updating credentials for workload cluster %q", options.ClusterName)
 	if infraProviderName == VSphereProviderName {
-		if err := c.UpdateVSphereClusterCredentials(regionalClusterClient, options); err != nil {
+		recorder := recordOperationStarted(regionalClusterClient, options.ClusterName, options.Namespace, clusterclient.OperationTypeUpdateCredentials)
+		err := c.UpdateVSphereClusterCredentials(regionalClusterClient, options)
+		recorder.finished(err)
+		if err != nil {
 			return err
 		}
 	}
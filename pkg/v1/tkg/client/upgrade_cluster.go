@@ -12,6 +12,8 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	capav1alpha3 "sigs.k8s.io/cluster-api-provider-aws/api/v1alpha3"
 	capzv1alpha3 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
@@ -84,9 +86,29 @@ const (
 	upgradeStateKCPPatchApplied       = "KCPPatchApplied"
 	upgradeStateKCPUpgraded           = "KCPUpgraded"
 	upgradeStateMDPatchApplied        = "MDPatchApplied"
+	upgradeStateMDUpgraded            = "MDUpgraded"
 	upgradeStateSuccess               = "Success"
 )
 
+// cluster upgrade step constants
+const (
+	StepCreateInfrastructureTemplates = "Create infrastructure templates"
+	StepUpgradeControlPlane           = "Upgrade control plane nodes"
+	StepUpgradeWorkerNodes            = "Upgrade worker nodes"
+	StepUpgradeAddons                 = "Upgrade addons"
+	StepWaitForPackages               = "Wait for packages"
+)
+
+// UpgradeClusterSteps cluster upgrade step sequence
+var UpgradeClusterSteps = []string{
+	StepValidateConfiguration,
+	StepCreateInfrastructureTemplates,
+	StepUpgradeControlPlane,
+	StepUpgradeWorkerNodes,
+	StepUpgradeAddons,
+	StepWaitForPackages,
+}
+
 type clusterUpgradeInfo struct {
 	UpgradeComponentInfo componentInfo
 	ActualComponentInfo  componentInfo
@@ -98,6 +120,18 @@ type clusterUpgradeInfo struct {
 	ClusterNamespace   string
 
 	UpgradeState upgradeStatus
+
+	// ResumeState is the last step completed by the interrupted upgrade being resumed
+	ResumeState upgradeStatus
+	// TemplateSuffix is appended to the names of the infrastructure templates created for the
+	// upgrade, so that a resumed upgrade finds the templates it already created
+	TemplateSuffix string
+	StartTimestamp string
+
+	// DryRun records the infrastructure templates required for the upgrade in
+	// PlannedTemplates instead of creating them.
+	DryRun           bool
+	PlannedTemplates []runtime.Object
 }
 
 // UpgradeCluster upgrades workload and management clusters k8s version
@@ -110,7 +144,9 @@ type clusterUpgradeInfo struct {
 // 5. Wait for k8s version to be updated for the cluster
 // 6. Patch MachineDeployment object to upgrade worker nodes
 // 7. Wait for k8s version to be updated for all worker nodes
-func (c *TkgClient) UpgradeCluster(options *UpgradeClusterOptions) error { // nolint:gocyclo
+// The step reached is persisted on the cluster object after each step, and an interrupted
+// upgrade to the same TKr is resumed after the last completed step.
+func (c *TkgClient) UpgradeCluster(options *UpgradeClusterOptions) (err error) { // nolint:gocyclo
 	if options == nil {
 		return errors.New("invalid upgrade cluster options nil")
 	}
@@ -155,6 +191,16 @@ func (c *TkgClient) UpgradeCluster(options *UpgradeClusterOptions) error { // no
 		options.Namespace = constants.DefaultNamespace
 	}
 
+	recorder := recordOperationStarted(regionalClusterClient, options.ClusterName, options.Namespace, clusterclient.OperationTypeUpgrade)
+	defer func() {
+		recorder.finished(err)
+		if err != nil {
+			log.SendProgressUpdate(statusFailed, "", UpgradeClusterSteps)
+		} else {
+			log.SendProgressUpdate(statusSuccessful, "", UpgradeClusterSteps)
+		}
+	}()
+
 	var currentClusterClient clusterclient.Client
 	if options.IsRegionalCluster {
 		currentClusterClient = regionalClusterClient
@@ -183,7 +229,8 @@ func (c *TkgClient) UpgradeCluster(options *UpgradeClusterOptions) error { // no
 			return errors.Wrapf(err, "failed to upgrade autoscaler for cluster '%s'", options.ClusterName)
 		}
 
-		log.Info("Waiting for packages to be up and running...")
+		log.SendProgressUpdate(statusRunning, StepWaitForPackages, UpgradeClusterSteps)
+		logWaitingFor("PackageInstall", "", "", "Waiting for packages to be up and running...")
 		if err := c.WaitForPackages(regionalClusterClient, currentClusterClient, options.ClusterName, options.Namespace); err != nil {
 			log.Warningf("Warning: Cluster is upgraded successfully, but some packages are failing. %v", err)
 		}
@@ -226,7 +273,7 @@ func (c *TkgClient) applyPatchForAutoScalerDeployment(regionalClusterClient clus
 		return errors.Wrap(err, "unable to update the container image for autoscaler deployment")
 	}
 
-	log.Infof("Waiting for cluster autoscaler to be patched and available...")
+	logWaitingFor("Deployment", options.Namespace, autoscalerDeploymentName, "Waiting for cluster autoscaler to be patched and available...")
 	if err = regionalClusterClient.WaitForAutoscalerDeployment(autoscalerDeploymentName, options.Namespace); err != nil {
 		log.Warningf("Unable to wait for autoscaler deployment to be ready. reason: %v", err)
 	}
@@ -254,6 +301,7 @@ func (c *TkgClient) DoPacificClusterUpgrade(regionalClusterClient clusterclient.
 func (c *TkgClient) DoClusterUpgrade(regionalClusterClient clusterclient.Client,
 	currentClusterClient clusterclient.Client, options *UpgradeClusterOptions) error {
 
+	log.SendProgressUpdate(statusRunning, StepValidateConfiguration, UpgradeClusterSteps)
 	log.Info("Verifying kubernetes version...")
 	err := c.verifyK8sVersion(currentClusterClient, options.KubernetesVersion)
 	if err != nil {
@@ -270,14 +318,24 @@ func (c *TkgClient) DoClusterUpgrade(regionalClusterClient clusterclient.Client,
 		return errors.Wrap(err, "unable to retrieve component upgrade info")
 	}
 
-	upgradeClusterConfig.UpgradeState = upgradeStateInitiated
+	if err := c.resumeUpgrade(regionalClusterClient, upgradeClusterConfig); err != nil {
+		return errors.Wrap(err, "unable to retrieve the upgrade status of the cluster")
+	}
+	if !upgradeClusterConfig.isStepCompleted(upgradeStateInitiated) {
+		c.setUpgradeState(regionalClusterClient, upgradeClusterConfig, upgradeStateInitiated)
+	}
 
+	// Infrastructure templates are looked up even when resuming, as they are needed to patch
+	// the KCP and MD objects. Templates created before the interruption are reused.
+	log.SendProgressUpdate(statusRunning, StepCreateInfrastructureTemplates, UpgradeClusterSteps)
 	log.Info("Create InfrastructureTemplate for upgrade...")
 	err = c.createInfrastructureTemplateForUpgrade(regionalClusterClient, upgradeClusterConfig)
 	if err != nil {
 		return errors.Wrap(err, "unable to create infrastructure template for upgrade")
 	}
-	upgradeClusterConfig.UpgradeState = upgradeStateInfraTemplatesCreated
+	if !upgradeClusterConfig.isStepCompleted(upgradeStateInfraTemplatesCreated) {
+		c.setUpgradeState(regionalClusterClient, upgradeClusterConfig, upgradeStateInfraTemplatesCreated)
+	}
 
 	err = c.applyPatchAndWait(regionalClusterClient, currentClusterClient, upgradeClusterConfig)
 	if err != nil {
@@ -289,9 +347,15 @@ func (c *TkgClient) DoClusterUpgrade(regionalClusterClient clusterclient.Client,
 	// for the management cluster is done as part of management cluster upgrade
 	// once we update the TKG version in cluster object
 	if !options.IsRegionalCluster && !options.SkipAddonUpgrade {
-		return c.upgradeAddons(regionalClusterClient, currentClusterClient, upgradeClusterConfig.ClusterName,
+		log.SendProgressUpdate(statusRunning, StepUpgradeAddons, UpgradeClusterSteps)
+		err = c.upgradeAddons(regionalClusterClient, currentClusterClient, upgradeClusterConfig.ClusterName,
 			upgradeClusterConfig.ClusterNamespace, options.IsRegionalCluster, options.Edition)
+		if err != nil {
+			return err
+		}
 	}
+
+	c.setUpgradeState(regionalClusterClient, upgradeClusterConfig, upgradeStateSuccess)
 	return nil
 }
 
@@ -342,7 +406,44 @@ func (c *TkgClient) upgradeAddons(regionalClusterClient clusterclient.Client, cu
 }
 
 func (c *TkgClient) applyPatchAndWait(regionalClusterClient, currentClusterClient clusterclient.Client, upgradeClusterConfig *clusterUpgradeInfo) error {
-	var err error
+	kubernetesVersion := upgradeClusterConfig.UpgradeComponentInfo.KubernetesVersion
+
+	log.SendProgressUpdate(statusRunning, StepUpgradeControlPlane, UpgradeClusterSteps)
+	if !upgradeClusterConfig.isStepCompleted(upgradeStateKCPPatchApplied) {
+		if err := c.prepareAndPatchControlPlane(regionalClusterClient, currentClusterClient, upgradeClusterConfig); err != nil {
+			return err
+		}
+	}
+
+	if !upgradeClusterConfig.isStepCompleted(upgradeStateKCPUpgraded) {
+		if err := c.waitForControlPlaneUpgrade(regionalClusterClient, currentClusterClient, upgradeClusterConfig); err != nil {
+			return err
+		}
+	}
+
+	log.SendProgressUpdate(statusRunning, StepUpgradeWorkerNodes, UpgradeClusterSteps)
+	if !upgradeClusterConfig.isStepCompleted(upgradeStateMDPatchApplied) {
+		log.Info("Upgrading worker nodes...")
+		log.Infof("Patching MachineDeployment with the kubernetes version %s...", kubernetesVersion)
+		if err := c.patchKubernetesVersionToMachineDeployment(regionalClusterClient, upgradeClusterConfig); err != nil {
+			return errors.Wrap(err, "unable to patch kubernetes version to kubeadm control plane")
+		}
+		c.setUpgradeState(regionalClusterClient, upgradeClusterConfig, upgradeStateMDPatchApplied)
+	}
+
+	if !upgradeClusterConfig.isStepCompleted(upgradeStateMDUpgraded) {
+		logWaitingFor("MachineDeployment", upgradeClusterConfig.ClusterNamespace, "", "Waiting for kubernetes version to be updated for worker nodes...")
+		err := regionalClusterClient.WaitK8sVersionUpdateForWorkerNodes(upgradeClusterConfig.ClusterName, upgradeClusterConfig.ClusterNamespace, kubernetesVersion, currentClusterClient)
+		if err != nil {
+			return errors.Wrap(err, "error waiting for kubernetes version update for worker nodes")
+		}
+		c.setUpgradeState(regionalClusterClient, upgradeClusterConfig, upgradeStateMDUpgraded)
+	}
+	return nil
+}
+
+// prepareAndPatchControlPlane applies the fixes required on the cluster before the upgrade and patches the KCP object
+func (c *TkgClient) prepareAndPatchControlPlane(regionalClusterClient, currentClusterClient clusterclient.Client, upgradeClusterConfig *clusterUpgradeInfo) error {
 	kubernetesVersion := upgradeClusterConfig.UpgradeComponentInfo.KubernetesVersion
 
 	// Ensure Cluster API Provider AWS is running on the control plane before continuing with EC2 instance profile
@@ -372,7 +473,7 @@ func (c *TkgClient) applyPatchAndWait(regionalClusterClient, currentClusterClien
 	if tkgconfighelper.IsCustomRepository(upgradeClusterConfig.UpgradeComponentInfo.ImageRepository) && !tkgconfighelper.SkipImageReferenceUpdateOnUpgrade() {
 		log.Info("Configuring cluster for upgrade...")
 		log.V(3).Info("Updating coreDNS imageRepository in kubeadm-config ConfigMap...")
-		if err = currentClusterClient.PatchCoreDNSImageRepositoryInKubeadmConfigMap(upgradeClusterConfig.UpgradeComponentInfo.ImageRepository); err != nil {
+		if err := currentClusterClient.PatchCoreDNSImageRepositoryInKubeadmConfigMap(upgradeClusterConfig.UpgradeComponentInfo.ImageRepository); err != nil {
 			return errors.Wrap(err, "unable to update the kubeadm configmap with new image repository")
 		}
 	}
@@ -384,11 +485,16 @@ func (c *TkgClient) applyPatchAndWait(regionalClusterClient, currentClusterClien
 
 	log.Info("Upgrading control plane nodes...")
 	log.Infof("Patching KubeadmControlPlane with the kubernetes version %s...", kubernetesVersion)
-	err = c.patchKubernetesVersionToKubeadmControlPlane(regionalClusterClient, upgradeClusterConfig)
-	if err != nil {
+	if err := c.patchKubernetesVersionToKubeadmControlPlane(regionalClusterClient, upgradeClusterConfig); err != nil {
 		return errors.Wrap(err, "unable to patch kubernetes version to kubeadm control plane")
 	}
-	upgradeClusterConfig.UpgradeState = upgradeStateKCPPatchApplied
+	c.setUpgradeState(regionalClusterClient, upgradeClusterConfig, upgradeStateKCPPatchApplied)
+	return nil
+}
+
+// waitForControlPlaneUpgrade waits for the control plane nodes to be upgraded after the KCP object is patched
+func (c *TkgClient) waitForControlPlaneUpgrade(regionalClusterClient, currentClusterClient clusterclient.Client, upgradeClusterConfig *clusterUpgradeInfo) error {
+	kubernetesVersion := upgradeClusterConfig.UpgradeComponentInfo.KubernetesVersion
 
 	// If user is using custom image repository, update the kube-proxy imageRepository
 	// in kube-proxy daemonset after starting control-plane upgrade
@@ -406,28 +512,12 @@ func (c *TkgClient) applyPatchAndWait(regionalClusterClient, currentClusterClien
 		}
 	}
 
-	log.Info("Waiting for kubernetes version to be updated for control plane nodes")
-	err = regionalClusterClient.WaitK8sVersionUpdateForCPNodes(upgradeClusterConfig.ClusterName, upgradeClusterConfig.ClusterNamespace, kubernetesVersion, currentClusterClient)
+	logWaitingFor("KubeadmControlPlane", upgradeClusterConfig.KCPObjectNamespace, upgradeClusterConfig.KCPObjectName, "Waiting for kubernetes version to be updated for control plane nodes")
+	err := regionalClusterClient.WaitK8sVersionUpdateForCPNodes(upgradeClusterConfig.ClusterName, upgradeClusterConfig.ClusterNamespace, kubernetesVersion, currentClusterClient)
 	if err != nil {
 		return errors.Wrap(err, "error waiting for kubernetes version update for kubeadm control plane")
 	}
-	upgradeClusterConfig.UpgradeState = upgradeStateKCPUpgraded
-
-	log.Info("Upgrading worker nodes...")
-	log.Infof("Patching MachineDeployment with the kubernetes version %s...", kubernetesVersion)
-	err = c.patchKubernetesVersionToMachineDeployment(regionalClusterClient, upgradeClusterConfig)
-	if err != nil {
-		return errors.Wrap(err, "unable to patch kubernetes version to kubeadm control plane")
-	}
-	upgradeClusterConfig.UpgradeState = upgradeStateMDPatchApplied
-
-	log.Info("Waiting for kubernetes version to be updated for worker nodes...")
-	err = regionalClusterClient.WaitK8sVersionUpdateForWorkerNodes(upgradeClusterConfig.ClusterName, upgradeClusterConfig.ClusterNamespace, kubernetesVersion, currentClusterClient)
-	if err != nil {
-		return errors.Wrap(err, "error waiting for kubernetes version update for worker nodes")
-	}
-
-	upgradeClusterConfig.UpgradeState = upgradeStateSuccess
+	c.setUpgradeState(regionalClusterClient, upgradeClusterConfig, upgradeStateKCPUpgraded)
 	return nil
 }
 
@@ -450,6 +540,7 @@ func (c *TkgClient) getUpgradeClusterConfig(options *UpgradeClusterOptions) (*cl
 
 	upgradeInfo.ClusterName = options.ClusterName
 	upgradeInfo.ClusterNamespace = options.Namespace
+	upgradeInfo.TemplateSuffix = utils.GenerateRandomID(5, true)
 
 	upgradeInfo.UpgradeComponentInfo.AwsRegionToAMIMap = bomConfiguration.AMI
 	upgradeInfo.UpgradeComponentInfo.VSphereVMTemplateName = options.VSphereTemplateName
@@ -536,6 +627,27 @@ func (c *TkgClient) createInfrastructureTemplateForUpgrade(regionalClusterClient
 	}
 }
 
+// createInfrastructureTemplate creates the infrastructure machine template required for the upgrade,
+// or records it in the upgrade config if the upgrade is a dry run. Templates that already exist
+// are reused when resuming an interrupted upgrade.
+func (c *TkgClient) createInfrastructureTemplate(regionalClusterClient clusterclient.Client, clusterUpgradeConfig *clusterUpgradeInfo, template runtime.Object) error {
+	if clusterUpgradeConfig.DryRun {
+		clusterUpgradeConfig.PlannedTemplates = append(clusterUpgradeConfig.PlannedTemplates, template)
+		return nil
+	}
+	accessor, err := meta.Accessor(template)
+	if err != nil {
+		return err
+	}
+	err = regionalClusterClient.CreateResource(template, accessor.GetName(), accessor.GetNamespace())
+	if err != nil && clusterUpgradeConfig.ResumeState != "" && apierrors.IsAlreadyExists(errors.Cause(err)) {
+		// the template was created before the upgrade being resumed was interrupted
+		log.V(3).Infof("Reusing infrastructure template '%s' created by the interrupted upgrade", accessor.GetName())
+		return nil
+	}
+	return err
+}
+
 func isNewAWSTemplateRequired(machineTemplate *capav1alpha3.AWSMachineTemplate, clusterUpgradeConfig *clusterUpgradeInfo, actualK8sVersion *string) bool {
 	if actualK8sVersion == nil || *actualK8sVersion != clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion {
 		return true
@@ -571,7 +683,7 @@ func (c *TkgClient) createAWSControlPlaneMachineTemplate(regionalClusterClient c
 
 	// Naming format of the template: Current naming format for AWSMachineTemplate for KCP is {CLUSTER_NAME}-control-plane-{KUBERNETES_VERSION}-{random-string}
 	clusterUpgradeConfig.UpgradeComponentInfo.KCPInfrastructureTemplateName = clusterUpgradeConfig.ClusterName + "-control-plane-" +
-		utils.ReplaceSpecialChars(clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion) + "-" + utils.GenerateRandomID(5, true)
+		utils.ReplaceSpecialChars(clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion) + "-" + clusterUpgradeConfig.TemplateSuffix
 	clusterUpgradeConfig.UpgradeComponentInfo.KCPInfrastructureTemplateNamespace = awsMachineTemplate.Namespace
 
 	if !isNewAWSTemplateRequired(awsMachineTemplate, clusterUpgradeConfig, &kcp.Spec.Version) {
@@ -585,7 +697,7 @@ func (c *TkgClient) createAWSControlPlaneMachineTemplate(regionalClusterClient c
 	awsMachineTemplateForUpgrade.Spec = awsMachineTemplate.DeepCopy().Spec
 	awsMachineTemplateForUpgrade.Spec.Template.Spec.AMI.ID = &clusterUpgradeConfig.UpgradeComponentInfo.AwsAMIID // TODO(anuj): Decide on AMI-ID vs ImageLookupOrg implementation approach
 
-	err = regionalClusterClient.CreateResource(awsMachineTemplateForUpgrade, awsMachineTemplateForUpgrade.Name, awsMachineTemplateForUpgrade.Namespace)
+	err = c.createInfrastructureTemplate(regionalClusterClient, clusterUpgradeConfig, awsMachineTemplateForUpgrade)
 	if err != nil {
 		return errors.Wrapf(err, "unable to create AWSMachineTemplate for upgrade with name '%s' in namespace '%s'", awsMachineTemplateForUpgrade.Name, awsMachineTemplateForUpgrade.Namespace)
 	}
@@ -616,7 +728,7 @@ func (c *TkgClient) createAWSMachineDeploymentMachineTemplateForWorkers(regional
 		// Naming format of the MD template: Current naming format for AWSMachineTemplate for MachineDeployment is {ACTUAL_TEMPLATE_NAME}-{KUBERNETES_VERSION}-{random-string}
 		clusterUpgradeConfig.UpgradeComponentInfo.MDInfastructureTemplates[clusterUpgradeConfig.MDObjects[i].Name] = mdInfastructureTemplateInfo{
 			MDInfrastructureTemplateName: clusterUpgradeConfig.ActualComponentInfo.MDInfastructureTemplates[clusterUpgradeConfig.MDObjects[i].Name].MDInfrastructureTemplateName + "-" +
-				utils.ReplaceSpecialChars(clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion) + "-" + utils.GenerateRandomID(5, true),
+				utils.ReplaceSpecialChars(clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion) + "-" + clusterUpgradeConfig.TemplateSuffix,
 			MDInfrastructureTemplateNamespace: awsMachineTemplateForMD.Namespace,
 		}
 
@@ -627,7 +739,7 @@ func (c *TkgClient) createAWSMachineDeploymentMachineTemplateForWorkers(regional
 		awsMachineTemplateMDForUpgrade.Spec.Template.Spec.AMI.ID = &clusterUpgradeConfig.UpgradeComponentInfo.AwsAMIID // TODO(anuj): Decide on AMI-ID vs ImageLookupOrg implementation approach
 
 		// create template for each machine deployment object
-		err = regionalClusterClient.CreateResource(awsMachineTemplateMDForUpgrade, awsMachineTemplateMDForUpgrade.Name, awsMachineTemplateMDForUpgrade.Namespace)
+		err = c.createInfrastructureTemplate(regionalClusterClient, clusterUpgradeConfig, awsMachineTemplateMDForUpgrade)
 		if err != nil {
 			return errors.Wrapf(err, "unable to create AWSMachineTemplate for upgrade with name '%s' in namespace '%s'", awsMachineTemplateMDForUpgrade.Name, awsMachineTemplateMDForUpgrade.Namespace)
 		}
@@ -743,7 +855,7 @@ func (c *TkgClient) createAzureControlPlaneMachineTemplate(regionalClusterClient
 
 	// Naming format of the template: Current naming format for AzureMachineTemplate for KCP is {CLUSTER_NAME}-control-plane-{KUBERNETES_VERSION}-{random-string}
 	clusterUpgradeConfig.UpgradeComponentInfo.KCPInfrastructureTemplateName = clusterUpgradeConfig.ClusterName + "-control-plane-" +
-		utils.ReplaceSpecialChars(clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion) + "-" + utils.GenerateRandomID(5, true)
+		utils.ReplaceSpecialChars(clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion) + "-" + clusterUpgradeConfig.TemplateSuffix
 	clusterUpgradeConfig.UpgradeComponentInfo.KCPInfrastructureTemplateNamespace = azureMachineTemplate.Namespace
 
 	if !isNewAzureTemplateRequired(azureMachineTemplate, clusterUpgradeConfig, &kcp.Spec.Version) {
@@ -757,7 +869,7 @@ func (c *TkgClient) createAzureControlPlaneMachineTemplate(regionalClusterClient
 	azureMachineTemplateForUpgrade.Spec = azureMachineTemplate.DeepCopy().Spec
 	azureMachineTemplateForUpgrade.Spec.Template.Spec.Image = getAzureImage(&clusterUpgradeConfig.UpgradeComponentInfo.AzureImage)
 
-	err = regionalClusterClient.CreateResource(azureMachineTemplateForUpgrade, azureMachineTemplateForUpgrade.Name, azureMachineTemplateForUpgrade.Namespace)
+	err = c.createInfrastructureTemplate(regionalClusterClient, clusterUpgradeConfig, azureMachineTemplateForUpgrade)
 	if err != nil {
 		return errors.Wrapf(err, "unable to create AzureMachineTemplate for upgrade with name %s in namespace %s", azureMachineTemplateForUpgrade.Name, azureMachineTemplateForUpgrade.Namespace)
 	}
@@ -775,7 +887,7 @@ func (c *TkgClient) createCAPDControlPlaneMachineTemplate(regionalClusterClient
 
 	// Naming format of the template: Current naming format for DockerMachineTemplate for KCP is {CLUSTER_NAME}-control-plane-{KUBERNETES_VERSION}-{random-string}
 	clusterUpgradeConfig.UpgradeComponentInfo.KCPInfrastructureTemplateName = clusterUpgradeConfig.ClusterName + "-control-plane-" +
-		utils.ReplaceSpecialChars(clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion) + "-" + utils.GenerateRandomID(5, true)
+		utils.ReplaceSpecialChars(clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion) + "-" + clusterUpgradeConfig.TemplateSuffix
 	clusterUpgradeConfig.UpgradeComponentInfo.KCPInfrastructureTemplateNamespace = dockerMachineTemplate.Namespace
 
 	if !isNewDockerTemplateRequired(dockerMachineTemplate, clusterUpgradeConfig, &kcp.Spec.Version) {
@@ -789,7 +901,7 @@ func (c *TkgClient) createCAPDControlPlaneMachineTemplate(regionalClusterClient
 	dockerMachineTemplateForUpgrade.Spec = dockerMachineTemplate.DeepCopy().Spec
 	dockerMachineTemplateForUpgrade.Spec.Template.Spec.CustomImage = clusterUpgradeConfig.UpgradeComponentInfo.CAPDImageName
 
-	err = regionalClusterClient.CreateResource(dockerMachineTemplateForUpgrade, dockerMachineTemplateForUpgrade.Name, dockerMachineTemplateForUpgrade.Namespace)
+	err = c.createInfrastructureTemplate(regionalClusterClient, clusterUpgradeConfig, dockerMachineTemplateForUpgrade)
 	if err != nil {
 		return errors.Wrapf(err, "unable to create dockerMachineTemplate for upgrade with name %s in namespace %s", dockerMachineTemplateForUpgrade.Name, dockerMachineTemplateForUpgrade.Namespace)
 	}
@@ -817,7 +929,7 @@ func (c *TkgClient) createCAPDMachineDeploymentMachineTemplateForWorkers(regiona
 		// Naming format of the MD template: Current naming format for AzureMachineTemplate for MachineDeployment is {ACTUAL_TEMPLATE_NAME}-{KUBERNETES_VERSION}-{random-string}
 		clusterUpgradeConfig.UpgradeComponentInfo.MDInfastructureTemplates[clusterUpgradeConfig.MDObjects[i].Name] = mdInfastructureTemplateInfo{
 			MDInfrastructureTemplateName: clusterUpgradeConfig.ActualComponentInfo.MDInfastructureTemplates[clusterUpgradeConfig.MDObjects[i].Name].MDInfrastructureTemplateName + "-" +
-				utils.ReplaceSpecialChars(clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion) + "-" + utils.GenerateRandomID(5, true),
+				utils.ReplaceSpecialChars(clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion) + "-" + clusterUpgradeConfig.TemplateSuffix,
 			MDInfrastructureTemplateNamespace: dockerMachineTemplateForMD.Namespace,
 		}
 
@@ -827,7 +939,7 @@ func (c *TkgClient) createCAPDMachineDeploymentMachineTemplateForWorkers(regiona
 		dockerMachineTemplateMDForUpgrade.Spec = dockerMachineTemplateForMD.DeepCopy().Spec
 		dockerMachineTemplateMDForUpgrade.Spec.Template.Spec.CustomImage = clusterUpgradeConfig.UpgradeComponentInfo.CAPDImageName
 
-		err = regionalClusterClient.CreateResource(dockerMachineTemplateMDForUpgrade, dockerMachineTemplateMDForUpgrade.Name, dockerMachineTemplateMDForUpgrade.Namespace)
+		err = c.createInfrastructureTemplate(regionalClusterClient, clusterUpgradeConfig, dockerMachineTemplateMDForUpgrade)
 		if err != nil {
 			return errors.Wrapf(err, "unable to create DockerMachineTemplate for upgrade with name %s in namespace %s", dockerMachineTemplateMDForUpgrade.Name, dockerMachineTemplateMDForUpgrade.Namespace)
 		}
@@ -857,7 +969,7 @@ func (c *TkgClient) createAzureMachineDeploymentMachineTemplateForWorkers(region
 		// Naming format of the MD template: Current naming format for AzureMachineTemplate for MachineDeployment is {ACTUAL_TEMPLATE_NAME}-{KUBERNETES_VERSION}-{random-string}
 		clusterUpgradeConfig.UpgradeComponentInfo.MDInfastructureTemplates[clusterUpgradeConfig.MDObjects[i].Name] = mdInfastructureTemplateInfo{
 			MDInfrastructureTemplateName: clusterUpgradeConfig.ActualComponentInfo.MDInfastructureTemplates[clusterUpgradeConfig.MDObjects[i].Name].MDInfrastructureTemplateName + "-" +
-				utils.ReplaceSpecialChars(clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion) + "-" + utils.GenerateRandomID(5, true),
+				utils.ReplaceSpecialChars(clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion) + "-" + clusterUpgradeConfig.TemplateSuffix,
 			MDInfrastructureTemplateNamespace: azureMachineTemplateForMD.Namespace,
 		}
 
@@ -867,7 +979,7 @@ func (c *TkgClient) createAzureMachineDeploymentMachineTemplateForWorkers(region
 		azureMachineTemplateMDForUpgrade.Spec = azureMachineTemplateForMD.DeepCopy().Spec
 		azureMachineTemplateMDForUpgrade.Spec.Template.Spec.Image = getAzureImage(&clusterUpgradeConfig.UpgradeComponentInfo.AzureImage)
 
-		err = regionalClusterClient.CreateResource(azureMachineTemplateMDForUpgrade, azureMachineTemplateMDForUpgrade.Name, azureMachineTemplateMDForUpgrade.Namespace)
+		err = c.createInfrastructureTemplate(regionalClusterClient, clusterUpgradeConfig, azureMachineTemplateMDForUpgrade)
 		if err != nil {
 			return errors.Wrapf(err, "unable to create AzureMachineTemplate for upgrade with name %s in namespace %s", azureMachineTemplateMDForUpgrade.Name, azureMachineTemplateMDForUpgrade.Namespace)
 		}
@@ -910,7 +1022,7 @@ func (c *TkgClient) createVSphereControlPlaneMachineTemplate(regionalClusterClie
 
 	// Naming format of the template: Current naming format for vsphereTemplate for KCP is {CLUSTER_NAME}-control-plane-{KUBERNETES_VERSION}-{random-string}
 	clusterUpgradeConfig.UpgradeComponentInfo.KCPInfrastructureTemplateName = clusterUpgradeConfig.ClusterName + "-control-plane-" +
-		utils.ReplaceSpecialChars(clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion) + "-" + utils.GenerateRandomID(5, true)
+		utils.ReplaceSpecialChars(clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion) + "-" + clusterUpgradeConfig.TemplateSuffix
 	clusterUpgradeConfig.UpgradeComponentInfo.KCPInfrastructureTemplateNamespace = actualVsphereMachineTemplate.Namespace
 
 	if !isNewVSphereTemplateRequired(actualVsphereMachineTemplate, clusterUpgradeConfig, &kcp.Spec.Version) {
@@ -924,7 +1036,7 @@ func (c *TkgClient) createVSphereControlPlaneMachineTemplate(regionalClusterClie
 	vsphereMachineTemplateForUpgrade.Spec = actualVsphereMachineTemplate.DeepCopy().Spec
 	vsphereMachineTemplateForUpgrade.Spec.Template.Spec.Template = clusterUpgradeConfig.UpgradeComponentInfo.VSphereVMTemplateName
 
-	err = regionalClusterClient.CreateResource(vsphereMachineTemplateForUpgrade, vsphereMachineTemplateForUpgrade.Name, vsphereMachineTemplateForUpgrade.Namespace)
+	err = c.createInfrastructureTemplate(regionalClusterClient, clusterUpgradeConfig, vsphereMachineTemplateForUpgrade)
 	if err != nil {
 		return errors.Wrapf(err, "unable to create VSphereMachineTemplate for upgrade with name '%s' in namespace '%s'", vsphereMachineTemplateForUpgrade.Name, vsphereMachineTemplateForUpgrade.Namespace)
 	}
@@ -954,7 +1066,7 @@ func (c *TkgClient) createVSphereMachineDeploymentMachineTemplateForWorkers(regi
 		// Naming format of the MD template: Current naming format for VSphereTemplate for MachineDeployment is {ACTUAL_TEMPLATE_NAME}-{KUBERNETES_VERSION}-{random-string}
 		clusterUpgradeConfig.UpgradeComponentInfo.MDInfastructureTemplates[clusterUpgradeConfig.MDObjects[i].Name] = mdInfastructureTemplateInfo{
 			MDInfrastructureTemplateName: clusterUpgradeConfig.ActualComponentInfo.MDInfastructureTemplates[clusterUpgradeConfig.MDObjects[i].Name].MDInfrastructureTemplateName + "-" +
-				utils.ReplaceSpecialChars(clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion) + "-" + utils.GenerateRandomID(5, true),
+				utils.ReplaceSpecialChars(clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion) + "-" + clusterUpgradeConfig.TemplateSuffix,
 			MDInfrastructureTemplateNamespace: actualVsphereMachineTemplate.Namespace,
 		}
 
@@ -965,7 +1077,7 @@ func (c *TkgClient) createVSphereMachineDeploymentMachineTemplateForWorkers(regi
 		vsphereMachineTemplateForUpgrade.Spec.Template.Spec.Template = clusterUpgradeConfig.UpgradeComponentInfo.VSphereVMTemplateName
 
 		// create template for each machine deployment object
-		err = regionalClusterClient.CreateResource(vsphereMachineTemplateForUpgrade, vsphereMachineTemplateForUpgrade.Name, vsphereMachineTemplateForUpgrade.Namespace)
+		err = c.createInfrastructureTemplate(regionalClusterClient, clusterUpgradeConfig, vsphereMachineTemplateForUpgrade)
 		if err != nil {
 			return errors.Wrapf(err, "unable to create VSphereMachineTemplate for upgrade with name '%s' in namespace '%s'", vsphereMachineTemplateForUpgrade.Name, vsphereMachineTemplateForUpgrade.Namespace)
 		}
@@ -1010,12 +1122,41 @@ func (c *TkgClient) createVsphereInfrastructureTemplateForUpgrade(regionalCluste
 }
 
 func (c *TkgClient) patchKubernetesVersionToKubeadmControlPlane(regionalClusterClient clusterclient.Client, clusterUpgradeConfig *clusterUpgradeInfo) error {
-	if clusterUpgradeConfig.ActualComponentInfo.KubernetesVersion == clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion &&
-		clusterUpgradeConfig.ActualComponentInfo.KCPInfrastructureTemplateName == clusterUpgradeConfig.UpgradeComponentInfo.KCPInfrastructureTemplateName {
+	patchKubernetesVersion, ok := kubeadmControlPlaneUpgradePatch(clusterUpgradeConfig)
+	if !ok {
 		log.Infof("Skipping KubeadmControlPlane patch as kubernetes versions are already same %s", clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion)
 		return nil
 	}
 
+	log.V(3).Infof("Applying KubeadmControlPlane Patch: %s", patchKubernetesVersion)
+
+	// Using polling to retry on any failed patch attempt. Sometimes if user upgrade
+	// workload cluster right after management cluster upgrade there is a chance
+	// that all controller pods are not started on management cluster
+	// and in this case patch fails. Retrying again should fix this issue.
+	pollOptions := &clusterclient.PollOptions{Interval: upgradePatchInterval, Timeout: upgradePatchTimeout}
+	err := regionalClusterClient.PatchResource(&capikubeadmv1alpha3.KubeadmControlPlane{}, clusterUpgradeConfig.KCPObjectName, clusterUpgradeConfig.KCPObjectNamespace, patchKubernetesVersion, types.MergePatchType, pollOptions)
+	if err != nil {
+		return errors.Wrap(err, "unable to update the kubernetes version for kubeadm control plane nodes")
+	}
+
+	operationTimeout := 15 * time.Minute
+	err = regionalClusterClient.PatchClusterWithOperationStartedStatus(clusterUpgradeConfig.ClusterName, clusterUpgradeConfig.ClusterNamespace, clusterclient.OperationTypeUpgrade, operationTimeout)
+	if err != nil {
+		log.V(6).Infof("unable to patch cluster object with operation status, %s", err.Error())
+	}
+
+	return nil
+}
+
+// kubeadmControlPlaneUpgradePatch returns the merge patch the upgrade applies to the KubeadmControlPlane, and false
+// if the KubeadmControlPlane is already up to date and is not patched.
+func kubeadmControlPlaneUpgradePatch(clusterUpgradeConfig *clusterUpgradeInfo) (string, bool) {
+	if clusterUpgradeConfig.ActualComponentInfo.KubernetesVersion == clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion &&
+		clusterUpgradeConfig.ActualComponentInfo.KCPInfrastructureTemplateName == clusterUpgradeConfig.UpgradeComponentInfo.KCPInfrastructureTemplateName {
+		return "", false
+	}
+
 	patchString := `{
 		"spec": {
 		  "version": "%s",
@@ -1040,7 +1181,7 @@ func (c *TkgClient) patchKubernetesVersionToKubeadmControlPlane(regionalClusterC
 		  }
 		}
 	  }`
-	patchKubernetesVersion := fmt.Sprintf(patchString,
+	return fmt.Sprintf(patchString,
 		clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion,
 		clusterUpgradeConfig.UpgradeComponentInfo.KCPInfrastructureTemplateName,
 		clusterUpgradeConfig.UpgradeComponentInfo.KCPInfrastructureTemplateNamespace,
@@ -1048,37 +1189,36 @@ func (c *TkgClient) patchKubernetesVersionToKubeadmControlPlane(regionalClusterC
 		clusterUpgradeConfig.UpgradeComponentInfo.CoreDNSImageRepository,
 		clusterUpgradeConfig.UpgradeComponentInfo.CoreDNSImageTag,
 		clusterUpgradeConfig.UpgradeComponentInfo.EtcdImageRepository,
-		clusterUpgradeConfig.UpgradeComponentInfo.EtcdImageTag)
-
-	log.V(3).Infof("Applying KubeadmControlPlane Patch: %s", patchKubernetesVersion)
+		clusterUpgradeConfig.UpgradeComponentInfo.EtcdImageTag), true
+}
 
-	// Using polling to retry on any failed patch attempt. Sometimes if user upgrade
-	// workload cluster right after management cluster upgrade there is a chance
-	// that all controller pods are not started on management cluster
-	// and in this case patch fails. Retrying again should fix this issue.
-	pollOptions := &clusterclient.PollOptions{Interval: upgradePatchInterval, Timeout: upgradePatchTimeout}
-	err := regionalClusterClient.PatchResource(&capikubeadmv1alpha3.KubeadmControlPlane{}, clusterUpgradeConfig.KCPObjectName, clusterUpgradeConfig.KCPObjectNamespace, patchKubernetesVersion, types.MergePatchType, pollOptions)
-	if err != nil {
-		return errors.Wrap(err, "unable to update the kubernetes version for kubeadm control plane nodes")
+func (c *TkgClient) patchKubernetesVersionToMachineDeployment(regionalClusterClient clusterclient.Client, clusterUpgradeConfig *clusterUpgradeInfo) error {
+	patches := machineDeploymentUpgradePatches(clusterUpgradeConfig)
+	if len(patches) < len(clusterUpgradeConfig.MDObjects) {
+		log.Infof("Skipping MachineDeployment patch as kubernetes versions are already same %s", clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion)
 	}
+	for i, patchKubernetesVersion := range patches {
+		log.V(3).Infof("Applying MachineDeployment Patch: %s", patchKubernetesVersion)
 
-	operationTimeout := 15 * time.Minute
-	err = regionalClusterClient.PatchClusterWithOperationStartedStatus(clusterUpgradeConfig.ClusterName, clusterUpgradeConfig.ClusterNamespace, clusterclient.OperationTypeUpgrade, operationTimeout)
-	if err != nil {
-		log.V(6).Infof("unable to patch cluster object with operation status, %s", err.Error())
+		// Using polling to retry on any failed patch attempt.
+		pollOptions := &clusterclient.PollOptions{Interval: upgradePatchInterval, Timeout: upgradePatchTimeout}
+		err := regionalClusterClient.PatchResource(&capi.MachineDeployment{}, clusterUpgradeConfig.MDObjects[i].Name, clusterUpgradeConfig.MDObjects[i].Namespace, patchKubernetesVersion, types.MergePatchType, pollOptions)
+		if err != nil {
+			return errors.Wrap(err, "unable to update the kubernetes version for worker nodes")
+		}
 	}
-
 	return nil
 }
 
-func (c *TkgClient) patchKubernetesVersionToMachineDeployment(regionalClusterClient clusterclient.Client, clusterUpgradeConfig *clusterUpgradeInfo) error {
-	var err error
+// machineDeploymentUpgradePatches returns the merge patches the upgrade applies to the MachineDeployments, in the order
+// of clusterUpgradeConfig.MDObjects. The patching stops at the first MachineDeployment which is already up to date.
+func machineDeploymentUpgradePatches(clusterUpgradeConfig *clusterUpgradeInfo) []string {
+	patches := []string{}
 	for i := range clusterUpgradeConfig.MDObjects {
 		if clusterUpgradeConfig.MDObjects[i].Spec.Template.Spec.Version != nil &&
 			clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion == *clusterUpgradeConfig.MDObjects[i].Spec.Template.Spec.Version &&
 			clusterUpgradeConfig.ActualComponentInfo.MDInfastructureTemplates[clusterUpgradeConfig.MDObjects[i].Name].MDInfrastructureTemplateName == clusterUpgradeConfig.UpgradeComponentInfo.MDInfastructureTemplates[clusterUpgradeConfig.MDObjects[i].Name].MDInfrastructureTemplateName {
-			log.Infof("Skipping MachineDeployment patch as kubernetes versions are already same %s", clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion)
-			return nil
+			return patches
 		}
 
 		patchString := `{
@@ -1095,21 +1235,12 @@ func (c *TkgClient) patchKubernetesVersionToMachineDeployment(regionalClusterCli
 			}
 		  }`
 
-		patchKubernetesVersion := fmt.Sprintf(patchString,
+		patches = append(patches, fmt.Sprintf(patchString,
 			clusterUpgradeConfig.UpgradeComponentInfo.KubernetesVersion,
 			clusterUpgradeConfig.UpgradeComponentInfo.MDInfastructureTemplates[clusterUpgradeConfig.MDObjects[i].Name].MDInfrastructureTemplateName,
-			clusterUpgradeConfig.UpgradeComponentInfo.MDInfastructureTemplates[clusterUpgradeConfig.MDObjects[i].Name].MDInfrastructureTemplateNamespace)
-
-		log.V(3).Infof("Applying MachineDeployment Patch: %s", patchKubernetesVersion)
-
-		// Using polling to retry on any failed patch attempt.
-		pollOptions := &clusterclient.PollOptions{Interval: upgradePatchInterval, Timeout: upgradePatchTimeout}
-		err = regionalClusterClient.PatchResource(&capi.MachineDeployment{}, clusterUpgradeConfig.MDObjects[i].Name, clusterUpgradeConfig.MDObjects[i].Namespace, patchKubernetesVersion, types.MergePatchType, pollOptions)
-		if err != nil {
-			return errors.Wrap(err, "unable to update the kubernetes version for worker nodes")
-		}
+			clusterUpgradeConfig.UpgradeComponentInfo.MDInfastructureTemplates[clusterUpgradeConfig.MDObjects[i].Name].MDInfrastructureTemplateNamespace))
 	}
-	return nil
+	return patches
 }
 
 // handleKappControllerUpgrade contains upgrade logic required for kapp-controller.
@@ -0,0 +1,476 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	capikubeadmv1alpha3 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgconfigbom"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/utils"
+	tkrconstants "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkr/pkg/constants"
+)
+
+// Actions of the objects in an upgrade plan
+const (
+	UpgradeActionCreate    = "create"
+	UpgradeActionUpdate    = "update"
+	UpgradeActionUnchanged = "unchanged"
+)
+
+// addonComponents are the components of the TKr BOM the addons and packages of a cluster are rendered from
+var addonComponents = map[string]bool{
+	"antrea":                    true,
+	"calico_all":                true,
+	"cloud_provider_vsphere":    true,
+	"csi_attacher":              true,
+	"csi_livenessprobe":         true,
+	"csi_node_driver_registrar": true,
+	"csi_provisioner":           true,
+	"kapp-controller":           true,
+	"metrics-server":            true,
+	"pinniped":                  true,
+	"tanzu-framework-addons":    true,
+	"tkg-core-packages":         true,
+	"tkg-standard-packages":     true,
+	"vsphere_csi_driver":        true,
+}
+
+// UpgradeClusterPlan describes the changes an upgrade makes to a cluster
+type UpgradeClusterPlan struct {
+	ClusterName           string                   `json:"clusterName" yaml:"clusterName"`
+	Namespace             string                   `json:"namespace" yaml:"namespace"`
+	FromTkrVersion        string                   `json:"fromTkrVersion,omitempty" yaml:"fromTkrVersion,omitempty"`
+	ToTkrVersion          string                   `json:"toTkrVersion" yaml:"toTkrVersion"`
+	FromKubernetesVersion string                   `json:"fromKubernetesVersion" yaml:"fromKubernetesVersion"`
+	ToKubernetesVersion   string                   `json:"toKubernetesVersion" yaml:"toKubernetesVersion"`
+	Objects               []UpgradeObjectChange    `json:"objects" yaml:"objects"`
+	Components            []UpgradeComponentChange `json:"components,omitempty" yaml:"components,omitempty"`
+	Addons                []UpgradeComponentChange `json:"addons,omitempty" yaml:"addons,omitempty"`
+}
+
+// UpgradeObjectChange describes the change an upgrade makes to an object on the management cluster
+type UpgradeObjectChange struct {
+	Kind      string               `json:"kind" yaml:"kind"`
+	Name      string               `json:"name" yaml:"name"`
+	Namespace string               `json:"namespace" yaml:"namespace"`
+	Action    string               `json:"action" yaml:"action"`
+	Source    string               `json:"source,omitempty" yaml:"source,omitempty"`
+	Changes   []UpgradeFieldChange `json:"changes,omitempty" yaml:"changes,omitempty"`
+}
+
+// UpgradeFieldChange is a change of a field of an object, the path is relative to the object
+type UpgradeFieldChange struct {
+	Path string `json:"path" yaml:"path"`
+	From string `json:"from" yaml:"from"`
+	To   string `json:"to" yaml:"to"`
+}
+
+// UpgradeComponentChange is a change of the version of a component
+type UpgradeComponentChange struct {
+	Name string `json:"name" yaml:"name"`
+	From string `json:"from" yaml:"from"`
+	To   string `json:"to" yaml:"to"`
+}
+
+// PlanClusterUpgrade computes the changes the upgrade of the cluster would make, without changing anything
+// Steps:
+// 1. Verify k8s version
+// 2. Get the Upgrade configuration the same way the upgrade does
+// 3. Compute the InfrastructureMachineTemplates required for upgrade without creating them
+// 4. Compute the KCP and MachineDeployment objects after the upgrade and diff them against the live objects
+// 5. Compare the component versions of the current and target TKr BOMs
+func (c *TkgClient) PlanClusterUpgrade(options *UpgradeClusterOptions) (*UpgradeClusterPlan, error) {
+	if options == nil {
+		return nil, errors.New("invalid upgrade cluster options nil")
+	}
+
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get current management cluster context")
+	}
+	options.Kubeconfig = currentRegion.SourceFilePath
+
+	log.V(4).Info("Creating management cluster client...")
+	regionalClusterClient, err := clusterclient.NewClient(currentRegion.SourceFilePath, currentRegion.ContextName, clusterclient.Options{OperationTimeout: c.timeout})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get cluster client while planning cluster upgrade")
+	}
+
+	isPacific, err := regionalClusterClient.IsPacificRegionalCluster()
+	if err != nil {
+		return nil, errors.Wrap(err, "error determining 'Tanzu Kubernetes Cluster service for vSphere' management cluster")
+	}
+	if isPacific {
+		return nil, errors.New("upgrade plan for 'Tanzu Kubernetes Cluster service for vSphere' clusters is not yet supported")
+	}
+
+	if options.IsRegionalCluster {
+		clusterName, namespace, err := c.getRegionalClusterNameAndNamespace(regionalClusterClient)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to get current management cluster information")
+		}
+		options.ClusterName = clusterName
+		options.Namespace = namespace
+	} else {
+		log.Info("Validating configuration...")
+		err = c.ValidateSupportOfK8sVersionForManagmentCluster(regionalClusterClient, options.KubernetesVersion, false)
+		if err != nil {
+			return nil, errors.Wrap(err, "validation error")
+		}
+	}
+
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+
+	var currentClusterClient clusterclient.Client
+	if options.IsRegionalCluster {
+		currentClusterClient = regionalClusterClient
+	} else {
+		log.V(4).Info("Creating workload cluster client...")
+		currentClusterClient, err = c.getWorkloadClusterClient(options.ClusterName, options.Namespace)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to get workload cluster client")
+		}
+	}
+
+	return c.DoPlanClusterUpgrade(regionalClusterClient, currentClusterClient, options)
+}
+
+// DoPlanClusterUpgrade computes the upgrade plan of the cluster
+func (c *TkgClient) DoPlanClusterUpgrade(regionalClusterClient, currentClusterClient clusterclient.Client, options *UpgradeClusterOptions) (*UpgradeClusterPlan, error) {
+	log.Info("Verifying kubernetes version...")
+	if err := c.verifyK8sVersion(currentClusterClient, options.KubernetesVersion); err != nil {
+		return nil, errors.Wrap(err, "kubernetes version verification failed")
+	}
+
+	if err := c.configureOSOptionsForUpgrade(regionalClusterClient, options); err != nil {
+		return nil, errors.Wrap(err, "error configuring os options during upgrade")
+	}
+
+	log.Info("Retrieving configuration for upgrade cluster...")
+	upgradeClusterConfig, err := c.getUpgradeClusterConfig(options)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to retrieve component upgrade info")
+	}
+	upgradeClusterConfig.DryRun = true
+
+	log.Info("Computing InfrastructureTemplate for upgrade...")
+	if err := c.createInfrastructureTemplateForUpgrade(regionalClusterClient, upgradeClusterConfig); err != nil {
+		return nil, errors.Wrap(err, "unable to compute infrastructure template for upgrade")
+	}
+
+	plan := &UpgradeClusterPlan{
+		ClusterName:           upgradeClusterConfig.ClusterName,
+		Namespace:             upgradeClusterConfig.ClusterNamespace,
+		ToTkrVersion:          upgradeClusterConfig.UpgradeComponentInfo.TkrVersion,
+		FromKubernetesVersion: upgradeClusterConfig.ActualComponentInfo.KubernetesVersion,
+		ToKubernetesVersion:   upgradeClusterConfig.UpgradeComponentInfo.KubernetesVersion,
+	}
+
+	cluster := &capi.Cluster{}
+	if err := regionalClusterClient.GetResource(cluster, options.ClusterName, options.Namespace, nil, nil); err != nil {
+		return nil, errors.Wrap(err, "unable to get cluster object")
+	}
+	if tkrName, ok := cluster.Labels[tkrconstants.BomConfigMapTKRLabel]; ok {
+		plan.FromTkrVersion = utils.GetTKRVersionFromTKRName(tkrName)
+	}
+
+	if err := c.planInfrastructureTemplates(regionalClusterClient, upgradeClusterConfig, plan); err != nil {
+		return nil, err
+	}
+	if err := c.planKubeadmControlPlane(regionalClusterClient, upgradeClusterConfig, plan); err != nil {
+		return nil, err
+	}
+	if err := planMachineDeployments(upgradeClusterConfig, plan); err != nil {
+		return nil, err
+	}
+
+	components, addons := c.planComponentVersions(regionalClusterClient, plan.FromTkrVersion, plan.ToTkrVersion)
+	plan.Components = components
+	if !options.IsRegionalCluster && !options.SkipAddonUpgrade {
+		plan.Addons = addons
+	}
+	return plan, nil
+}
+
+// planInfrastructureTemplates adds the infrastructure templates the upgrade creates to the plan, diffed against the
+// templates they are copied from.
+func (c *TkgClient) planInfrastructureTemplates(regionalClusterClient clusterclient.Client, upgradeClusterConfig *clusterUpgradeInfo, plan *UpgradeClusterPlan) error {
+	for _, template := range upgradeClusterConfig.PlannedTemplates {
+		accessor, err := meta.Accessor(template)
+		if err != nil {
+			return err
+		}
+		change := UpgradeObjectChange{
+			Kind:      reflect.TypeOf(template).Elem().Name(),
+			Name:      accessor.GetName(),
+			Namespace: accessor.GetNamespace(),
+			Action:    UpgradeActionCreate,
+			Source:    sourceInfrastructureTemplateName(upgradeClusterConfig, accessor.GetName()),
+		}
+		if change.Source != "" {
+			source := reflect.New(reflect.TypeOf(template).Elem()).Interface().(runtime.Object)
+			if err := regionalClusterClient.GetResource(source, change.Source, change.Namespace, nil, nil); err != nil {
+				return errors.Wrapf(err, "unable to get %s '%s' in namespace '%s'", change.Kind, change.Source, change.Namespace)
+			}
+			change.Changes, err = diffObjectSpecs(source, template)
+			if err != nil {
+				return err
+			}
+		}
+		plan.Objects = append(plan.Objects, change)
+	}
+	return nil
+}
+
+// sourceInfrastructureTemplateName returns the name of the template the upgrade template of the given name replaces.
+func sourceInfrastructureTemplateName(upgradeClusterConfig *clusterUpgradeInfo, name string) string {
+	if upgradeClusterConfig.UpgradeComponentInfo.KCPInfrastructureTemplateName == name {
+		return upgradeClusterConfig.ActualComponentInfo.KCPInfrastructureTemplateName
+	}
+	for mdName, info := range upgradeClusterConfig.UpgradeComponentInfo.MDInfastructureTemplates {
+		if info.MDInfrastructureTemplateName == name {
+			return upgradeClusterConfig.ActualComponentInfo.MDInfastructureTemplates[mdName].MDInfrastructureTemplateName
+		}
+	}
+	return ""
+}
+
+// planKubeadmControlPlane adds the changes of the KubeadmControlPlane patch applied by the upgrade to the plan.
+func (c *TkgClient) planKubeadmControlPlane(regionalClusterClient clusterclient.Client, upgradeClusterConfig *clusterUpgradeInfo, plan *UpgradeClusterPlan) error {
+	kcp, err := regionalClusterClient.GetKCPObjectForCluster(upgradeClusterConfig.ClusterName, upgradeClusterConfig.ClusterNamespace)
+	if err != nil {
+		return errors.Wrapf(err, "unable to find control plane node object for cluster %s", upgradeClusterConfig.ClusterName)
+	}
+	change, err := kubeadmControlPlaneUpgradeChange(kcp, upgradeClusterConfig)
+	if err != nil {
+		return err
+	}
+	plan.Objects = append(plan.Objects, change)
+	return nil
+}
+
+// kubeadmControlPlaneUpgradeChange returns the change the KubeadmControlPlane patch of the upgrade makes to the kcp.
+func kubeadmControlPlaneUpgradeChange(kcp *capikubeadmv1alpha3.KubeadmControlPlane, upgradeClusterConfig *clusterUpgradeInfo) (UpgradeObjectChange, error) {
+	change := UpgradeObjectChange{
+		Kind:      reflect.TypeOf(capikubeadmv1alpha3.KubeadmControlPlane{}).Name(),
+		Name:      kcp.Name,
+		Namespace: kcp.Namespace,
+		Action:    UpgradeActionUnchanged,
+	}
+	patch, ok := kubeadmControlPlaneUpgradePatch(upgradeClusterConfig)
+	if !ok {
+		return change, nil
+	}
+	upgraded := kcp.DeepCopy()
+	if err := applyUpgradePatch(upgraded, patch); err != nil {
+		return change, err
+	}
+	changes, err := diffObjectSpecs(kcp, upgraded)
+	if err != nil {
+		return change, err
+	}
+	change.Action = UpgradeActionUpdate
+	change.Changes = changes
+	return change, nil
+}
+
+// planMachineDeployments adds the changes of the MachineDeployment patches applied by the upgrade to the plan.
+func planMachineDeployments(upgradeClusterConfig *clusterUpgradeInfo, plan *UpgradeClusterPlan) error {
+	patches := machineDeploymentUpgradePatches(upgradeClusterConfig)
+	for i := range upgradeClusterConfig.MDObjects {
+		md := &upgradeClusterConfig.MDObjects[i]
+		change := UpgradeObjectChange{
+			Kind:      reflect.TypeOf(capi.MachineDeployment{}).Name(),
+			Name:      md.Name,
+			Namespace: md.Namespace,
+			Action:    UpgradeActionUnchanged,
+		}
+		if i < len(patches) {
+			upgraded := md.DeepCopy()
+			if err := applyUpgradePatch(upgraded, patches[i]); err != nil {
+				return err
+			}
+			changes, err := diffObjectSpecs(md, upgraded)
+			if err != nil {
+				return err
+			}
+			if len(changes) != 0 {
+				change.Action = UpgradeActionUpdate
+				change.Changes = changes
+			}
+		}
+		plan.Objects = append(plan.Objects, change)
+	}
+	return nil
+}
+
+// applyUpgradePatch applies the merge patch of the upgrade to the object. The patches of the upgrade only set fields,
+// so decoding the patch over the object gives the same result as the merge patch applied by the API server.
+func applyUpgradePatch(obj runtime.Object, patch string) error {
+	if err := json.Unmarshal([]byte(patch), obj); err != nil {
+		return errors.Wrap(err, "unable to apply the upgrade patch")
+	}
+	return nil
+}
+
+// planComponentVersions compares the component versions of the BOMs of the current and target TKr. The changes of the
+// addon and package components are returned apart from the changes of the other components.
+func (c *TkgClient) planComponentVersions(regionalClusterClient clusterclient.Client, fromTkrVersion, toTkrVersion string) ([]UpgradeComponentChange, []UpgradeComponentChange) {
+	toBOM, err := c.tkgBomClient.GetBOMConfigurationFromTkrVersion(toTkrVersion)
+	if err != nil {
+		log.V(3).Infof("unable to get the BOM of the TanzuKubernetesRelease %s: %v", toTkrVersion, err)
+		return nil, nil
+	}
+	fromBOM := &tkgconfigbom.BOMConfiguration{}
+	if fromTkrVersion != "" {
+		fromBOM, err = c.getClusterTkrBOM(regionalClusterClient, fromTkrVersion)
+		if err != nil {
+			log.V(3).Infof("unable to get the BOM of the current TanzuKubernetesRelease %s: %v", fromTkrVersion, err)
+			fromBOM = &tkgconfigbom.BOMConfiguration{}
+		}
+	}
+	return diffBOMComponentVersions(fromBOM, toBOM)
+}
+
+// diffBOMComponentVersions returns the changes of the versions of the components of the BOMs, and apart from them the
+// changes of the versions of the addon and package components.
+func diffBOMComponentVersions(fromBOM, toBOM *tkgconfigbom.BOMConfiguration) ([]UpgradeComponentChange, []UpgradeComponentChange) {
+	fromComponents, fromAddons := splitAddonComponents(fromBOM.Components)
+	toComponents, toAddons := splitAddonComponents(toBOM.Components)
+	return diffComponentVersions(fromComponents, toComponents), diffComponentVersions(fromAddons, toAddons)
+}
+
+// splitAddonComponents splits the components of a BOM into the addon and package components and the other components.
+func splitAddonComponents(components map[string][]*tkgconfigbom.ComponentInfo) (map[string][]*tkgconfigbom.ComponentInfo, map[string][]*tkgconfigbom.ComponentInfo) {
+	others := map[string][]*tkgconfigbom.ComponentInfo{}
+	addons := map[string][]*tkgconfigbom.ComponentInfo{}
+	for name, infos := range components {
+		if addonComponents[name] {
+			addons[name] = infos
+		} else {
+			others[name] = infos
+		}
+	}
+	return others, addons
+}
+
+// getClusterTkrBOM returns the BOM of the TKr from the local BOM files, or the BOM ConfigMap on the management cluster.
+func (c *TkgClient) getClusterTkrBOM(regionalClusterClient clusterclient.Client, tkrVersion string) (*tkgconfigbom.BOMConfiguration, error) {
+	if bom, err := c.tkgBomClient.GetBOMConfigurationFromTkrVersion(tkrVersion); err == nil {
+		return bom, nil
+	}
+	cm, err := regionalClusterClient.GetBomConfigMap(utils.GetTkrNameFromTkrVersion(tkrVersion))
+	if err != nil {
+		return nil, err
+	}
+	bom := &tkgconfigbom.BOMConfiguration{}
+	if err := yaml.Unmarshal(cm.BinaryData[tkrconstants.BomConfigMapContentKey], bom); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal BOM ConfigMap content")
+	}
+	return bom, nil
+}
+
+// diffComponentVersions returns the components which versions differ, sorted by name.
+func diffComponentVersions(from, to map[string][]*tkgconfigbom.ComponentInfo) []UpgradeComponentChange {
+	componentVersion := func(components map[string][]*tkgconfigbom.ComponentInfo, name string) string {
+		if len(components[name]) == 0 || components[name][0] == nil {
+			return ""
+		}
+		return components[name][0].Version
+	}
+
+	names := map[string]bool{}
+	for name := range from {
+		names[name] = true
+	}
+	for name := range to {
+		names[name] = true
+	}
+	changes := []UpgradeComponentChange{}
+	for name := range names {
+		fromVersion, toVersion := componentVersion(from, name), componentVersion(to, name)
+		if fromVersion != toVersion {
+			changes = append(changes, UpgradeComponentChange{Name: name, From: fromVersion, To: toVersion})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+// diffObjectSpecs returns the changes between the specs of the objects.
+func diffObjectSpecs(from, to runtime.Object) ([]UpgradeFieldChange, error) {
+	fromFields, err := objectSpecFields(from)
+	if err != nil {
+		return nil, err
+	}
+	toFields, err := objectSpecFields(to)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := map[string]bool{}
+	for path := range fromFields {
+		paths[path] = true
+	}
+	for path := range toFields {
+		paths[path] = true
+	}
+	changes := []UpgradeFieldChange{}
+	for path := range paths {
+		if fromFields[path] != toFields[path] {
+			changes = append(changes, UpgradeFieldChange{Path: path, From: fromFields[path], To: toFields[path]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// objectSpecFields flattens the spec of the object to its leaf fields by path.
+func objectSpecFields(obj runtime.Object) (map[string]string, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to convert object")
+	}
+	fields := map[string]string{}
+	flattenFields("spec", content["spec"], fields)
+	return fields, nil
+}
+
+func flattenFields(path string, value interface{}, fields map[string]string) {
+	switch v := value.(type) {
+	case nil:
+	case map[string]interface{}:
+		for key, child := range v {
+			flattenFields(path+"."+key, child, fields)
+		}
+	case []interface{}:
+		for i, child := range v {
+			flattenFields(fmt.Sprintf("%s[%d]", path, i), child, fields)
+		}
+	case string:
+		fields[path] = v
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			fields[path] = fmt.Sprint(v)
+			return
+		}
+		fields[path] = string(b)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	kubeadmv1beta1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/v1beta1"
+	capikubeadmv1alpha3 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgconfigbom"
+)
+
+var _ = Describe("Upgrade cluster plan", func() {
+	Describe("diffObjectSpecs", func() {
+		It("returns the changed spec fields sorted by path", func() {
+			from := &capi.MachineDeployment{Spec: capi.MachineDeploymentSpec{
+				ClusterName: "fake-cluster",
+				Replicas:    pointer.Int32Ptr(1),
+				Template: capi.MachineTemplateSpec{Spec: capi.MachineSpec{
+					Version: pointer.StringPtr("v1.17.3+vmware.2"),
+				}},
+			}}
+			to := from.DeepCopy()
+			to.Spec.Template.Spec.Version = pointer.StringPtr("v1.18.0+vmware.1")
+			to.Spec.Template.Spec.InfrastructureRef.Name = "fake-template-v1.18.0"
+
+			changes, err := diffObjectSpecs(from, to)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(Equal([]UpgradeFieldChange{
+				{Path: "spec.template.spec.infrastructureRef.name", From: "", To: "fake-template-v1.18.0"},
+				{Path: "spec.template.spec.version", From: "v1.17.3+vmware.2", To: "v1.18.0+vmware.1"},
+			}))
+		})
+		It("returns no changes for identical specs", func() {
+			md := &capi.MachineDeployment{Spec: capi.MachineDeploymentSpec{Replicas: pointer.Int32Ptr(3)}}
+			changes, err := diffObjectSpecs(md, md.DeepCopy())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(BeEmpty())
+		})
+	})
+
+	Describe("diffBOMComponentVersions", func() {
+		It("returns the changes of the addon and package components apart from the other components", func() {
+			from := &tkgconfigbom.BOMConfiguration{Components: map[string][]*tkgconfigbom.ComponentInfo{
+				"antrea":                {{Version: "v0.11.3+vmware.1"}},
+				"kubernetes":            {{Version: "v1.17.3+vmware.2"}},
+				"tkg-standard-packages": {{Version: "v1.4.0"}},
+				"vsphere_csi_driver":    {{Version: "v2.1.0+vmware.1"}},
+			}}
+			to := &tkgconfigbom.BOMConfiguration{Components: map[string][]*tkgconfigbom.ComponentInfo{
+				"antrea":                {{Version: "v0.13.3+vmware.1"}},
+				"kubernetes":            {{Version: "v1.18.0+vmware.1"}},
+				"tkg-standard-packages": {{Version: "v1.4.1"}},
+				"vsphere_csi_driver":    {{Version: "v2.1.0+vmware.1"}},
+			}}
+			components, addons := diffBOMComponentVersions(from, to)
+			Expect(components).To(Equal([]UpgradeComponentChange{
+				{Name: "kubernetes", From: "v1.17.3+vmware.2", To: "v1.18.0+vmware.1"},
+			}))
+			Expect(addons).To(Equal([]UpgradeComponentChange{
+				{Name: "antrea", From: "v0.11.3+vmware.1", To: "v0.13.3+vmware.1"},
+				{Name: "tkg-standard-packages", From: "v1.4.0", To: "v1.4.1"},
+			}))
+		})
+	})
+
+	Describe("diffComponentVersions", func() {
+		It("returns the added, removed and changed components sorted by name", func() {
+			from := map[string][]*tkgconfigbom.ComponentInfo{
+				"antrea":     {{Version: "v0.11.3+vmware.1"}},
+				"etcd":       {{Version: "v3.4.13+vmware.4"}},
+				"kubernetes": {{Version: "v1.17.3+vmware.2"}},
+			}
+			to := map[string][]*tkgconfigbom.ComponentInfo{
+				"antrea":     {{Version: "v0.11.3+vmware.1"}},
+				"kubernetes": {{Version: "v1.18.0+vmware.1"}},
+				"pinniped":   {{Version: "v0.4.1+vmware.1"}},
+			}
+			Expect(diffComponentVersions(from, to)).To(Equal([]UpgradeComponentChange{
+				{Name: "etcd", From: "v3.4.13+vmware.4", To: ""},
+				{Name: "kubernetes", From: "v1.17.3+vmware.2", To: "v1.18.0+vmware.1"},
+				{Name: "pinniped", From: "", To: "v0.4.1+vmware.1"},
+			}))
+		})
+	})
+
+	Describe("kubeadmControlPlaneUpgradeChange", func() {
+		var (
+			kcp                  *capikubeadmv1alpha3.KubeadmControlPlane
+			upgradeClusterConfig *clusterUpgradeInfo
+		)
+
+		BeforeEach(func() {
+			kcp = &capikubeadmv1alpha3.KubeadmControlPlane{}
+			kcp.Name = "fake-kcp"
+			kcp.Namespace = "fake-namespace"
+			kcp.Spec.Version = "v1.17.3+vmware.2"
+			kcp.Spec.InfrastructureTemplate.Name = "fake-kcp-template"
+			kcp.Spec.InfrastructureTemplate.Namespace = "fake-namespace"
+			kcp.Spec.KubeadmConfigSpec.ClusterConfiguration = &kubeadmv1beta1.ClusterConfiguration{
+				ImageRepository: "fake-image-repo",
+				Etcd:            kubeadmv1beta1.Etcd{Local: &kubeadmv1beta1.LocalEtcd{DataDir: "/var/lib/etcd"}},
+			}
+
+			upgradeClusterConfig = &clusterUpgradeInfo{
+				ClusterName:      "fake-cluster",
+				ClusterNamespace: "fake-namespace",
+				ActualComponentInfo: componentInfo{
+					KubernetesVersion:             "v1.17.3+vmware.2",
+					KCPInfrastructureTemplateName: "fake-kcp-template",
+				},
+				UpgradeComponentInfo: componentInfo{
+					KubernetesVersion:                  "v1.18.0+vmware.1",
+					KCPInfrastructureTemplateName:      "fake-kcp-template-v1.18.0",
+					KCPInfrastructureTemplateNamespace: "fake-namespace",
+					ImageRepository:                    "fake-image-repo",
+					CoreDNSImageRepository:             "fake-image-repo",
+					CoreDNSImageTag:                    "v1.6.7_vmware.1",
+					EtcdImageRepository:                "fake-image-repo",
+					EtcdImageTag:                       "v3.4.3_vmware.5",
+				},
+			}
+		})
+
+		It("returns the changes of the patch applied by the upgrade", func() {
+			change, err := kubeadmControlPlaneUpgradeChange(kcp, upgradeClusterConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(change).To(Equal(UpgradeObjectChange{
+				Kind:      "KubeadmControlPlane",
+				Name:      "fake-kcp",
+				Namespace: "fake-namespace",
+				Action:    UpgradeActionUpdate,
+				Changes: []UpgradeFieldChange{
+					{Path: "spec.infrastructureTemplate.name", From: "fake-kcp-template", To: "fake-kcp-template-v1.18.0"},
+					{Path: "spec.kubeadmConfigSpec.clusterConfiguration.dns.imageRepository", From: "", To: "fake-image-repo"},
+					{Path: "spec.kubeadmConfigSpec.clusterConfiguration.dns.imageTag", From: "", To: "v1.6.7_vmware.1"},
+					{Path: "spec.kubeadmConfigSpec.clusterConfiguration.etcd.local.imageRepository", From: "", To: "fake-image-repo"},
+					{Path: "spec.kubeadmConfigSpec.clusterConfiguration.etcd.local.imageTag", From: "", To: "v3.4.3_vmware.5"},
+					{Path: "spec.version", From: "v1.17.3+vmware.2", To: "v1.18.0+vmware.1"},
+				},
+			}))
+		})
+
+		It("returns an unchanged KubeadmControlPlane when the upgrade skips the patch", func() {
+			upgradeClusterConfig.UpgradeComponentInfo.KubernetesVersion = "v1.17.3+vmware.2"
+			upgradeClusterConfig.UpgradeComponentInfo.KCPInfrastructureTemplateName = "fake-kcp-template"
+			change, err := kubeadmControlPlaneUpgradeChange(kcp, upgradeClusterConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(change.Action).To(Equal(UpgradeActionUnchanged))
+			Expect(change.Changes).To(BeEmpty())
+		})
+	})
+
+	Describe("planMachineDeployments", func() {
+		var upgradeClusterConfig *clusterUpgradeInfo
+
+		BeforeEach(func() {
+			md := capi.MachineDeployment{}
+			md.Name = "fake-md"
+			md.Namespace = "fake-namespace"
+			md.Spec.Template.Spec.Version = pointer.StringPtr("v1.17.3+vmware.2")
+			md.Spec.Template.Spec.InfrastructureRef.Name = "fake-md-template"
+			md.Spec.Template.Spec.InfrastructureRef.Namespace = "fake-namespace"
+			upgradeClusterConfig = &clusterUpgradeInfo{
+				MDObjects: []capi.MachineDeployment{md},
+				ActualComponentInfo: componentInfo{
+					MDInfastructureTemplates: map[string]mdInfastructureTemplateInfo{
+						"fake-md": {MDInfrastructureTemplateName: "fake-md-template", MDInfrastructureTemplateNamespace: "fake-namespace"},
+					},
+				},
+				UpgradeComponentInfo: componentInfo{
+					KubernetesVersion: "v1.18.0+vmware.1",
+					MDInfastructureTemplates: map[string]mdInfastructureTemplateInfo{
+						"fake-md": {MDInfrastructureTemplateName: "fake-md-template-v1.18.0", MDInfrastructureTemplateNamespace: "fake-namespace"},
+					},
+				},
+			}
+		})
+
+		It("returns the changes of the patches applied by the upgrade", func() {
+			plan := &UpgradeClusterPlan{}
+			Expect(planMachineDeployments(upgradeClusterConfig, plan)).To(Succeed())
+			Expect(plan.Objects).To(Equal([]UpgradeObjectChange{{
+				Kind:      "MachineDeployment",
+				Name:      "fake-md",
+				Namespace: "fake-namespace",
+				Action:    UpgradeActionUpdate,
+				Changes: []UpgradeFieldChange{
+					{Path: "spec.template.spec.infrastructureRef.name", From: "fake-md-template", To: "fake-md-template-v1.18.0"},
+					{Path: "spec.template.spec.version", From: "v1.17.3+vmware.2", To: "v1.18.0+vmware.1"},
+				},
+			}}))
+			Expect(machineDeploymentUpgradePatches(upgradeClusterConfig)).To(HaveLen(1))
+		})
+
+		It("returns an unchanged MachineDeployment when the upgrade skips the patch", func() {
+			upgradeClusterConfig.UpgradeComponentInfo.KubernetesVersion = "v1.17.3+vmware.2"
+			upgradeClusterConfig.UpgradeComponentInfo.MDInfastructureTemplates = upgradeClusterConfig.ActualComponentInfo.MDInfastructureTemplates
+			plan := &UpgradeClusterPlan{}
+			Expect(planMachineDeployments(upgradeClusterConfig, plan)).To(Succeed())
+			Expect(plan.Objects).To(HaveLen(1))
+			Expect(plan.Objects[0].Action).To(Equal(UpgradeActionUnchanged))
+			Expect(machineDeploymentUpgradePatches(upgradeClusterConfig)).To(BeEmpty())
+		})
+	})
+})
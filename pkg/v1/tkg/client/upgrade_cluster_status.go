@@ -0,0 +1,198 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+
+	"github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha1/upgrade"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterclient"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+)
+
+// UpgradeStatus describes the progress of the kubernetes upgrade of a cluster.
+// It is persisted on the cluster object so that an interrupted upgrade can be
+// resumed from the last completed step.
+type UpgradeStatus struct {
+	ClusterName          string        `json:"clusterName" yaml:"clusterName"`
+	Namespace            string        `json:"namespace" yaml:"namespace"`
+	TkrVersion           string        `json:"tkrVersion" yaml:"tkrVersion"`
+	KubernetesVersion    string        `json:"kubernetesVersion" yaml:"kubernetesVersion"`
+	Phase                upgrade.Phase `json:"phase" yaml:"phase"`
+	Step                 string        `json:"step" yaml:"step"`
+	TemplateSuffix       string        `json:"templateSuffix" yaml:"templateSuffix"`
+	StartTimestamp       string        `json:"startTimestamp" yaml:"startTimestamp"`
+	LastUpdatedTimestamp string        `json:"lastUpdatedTimestamp" yaml:"lastUpdatedTimestamp"`
+}
+
+// upgradeSteps lists the checkpoints of the upgrade in the order they are reached
+var upgradeSteps = []upgradeStatus{
+	upgradeStateInitiated,
+	upgradeStateInfraTemplatesCreated,
+	upgradeStateKCPPatchApplied,
+	upgradeStateKCPUpgraded,
+	upgradeStateMDPatchApplied,
+	upgradeStateMDUpgraded,
+	upgradeStateSuccess,
+}
+
+func upgradeStepIndex(step upgradeStatus) int {
+	for i := range upgradeSteps {
+		if upgradeSteps[i] == step {
+			return i
+		}
+	}
+	return -1
+}
+
+// upgradePhaseForStep returns the upgrade phase the cluster is in once the step is completed
+func upgradePhaseForStep(step upgradeStatus) upgrade.Phase {
+	switch step {
+	case upgradeStateKCPUpgraded, upgradeStateMDPatchApplied, upgradeStateMDUpgraded:
+		return upgrade.WorkerPlane
+	case upgradeStateSuccess:
+		return upgrade.Success
+	default:
+		return upgrade.ControlPlane
+	}
+}
+
+// isStepCompleted returns true if the step was completed by the upgrade being resumed
+func (u *clusterUpgradeInfo) isStepCompleted(step upgradeStatus) bool {
+	if u.ResumeState == "" {
+		return false
+	}
+	return upgradeStepIndex(u.ResumeState) >= upgradeStepIndex(step)
+}
+
+// GetClusterUpgradeStatus returns the status of the last upgrade of the cluster,
+// or nil if the cluster has no recorded upgrade
+func (c *TkgClient) GetClusterUpgradeStatus(options *UpgradeClusterOptions) (*UpgradeStatus, error) {
+	if options == nil {
+		return nil, errors.New("invalid upgrade cluster options nil")
+	}
+
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get current management cluster context")
+	}
+
+	regionalClusterClient, err := clusterclient.NewClient(currentRegion.SourceFilePath, currentRegion.ContextName, clusterclient.Options{OperationTimeout: c.timeout})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get cluster client while getting cluster upgrade status")
+	}
+
+	isPacific, err := regionalClusterClient.IsPacificRegionalCluster()
+	if err != nil {
+		return nil, errors.Wrap(err, "error determining 'Tanzu Kubernetes Cluster service for vSphere' management cluster")
+	}
+	if isPacific {
+		return nil, errors.New("upgrade status of 'Tanzu Kubernetes Cluster service for vSphere' clusters is not yet supported")
+	}
+
+	if options.IsRegionalCluster {
+		options.ClusterName, options.Namespace, err = c.getRegionalClusterNameAndNamespace(regionalClusterClient)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to get current management cluster information")
+		}
+	}
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+
+	return c.getUpgradeStatus(regionalClusterClient, options.ClusterName, options.Namespace)
+}
+
+// getUpgradeStatus reads the upgrade status persisted on the cluster object
+func (c *TkgClient) getUpgradeStatus(regionalClusterClient clusterclient.Client, clusterName, namespace string) (*UpgradeStatus, error) {
+	clusterObject := &capi.Cluster{}
+	if err := regionalClusterClient.GetResource(clusterObject, clusterName, namespace, nil, nil); err != nil {
+		return nil, errors.Wrapf(err, "unable to get cluster object '%s' in namespace '%s'", clusterName, namespace)
+	}
+
+	statusString, exists := clusterObject.Annotations[clusterclient.TKGUpgradeStatusKey]
+	if !exists || statusString == "" {
+		return nil, nil
+	}
+	status := &UpgradeStatus{}
+	if err := json.Unmarshal([]byte(statusString), status); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse the upgrade status of cluster '%s'", clusterName)
+	}
+	return status, nil
+}
+
+// resumeUpgrade restores the progress of an interrupted upgrade of the cluster to the same TKr,
+// so that the completed steps are skipped and the infrastructure templates are created with the same names
+func (c *TkgClient) resumeUpgrade(regionalClusterClient clusterclient.Client, upgradeClusterConfig *clusterUpgradeInfo) error {
+	status, err := c.getUpgradeStatus(regionalClusterClient, upgradeClusterConfig.ClusterName, upgradeClusterConfig.ClusterNamespace)
+	if err != nil {
+		return err
+	}
+	if status == nil || upgradeStatus(status.Step) == upgradeStateSuccess {
+		return nil
+	}
+	if status.TkrVersion != upgradeClusterConfig.UpgradeComponentInfo.TkrVersion {
+		log.Warningf("Previous upgrade of cluster '%s' to '%s' did not complete, starting upgrade to '%s'",
+			upgradeClusterConfig.ClusterName, status.TkrVersion, upgradeClusterConfig.UpgradeComponentInfo.TkrVersion)
+		return nil
+	}
+
+	log.Infof("Resuming upgrade of cluster '%s' after step '%s'...", upgradeClusterConfig.ClusterName, status.Step)
+	upgradeClusterConfig.ResumeState = upgradeStatus(status.Step)
+	upgradeClusterConfig.StartTimestamp = status.StartTimestamp
+	if status.TemplateSuffix != "" {
+		upgradeClusterConfig.TemplateSuffix = status.TemplateSuffix
+	}
+	return nil
+}
+
+// setUpgradeState records the step reached by the upgrade and persists it on the cluster object
+func (c *TkgClient) setUpgradeState(regionalClusterClient clusterclient.Client, upgradeClusterConfig *clusterUpgradeInfo, state upgradeStatus) {
+	upgradeClusterConfig.UpgradeState = state
+	if upgradeClusterConfig.DryRun {
+		return
+	}
+
+	currentTimestamp := time.Now().UTC().String()
+	if upgradeClusterConfig.StartTimestamp == "" {
+		upgradeClusterConfig.StartTimestamp = currentTimestamp
+	}
+	status := UpgradeStatus{
+		ClusterName:          upgradeClusterConfig.ClusterName,
+		Namespace:            upgradeClusterConfig.ClusterNamespace,
+		TkrVersion:           upgradeClusterConfig.UpgradeComponentInfo.TkrVersion,
+		KubernetesVersion:    upgradeClusterConfig.UpgradeComponentInfo.KubernetesVersion,
+		Phase:                upgradePhaseForStep(state),
+		Step:                 string(state),
+		TemplateSuffix:       upgradeClusterConfig.TemplateSuffix,
+		StartTimestamp:       upgradeClusterConfig.StartTimestamp,
+		LastUpdatedTimestamp: currentTimestamp,
+	}
+	statusBytes, err := json.Marshal(status)
+	if err != nil {
+		log.V(6).Infof("unable to marshal upgrade status, %s", err.Error())
+		return
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				clusterclient.TKGUpgradeStatusKey: string(statusBytes),
+			},
+		},
+	})
+	if err != nil {
+		log.V(6).Infof("unable to marshal upgrade status patch, %s", err.Error())
+		return
+	}
+
+	log.V(6).Infof("patch cluster object with upgrade status: %s", string(patch))
+	if err := regionalClusterClient.PatchClusterObject(upgradeClusterConfig.ClusterName, upgradeClusterConfig.ClusterNamespace, string(patch)); err != nil {
+		log.Warningf("Warning: unable to record upgrade progress on cluster '%s', %s", upgradeClusterConfig.ClusterName, err.Error())
+	}
+}
@@ -6,6 +6,7 @@ package client_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -33,6 +34,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/types"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/utils"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/vc"
+	tkrconstants "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkr/pkg/constants"
 )
 
 var (
@@ -177,6 +179,48 @@ var _ = Describe("Unit tests for upgrade cluster", func() {
 				Expect(err.Error()).To(ContainSubstring("attempted to upgrade kubernetes from v1.18.5+vmware.1 to v1.18.0+vmware.1. Kubernetes version downgrade is not allowed."))
 			})
 		})
+		Context("When the upgrade completes", func() {
+			It("persists the upgrade progress on the cluster object", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(regionalClusterClient.PatchClusterObjectCallCount()).To(BeNumerically(">", 0))
+				_, _, patch := regionalClusterClient.PatchClusterObjectArgsForCall(regionalClusterClient.PatchClusterObjectCallCount() - 1)
+				Expect(patch).To(ContainSubstring(clusterclient.TKGUpgradeStatusKey))
+				Expect(patch).To(ContainSubstring(`\"step\":\"Success\"`))
+			})
+		})
+		Context("When resuming an interrupted upgrade to the same TKr", func() {
+			BeforeEach(func() {
+				regionalClusterClient.GetResourceCalls(func(resourceReference interface{}, resourceName, namespace string, postVerify clusterclient.PostVerifyrFunc, pollOptions *clusterclient.PollOptions) error {
+					if clusterObj, ok := resourceReference.(*capi.Cluster); ok {
+						clusterObj.Annotations = map[string]string{
+							clusterclient.TKGUpgradeStatusKey: `{"tkrVersion":"` + newTKRVersion + `","step":"KCPUpgraded","templateSuffix":"abcde"}`,
+						}
+					}
+					return nil
+				})
+			})
+			It("skips the completed steps", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(regionalClusterClient.WaitK8sVersionUpdateForCPNodesCallCount()).To(Equal(0))
+				Expect(regionalClusterClient.WaitK8sVersionUpdateForWorkerNodesCallCount()).To(Equal(1))
+			})
+		})
+		Context("When the interrupted upgrade was to a different TKr", func() {
+			BeforeEach(func() {
+				regionalClusterClient.GetResourceCalls(func(resourceReference interface{}, resourceName, namespace string, postVerify clusterclient.PostVerifyrFunc, pollOptions *clusterclient.PollOptions) error {
+					if clusterObj, ok := resourceReference.(*capi.Cluster); ok {
+						clusterObj.Annotations = map[string]string{
+							clusterclient.TKGUpgradeStatusKey: `{"tkrVersion":"v1.17.16+vmware.1-tkg.1","step":"KCPUpgraded"}`,
+						}
+					}
+					return nil
+				})
+			})
+			It("starts the upgrade from the beginning", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(regionalClusterClient.WaitK8sVersionUpdateForCPNodesCallCount()).To(Equal(1))
+			})
+		})
 		Context("When KCP object retrival fails from management cluster", func() {
 			BeforeEach(func() {
 				regionalClusterClient.GetKCPObjectForClusterReturns(nil, errors.New("fake-error"))
@@ -204,6 +248,7 @@ var _ = Describe("Unit tests for upgrade cluster", func() {
 			Context("When get VSphereMachineTemplate fails", func() {
 				BeforeEach(func() {
 					regionalClusterClient.GetResourceReturnsOnCall(0, nil)
+					regionalClusterClient.GetResourceReturnsOnCall(1, nil)
 					regionalClusterClient.GetResourceReturns(errors.New("fake-error"))
 				})
 				It("returns an error", func() {
@@ -216,7 +261,8 @@ var _ = Describe("Unit tests for upgrade cluster", func() {
 				BeforeEach(func() {
 					regionalClusterClient.GetResourceReturnsOnCall(0, nil)
 					regionalClusterClient.GetResourceReturnsOnCall(1, nil)
-					regionalClusterClient.GetResourceReturnsOnCall(2, errors.New("fake-error"))
+					regionalClusterClient.GetResourceReturnsOnCall(2, nil)
+					regionalClusterClient.GetResourceReturnsOnCall(3, errors.New("fake-error"))
 					regionalClusterClient.CreateResourceReturns(errors.New("fake-error-create-resource"))
 				})
 				It("returns an error", func() {
@@ -243,7 +289,8 @@ var _ = Describe("Unit tests for upgrade cluster", func() {
 			Context("When get AWSCluster object fails", func() {
 				BeforeEach(func() {
 					regionalClusterClient.GetResourceReturnsOnCall(0, nil)
-					regionalClusterClient.GetResourceReturnsOnCall(1, errors.New("fake-error"))
+					regionalClusterClient.GetResourceReturnsOnCall(1, nil)
+					regionalClusterClient.GetResourceReturnsOnCall(2, errors.New("fake-error"))
 				})
 				It("returns an error", func() {
 					Expect(err).To(HaveOccurred())
@@ -254,7 +301,7 @@ var _ = Describe("Unit tests for upgrade cluster", func() {
 			Context("When get AWSMachineTemplate object fails", func() {
 				BeforeEach(func() {
 					regionalClusterClient.GetResourceCalls(func(resourceReference interface{}, resourceName, namespace string, postVerify clusterclient.PostVerifyrFunc, pollOptions *clusterclient.PollOptions) error {
-						if regionalClusterClient.GetResourceCallCount() == 3 {
+						if regionalClusterClient.GetResourceCallCount() == 4 {
 							return errors.New("fake-error")
 						}
 						clusterObj, ok := resourceReference.(*capav1alpha3.AWSCluster)
@@ -274,7 +321,7 @@ var _ = Describe("Unit tests for upgrade cluster", func() {
 			Context("When get AWSMachineTemplate object fails", func() {
 				BeforeEach(func() {
 					regionalClusterClient.GetResourceCalls(func(resourceReference interface{}, resourceName, namespace string, postVerify clusterclient.PostVerifyrFunc, pollOptions *clusterclient.PollOptions) error {
-						if regionalClusterClient.GetResourceCallCount() == 3 {
+						if regionalClusterClient.GetResourceCallCount() == 4 {
 							return errors.New("fake-error")
 						}
 						clusterObj, ok := resourceReference.(*capav1alpha3.AWSCluster)
@@ -294,7 +341,7 @@ var _ = Describe("Unit tests for upgrade cluster", func() {
 			Context("When create AWSMachineTemplate fails", func() {
 				BeforeEach(func() {
 					regionalClusterClient.GetResourceCalls(func(resourceReference interface{}, resourceName, namespace string, postVerify clusterclient.PostVerifyrFunc, pollOptions *clusterclient.PollOptions) error {
-						if regionalClusterClient.GetResourceCallCount() == 4 {
+						if regionalClusterClient.GetResourceCallCount() == 5 {
 							return errors.New("fake-error")
 						}
 						clusterObj, ok := resourceReference.(*capav1alpha3.AWSCluster)
@@ -442,6 +489,91 @@ var _ = Describe("Unit tests for upgrade cluster", func() {
 			})
 		})
 	})
+
+	Describe("When planning the upgrade of a cluster", func() {
+		var plan *UpgradeClusterPlan
+
+		BeforeEach(func() {
+			newK8sVersion = "v1.18.0+vmware.1"     // nolint:goconst
+			currentK8sVersion = "v1.17.3+vmware.2" // nolint:goconst
+			setupBomFile("../fakes/config/bom/tkg-bom-v1.3.1.yaml", testingDir)
+			setupBomFile("../fakes/config/bom/tkr-bom-v1.18.0+vmware.1-tkg.2.yaml", testingDir)
+			regionalClusterClient.GetKCPObjectForClusterReturns(getDummyKCP(constants.VSphereMachineTemplate), nil)
+			regionalClusterClient.GetResourceReturns(nil)
+			regionalClusterClient.GetMDObjectForClusterReturns(getDummyMD(), nil)
+			currentClusterClient.GetKubernetesVersionReturns(currentK8sVersion, nil)
+		})
+		JustBeforeEach(func() {
+			plan, err = tkgClient.DoPlanClusterUpgrade(regionalClusterClient, currentClusterClient, &upgradeClusterOptions)
+		})
+		Context("When unable to get current k8s version of cluster", func() {
+			BeforeEach(func() {
+				currentClusterClient.GetKubernetesVersionReturns("", errors.New("fake-error"))
+			})
+			It("returns an error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("kubernetes version verification failed"))
+			})
+		})
+		Context("When everything is successful", func() {
+			It("returns the changes of the upgrade without changing the cluster", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(regionalClusterClient.CreateResourceCallCount()).To(Equal(0))
+				Expect(regionalClusterClient.PatchResourceCallCount()).To(Equal(0))
+				Expect(plan.FromKubernetesVersion).To(Equal(currentK8sVersion))
+				Expect(plan.ToKubernetesVersion).To(Equal(newK8sVersion))
+
+				objects := map[string]UpgradeObjectChange{}
+				for _, object := range plan.Objects {
+					objects[object.Kind] = object
+				}
+				Expect(objects).To(HaveKey("VSphereMachineTemplate"))
+				Expect(objects["VSphereMachineTemplate"].Action).To(Equal(UpgradeActionCreate))
+				Expect(objects["KubeadmControlPlane"].Action).To(Equal(UpgradeActionUpdate))
+				Expect(objects["KubeadmControlPlane"].Changes).To(ContainElement(UpgradeFieldChange{Path: "spec.version", From: currentK8sVersion, To: newK8sVersion}))
+				Expect(objects["MachineDeployment"].Action).To(Equal(UpgradeActionUpdate))
+				Expect(objects["MachineDeployment"].Changes).To(ContainElement(UpgradeFieldChange{Path: "spec.template.spec.version", From: "", To: newK8sVersion}))
+			})
+		})
+		Context("When only the TKr of the cluster changes", func() {
+			var tkrBOMFile string
+
+			BeforeEach(func() {
+				upgradeClusterOptions.SkipAddonUpgrade = false
+				bomDir, err := tkgconfigpaths.New(testingDir).GetTKGBoMDirectory()
+				Expect(err).NotTo(HaveOccurred())
+				tkrBOMFile = filepath.Join(bomDir, "tkr-bom-v1.18.0+vmware.1-tkg.2.yaml")
+				content, err := os.ReadFile(tkrBOMFile)
+				Expect(err).NotTo(HaveOccurred())
+				content = []byte(strings.Replace(string(content), "components:\n", "components:\n  antrea:\n  - version: v0.13.3+vmware.1\n  kapp-controller:\n  - version: v0.20.0+vmware.1\n  metrics-server:\n  - version: v0.4.0+vmware.1\n", 1))
+				Expect(os.WriteFile(tkrBOMFile, content, 0o600)).To(Succeed())
+
+				fromBOM := "release:\n  version: v1.17.3+vmware.2-tkg.1\ncomponents:\n  antrea:\n  - version: v0.11.3+vmware.1\n  kapp-controller:\n  - version: v0.18.0+vmware.1\n  metrics-server:\n  - version: v0.4.0+vmware.1\n  kubernetes:\n  - version: v1.17.3+vmware.2\n"
+				regionalClusterClient.GetBomConfigMapReturns(corev1.ConfigMap{BinaryData: map[string][]byte{tkrconstants.BomConfigMapContentKey: []byte(fromBOM)}}, nil)
+				regionalClusterClient.GetResourceStub = func(obj interface{}, name string, namespace string, verifyFunc clusterclient.PostVerifyrFunc, pollOptions *clusterclient.PollOptions) error {
+					if cluster, ok := obj.(*capi.Cluster); ok {
+						cluster.Labels = map[string]string{tkrconstants.BomConfigMapTKRLabel: "v1.17.3---vmware.2-tkg.1"}
+					}
+					return nil
+				}
+			})
+			AfterEach(func() {
+				setupBomFile("../fakes/config/bom/tkr-bom-v1.18.0+vmware.1-tkg.2.yaml", testingDir)
+			})
+			It("lists the addon and package versions changed by the TKr apart from the other components", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(plan.FromTkrVersion).To(Equal("v1.17.3+vmware.2-tkg.1"))
+				Expect(plan.Addons).To(Equal([]UpgradeComponentChange{
+					{Name: "antrea", From: "v0.11.3+vmware.1", To: "v0.13.3+vmware.1"},
+					{Name: "kapp-controller", From: "v0.18.0+vmware.1", To: "v0.20.0+vmware.1"},
+				}))
+				Expect(plan.Components).To(ContainElement(UpgradeComponentChange{Name: "kubernetes", From: "v1.17.3+vmware.2", To: "v1.18.0+vmware.1"}))
+				for _, component := range plan.Components {
+					Expect(component.Name).NotTo(BeElementOf("antrea", "kapp-controller", "metrics-server"))
+				}
+			})
+		})
+	})
 })
 
 var _ = Describe("When upgrading cluster with fake controller runtime client", func() {
@@ -160,12 +160,12 @@ func (c *TkgClient) UpgradeManagementCluster(options *UpgradeClusterOptions) err
 		}
 	}
 
-	log.Info("Waiting for additional components to be up and running...")
+	logWaitingFor("Deployment", "", "", "Waiting for additional components to be up and running...")
 	if err := c.WaitForAddonsDeployments(regionalClusterClient); err != nil {
 		return err
 	}
 
-	log.Info("Waiting for packages to be up and running...")
+	logWaitingFor("PackageInstall", "", "", "Waiting for packages to be up and running...")
 	if err := c.WaitForPackages(regionalClusterClient, regionalClusterClient, options.ClusterName, options.Namespace); err != nil {
 		log.Warningf("Warning: Management cluster is upgraded successfully, but some packages are failing. %v", err)
 	}
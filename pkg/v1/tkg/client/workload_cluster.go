@@ -88,19 +88,29 @@ func (c *TkgClient) GetWorkloadClusterCredentials(options GetWorkloadClusterCred
 	return newConfig.CurrentContext, "", nil
 }
 
-// DeleteWorkloadCluster deletes workload cluster
-func (c *TkgClient) DeleteWorkloadCluster(options DeleteWorkloadClusterOptions) error {
-	currentRegion, err := c.GetCurrentRegionContext()
+// VerifyWorkloadClusterDeletion returns an error if the workload cluster is protected from deletion
+func (c *TkgClient) VerifyWorkloadClusterDeletion(options DeleteWorkloadClusterOptions) error {
+	clusterClient, err := c.getDeleteWorkloadClusterClient()
 	if err != nil {
-		return errors.Wrap(err, "cannot get current management cluster context")
+		return err
 	}
-	clusterclientOptions := clusterclient.Options{
-		GetClientInterval: 1 * time.Second,
-		GetClientTimeout:  3 * time.Second,
+
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
 	}
-	clusterClient, err := clusterclient.NewClient(currentRegion.SourceFilePath, currentRegion.ContextName, clusterclientOptions)
+
+	isPacific, err := clusterClient.IsPacificRegionalCluster()
+	if err != nil || isPacific {
+		return nil
+	}
+	return verifyClusterNotDeletionProtected(clusterClient, options.ClusterName, options.Namespace)
+}
+
+// DeleteWorkloadCluster deletes workload cluster
+func (c *TkgClient) DeleteWorkloadCluster(options DeleteWorkloadClusterOptions) error {
+	clusterClient, err := c.getDeleteWorkloadClusterClient()
 	if err != nil {
-		return errors.Wrap(err, "unable to get cluster client while deleting cluster")
+		return err
 	}
 
 	if options.Namespace == "" {
@@ -109,6 +119,10 @@ func (c *TkgClient) DeleteWorkloadCluster(options DeleteWorkloadClusterOptions)
 
 	isPacific, err := clusterClient.IsPacificRegionalCluster()
 	if err == nil && !isPacific {
+		if err := verifyClusterNotDeletionProtected(clusterClient, options.ClusterName, options.Namespace); err != nil {
+			return err
+		}
+
 		err = deleteAutoscalerDeploymentIfPresent(clusterClient, options.ClusterName, options.Namespace)
 		if err != nil {
 			log.Warningf("failed to delete autoscaler resources from management cluster, reason: %v", err)
@@ -123,6 +137,22 @@ func (c *TkgClient) DeleteWorkloadCluster(options DeleteWorkloadClusterOptions)
 	return clusterClient.DeleteCluster(options.ClusterName, options.Namespace)
 }
 
+func (c *TkgClient) getDeleteWorkloadClusterClient() (clusterclient.Client, error) {
+	currentRegion, err := c.GetCurrentRegionContext()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get current management cluster context")
+	}
+	clusterclientOptions := clusterclient.Options{
+		GetClientInterval: 1 * time.Second,
+		GetClientTimeout:  3 * time.Second,
+	}
+	clusterClient, err := clusterclient.NewClient(currentRegion.SourceFilePath, currentRegion.ContextName, clusterclientOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get cluster client while deleting cluster")
+	}
+	return clusterClient, nil
+}
+
 func deleteAutoscalerDeploymentIfPresent(clusterClient clusterclient.Client, clusterName, namespace string) error {
 	var autoScalerDeployment appsv1.Deployment
 	autoScalerDeploymentName := clusterName + "-cluster-autoscaler"
@@ -28,6 +28,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	extensionsV1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -53,6 +54,7 @@ import (
 	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
+	kappctrl "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
 	kappipkg "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
 
 	runv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha1"
@@ -332,6 +334,8 @@ type client struct {
 	getClientTimeout          time.Duration
 	operationTimeout          time.Duration
 	verificationClientFactory *VerificationClientFactory
+	watcherFactory            WatcherFactory
+	watcher                   Watcher
 }
 
 // constants regarding timeout and configs
@@ -394,6 +398,7 @@ func init() {
 	_ = addonsv1.AddToScheme(scheme)
 	_ = runv1alpha1.AddToScheme(scheme)
 	_ = kappipkg.AddToScheme(scheme)
+	_ = kappctrl.AddToScheme(scheme)
 }
 
 // ClusterStatusInfo defines the cluster status involving all main components
@@ -469,6 +474,7 @@ func (c *client) WaitForClusterInitialized(clusterName, namespace string) error
 	var lastReason string
 	unchangedCounter := 0
 	interval := 15 * time.Second
+	lastChangeTime := time.Now()
 
 	getterFunc := func() (interface{}, error) {
 		currentClusterInfo = c.GetClusterStatusInfo(clusterName, namespace, nil)
@@ -494,6 +500,7 @@ func (c *client) WaitForClusterInitialized(clusterName, namespace string) error
 		if isClusterStateChanged(&lastClusterInfo, &currentClusterInfo) ||
 			isClusterStateChangedForMD(&lastClusterInfo, &currentClusterInfo) {
 			unchangedCounter = 0
+			lastChangeTime = time.Now()
 			// Patch cluster object with updated timestamp information
 			// This timestamp will be used with operation timeout to determine
 			// stalled state of the cluster
@@ -516,27 +523,42 @@ func (c *client) WaitForClusterInitialized(clusterName, namespace string) error
 		lastClusterInfo = currentClusterInfo
 
 		// if unchanged for operationTimeout(30 min default), return error
-		if interval*time.Duration(unchangedCounter) > c.operationTimeout {
+		if time.Since(lastChangeTime) > c.operationTimeout {
 			return true, errors.Wrap(err, "timed out waiting for cluster creation to complete")
 		}
 
 		return false, err
 	}
 
-	return c.poller.PollImmediateInfiniteWithGetter(interval, getterFunc)
+	if c.watcher == nil {
+		return c.poller.PollImmediateInfiniteWithGetter(interval, getterFunc)
+	}
+	// the cluster state is verified again as soon as the cluster or its machines change
+	targets := []watchTarget{
+		{obj: &capi.Cluster{}, name: clusterName},
+		{obj: &capi.MachineList{}, clusterName: clusterName},
+		{obj: &capi.MachineDeploymentList{}, clusterName: clusterName},
+	}
+	return c.waitForChanges(targets, namespace, interval, 0, func() (bool, error) {
+		timedOut, err := getterFunc()
+		if err == nil {
+			return true, nil
+		}
+		return timedOut.(bool), err
+	})
 }
 
 func (c *client) WaitForClusterReady(clusterName, namespace string, checkAllReplicas bool) error {
-	if err := c.GetResource(&capi.Cluster{}, clusterName, namespace, VerifyClusterReady, &PollOptions{Interval: CheckClusterInterval, Timeout: c.operationTimeout}); err != nil {
+	if err := c.waitForResource(&capi.Cluster{}, clusterName, namespace, VerifyClusterReady, &PollOptions{Interval: CheckClusterInterval, Timeout: c.operationTimeout}); err != nil {
 		return err
 	}
 	if checkAllReplicas {
 		// Check and wait only for MD replicas as KCP replicas would be checked in above VerifyClusterReady() since the KCP Ready condition is mirrored into cluster ControPlaneReady condition
-		if err := c.GetResourceList(&capi.MachineDeploymentList{}, clusterName, namespace, VerifyMachineDeploymentsReplicas, &PollOptions{Interval: CheckClusterInterval, Timeout: c.operationTimeout}); err != nil {
+		if err := c.waitForResourceList(&capi.MachineDeploymentList{}, clusterName, namespace, VerifyMachineDeploymentsReplicas, &PollOptions{Interval: CheckClusterInterval, Timeout: c.operationTimeout}); err != nil {
 			return err
 		}
 	}
-	if err := c.GetResourceList(&capi.MachineList{}, clusterName, namespace, VerifyMachinesReady, &PollOptions{Interval: CheckClusterInterval, Timeout: c.operationTimeout}); err != nil {
+	if err := c.waitForResourceList(&capi.MachineList{}, clusterName, namespace, VerifyMachinesReady, &PollOptions{Interval: CheckClusterInterval, Timeout: c.operationTimeout}); err != nil {
 		return err
 	}
 	return nil
@@ -580,7 +602,7 @@ func (c *client) WaitForResourceDeletion(resourceReference interface{}, resource
 }
 
 func (c *client) WaitForDeployment(deploymentName, namespace string) error {
-	return c.GetResource(&appsv1.Deployment{}, deploymentName, namespace, VerifyDeploymentAvailable, &PollOptions{Interval: CheckResourceInterval, Timeout: c.operationTimeout})
+	return c.waitForResource(&appsv1.Deployment{}, deploymentName, namespace, VerifyDeploymentAvailable, &PollOptions{Interval: CheckResourceInterval, Timeout: c.operationTimeout})
 }
 
 func (c *client) WaitForAutoscalerDeployment(deploymentName, namespace string) error {
@@ -595,7 +617,7 @@ func (c *client) WaitForPackageInstall(packageName, namespace string, packageIns
 	if packageInstallTimeout == 0 {
 		packageInstallTimeout = PackageInstallTimeout
 	}
-	return c.GetResource(&kappipkg.PackageInstall{}, packageName, namespace, VerifyPackageInstallReconciledSuccessfully, &PollOptions{Interval: PackageInstallPollInterval, Timeout: packageInstallTimeout})
+	return c.waitForResource(&kappipkg.PackageInstall{}, packageName, namespace, VerifyPackageInstallReconciledSuccessfully, &PollOptions{Interval: PackageInstallPollInterval, Timeout: packageInstallTimeout})
 }
 
 func verifyKubernetesUpgradeForCPNodes(clusterStatusInfo *ClusterStatusInfo, newK8sVersion string) error {
@@ -2233,6 +2255,10 @@ type Options struct {
 	GetClientTimeout          time.Duration
 	OperationTimeout          time.Duration
 	verificationClientFactory *VerificationClientFactory
+	// WatcherFactory creates the watcher the waits use to react to the changes of the objects
+	// they wait for. It defaults to a dynamic client based watcher with the default poller,
+	// with a custom poller the waits only poll unless a watcher factory is set.
+	WatcherFactory WatcherFactory
 }
 
 // NewOptions returns new options
@@ -2258,6 +2284,9 @@ func NewClient(kubeConfigPath string, context string, options Options) (Client,
 	}
 	if options.poller == nil {
 		options.poller = NewPoller()
+		if options.WatcherFactory == nil {
+			options.WatcherFactory = &watcherFactory{}
+		}
 	}
 	if options.crtClientFactory == nil {
 		options.crtClientFactory = &crtClientFactory{}
@@ -2287,6 +2316,7 @@ func NewClient(kubeConfigPath string, context string, options Options) (Client,
 		getClientTimeout:          options.GetClientTimeout,
 		operationTimeout:          options.OperationTimeout,
 		verificationClientFactory: options.verificationClientFactory,
+		watcherFactory:            options.WatcherFactory,
 	}
 	err = client.updateK8sClients(context)
 	if err != nil {
@@ -2310,6 +2340,8 @@ func (c *client) CloneWithTimeout(getClientTimeout time.Duration) Client {
 		getClientTimeout:       getClientTimeout,
 		// copy the getClientTimeout to operationTimeout as well
 		operationTimeout: getClientTimeout,
+		watcherFactory:   c.watcherFactory,
+		watcher:          c.watcher,
 	}
 }
 
@@ -2343,6 +2375,15 @@ func (c *client) updateK8sClients(ctx string) error {
 	c.discoveryClient = k8sClients.discoveryClient
 	c.currentContext = ctx
 
+	c.watcher = nil
+	if c.watcherFactory != nil {
+		// the waits fall back to polling if no watcher can be created
+		if c.watcher, err = c.watcherFactory.NewWatcher(k8sClients.restConfig, k8sClients.mapper); err != nil {
+			log.V(6).Infof("unable to create watcher, %s", err.Error())
+			c.watcher = nil
+		}
+	}
+
 	return nil
 }
 
@@ -2418,6 +2459,8 @@ func getK8sClients(kubeConfigBytes []byte, crtClientFactory CrtClientFactory, di
 	clientSet := k8ClientSet{
 		crtClient:       crtClient,
 		discoveryClient: discoveryClient,
+		restConfig:      restConfig,
+		mapper:          mapper,
 	}
 
 	return clientSet, nil
@@ -2426,6 +2469,8 @@ func getK8sClients(kubeConfigBytes []byte, crtClientFactory CrtClientFactory, di
 type k8ClientSet struct {
 	crtClient       crtclient.Client
 	discoveryClient discovery.DiscoveryInterface
+	restConfig      *rest.Config
+	mapper          meta.RESTMapper
 }
 
 // LoadCurrentKubeconfigBytes loads current kubeconfig bytes
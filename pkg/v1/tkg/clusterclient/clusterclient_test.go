@@ -27,7 +27,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -2203,6 +2205,99 @@ var _ = Describe("Cluster Client", func() {
 			})
 		})
 	})
+
+	Describe("Wait for resources with watches", func() {
+		var (
+			watcher   *fakes.Watcher
+			fakeWatch *watch.FakeWatcher
+			getCount  int
+		)
+		BeforeEach(func() {
+			reInitialize()
+			watcher = &fakes.Watcher{}
+			watcherFactory := &fakes.WatcherFactory{}
+			watcherFactory.NewWatcherReturns(watcher, nil)
+			clusterClientOptions.WatcherFactory = watcherFactory
+			kubeConfigPath := getConfigFilePath("config1.yaml")
+			clstClient, err = NewClient(kubeConfigPath, "", clusterClientOptions)
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeWatch = watch.NewFakeWithChanSize(1, false)
+			watcher.WatchReturns(fakeWatch, nil)
+			getCount = 0
+			// the deployment becomes available on the second get
+			clientset.GetCalls(func(ctx context.Context, key types.NamespacedName, obj runtime.Object) error {
+				getCount++
+				if deployment, ok := obj.(*appsv1.Deployment); ok {
+					deployment.Name = key.Name
+					deployment.Namespace = key.Namespace
+					deployment.Status.AvailableReplicas = int32(getCount - 1)
+				}
+				return nil
+			})
+		})
+		Context("When the watch reports a change of the deployment", func() {
+			JustBeforeEach(func() {
+				fakeWatch.Modify(&appsv1.Deployment{})
+				err = clstClient.WaitForDeployment("fake-deployment", "fake-namespace")
+			})
+			It("should verify the deployment again and return once it is available", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(getCount).To(Equal(2))
+				Expect(watcher.WatchCallCount()).To(Equal(1))
+				_, name, namespace, _ := watcher.WatchArgsForCall(0)
+				Expect(name).To(Equal("fake-deployment"))
+				Expect(namespace).To(Equal("fake-namespace"))
+				Expect(poller.PollImmediateCallCount()).To(Equal(0))
+			})
+		})
+		Context("When the watch cannot be opened", func() {
+			JustBeforeEach(func() {
+				watcher.WatchReturns(nil, errors.New("fake-watch-error"))
+				poller.PollImmediateCalls(func(interval, timeout time.Duration, condition wait.ConditionFunc) error {
+					for {
+						done, err := condition()
+						if err != nil || done {
+							return err
+						}
+					}
+				})
+				err = clstClient.WaitForDeployment("fake-deployment", "fake-namespace")
+			})
+			It("should fall back to polling", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(poller.PollImmediateCallCount()).To(Equal(1))
+				Expect(getCount).To(Equal(2))
+			})
+		})
+		Context("When the watch breaks", func() {
+			JustBeforeEach(func() {
+				fakeWatch.Stop()
+				clientset.GetCalls(nil)
+				clientset.GetReturns(nil)
+				poller.PollImmediateCalls(func(interval, timeout time.Duration, condition wait.ConditionFunc) error {
+					_, _ = condition()
+					return wait.ErrWaitTimeout
+				})
+				err = clstClient.WaitForDeployment("fake-deployment", "fake-namespace")
+			})
+			It("should fall back to polling and return the last verification error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("pods are not yet running for deployment"))
+				Expect(poller.PollImmediateCallCount()).To(Equal(1))
+			})
+		})
+		Context("When the package install is not reconciled before the timeout", func() {
+			JustBeforeEach(func() {
+				err = clstClient.WaitForPackageInstall("fake-package", "fake-namespace", 100*time.Millisecond)
+			})
+			It("should return the last verification error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Package to be installed"))
+				Expect(poller.PollImmediateCallCount()).To(Equal(0))
+			})
+		})
+	})
 })
 
 func createTempDirectory() {
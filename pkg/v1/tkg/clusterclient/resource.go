@@ -248,6 +248,18 @@ func (c *client) getRuntimeObject(o interface{}) (runtime.Object, error) { //nol
 		return obj, nil
 	case *kappipkg.PackageInstall:
 		return obj, nil
+	case *kappipkg.PackageInstallList:
+		return obj, nil
+	case *kappctrl.App:
+		return obj, nil
+	case *corev1.NodeList:
+		return obj, nil
+	case *corev1.ConfigMapList:
+		return obj, nil
+	case *unstructured.UnstructuredList:
+		return obj, nil
+	case *extensionsV1.CustomResourceDefinitionList:
+		return obj, nil
 	default:
 		return nil, errors.New("invalid object type")
 	}
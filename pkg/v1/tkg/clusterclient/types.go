@@ -10,6 +10,8 @@ import "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/vc"
 const (
 	TKGOperationInfoKey                  = "TKGOperationInfo"
 	TKGOperationLastObservedTimestampKey = "TKGOperationLastObservedTimestamp"
+	TKGUpgradeStatusKey                  = "TKGUpgradeStatus"
+	TKGOperationHistoryKey               = "TKGOperationHistory"
 	TKGVersionKey                        = "TKGVERSION"
 	CAPAControllerNamespace              = "capa-system"
 	CAPACredentialsSecretName            = "capa-manager-bootstrap-credentials"
@@ -18,8 +20,15 @@ const (
 
 // Operation type constants
 const (
-	OperationTypeUpgrade = "Upgrade"
-	OperationTypeCreate  = "Create"
+	OperationTypeUpgrade                  = "Upgrade"
+	OperationTypeCreate                   = "Create"
+	OperationTypeScale                    = "Scale"
+	OperationTypeUpdateCredentials        = "UpdateCredentials"
+	OperationTypeSetMachineHealthCheck    = "SetMachineHealthCheck"
+	OperationTypeDeleteMachineHealthCheck = "DeleteMachineHealthCheck"
+	OperationTypeRotateCertificates       = "RotateCertificates"
+	OperationTypeHibernate                = "Hibernate"
+	OperationTypeResume                   = "Resume"
 )
 
 const (
@@ -0,0 +1,360 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterclient
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+)
+
+// watchResyncInterval is the interval at which the watched objects are verified again even if no change
+// is reported, so that a change missed by the watch does not hold the wait until its timeout
+const watchResyncInterval = 1 * time.Minute
+
+//go:generate counterfeiter -o ../fakes/watcher.go --fake-name Watcher . Watcher
+
+// Watcher opens watches on kubernetes objects
+type Watcher interface {
+	// Watch watches the object of the kind of obj with the given name, or if obj is a list, the objects
+	// of the kind of the list items matching the labels
+	Watch(obj runtime.Object, name, namespace string, matchingLabels map[string]string) (watch.Interface, error)
+}
+
+//go:generate counterfeiter -o ../fakes/watcherfactory.go --fake-name WatcherFactory . WatcherFactory
+
+// WatcherFactory is a interface to create watcher
+type WatcherFactory interface {
+	NewWatcher(config *rest.Config, mapper meta.RESTMapper) (Watcher, error)
+}
+
+type watcherFactory struct{}
+
+// NewWatcher creates new watcher
+func (f *watcherFactory) NewWatcher(config *rest.Config, mapper meta.RESTMapper) (Watcher, error) {
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &dynamicWatcher{dynamicClient: dynamicClient, mapper: mapper}, nil
+}
+
+type dynamicWatcher struct {
+	dynamicClient dynamic.Interface
+	mapper        meta.RESTMapper
+}
+
+// Watch watches the object of the kind of obj with the given name, or if obj is a list, the objects
+// of the kind of the list items matching the labels
+func (w *dynamicWatcher) Watch(obj runtime.Object, name, namespace string, matchingLabels map[string]string) (watch.Interface, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return nil, err
+	}
+	if meta.IsListType(obj) {
+		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	}
+	mapping, err := w.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	listOptions := metav1.ListOptions{}
+	if name != "" {
+		listOptions.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+	}
+	if len(matchingLabels) != 0 {
+		listOptions.LabelSelector = labels.SelectorFromSet(matchingLabels).String()
+	}
+	return w.dynamicClient.Resource(mapping.Resource).Namespace(namespace).Watch(listOptions)
+}
+
+// watchTarget is an object, or if obj is a list the objects labeled with the cluster name, a wait watches for changes
+type watchTarget struct {
+	obj         runtime.Object
+	name        string
+	clusterName string
+}
+
+// conditionFunc returns true when the wait is over, either because the awaited state is
+// reached or because of an error which is not worth waiting for
+type conditionFunc func() (bool, error)
+
+// waitForChanges calls condition immediately, on each change of the watched targets and at least every
+// watchResyncInterval until it returns true or timeout is reached, 0 meaning no timeout. It falls back to
+// polling every interval if the watches cannot be opened or break. On timeout the last error of condition is returned.
+func (c *client) waitForChanges(targets []watchTarget, namespace string, interval, timeout time.Duration, condition conditionFunc) error {
+	var lastErr error
+	check := func() bool {
+		done, err := condition()
+		lastErr = err
+		return done
+	}
+	if check() {
+		return lastErr
+	}
+
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	startTime := time.Now()
+
+	events, stop, err := c.openWatches(targets, namespace)
+	if err != nil {
+		log.V(6).Infof("unable to watch for changes, falling back to polling: %s", err.Error())
+		return c.pollForChanges(interval, remainingTimeout(startTime, timeout), condition, lastErr)
+	}
+	defer stop()
+
+	resync := time.NewTicker(watchResyncInterval)
+	defer resync.Stop()
+	for {
+		select {
+		case event := <-events:
+			if event.Type == watch.Error {
+				log.V(6).Infof("watch for changes broke, falling back to polling: %s", watchErrorMessage(event))
+				stop()
+				return c.pollForChanges(interval, remainingTimeout(startTime, timeout), condition, lastErr)
+			}
+		case <-resync.C:
+		case <-deadline:
+			return lastErr
+		}
+		if check() {
+			return lastErr
+		}
+	}
+}
+
+// pollForChanges polls the condition every interval until it returns true or the timeout is reached, 0 meaning no timeout
+func (c *client) pollForChanges(interval, timeout time.Duration, condition conditionFunc, lastErr error) error {
+	pollerFunc := func() (bool, error) {
+		done, err := condition()
+		lastErr = err
+		if done {
+			// stop the polling with the error of the condition
+			return true, err
+		}
+		return false, nil
+	}
+
+	var errPoll error
+	if timeout == 0 {
+		errPoll = c.poller.PollImmediateInfinite(interval, pollerFunc)
+	} else if timeout > 0 {
+		errPoll = c.poller.PollImmediate(interval, timeout, pollerFunc)
+	}
+	if errPoll != nil || timeout < 0 {
+		// note: this function will return actual error which is returned by the condition
+		// and will not return error of the polling (which is always time-out...)
+		return lastErr
+	}
+	return nil
+}
+
+// remainingTimeout returns the time left until the timeout, a negative value if it is reached and 0 if there is no timeout
+func remainingTimeout(startTime time.Time, timeout time.Duration) time.Duration {
+	if timeout == 0 {
+		return 0
+	}
+	if remaining := timeout - time.Since(startTime); remaining > 0 {
+		return remaining
+	}
+	return -1
+}
+
+// openWatches opens a watch on each target and merges their events, the closing of
+// a watch is reported as an error event. The returned function stops all the watches.
+func (c *client) openWatches(targets []watchTarget, namespace string) (<-chan watch.Event, func(), error) {
+	watches := []watch.Interface{}
+	done := make(chan struct{})
+	var stopOnce sync.Once
+	stop := func() {
+		stopOnce.Do(func() {
+			close(done)
+			for _, w := range watches {
+				w.Stop()
+			}
+		})
+	}
+
+	events := make(chan watch.Event)
+	for _, target := range targets {
+		var matchingLabels map[string]string
+		if target.clusterName != "" {
+			matchingLabels = map[string]string{capi.ClusterLabelName: target.clusterName}
+		}
+		w, err := c.watcher.Watch(target.obj, target.name, namespace, matchingLabels)
+		if err != nil {
+			stop()
+			return nil, nil, errors.Wrapf(err, "unable to watch %s", objectKind(target.obj))
+		}
+		watches = append(watches, w)
+
+		go func() {
+			for event := range w.ResultChan() {
+				select {
+				case events <- event:
+				case <-done:
+					return
+				}
+			}
+			select {
+			case events <- watch.Event{Type: watch.Error}:
+			case <-done:
+			}
+		}()
+	}
+	return events, stop, nil
+}
+
+func watchErrorMessage(event watch.Event) string {
+	if status, ok := event.Object.(*metav1.Status); ok {
+		return status.Message
+	}
+	return "watch closed"
+}
+
+// waitForResource waits until the postVerify of the resource succeeds, verifying it again on each change
+func (c *client) waitForResource(resourceReference interface{}, resourceName, namespace string, postVerify PostVerifyrFunc, pollOptions *PollOptions) error {
+	if c.watcher == nil {
+		return c.GetResource(resourceReference, resourceName, namespace, postVerify, pollOptions)
+	}
+
+	var err error
+	if namespace == "" {
+		if namespace, err = c.GetCurrentNamespace(); err != nil {
+			return err
+		}
+	}
+	obj, err := c.getRuntimeObject(resourceReference)
+	if err != nil {
+		return err
+	}
+
+	log.V(4).Infof("Waiting for resource %s of type %s to be up and running", resourceName, reflect.TypeOf(resourceReference))
+	reporter := &blockingConditionsReporter{}
+	targets := []watchTarget{{obj: obj, name: resourceName}}
+	return c.waitForChanges(targets, namespace, pollOptions.Interval, pollOptions.Timeout, func() (bool, error) {
+		err := c.get(resourceName, namespace, obj, postVerify)
+		if err != nil {
+			reporter.report(fmt.Sprintf("%s %s/%s", objectKind(obj), namespace, resourceName), obj, err)
+			return false, err
+		}
+		return true, nil
+	})
+}
+
+// waitForResourceList waits until the postVerify of the resources of the cluster succeeds, verifying them again on each change
+func (c *client) waitForResourceList(resourceReference interface{}, clusterName, namespace string, postVerify PostVerifyrFunc, pollOptions *PollOptions) error {
+	if c.watcher == nil {
+		return c.GetResourceList(resourceReference, clusterName, namespace, postVerify, pollOptions)
+	}
+
+	var err error
+	if namespace == "" {
+		if namespace, err = c.GetCurrentNamespace(); err != nil {
+			return err
+		}
+	}
+	obj, err := c.getRuntimeObject(resourceReference)
+	if err != nil {
+		return err
+	}
+
+	log.V(4).Infof("Waiting for resources type %s to be up and running", reflect.TypeOf(resourceReference))
+	reporter := &blockingConditionsReporter{}
+	targets := []watchTarget{{obj: obj, clusterName: clusterName}}
+	return c.waitForChanges(targets, namespace, pollOptions.Interval, pollOptions.Timeout, func() (bool, error) {
+		err := c.list(clusterName, namespace, obj, postVerify)
+		if err != nil {
+			reporter.report(fmt.Sprintf("%s of cluster %s/%s", objectKind(obj), namespace, clusterName), obj, err)
+			return false, err
+		}
+		return true, nil
+	})
+}
+
+// blockingConditionsReporter logs the conditions a wait is blocked on each time they change
+type blockingConditionsReporter struct {
+	lastReport string
+}
+
+func (r *blockingConditionsReporter) report(subject string, obj runtime.Object, err error) {
+	blocking := blockingConditions(obj)
+	if blocking == "" {
+		blocking = err.Error()
+	}
+	report := fmt.Sprintf("Waiting for %s: %s", subject, blocking)
+	if report != r.lastReport {
+		log.V(3).Info(report)
+		r.lastReport = report
+	}
+}
+
+// blockingConditions returns the conditions which are not true of the cluster api object,
+// or of the items of the cluster api objects list
+func blockingConditions(obj runtime.Object) string {
+	getters := []conditions.Getter{}
+	if meta.IsListType(obj) {
+		items, err := meta.ExtractList(obj)
+		if err != nil {
+			return ""
+		}
+		for _, item := range items {
+			if getter, ok := item.(conditions.Getter); ok {
+				getters = append(getters, getter)
+			}
+		}
+	} else if getter, ok := obj.(conditions.Getter); ok {
+		getters = append(getters, getter)
+	}
+
+	blocking := []string{}
+	for _, getter := range getters {
+		for _, condition := range getter.GetConditions() {
+			if condition.Status == corev1.ConditionTrue {
+				continue
+			}
+			description := fmt.Sprintf("%s=%s", condition.Type, condition.Status)
+			if condition.Reason != "" {
+				description += fmt.Sprintf(" (%s)", condition.Reason)
+			}
+			if len(getters) > 1 {
+				description = getter.GetName() + " " + description
+			}
+			blocking = append(blocking, description)
+		}
+	}
+	return strings.Join(blocking, ", ")
+}
+
+func objectKind(obj runtime.Object) string {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return reflect.TypeOf(obj).String()
+	}
+	return gvk.Kind
+}
@@ -0,0 +1,164 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package clusterprofile manages named cluster profiles, reusable cluster
+// configuration files stored in the tkg config directory.
+package clusterprofile
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterspec"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+)
+
+const profileFileExtension = ".yaml"
+
+var profileNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Profile describes a saved cluster profile
+type Profile struct {
+	Name                   string    `json:"name" yaml:"name"`
+	Plan                   string    `json:"plan,omitempty" yaml:"plan,omitempty"`
+	InfrastructureProvider string    `json:"infrastructureProvider,omitempty" yaml:"infrastructureProvider,omitempty"`
+	LastModified           time.Time `json:"lastModified" yaml:"lastModified"`
+	Path                   string    `json:"path" yaml:"path"`
+}
+
+// Store saves the cluster profiles as files of a directory
+type Store struct {
+	dir string
+}
+
+// NewStore returns a store of the cluster profiles saved in the directory
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Save saves the cluster configuration file or cluster spec as a profile.
+// An existing profile is only replaced if overwrite is set.
+func (s *Store) Save(name string, data []byte, overwrite bool) (*Profile, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	if _, err := clusterspec.ReadConfigVariables(data); err != nil {
+		return nil, err
+	}
+
+	profilePath := s.path(name)
+	if _, err := os.Stat(profilePath); err == nil && !overwrite {
+		return nil, errors.Errorf("cluster profile '%s' already exists", name)
+	}
+	if err := os.MkdirAll(s.dir, constants.DefaultDirectoryPermissions); err != nil {
+		return nil, errors.Wrap(err, "unable to create the cluster profiles directory")
+	}
+	if err := os.WriteFile(profilePath, data, constants.ConfigFilePermissions); err != nil {
+		return nil, errors.Wrapf(err, "unable to save cluster profile '%s'", name)
+	}
+	return s.describe(name, data)
+}
+
+// List returns the saved profiles sorted by name
+func (s *Store) List() ([]Profile, error) {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*"+profileFileExtension))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	profiles := []Profile{}
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), profileFileExtension)
+		if ValidateName(name) != nil {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read cluster profile '%s'", name)
+		}
+		profile, err := s.describe(name, data)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, *profile)
+	}
+	return profiles, nil
+}
+
+// Get returns the content of the profile
+func (s *Store) Get(name string) ([]byte, error) {
+	profilePath, err := s.Path(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(profilePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read cluster profile '%s'", name)
+	}
+	return data, nil
+}
+
+// Path returns the path of the file of the profile
+func (s *Store) Path(name string) (string, error) {
+	if err := ValidateName(name); err != nil {
+		return "", err
+	}
+	profilePath := s.path(name)
+	if _, err := os.Stat(profilePath); err != nil {
+		if os.IsNotExist(err) {
+			return "", errors.Errorf("cluster profile '%s' not found", name)
+		}
+		return "", errors.Wrapf(err, "unable to read cluster profile '%s'", name)
+	}
+	return profilePath, nil
+}
+
+// Delete deletes the profile
+func (s *Store) Delete(name string) error {
+	profilePath, err := s.Path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(profilePath); err != nil {
+		return errors.Wrapf(err, "unable to delete cluster profile '%s'", name)
+	}
+	return nil
+}
+
+// ValidateName checks the profile name can be used as a file name on all platforms
+func ValidateName(name string) error {
+	if !profileNameRegex.MatchString(name) {
+		return errors.Errorf("invalid cluster profile name '%s', it must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character", name)
+	}
+	return nil
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name+profileFileExtension)
+}
+
+func (s *Store) describe(name string, data []byte) (*Profile, error) {
+	variables, err := clusterspec.ReadConfigVariables(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cluster profile '%s'", name)
+	}
+	profilePath := s.path(name)
+	info, err := os.Stat(profilePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read cluster profile '%s'", name)
+	}
+	return &Profile{
+		Name:                   name,
+		Plan:                   variables[constants.ConfigVariableClusterPlan],
+		InfrastructureProvider: variables[constants.ConfigVariableInfraProvider],
+		LastModified:           info.ModTime(),
+		Path:                   profilePath,
+	}, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterprofile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterprofile"
+)
+
+func TestClusterProfile(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cluster profile Suite")
+}
+
+const (
+	flatConfig = `CLUSTER_PLAN: dev
+INFRASTRUCTURE_PROVIDER: vsphere
+WORKER_MACHINE_COUNT: 2
+`
+	specConfig = `apiVersion: tkg.tanzu.vmware.com/v1alpha1
+kind: ClusterSpec
+metadata:
+  name: my-cluster
+spec:
+  plan: prod
+  infrastructure:
+    provider: aws
+`
+)
+
+var _ = Describe("Store", func() {
+	var (
+		dir   string
+		store *Store
+		err   error
+	)
+
+	BeforeEach(func() {
+		dir, err = os.MkdirTemp("", "cluster_profiles")
+		Expect(err).NotTo(HaveOccurred())
+		store = NewStore(filepath.Join(dir, "profiles"))
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("saves, lists, gets and deletes profiles", func() {
+		profile, err := store.Save("small-dev", []byte(flatConfig), false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(profile.Plan).To(Equal("dev"))
+		Expect(profile.InfrastructureProvider).To(Equal("vsphere"))
+		Expect(profile.Path).To(Equal(filepath.Join(dir, "profiles", "small-dev.yaml")))
+
+		_, err = store.Save("aws-prod", []byte(specConfig), false)
+		Expect(err).NotTo(HaveOccurred())
+
+		profiles, err := store.List()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(profiles).To(HaveLen(2))
+		Expect(profiles[0].Name).To(Equal("aws-prod"))
+		Expect(profiles[0].Plan).To(Equal("prod"))
+		Expect(profiles[0].InfrastructureProvider).To(Equal("aws"))
+		Expect(profiles[1].Name).To(Equal("small-dev"))
+
+		data, err := store.Get("small-dev")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(flatConfig))
+
+		Expect(store.Delete("small-dev")).To(Succeed())
+		_, err = store.Get("small-dev")
+		Expect(err).To(MatchError("cluster profile 'small-dev' not found"))
+	})
+
+	It("lists no profiles if none was saved", func() {
+		profiles, err := store.List()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(profiles).To(BeEmpty())
+	})
+
+	It("only replaces an existing profile if overwrite is set", func() {
+		_, err = store.Save("small-dev", []byte(flatConfig), false)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = store.Save("small-dev", []byte(specConfig), false)
+		Expect(err).To(MatchError("cluster profile 'small-dev' already exists"))
+
+		profile, err := store.Save("small-dev", []byte(specConfig), true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(profile.Plan).To(Equal("prod"))
+	})
+
+	It("rejects invalid profile names and content", func() {
+		_, err = store.Save("Small_Dev", []byte(flatConfig), false)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid cluster profile name 'Small_Dev'"))
+
+		_, err = store.Save("../small-dev", []byte(flatConfig), false)
+		Expect(err).To(HaveOccurred())
+
+		_, err = store.Save("small-dev", []byte("WORKER_MACHINE_COUNT: [1, 2]\n"), false)
+		Expect(err).To(MatchError("config variable 'WORKER_MACHINE_COUNT' must have a scalar value"))
+	})
+})
@@ -0,0 +1,192 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterspec
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+)
+
+var configVariableNameRegex = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// IsClusterSpec returns true if the document is a cluster spec rather than a flat config variable file
+func IsClusterSpec(data []byte) bool {
+	header := struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+	}{}
+	if err := yaml.Unmarshal(data, &header); err != nil {
+		return false
+	}
+	return header.Kind == Kind
+}
+
+// Parse parses and validates a cluster spec, unknown fields are rejected
+func Parse(data []byte) (*ClusterSpec, error) {
+	spec := &ClusterSpec{}
+	if err := yaml.UnmarshalStrict(data, spec); err != nil {
+		return nil, errors.Wrap(err, "unable to parse cluster spec")
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// Marshal returns the yaml representation of the cluster spec
+func Marshal(spec *ClusterSpec) ([]byte, error) {
+	return yaml.Marshal(spec)
+}
+
+// Validate checks the cluster spec can be translated into config variables
+func (c *ClusterSpec) Validate() error {
+	if c.APIVersion != APIVersion {
+		return errors.Errorf("unsupported cluster spec apiVersion '%s', expected '%s'", c.APIVersion, APIVersion)
+	}
+	if c.Kind != Kind {
+		return errors.Errorf("unsupported cluster spec kind '%s', expected '%s'", c.Kind, Kind)
+	}
+	if c.Spec.ControlPlane.MachineCount < 0 {
+		return errors.New("spec.controlPlane.machineCount must not be negative")
+	}
+	if len(c.Spec.NodePools) > MaxNodePools {
+		return errors.Errorf("at most %d node pools are supported, found %d", MaxNodePools, len(c.Spec.NodePools))
+	}
+	for i := range c.Spec.NodePools {
+		pool := &c.Spec.NodePools[i]
+		if pool.MachineCount < 0 {
+			return errors.Errorf("spec.nodePools[%d].machineCount must not be negative", i)
+		}
+		if pool.Size != c.Spec.NodePools[0].Size {
+			return errors.Errorf("spec.nodePools[%d].size must match the size of the first node pool", i)
+		}
+		if pool.Autoscaler != nil && (pool.Autoscaler.MinSize < 0 || pool.Autoscaler.MaxSize < pool.Autoscaler.MinSize) {
+			return errors.Errorf("spec.nodePools[%d].autoscaler must have 0 <= minSize <= maxSize", i)
+		}
+	}
+
+	variables := c.typedConfigVariables()
+	for name := range c.Spec.Variables {
+		if !configVariableNameRegex.MatchString(name) {
+			return errors.Errorf("invalid config variable name '%s' in spec.variables", name)
+		}
+		if _, exists := variables[name]; exists {
+			return errors.Errorf("spec.variables.%s conflicts with a setting of the cluster spec", name)
+		}
+	}
+	return nil
+}
+
+// ToConfigVariables translates the cluster spec into the config variables used by the cluster templates
+func (c *ClusterSpec) ToConfigVariables() map[string]string {
+	variables := c.typedConfigVariables()
+	for name, value := range c.Spec.Variables {
+		variables[name] = value
+	}
+	return variables
+}
+
+func (c *ClusterSpec) typedConfigVariables() map[string]string {
+	variables := map[string]string{}
+	setString := func(name, value string) {
+		if value != "" {
+			variables[name] = value
+		}
+	}
+	setInt := func(name string, value int) {
+		if value > 0 {
+			variables[name] = strconv.Itoa(value)
+		}
+	}
+	setBool := func(name string, value *bool) {
+		if value != nil {
+			variables[name] = strconv.FormatBool(*value)
+		}
+	}
+
+	setString(constants.ConfigVariableClusterName, c.Metadata.Name)
+	setString(constants.ConfigVariableNamespace, c.Metadata.Namespace)
+
+	spec := &c.Spec
+	setString(constants.ConfigVariableClusterPlan, spec.Plan)
+	setString(constants.ConfigVariableInfraProvider, spec.Infrastructure.Provider)
+	if spec.OS != nil {
+		setString(constants.ConfigVariableOSName, spec.OS.Name)
+		setString(constants.ConfigVariableOSVersion, spec.OS.Version)
+		setString(constants.ConfigVariableOSArch, spec.OS.Arch)
+	}
+
+	setInt(constants.ConfigVariableControlPlaneMachineCount, spec.ControlPlane.MachineCount)
+	setString(constants.ConfigVariableControlPlaneSize, spec.ControlPlane.Size)
+	setString(constants.ConfigVariableCPMachineType, spec.ControlPlane.MachineType)
+	setString(constants.ConfigVariableVsphereControlPlaneEndpoint, spec.ControlPlane.Endpoint)
+
+	workerMachineCount := 0
+	for i := range spec.NodePools {
+		pool := &spec.NodePools[i]
+		workerMachineCount += pool.MachineCount
+		if len(spec.NodePools) > 1 {
+			setInt(workerMachineCountVariable(i), pool.MachineCount)
+		}
+		setString(constants.ConfigVariableWorkerSize, pool.Size)
+		setString(nodeMachineTypeVariable(i), pool.MachineType)
+		if pool.Autoscaler != nil {
+			variables[constants.ConfigVariableEnableAutoscaler] = strconv.FormatBool(true)
+			variables[autoscalerMinSizeVariable(i)] = strconv.Itoa(pool.Autoscaler.MinSize)
+			variables[autoscalerMaxSizeVariable(i)] = strconv.Itoa(pool.Autoscaler.MaxSize)
+		}
+	}
+	setInt(constants.ConfigVariableWorkerMachineCount, workerMachineCount)
+
+	setString(constants.ConfigVariableCNI, spec.Network.CNI)
+	setString(constants.ConfigVariableClusterCIDR, spec.Network.PodCIDR)
+	setString(constants.ConfigVariableServiceCIDR, spec.Network.ServiceCIDR)
+	setString(constants.ConfigVariableIPFamily, spec.Network.IPFamily)
+	if spec.Network.Proxy != nil {
+		variables[constants.TKGHTTPProxyEnabled] = strconv.FormatBool(true)
+		setString(constants.TKGHTTPProxy, spec.Network.Proxy.HTTPProxy)
+		setString(constants.TKGHTTPSProxy, spec.Network.Proxy.HTTPSProxy)
+		setString(constants.TKGNoProxy, spec.Network.Proxy.NoProxy)
+	}
+
+	if mhc := spec.MachineHealthCheck; mhc != nil {
+		setBool(constants.ConfigVariableEnableMHC, mhc.Enabled)
+		setBool(constants.ConfigVariableEnableMHCControlPlane, mhc.ControlPlane)
+		setBool(constants.ConfigVariableEnableMHCWorkerNode, mhc.WorkerNodes)
+		setString(constants.ConfigVariableMHCUnknownStatusTimeout, mhc.UnknownStatusTimeout)
+		setString(constants.ConfigVariableMHCFalseStatusTimeout, mhc.FalseStatusTimeout)
+	}
+
+	setBool(constants.ConfigVariableEnableAuditLogging, spec.Addons.AuditLogging)
+	setBool(constants.ConfigVariableEnableDefaultStorageClass, spec.Addons.DefaultStorageClass)
+	setString(constants.ConfigVariableEnableClusterOptions, strings.Join(spec.Addons.ClusterOptions, ","))
+	return variables
+}
+
+func workerMachineCountVariable(index int) string {
+	return fmt.Sprintf("%s_%d", constants.ConfigVariableWorkerMachineCount, index)
+}
+
+// nodeMachineTypeVariable returns the machine type variable of the node pool, the first pool has no suffix
+func nodeMachineTypeVariable(index int) string {
+	if index == 0 {
+		return constants.ConfigVariableNodeMachineType
+	}
+	return fmt.Sprintf("%s_%d", constants.ConfigVariableNodeMachineType, index)
+}
+
+func autoscalerMinSizeVariable(index int) string {
+	return fmt.Sprintf("%s_%d", constants.ConfigVariableAutoscalerMinSize, index)
+}
+
+func autoscalerMaxSizeVariable(index int) string {
+	return fmt.Sprintf("%s_%d", constants.ConfigVariableAutoscalerMaxSize, index)
+}
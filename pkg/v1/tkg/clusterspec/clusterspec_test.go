@@ -0,0 +1,187 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterspec_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterspec"
+)
+
+func TestClusterSpec(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cluster spec Suite")
+}
+
+const clusterSpecYaml = `apiVersion: tkg.tanzu.vmware.com/v1alpha1
+kind: ClusterSpec
+metadata:
+  name: my-cluster
+  namespace: my-namespace
+spec:
+  plan: prod
+  infrastructure:
+    provider: aws
+  controlPlane:
+    machineCount: 3
+    machineType: t3.large
+  nodePools:
+  - machineCount: 2
+    machineType: m5.large
+    autoscaler:
+      minSize: 1
+      maxSize: 4
+  - machineCount: 1
+    machineType: m5.xlarge
+  network:
+    cni: calico
+    podCIDR: 100.96.0.0/11
+    proxy:
+      httpProxy: http://proxy:3128
+  machineHealthCheck:
+    enabled: false
+  addons:
+    clusterOptions: [foo, bar]
+  variables:
+    AWS_REGION: us-west-2
+`
+
+var _ = Describe("ClusterSpec", func() {
+	var (
+		spec *ClusterSpec
+		err  error
+	)
+
+	Describe("IsClusterSpec", func() {
+		It("detects cluster specs and flat config files", func() {
+			Expect(IsClusterSpec([]byte(clusterSpecYaml))).To(BeTrue())
+			Expect(IsClusterSpec([]byte("CLUSTER_NAME: my-cluster\n"))).To(BeFalse())
+		})
+	})
+
+	Describe("Parse", func() {
+		It("translates the spec into config variables", func() {
+			spec, err = Parse([]byte(clusterSpecYaml))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec.ToConfigVariables()).To(Equal(map[string]string{
+				"CLUSTER_NAME":                "my-cluster",
+				"NAMESPACE":                   "my-namespace",
+				"CLUSTER_PLAN":                "prod",
+				"INFRASTRUCTURE_PROVIDER":     "aws",
+				"CONTROL_PLANE_MACHINE_COUNT": "3",
+				"CONTROL_PLANE_MACHINE_TYPE":  "t3.large",
+				"WORKER_MACHINE_COUNT":        "3",
+				"WORKER_MACHINE_COUNT_0":      "2",
+				"WORKER_MACHINE_COUNT_1":      "1",
+				"NODE_MACHINE_TYPE":           "m5.large",
+				"NODE_MACHINE_TYPE_1":         "m5.xlarge",
+				"ENABLE_AUTOSCALER":           "true",
+				"AUTOSCALER_MIN_SIZE_0":       "1",
+				"AUTOSCALER_MAX_SIZE_0":       "4",
+				"CNI":                         "calico",
+				"CLUSTER_CIDR":                "100.96.0.0/11",
+				"TKG_HTTP_PROXY_ENABLED":      "true",
+				"TKG_HTTP_PROXY":              "http://proxy:3128",
+				"ENABLE_MHC":                  "false",
+				"ENABLE_CLUSTER_OPTIONS":      "foo,bar",
+				"AWS_REGION":                  "us-west-2",
+			}))
+		})
+
+		It("rejects unknown fields", func() {
+			_, err = Parse([]byte(clusterSpecYaml + "  workerPools:\n  - machineCount: 1\n"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`unknown field "workerPools"`))
+		})
+
+		It("rejects unsupported versions", func() {
+			_, err = Parse([]byte("apiVersion: tkg.tanzu.vmware.com/v1\nkind: ClusterSpec\n"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unsupported cluster spec apiVersion"))
+		})
+
+		It("rejects variables overriding a setting of the spec", func() {
+			_, err = Parse([]byte(clusterSpecYaml + "    CNI: antrea\n"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.variables.CNI conflicts"))
+		})
+	})
+
+	Describe("ParseConfigVariables", func() {
+		It("keeps the text of the values", func() {
+			variables, err := ParseConfigVariables([]byte(`CLUSTER_NAME: &name my-cluster
+AZURE_IMAGE_VERSION: 1.20
+VSPHERE_DISK_GIB: 040
+ENABLE_MHC: yes
+cluster_alias: *name
+OS_NAME: ~
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(variables).To(Equal(map[string]string{
+				"CLUSTER_NAME":        "my-cluster",
+				"AZURE_IMAGE_VERSION": "1.20",
+				"VSPHERE_DISK_GIB":    "040",
+				"ENABLE_MHC":          "yes",
+				"CLUSTER_ALIAS":       "my-cluster",
+			}))
+		})
+
+		It("rejects variables with a value which is not a scalar", func() {
+			_, err := ParseConfigVariables([]byte("CLUSTER_NAME: [a, b]\n"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("config variable 'CLUSTER_NAME' must have a scalar value"))
+		})
+
+		It("returns no variables for an empty file", func() {
+			variables, err := ParseConfigVariables([]byte("# no variables\n"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(variables).To(BeEmpty())
+		})
+	})
+
+	Describe("FromConfigVariables", func() {
+		It("converts flat config variables into an equivalent spec", func() {
+			variables, err := ParseConfigVariables([]byte(`CLUSTER_NAME: my-cluster
+CLUSTER_PLAN: dev
+INFRASTRUCTURE_PROVIDER: vsphere
+VSPHERE_CONTROL_PLANE_ENDPOINT: 10.0.0.1
+VSPHERE_SERVER: vc.example.com
+WORKER_MACHINE_COUNT: 2
+WORKER_SIZE: large
+ENABLE_MHC: true
+ENABLE_AUDIT_LOGGING: false
+ENABLE_AUTOSCALER: false
+TKG_HTTP_PROXY_ENABLED: false
+TKG_HTTP_PROXY: ""
+OS_NAME:
+`))
+			Expect(err).NotTo(HaveOccurred())
+
+			spec = FromConfigVariables(variables)
+			Expect(spec.Validate()).To(Succeed())
+			Expect(spec.Metadata.Name).To(Equal("my-cluster"))
+			Expect(spec.Spec.ControlPlane.Endpoint).To(Equal("10.0.0.1"))
+			Expect(spec.Spec.NodePools).To(Equal([]NodePool{{MachineCount: 2, Size: "large"}}))
+			Expect(spec.Spec.OS).To(BeNil())
+			Expect(spec.Spec.Network.Proxy).To(BeNil())
+			Expect(*spec.Spec.MachineHealthCheck.Enabled).To(BeTrue())
+			Expect(spec.Spec.Variables).To(Equal(map[string]string{
+				"VSPHERE_SERVER":         "vc.example.com",
+				"ENABLE_AUTOSCALER":      "false",
+				"TKG_HTTP_PROXY_ENABLED": "false",
+			}))
+			Expect(spec.ToConfigVariables()).To(Equal(variables))
+		})
+
+		It("round trips a cluster spec", func() {
+			spec, err = Parse([]byte(clusterSpecYaml))
+			Expect(err).NotTo(HaveOccurred())
+			variables := spec.ToConfigVariables()
+			Expect(FromConfigVariables(variables).ToConfigVariables()).To(Equal(variables))
+		})
+	})
+})
@@ -0,0 +1,234 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package clusterspec
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+)
+
+// nullTag is the yaml tag of the values of the variables without a value
+const nullTag = "!!null"
+
+// ParseConfigVariables parses a flat config variable file. Variables without a value are dropped.
+// The values keep their text in the file, so that e.g. a version 1.20 is not read as the number 1.2.
+func ParseConfigVariables(data []byte) (map[string]string, error) {
+	doc := &yamlv3.Node{}
+	if err := yamlv3.Unmarshal(data, doc); err != nil {
+		return nil, errors.Wrap(err, "unable to parse cluster config file")
+	}
+	variables := map[string]string{}
+	if len(doc.Content) == 0 {
+		return variables, nil
+	}
+	root := doc.Content[0]
+	if root.Kind == yamlv3.ScalarNode && root.ShortTag() == nullTag {
+		return variables, nil
+	}
+	if root.Kind != yamlv3.MappingNode {
+		return nil, errors.New("unable to parse cluster config file: the config variables must be a map")
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		name, value := root.Content[i].Value, root.Content[i+1]
+		if value.Kind == yamlv3.AliasNode {
+			value = value.Alias
+		}
+		switch {
+		case value.Kind != yamlv3.ScalarNode:
+			return nil, errors.Errorf("config variable '%s' must have a scalar value", name)
+		case value.ShortTag() == nullTag || value.Value == "":
+			continue
+		default:
+			variables[strings.ToUpper(name)] = value.Value
+		}
+	}
+	return variables, nil
+}
+
+// ReadConfigVariables returns the config variables of a flat config variable file,
+// or the config variables a cluster spec translates into
+func ReadConfigVariables(data []byte) (map[string]string, error) {
+	if IsClusterSpec(data) {
+		spec, err := Parse(data)
+		if err != nil {
+			return nil, err
+		}
+		return spec.ToConfigVariables(), nil
+	}
+	return ParseConfigVariables(data)
+}
+
+// FromConfigVariables converts flat config variables into a cluster spec. Variables without
+// a typed equivalent, or with a value that cannot be converted, are kept in spec.variables
+// so that the spec translates back into the same variables.
+func FromConfigVariables(variables map[string]string) *ClusterSpec {
+	c := &configVariableConverter{variables: map[string]string{}}
+	for name, value := range variables {
+		if value != "" {
+			c.variables[name] = value
+		}
+	}
+
+	spec := &ClusterSpec{
+		APIVersion: APIVersion,
+		Kind:       Kind,
+		Metadata: Metadata{
+			Name:      c.takeString(constants.ConfigVariableClusterName),
+			Namespace: c.takeString(constants.ConfigVariableNamespace),
+		},
+	}
+	spec.Spec.Plan = c.takeString(constants.ConfigVariableClusterPlan)
+	spec.Spec.Infrastructure.Provider = c.takeString(constants.ConfigVariableInfraProvider)
+	if c.hasAny(constants.ConfigVariableOSName, constants.ConfigVariableOSVersion, constants.ConfigVariableOSArch) {
+		spec.Spec.OS = &OS{
+			Name:    c.takeString(constants.ConfigVariableOSName),
+			Version: c.takeString(constants.ConfigVariableOSVersion),
+			Arch:    c.takeString(constants.ConfigVariableOSArch),
+		}
+	}
+
+	spec.Spec.ControlPlane = ControlPlane{
+		MachineCount: c.takeInt(constants.ConfigVariableControlPlaneMachineCount),
+		Size:         c.takeString(constants.ConfigVariableControlPlaneSize),
+		MachineType:  c.takeString(constants.ConfigVariableCPMachineType),
+		Endpoint:     c.takeString(constants.ConfigVariableVsphereControlPlaneEndpoint),
+	}
+	spec.Spec.NodePools = c.takeNodePools()
+
+	spec.Spec.Network = Network{
+		CNI:         c.takeString(constants.ConfigVariableCNI),
+		PodCIDR:     c.takeString(constants.ConfigVariableClusterCIDR),
+		ServiceCIDR: c.takeString(constants.ConfigVariableServiceCIDR),
+		IPFamily:    c.takeString(constants.ConfigVariableIPFamily),
+	}
+	if enabled, err := strconv.ParseBool(c.variables[constants.TKGHTTPProxyEnabled]); err == nil && enabled {
+		delete(c.variables, constants.TKGHTTPProxyEnabled)
+		spec.Spec.Network.Proxy = &Proxy{
+			HTTPProxy:  c.takeString(constants.TKGHTTPProxy),
+			HTTPSProxy: c.takeString(constants.TKGHTTPSProxy),
+			NoProxy:    c.takeString(constants.TKGNoProxy),
+		}
+	}
+
+	if c.hasAny(constants.ConfigVariableEnableMHC, constants.ConfigVariableEnableMHCControlPlane, constants.ConfigVariableEnableMHCWorkerNode,
+		constants.ConfigVariableMHCUnknownStatusTimeout, constants.ConfigVariableMHCFalseStatusTimeout) {
+		spec.Spec.MachineHealthCheck = &MachineHealthCheck{
+			Enabled:              c.takeBool(constants.ConfigVariableEnableMHC),
+			ControlPlane:         c.takeBool(constants.ConfigVariableEnableMHCControlPlane),
+			WorkerNodes:          c.takeBool(constants.ConfigVariableEnableMHCWorkerNode),
+			UnknownStatusTimeout: c.takeString(constants.ConfigVariableMHCUnknownStatusTimeout),
+			FalseStatusTimeout:   c.takeString(constants.ConfigVariableMHCFalseStatusTimeout),
+		}
+	}
+
+	spec.Spec.Addons.AuditLogging = c.takeBool(constants.ConfigVariableEnableAuditLogging)
+	spec.Spec.Addons.DefaultStorageClass = c.takeBool(constants.ConfigVariableEnableDefaultStorageClass)
+	if clusterOptions := c.takeString(constants.ConfigVariableEnableClusterOptions); clusterOptions != "" {
+		for _, option := range strings.Split(clusterOptions, ",") {
+			if option = strings.TrimSpace(option); option != "" {
+				spec.Spec.Addons.ClusterOptions = append(spec.Spec.Addons.ClusterOptions, option)
+			}
+		}
+	}
+
+	if len(c.variables) != 0 {
+		spec.Spec.Variables = c.variables
+	}
+	return spec
+}
+
+// takeNodePools converts the worker variables, there is one node pool per machine deployment of the plan
+func (c *configVariableConverter) takeNodePools() []NodePool {
+	poolCount := 0
+	if c.hasAny(constants.ConfigVariableWorkerMachineCount, constants.ConfigVariableWorkerSize) {
+		poolCount = 1
+	}
+	for i := 0; i < MaxNodePools; i++ {
+		if c.hasAny(workerMachineCountVariable(i), nodeMachineTypeVariable(i), autoscalerMinSizeVariable(i), autoscalerMaxSizeVariable(i)) {
+			poolCount = i + 1
+		}
+	}
+	if poolCount == 0 {
+		return nil
+	}
+
+	autoscalerEnabled, _ := strconv.ParseBool(c.variables[constants.ConfigVariableEnableAutoscaler])
+	workerSize := c.takeString(constants.ConfigVariableWorkerSize)
+	pools := make([]NodePool, poolCount)
+	workerMachineCount := 0
+	for i := range pools {
+		pools[i].Size = workerSize
+		pools[i].MachineType = c.takeString(nodeMachineTypeVariable(i))
+		if poolCount > 1 {
+			pools[i].MachineCount = c.takeInt(workerMachineCountVariable(i))
+			workerMachineCount += pools[i].MachineCount
+		}
+		if autoscalerEnabled {
+			minSize, minErr := strconv.Atoi(c.variables[autoscalerMinSizeVariable(i)])
+			maxSize, maxErr := strconv.Atoi(c.variables[autoscalerMaxSizeVariable(i)])
+			if minErr == nil && maxErr == nil {
+				delete(c.variables, autoscalerMinSizeVariable(i))
+				delete(c.variables, autoscalerMaxSizeVariable(i))
+				pools[i].Autoscaler = &Autoscaler{MinSize: minSize, MaxSize: maxSize}
+			}
+		}
+	}
+	for i := range pools {
+		if pools[i].Autoscaler != nil {
+			delete(c.variables, constants.ConfigVariableEnableAutoscaler)
+			break
+		}
+	}
+
+	if poolCount == 1 {
+		pools[0].MachineCount = c.takeInt(constants.ConfigVariableWorkerMachineCount)
+	} else if workerMachineCount > 0 {
+		// the total is derived from the node pools
+		delete(c.variables, constants.ConfigVariableWorkerMachineCount)
+	}
+	return pools
+}
+
+// configVariableConverter removes the config variables from the set as they are converted
+type configVariableConverter struct {
+	variables map[string]string
+}
+
+func (c *configVariableConverter) hasAny(names ...string) bool {
+	for _, name := range names {
+		if _, exists := c.variables[name]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *configVariableConverter) takeString(name string) string {
+	value := c.variables[name]
+	delete(c.variables, name)
+	return value
+}
+
+func (c *configVariableConverter) takeInt(name string) int {
+	value, err := strconv.Atoi(c.variables[name])
+	if err != nil || value <= 0 {
+		return 0
+	}
+	delete(c.variables, name)
+	return value
+}
+
+func (c *configVariableConverter) takeBool(name string) *bool {
+	value, err := strconv.ParseBool(c.variables[name])
+	if err != nil {
+		return nil
+	}
+	delete(c.variables, name)
+	return &value
+}
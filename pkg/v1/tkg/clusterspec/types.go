@@ -0,0 +1,120 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package clusterspec implements the declarative cluster spec file format,
+// an alternative to the flat config variable files used to create clusters.
+package clusterspec
+
+const (
+	// APIVersion is the version of the cluster spec format
+	APIVersion = "tkg.tanzu.vmware.com/v1alpha1"
+	// Kind is the kind of the cluster spec document
+	Kind = "ClusterSpec"
+
+	// MaxNodePools is the maximum number of node pools supported by the cluster plans
+	MaxNodePools = 3
+)
+
+// ClusterSpec is the declarative definition of a workload cluster
+type ClusterSpec struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Metadata   Metadata `json:"metadata"`
+	Spec       Spec     `json:"spec"`
+}
+
+// Metadata identifies the cluster
+type Metadata struct {
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Spec describes the desired cluster
+type Spec struct {
+	// Plan is the cluster plan, e.g. dev or prod
+	Plan string `json:"plan,omitempty"`
+	// Infrastructure is the infrastructure the cluster is deployed on
+	Infrastructure Infrastructure `json:"infrastructure,omitempty"`
+	// OS selects the machine image of the cluster nodes
+	OS *OS `json:"os,omitempty"`
+	// ControlPlane describes the control plane machines
+	ControlPlane ControlPlane `json:"controlPlane,omitempty"`
+	// NodePools describes the worker machines, one entry per machine deployment
+	NodePools []NodePool `json:"nodePools,omitempty"`
+	// Network describes the cluster networking
+	Network Network `json:"network,omitempty"`
+	// MachineHealthCheck configures the machine health checks of the cluster
+	MachineHealthCheck *MachineHealthCheck `json:"machineHealthCheck,omitempty"`
+	// Addons configures the optional cluster features
+	Addons Addons `json:"addons,omitempty"`
+	// Variables are additional config variables, e.g. infrastructure specific settings,
+	// passed as is to the cluster templates. They cannot override the settings above.
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+// Infrastructure describes the infrastructure the cluster is deployed on
+type Infrastructure struct {
+	Provider string `json:"provider,omitempty"`
+}
+
+// OS selects the machine image of the cluster nodes
+type OS struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+	Arch    string `json:"arch,omitempty"`
+}
+
+// ControlPlane describes the control plane machines
+type ControlPlane struct {
+	MachineCount int    `json:"machineCount,omitempty"`
+	Size         string `json:"size,omitempty"`
+	MachineType  string `json:"machineType,omitempty"`
+	// Endpoint is the virtual IP address or FQDN of the control plane on vSphere
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// NodePool describes a set of worker machines
+type NodePool struct {
+	MachineCount int         `json:"machineCount,omitempty"`
+	Size         string      `json:"size,omitempty"`
+	MachineType  string      `json:"machineType,omitempty"`
+	Autoscaler   *Autoscaler `json:"autoscaler,omitempty"`
+}
+
+// Autoscaler sets the bounds of an autoscaled node pool
+type Autoscaler struct {
+	MinSize int `json:"minSize"`
+	MaxSize int `json:"maxSize"`
+}
+
+// Network describes the cluster networking
+type Network struct {
+	CNI         string `json:"cni,omitempty"`
+	PodCIDR     string `json:"podCIDR,omitempty"`
+	ServiceCIDR string `json:"serviceCIDR,omitempty"`
+	IPFamily    string `json:"ipFamily,omitempty"`
+	Proxy       *Proxy `json:"proxy,omitempty"`
+}
+
+// Proxy configures the proxy used by the cluster nodes
+type Proxy struct {
+	HTTPProxy  string `json:"httpProxy,omitempty"`
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	NoProxy    string `json:"noProxy,omitempty"`
+}
+
+// MachineHealthCheck configures the machine health checks of the cluster
+type MachineHealthCheck struct {
+	Enabled              *bool  `json:"enabled,omitempty"`
+	ControlPlane         *bool  `json:"controlPlane,omitempty"`
+	WorkerNodes          *bool  `json:"workerNodes,omitempty"`
+	UnknownStatusTimeout string `json:"unknownStatusTimeout,omitempty"`
+	FalseStatusTimeout   string `json:"falseStatusTimeout,omitempty"`
+}
+
+// Addons configures the optional cluster features
+type Addons struct {
+	AuditLogging        *bool    `json:"auditLogging,omitempty"`
+	DefaultStorageClass *bool    `json:"defaultStorageClass,omitempty"`
+	ClusterOptions      []string `json:"clusterOptions,omitempty"`
+}
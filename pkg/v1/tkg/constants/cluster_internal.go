@@ -115,4 +115,24 @@ const (
 	AddonNameLabel = "tkg.tanzu.vmware.com/addon-name"
 	// ClusterNameLabel is the label on the Secret to indicate the cluster on which addon is to be installed
 	ClusterNameLabel = "tkg.tanzu.vmware.com/cluster-name"
+	// AddonPausedAnnotation is the annotation on the Secret which pauses the reconciliation of the addon
+	AddonPausedAnnotation = "tkg.tanzu.vmware.com/addon-paused"
+	// AddonRemoteAppAnnotation is the annotation on the Secret to indicate the addon App is created on the management cluster
+	AddonRemoteAppAnnotation = "tkg.tanzu.vmware.com/remote-app"
+)
+
+// cluster annotations
+const (
+	// DeletionProtectionAnnotation is the annotation on the Cluster which blocks its deletion when set to true
+	DeletionProtectionAnnotation = "tkg.tanzu.vmware.com/deletion-protection"
+	// HibernationAnnotation is the annotation on the Cluster recording the worker replicas to restore on resume while it is hibernated
+	HibernationAnnotation = "tkg.tanzu.vmware.com/hibernation"
+)
+
+// cluster autoscaler related constants
+const (
+	// AutoscalerMinSizeAnnotation is the annotation on the MachineDeployment holding the minimum size of the node pool
+	AutoscalerMinSizeAnnotation = "cluster.k8s.io/cluster-api-autoscaler-node-group-min-size"
+	// AutoscalerMaxSizeAnnotation is the annotation on the MachineDeployment holding the maximum size of the node pool
+	AutoscalerMaxSizeAnnotation = "cluster.k8s.io/cluster-api-autoscaler-node-group-max-size"
 )
@@ -12,6 +12,10 @@ const (
 	ConfigVariableCustomImageRepositorySkipTLSVerify = "TKG_CUSTOM_IMAGE_REPOSITORY_SKIP_TLS_VERIFY"
 	ConfigVariableCustomImageRepositoryCaCertificate = "TKG_CUSTOM_IMAGE_REPOSITORY_CA_CERTIFICATE"
 
+	ConfigVariableLogFormat         = "TKG_LOG_FORMAT"
+	ConfigVariableLogFileMaxSize    = "TKG_LOG_FILE_MAX_SIZE"
+	ConfigVariableLogFileMaxBackups = "TKG_LOG_FILE_MAX_BACKUPS"
+
 	ConfigVariableAWSRegion          = "AWS_REGION"
 	ConfigVariableAWSSecretAccessKey = "AWS_SECRET_ACCESS_KEY" //nolint:gosec
 	ConfigVariableAWSAccessKeyID     = "AWS_ACCESS_KEY_ID"     //nolint:gosec
@@ -108,6 +112,16 @@ const (
 	ConfigVariableFilterByAddonType      = "FILTER_BY_ADDON_TYPE"
 	ConfigVaraibleDisableCRSForAddonType = "DISABLE_CRS_FOR_ADDON_TYPE"
 	ConfigVariableEnableAutoscaler       = "ENABLE_AUTOSCALER"
+	ConfigVariableAutoscalerMinSize      = "AUTOSCALER_MIN_SIZE"
+	ConfigVariableAutoscalerMaxSize      = "AUTOSCALER_MAX_SIZE"
+
+	ConfigVariableEnableMHC                 = "ENABLE_MHC"
+	ConfigVariableEnableMHCControlPlane     = "ENABLE_MHC_CONTROL_PLANE"
+	ConfigVariableEnableMHCWorkerNode       = "ENABLE_MHC_WORKER_NODE"
+	ConfigVariableMHCUnknownStatusTimeout   = "MHC_UNKNOWN_STATUS_TIMEOUT"
+	ConfigVariableMHCFalseStatusTimeout     = "MHC_FALSE_STATUS_TIMEOUT"
+	ConfigVariableEnableAuditLogging        = "ENABLE_AUDIT_LOGGING"
+	ConfigVariableEnableDefaultStorageClass = "ENABLE_DEFAULT_STORAGE_CLASS"
 
 	ConfigVariableControlPlaneMachineCount = "CONTROL_PLANE_MACHINE_COUNT"
 	ConfigVariableWorkerMachineCount       = "WORKER_MACHINE_COUNT"
@@ -18,6 +18,7 @@ const (
 	LocalProvidersConfigFileName = "config.yaml"
 	LocalBOMsFolderName          = "bom"
 	LocalCompatibilityFolderName = "compatibility"
+	LocalClusterProfilesFolder   = "profiles"
 
 	LocalProvidersChecksumFileName = "providers.sha256sum"
 	OverrideFolder                 = "overrides"
@@ -42,6 +42,17 @@ type Client struct {
 	addRegionContextReturnsOnCall map[int]struct {
 		result1 error
 	}
+	BackupManagementClusterStub        func(client.BackupManagementClusterOptions) error
+	backupManagementClusterMutex       sync.RWMutex
+	backupManagementClusterArgsForCall []struct {
+		arg1 client.BackupManagementClusterOptions
+	}
+	backupManagementClusterReturns struct {
+		result1 error
+	}
+	backupManagementClusterReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ConfigureAndValidateManagementClusterConfigurationStub        func(*client.InitRegionOptions, bool) *client.ValidationError
 	configureAndValidateManagementClusterConfigurationMutex       sync.RWMutex
 	configureAndValidateManagementClusterConfigurationArgsForCall []struct {
@@ -202,6 +213,17 @@ type Client struct {
 	downloadBomFileReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ExportImageBundleStub        func(client.ExportImageBundleOptions) error
+	exportImageBundleMutex       sync.RWMutex
+	exportImageBundleArgsForCall []struct {
+		arg1 client.ExportImageBundleOptions
+	}
+	exportImageBundleReturns struct {
+		result1 error
+	}
+	exportImageBundleReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetCEIPParticipationStub        func() (client.ClusterCeipInfo, error)
 	getCEIPParticipationMutex       sync.RWMutex
 	getCEIPParticipationArgsForCall []struct {
@@ -227,6 +249,32 @@ type Client struct {
 		result1 []byte
 		result2 error
 	}
+	GetClusterHealthStub        func(client.GetClusterHealthOptions) (*client.ClusterHealthReport, error)
+	getClusterHealthMutex       sync.RWMutex
+	getClusterHealthArgsForCall []struct {
+		arg1 client.GetClusterHealthOptions
+	}
+	getClusterHealthReturns struct {
+		result1 *client.ClusterHealthReport
+		result2 error
+	}
+	getClusterHealthReturnsOnCall map[int]struct {
+		result1 *client.ClusterHealthReport
+		result2 error
+	}
+	GetClusterOperationHistoryStub        func(client.GetClusterOperationHistoryOptions) ([]client.OperationRecord, error)
+	getClusterOperationHistoryMutex       sync.RWMutex
+	getClusterOperationHistoryArgsForCall []struct {
+		arg1 client.GetClusterOperationHistoryOptions
+	}
+	getClusterOperationHistoryReturns struct {
+		result1 []client.OperationRecord
+		result2 error
+	}
+	getClusterOperationHistoryReturnsOnCall map[int]struct {
+		result1 []client.OperationRecord
+		result2 error
+	}
 	GetClusterPinnipedInfoStub        func(client.GetClusterPinnipedInfoOptions) (*client.ClusterPinnipedInfo, error)
 	getClusterPinnipedInfoMutex       sync.RWMutex
 	getClusterPinnipedInfoArgsForCall []struct {
@@ -240,6 +288,19 @@ type Client struct {
 		result1 *client.ClusterPinnipedInfo
 		result2 error
 	}
+	GetClusterUpgradeStatusStub        func(*client.UpgradeClusterOptions) (*client.UpgradeStatus, error)
+	getClusterUpgradeStatusMutex       sync.RWMutex
+	getClusterUpgradeStatusArgsForCall []struct {
+		arg1 *client.UpgradeClusterOptions
+	}
+	getClusterUpgradeStatusReturns struct {
+		result1 *client.UpgradeStatus
+		result2 error
+	}
+	getClusterUpgradeStatusReturnsOnCall map[int]struct {
+		result1 *client.UpgradeStatus
+		result2 error
+	}
 	GetCurrentRegionContextStub        func() (region.RegionContext, error)
 	getCurrentRegionContextMutex       sync.RWMutex
 	getCurrentRegionContextArgsForCall []struct {
@@ -344,6 +405,28 @@ type Client struct {
 		result2 string
 		result3 error
 	}
+	HibernateClusterStub        func(client.HibernateClusterOptions) error
+	hibernateClusterMutex       sync.RWMutex
+	hibernateClusterArgsForCall []struct {
+		arg1 client.HibernateClusterOptions
+	}
+	hibernateClusterReturns struct {
+		result1 error
+	}
+	hibernateClusterReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ImportImageBundleStub        func(client.ImportImageBundleOptions) error
+	importImageBundleMutex       sync.RWMutex
+	importImageBundleArgsForCall []struct {
+		arg1 client.ImportImageBundleOptions
+	}
+	importImageBundleReturns struct {
+		result1 error
+	}
+	importImageBundleReturnsOnCall map[int]struct {
+		result1 error
+	}
 	InitRegionStub        func(*client.InitRegionOptions) error
 	initRegionMutex       sync.RWMutex
 	initRegionArgsForCall []struct {
@@ -393,6 +476,19 @@ type Client struct {
 		result1 bool
 		result2 error
 	}
+	ListClusterCertificatesStub        func(client.ListClusterCertificatesOptions) ([]client.ClusterCertificate, error)
+	listClusterCertificatesMutex       sync.RWMutex
+	listClusterCertificatesArgsForCall []struct {
+		arg1 client.ListClusterCertificatesOptions
+	}
+	listClusterCertificatesReturns struct {
+		result1 []client.ClusterCertificate
+		result2 error
+	}
+	listClusterCertificatesReturnsOnCall map[int]struct {
+		result1 []client.ClusterCertificate
+		result2 error
+	}
 	ListTKGClustersStub        func(client.ListTKGClustersOptions) ([]client.ClusterInfo, error)
 	listTKGClustersMutex       sync.RWMutex
 	listTKGClustersArgsForCall []struct {
@@ -406,11 +502,63 @@ type Client struct {
 		result1 []client.ClusterInfo
 		result2 error
 	}
+	ListTKGClustersInAllRegionsStub        func(client.ListTKGClustersInAllRegionsOptions) ([]client.ClusterInfo, []client.UnreachableRegion, error)
+	listTKGClustersInAllRegionsMutex       sync.RWMutex
+	listTKGClustersInAllRegionsArgsForCall []struct {
+		arg1 client.ListTKGClustersInAllRegionsOptions
+	}
+	listTKGClustersInAllRegionsReturns struct {
+		result1 []client.ClusterInfo
+		result2 []client.UnreachableRegion
+		result3 error
+	}
+	listTKGClustersInAllRegionsReturnsOnCall map[int]struct {
+		result1 []client.ClusterInfo
+		result2 []client.UnreachableRegion
+		result3 error
+	}
+	MoveManagementClusterStub        func(client.MoveManagementClusterOptions) error
+	moveManagementClusterMutex       sync.RWMutex
+	moveManagementClusterArgsForCall []struct {
+		arg1 client.MoveManagementClusterOptions
+	}
+	moveManagementClusterReturns struct {
+		result1 error
+	}
+	moveManagementClusterReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ParseHiddenArgsAsFeatureFlagsStub        func(*client.InitRegionOptions)
 	parseHiddenArgsAsFeatureFlagsMutex       sync.RWMutex
 	parseHiddenArgsAsFeatureFlagsArgsForCall []struct {
 		arg1 *client.InitRegionOptions
 	}
+	PlanClusterUpgradeStub        func(*client.UpgradeClusterOptions) (*client.UpgradeClusterPlan, error)
+	planClusterUpgradeMutex       sync.RWMutex
+	planClusterUpgradeArgsForCall []struct {
+		arg1 *client.UpgradeClusterOptions
+	}
+	planClusterUpgradeReturns struct {
+		result1 *client.UpgradeClusterPlan
+		result2 error
+	}
+	planClusterUpgradeReturnsOnCall map[int]struct {
+		result1 *client.UpgradeClusterPlan
+		result2 error
+	}
+	PlanWorkloadClusterDeletionStub        func(client.DeleteWorkloadClusterOptions) (*client.DeleteClusterPlan, error)
+	planWorkloadClusterDeletionMutex       sync.RWMutex
+	planWorkloadClusterDeletionArgsForCall []struct {
+		arg1 client.DeleteWorkloadClusterOptions
+	}
+	planWorkloadClusterDeletionReturns struct {
+		result1 *client.DeleteClusterPlan
+		result2 error
+	}
+	planWorkloadClusterDeletionReturnsOnCall map[int]struct {
+		result1 *client.DeleteClusterPlan
+		result2 error
+	}
 	RegisterManagementClusterToTmcStub        func(string, string) error
 	registerManagementClusterToTmcMutex       sync.RWMutex
 	registerManagementClusterToTmcArgsForCall []struct {
@@ -423,6 +571,67 @@ type Client struct {
 	registerManagementClusterToTmcReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RestoreManagementClusterStub        func(client.RestoreManagementClusterOptions) error
+	restoreManagementClusterMutex       sync.RWMutex
+	restoreManagementClusterArgsForCall []struct {
+		arg1 client.RestoreManagementClusterOptions
+	}
+	restoreManagementClusterReturns struct {
+		result1 error
+	}
+	restoreManagementClusterReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ResumeClusterStub        func(client.ResumeClusterOptions) error
+	resumeClusterMutex       sync.RWMutex
+	resumeClusterArgsForCall []struct {
+		arg1 client.ResumeClusterOptions
+	}
+	resumeClusterReturns struct {
+		result1 error
+	}
+	resumeClusterReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RotateClusterCertificatesStub        func(client.RotateClusterCertificatesOptions) error
+	rotateClusterCertificatesMutex       sync.RWMutex
+	rotateClusterCertificatesArgsForCall []struct {
+		arg1 client.RotateClusterCertificatesOptions
+	}
+	rotateClusterCertificatesReturns struct {
+		result1 error
+	}
+	rotateClusterCertificatesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RunClusterPreflightStub        func(*client.CreateClusterOptions, []string) (*client.PreflightReport, error)
+	runClusterPreflightMutex       sync.RWMutex
+	runClusterPreflightArgsForCall []struct {
+		arg1 *client.CreateClusterOptions
+		arg2 []string
+	}
+	runClusterPreflightReturns struct {
+		result1 *client.PreflightReport
+		result2 error
+	}
+	runClusterPreflightReturnsOnCall map[int]struct {
+		result1 *client.PreflightReport
+		result2 error
+	}
+	RunManagementClusterPreflightStub        func(*client.InitRegionOptions, []string) (*client.PreflightReport, error)
+	runManagementClusterPreflightMutex       sync.RWMutex
+	runManagementClusterPreflightArgsForCall []struct {
+		arg1 *client.InitRegionOptions
+		arg2 []string
+	}
+	runManagementClusterPreflightReturns struct {
+		result1 *client.PreflightReport
+		result2 error
+	}
+	runManagementClusterPreflightReturnsOnCall map[int]struct {
+		result1 *client.PreflightReport
+		result2 error
+	}
 	SaveFeatureFlagsStub        func(map[string]string) error
 	saveFeatureFlagsMutex       sync.RWMutex
 	saveFeatureFlagsArgsForCall []struct {
@@ -458,6 +667,17 @@ type Client struct {
 	setCEIPParticipationReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetClusterDeletionProtectionStub        func(client.SetClusterDeletionProtectionOptions) error
+	setClusterDeletionProtectionMutex       sync.RWMutex
+	setClusterDeletionProtectionArgsForCall []struct {
+		arg1 client.SetClusterDeletionProtectionOptions
+	}
+	setClusterDeletionProtectionReturns struct {
+		result1 error
+	}
+	setClusterDeletionProtectionReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetMachineDeploymentStub        func(*client.SetMachineDeploymentOptions) error
 	setMachineDeploymentMutex       sync.RWMutex
 	setMachineDeploymentArgsForCall []struct {
@@ -571,6 +791,17 @@ type Client struct {
 		result1 region.RegionContext
 		result2 error
 	}
+	VerifyWorkloadClusterDeletionStub        func(client.DeleteWorkloadClusterOptions) error
+	verifyWorkloadClusterDeletionMutex       sync.RWMutex
+	verifyWorkloadClusterDeletionArgsForCall []struct {
+		arg1 client.DeleteWorkloadClusterOptions
+	}
+	verifyWorkloadClusterDeletionReturns struct {
+		result1 error
+	}
+	verifyWorkloadClusterDeletionReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -699,6 +930,67 @@ func (fake *Client) AddRegionContextReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Client) BackupManagementCluster(arg1 client.BackupManagementClusterOptions) error {
+	fake.backupManagementClusterMutex.Lock()
+	ret, specificReturn := fake.backupManagementClusterReturnsOnCall[len(fake.backupManagementClusterArgsForCall)]
+	fake.backupManagementClusterArgsForCall = append(fake.backupManagementClusterArgsForCall, struct {
+		arg1 client.BackupManagementClusterOptions
+	}{arg1})
+	stub := fake.BackupManagementClusterStub
+	fakeReturns := fake.backupManagementClusterReturns
+	fake.recordInvocation("BackupManagementCluster", []interface{}{arg1})
+	fake.backupManagementClusterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) BackupManagementClusterCallCount() int {
+	fake.backupManagementClusterMutex.RLock()
+	defer fake.backupManagementClusterMutex.RUnlock()
+	return len(fake.backupManagementClusterArgsForCall)
+}
+
+func (fake *Client) BackupManagementClusterCalls(stub func(client.BackupManagementClusterOptions) error) {
+	fake.backupManagementClusterMutex.Lock()
+	defer fake.backupManagementClusterMutex.Unlock()
+	fake.BackupManagementClusterStub = stub
+}
+
+func (fake *Client) BackupManagementClusterArgsForCall(i int) client.BackupManagementClusterOptions {
+	fake.backupManagementClusterMutex.RLock()
+	defer fake.backupManagementClusterMutex.RUnlock()
+	argsForCall := fake.backupManagementClusterArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) BackupManagementClusterReturns(result1 error) {
+	fake.backupManagementClusterMutex.Lock()
+	defer fake.backupManagementClusterMutex.Unlock()
+	fake.BackupManagementClusterStub = nil
+	fake.backupManagementClusterReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) BackupManagementClusterReturnsOnCall(i int, result1 error) {
+	fake.backupManagementClusterMutex.Lock()
+	defer fake.backupManagementClusterMutex.Unlock()
+	fake.BackupManagementClusterStub = nil
+	if fake.backupManagementClusterReturnsOnCall == nil {
+		fake.backupManagementClusterReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.backupManagementClusterReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Client) ConfigureAndValidateManagementClusterConfiguration(arg1 *client.InitRegionOptions, arg2 bool) *client.ValidationError {
 	fake.configureAndValidateManagementClusterConfigurationMutex.Lock()
 	ret, specificReturn := fake.configureAndValidateManagementClusterConfigurationReturnsOnCall[len(fake.configureAndValidateManagementClusterConfigurationArgsForCall)]
@@ -1528,6 +1820,67 @@ func (fake *Client) DownloadBomFileReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Client) ExportImageBundle(arg1 client.ExportImageBundleOptions) error {
+	fake.exportImageBundleMutex.Lock()
+	ret, specificReturn := fake.exportImageBundleReturnsOnCall[len(fake.exportImageBundleArgsForCall)]
+	fake.exportImageBundleArgsForCall = append(fake.exportImageBundleArgsForCall, struct {
+		arg1 client.ExportImageBundleOptions
+	}{arg1})
+	stub := fake.ExportImageBundleStub
+	fakeReturns := fake.exportImageBundleReturns
+	fake.recordInvocation("ExportImageBundle", []interface{}{arg1})
+	fake.exportImageBundleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) ExportImageBundleCallCount() int {
+	fake.exportImageBundleMutex.RLock()
+	defer fake.exportImageBundleMutex.RUnlock()
+	return len(fake.exportImageBundleArgsForCall)
+}
+
+func (fake *Client) ExportImageBundleCalls(stub func(client.ExportImageBundleOptions) error) {
+	fake.exportImageBundleMutex.Lock()
+	defer fake.exportImageBundleMutex.Unlock()
+	fake.ExportImageBundleStub = stub
+}
+
+func (fake *Client) ExportImageBundleArgsForCall(i int) client.ExportImageBundleOptions {
+	fake.exportImageBundleMutex.RLock()
+	defer fake.exportImageBundleMutex.RUnlock()
+	argsForCall := fake.exportImageBundleArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) ExportImageBundleReturns(result1 error) {
+	fake.exportImageBundleMutex.Lock()
+	defer fake.exportImageBundleMutex.Unlock()
+	fake.ExportImageBundleStub = nil
+	fake.exportImageBundleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) ExportImageBundleReturnsOnCall(i int, result1 error) {
+	fake.exportImageBundleMutex.Lock()
+	defer fake.exportImageBundleMutex.Unlock()
+	fake.ExportImageBundleStub = nil
+	if fake.exportImageBundleReturnsOnCall == nil {
+		fake.exportImageBundleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.exportImageBundleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Client) GetCEIPParticipation() (client.ClusterCeipInfo, error) {
 	fake.getCEIPParticipationMutex.Lock()
 	ret, specificReturn := fake.getCEIPParticipationReturnsOnCall[len(fake.getCEIPParticipationArgsForCall)]
@@ -1648,6 +2001,134 @@ func (fake *Client) GetClusterConfigurationReturnsOnCall(i int, result1 []byte,
 	}{result1, result2}
 }
 
+func (fake *Client) GetClusterHealth(arg1 client.GetClusterHealthOptions) (*client.ClusterHealthReport, error) {
+	fake.getClusterHealthMutex.Lock()
+	ret, specificReturn := fake.getClusterHealthReturnsOnCall[len(fake.getClusterHealthArgsForCall)]
+	fake.getClusterHealthArgsForCall = append(fake.getClusterHealthArgsForCall, struct {
+		arg1 client.GetClusterHealthOptions
+	}{arg1})
+	stub := fake.GetClusterHealthStub
+	fakeReturns := fake.getClusterHealthReturns
+	fake.recordInvocation("GetClusterHealth", []interface{}{arg1})
+	fake.getClusterHealthMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetClusterHealthCallCount() int {
+	fake.getClusterHealthMutex.RLock()
+	defer fake.getClusterHealthMutex.RUnlock()
+	return len(fake.getClusterHealthArgsForCall)
+}
+
+func (fake *Client) GetClusterHealthCalls(stub func(client.GetClusterHealthOptions) (*client.ClusterHealthReport, error)) {
+	fake.getClusterHealthMutex.Lock()
+	defer fake.getClusterHealthMutex.Unlock()
+	fake.GetClusterHealthStub = stub
+}
+
+func (fake *Client) GetClusterHealthArgsForCall(i int) client.GetClusterHealthOptions {
+	fake.getClusterHealthMutex.RLock()
+	defer fake.getClusterHealthMutex.RUnlock()
+	argsForCall := fake.getClusterHealthArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) GetClusterHealthReturns(result1 *client.ClusterHealthReport, result2 error) {
+	fake.getClusterHealthMutex.Lock()
+	defer fake.getClusterHealthMutex.Unlock()
+	fake.GetClusterHealthStub = nil
+	fake.getClusterHealthReturns = struct {
+		result1 *client.ClusterHealthReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetClusterHealthReturnsOnCall(i int, result1 *client.ClusterHealthReport, result2 error) {
+	fake.getClusterHealthMutex.Lock()
+	defer fake.getClusterHealthMutex.Unlock()
+	fake.GetClusterHealthStub = nil
+	if fake.getClusterHealthReturnsOnCall == nil {
+		fake.getClusterHealthReturnsOnCall = make(map[int]struct {
+			result1 *client.ClusterHealthReport
+			result2 error
+		})
+	}
+	fake.getClusterHealthReturnsOnCall[i] = struct {
+		result1 *client.ClusterHealthReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetClusterOperationHistory(arg1 client.GetClusterOperationHistoryOptions) ([]client.OperationRecord, error) {
+	fake.getClusterOperationHistoryMutex.Lock()
+	ret, specificReturn := fake.getClusterOperationHistoryReturnsOnCall[len(fake.getClusterOperationHistoryArgsForCall)]
+	fake.getClusterOperationHistoryArgsForCall = append(fake.getClusterOperationHistoryArgsForCall, struct {
+		arg1 client.GetClusterOperationHistoryOptions
+	}{arg1})
+	stub := fake.GetClusterOperationHistoryStub
+	fakeReturns := fake.getClusterOperationHistoryReturns
+	fake.recordInvocation("GetClusterOperationHistory", []interface{}{arg1})
+	fake.getClusterOperationHistoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetClusterOperationHistoryCallCount() int {
+	fake.getClusterOperationHistoryMutex.RLock()
+	defer fake.getClusterOperationHistoryMutex.RUnlock()
+	return len(fake.getClusterOperationHistoryArgsForCall)
+}
+
+func (fake *Client) GetClusterOperationHistoryCalls(stub func(client.GetClusterOperationHistoryOptions) ([]client.OperationRecord, error)) {
+	fake.getClusterOperationHistoryMutex.Lock()
+	defer fake.getClusterOperationHistoryMutex.Unlock()
+	fake.GetClusterOperationHistoryStub = stub
+}
+
+func (fake *Client) GetClusterOperationHistoryArgsForCall(i int) client.GetClusterOperationHistoryOptions {
+	fake.getClusterOperationHistoryMutex.RLock()
+	defer fake.getClusterOperationHistoryMutex.RUnlock()
+	argsForCall := fake.getClusterOperationHistoryArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) GetClusterOperationHistoryReturns(result1 []client.OperationRecord, result2 error) {
+	fake.getClusterOperationHistoryMutex.Lock()
+	defer fake.getClusterOperationHistoryMutex.Unlock()
+	fake.GetClusterOperationHistoryStub = nil
+	fake.getClusterOperationHistoryReturns = struct {
+		result1 []client.OperationRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetClusterOperationHistoryReturnsOnCall(i int, result1 []client.OperationRecord, result2 error) {
+	fake.getClusterOperationHistoryMutex.Lock()
+	defer fake.getClusterOperationHistoryMutex.Unlock()
+	fake.GetClusterOperationHistoryStub = nil
+	if fake.getClusterOperationHistoryReturnsOnCall == nil {
+		fake.getClusterOperationHistoryReturnsOnCall = make(map[int]struct {
+			result1 []client.OperationRecord
+			result2 error
+		})
+	}
+	fake.getClusterOperationHistoryReturnsOnCall[i] = struct {
+		result1 []client.OperationRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Client) GetClusterPinnipedInfo(arg1 client.GetClusterPinnipedInfoOptions) (*client.ClusterPinnipedInfo, error) {
 	fake.getClusterPinnipedInfoMutex.Lock()
 	ret, specificReturn := fake.getClusterPinnipedInfoReturnsOnCall[len(fake.getClusterPinnipedInfoArgsForCall)]
@@ -1712,6 +2193,70 @@ func (fake *Client) GetClusterPinnipedInfoReturnsOnCall(i int, result1 *client.C
 	}{result1, result2}
 }
 
+func (fake *Client) GetClusterUpgradeStatus(arg1 *client.UpgradeClusterOptions) (*client.UpgradeStatus, error) {
+	fake.getClusterUpgradeStatusMutex.Lock()
+	ret, specificReturn := fake.getClusterUpgradeStatusReturnsOnCall[len(fake.getClusterUpgradeStatusArgsForCall)]
+	fake.getClusterUpgradeStatusArgsForCall = append(fake.getClusterUpgradeStatusArgsForCall, struct {
+		arg1 *client.UpgradeClusterOptions
+	}{arg1})
+	stub := fake.GetClusterUpgradeStatusStub
+	fakeReturns := fake.getClusterUpgradeStatusReturns
+	fake.recordInvocation("GetClusterUpgradeStatus", []interface{}{arg1})
+	fake.getClusterUpgradeStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetClusterUpgradeStatusCallCount() int {
+	fake.getClusterUpgradeStatusMutex.RLock()
+	defer fake.getClusterUpgradeStatusMutex.RUnlock()
+	return len(fake.getClusterUpgradeStatusArgsForCall)
+}
+
+func (fake *Client) GetClusterUpgradeStatusCalls(stub func(*client.UpgradeClusterOptions) (*client.UpgradeStatus, error)) {
+	fake.getClusterUpgradeStatusMutex.Lock()
+	defer fake.getClusterUpgradeStatusMutex.Unlock()
+	fake.GetClusterUpgradeStatusStub = stub
+}
+
+func (fake *Client) GetClusterUpgradeStatusArgsForCall(i int) *client.UpgradeClusterOptions {
+	fake.getClusterUpgradeStatusMutex.RLock()
+	defer fake.getClusterUpgradeStatusMutex.RUnlock()
+	argsForCall := fake.getClusterUpgradeStatusArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) GetClusterUpgradeStatusReturns(result1 *client.UpgradeStatus, result2 error) {
+	fake.getClusterUpgradeStatusMutex.Lock()
+	defer fake.getClusterUpgradeStatusMutex.Unlock()
+	fake.GetClusterUpgradeStatusStub = nil
+	fake.getClusterUpgradeStatusReturns = struct {
+		result1 *client.UpgradeStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetClusterUpgradeStatusReturnsOnCall(i int, result1 *client.UpgradeStatus, result2 error) {
+	fake.getClusterUpgradeStatusMutex.Lock()
+	defer fake.getClusterUpgradeStatusMutex.Unlock()
+	fake.GetClusterUpgradeStatusStub = nil
+	if fake.getClusterUpgradeStatusReturnsOnCall == nil {
+		fake.getClusterUpgradeStatusReturnsOnCall = make(map[int]struct {
+			result1 *client.UpgradeStatus
+			result2 error
+		})
+	}
+	fake.getClusterUpgradeStatusReturnsOnCall[i] = struct {
+		result1 *client.UpgradeStatus
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Client) GetCurrentRegionContext() (region.RegionContext, error) {
 	fake.getCurrentRegionContextMutex.Lock()
 	ret, specificReturn := fake.getCurrentRegionContextReturnsOnCall[len(fake.getCurrentRegionContextArgsForCall)]
@@ -2211,16 +2756,16 @@ func (fake *Client) GetWorkloadClusterCredentialsReturnsOnCall(i int, result1 st
 	}{result1, result2, result3}
 }
 
-func (fake *Client) InitRegion(arg1 *client.InitRegionOptions) error {
-	fake.initRegionMutex.Lock()
-	ret, specificReturn := fake.initRegionReturnsOnCall[len(fake.initRegionArgsForCall)]
-	fake.initRegionArgsForCall = append(fake.initRegionArgsForCall, struct {
-		arg1 *client.InitRegionOptions
+func (fake *Client) HibernateCluster(arg1 client.HibernateClusterOptions) error {
+	fake.hibernateClusterMutex.Lock()
+	ret, specificReturn := fake.hibernateClusterReturnsOnCall[len(fake.hibernateClusterArgsForCall)]
+	fake.hibernateClusterArgsForCall = append(fake.hibernateClusterArgsForCall, struct {
+		arg1 client.HibernateClusterOptions
 	}{arg1})
-	stub := fake.InitRegionStub
-	fakeReturns := fake.initRegionReturns
-	fake.recordInvocation("InitRegion", []interface{}{arg1})
-	fake.initRegionMutex.Unlock()
+	stub := fake.HibernateClusterStub
+	fakeReturns := fake.hibernateClusterReturns
+	fake.recordInvocation("HibernateCluster", []interface{}{arg1})
+	fake.hibernateClusterMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
 	}
@@ -2230,9 +2775,131 @@ func (fake *Client) InitRegion(arg1 *client.InitRegionOptions) error {
 	return fakeReturns.result1
 }
 
-func (fake *Client) InitRegionCallCount() int {
-	fake.initRegionMutex.RLock()
-	defer fake.initRegionMutex.RUnlock()
+func (fake *Client) HibernateClusterCallCount() int {
+	fake.hibernateClusterMutex.RLock()
+	defer fake.hibernateClusterMutex.RUnlock()
+	return len(fake.hibernateClusterArgsForCall)
+}
+
+func (fake *Client) HibernateClusterCalls(stub func(client.HibernateClusterOptions) error) {
+	fake.hibernateClusterMutex.Lock()
+	defer fake.hibernateClusterMutex.Unlock()
+	fake.HibernateClusterStub = stub
+}
+
+func (fake *Client) HibernateClusterArgsForCall(i int) client.HibernateClusterOptions {
+	fake.hibernateClusterMutex.RLock()
+	defer fake.hibernateClusterMutex.RUnlock()
+	argsForCall := fake.hibernateClusterArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) HibernateClusterReturns(result1 error) {
+	fake.hibernateClusterMutex.Lock()
+	defer fake.hibernateClusterMutex.Unlock()
+	fake.HibernateClusterStub = nil
+	fake.hibernateClusterReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) HibernateClusterReturnsOnCall(i int, result1 error) {
+	fake.hibernateClusterMutex.Lock()
+	defer fake.hibernateClusterMutex.Unlock()
+	fake.HibernateClusterStub = nil
+	if fake.hibernateClusterReturnsOnCall == nil {
+		fake.hibernateClusterReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.hibernateClusterReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) ImportImageBundle(arg1 client.ImportImageBundleOptions) error {
+	fake.importImageBundleMutex.Lock()
+	ret, specificReturn := fake.importImageBundleReturnsOnCall[len(fake.importImageBundleArgsForCall)]
+	fake.importImageBundleArgsForCall = append(fake.importImageBundleArgsForCall, struct {
+		arg1 client.ImportImageBundleOptions
+	}{arg1})
+	stub := fake.ImportImageBundleStub
+	fakeReturns := fake.importImageBundleReturns
+	fake.recordInvocation("ImportImageBundle", []interface{}{arg1})
+	fake.importImageBundleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) ImportImageBundleCallCount() int {
+	fake.importImageBundleMutex.RLock()
+	defer fake.importImageBundleMutex.RUnlock()
+	return len(fake.importImageBundleArgsForCall)
+}
+
+func (fake *Client) ImportImageBundleCalls(stub func(client.ImportImageBundleOptions) error) {
+	fake.importImageBundleMutex.Lock()
+	defer fake.importImageBundleMutex.Unlock()
+	fake.ImportImageBundleStub = stub
+}
+
+func (fake *Client) ImportImageBundleArgsForCall(i int) client.ImportImageBundleOptions {
+	fake.importImageBundleMutex.RLock()
+	defer fake.importImageBundleMutex.RUnlock()
+	argsForCall := fake.importImageBundleArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) ImportImageBundleReturns(result1 error) {
+	fake.importImageBundleMutex.Lock()
+	defer fake.importImageBundleMutex.Unlock()
+	fake.ImportImageBundleStub = nil
+	fake.importImageBundleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) ImportImageBundleReturnsOnCall(i int, result1 error) {
+	fake.importImageBundleMutex.Lock()
+	defer fake.importImageBundleMutex.Unlock()
+	fake.ImportImageBundleStub = nil
+	if fake.importImageBundleReturnsOnCall == nil {
+		fake.importImageBundleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.importImageBundleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) InitRegion(arg1 *client.InitRegionOptions) error {
+	fake.initRegionMutex.Lock()
+	ret, specificReturn := fake.initRegionReturnsOnCall[len(fake.initRegionArgsForCall)]
+	fake.initRegionArgsForCall = append(fake.initRegionArgsForCall, struct {
+		arg1 *client.InitRegionOptions
+	}{arg1})
+	stub := fake.InitRegionStub
+	fakeReturns := fake.initRegionReturns
+	fake.recordInvocation("InitRegion", []interface{}{arg1})
+	fake.initRegionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) InitRegionCallCount() int {
+	fake.initRegionMutex.RLock()
+	defer fake.initRegionMutex.RUnlock()
 	return len(fake.initRegionArgsForCall)
 }
 
@@ -2456,6 +3123,70 @@ func (fake *Client) IsPacificManagementClusterReturnsOnCall(i int, result1 bool,
 	}{result1, result2}
 }
 
+func (fake *Client) ListClusterCertificates(arg1 client.ListClusterCertificatesOptions) ([]client.ClusterCertificate, error) {
+	fake.listClusterCertificatesMutex.Lock()
+	ret, specificReturn := fake.listClusterCertificatesReturnsOnCall[len(fake.listClusterCertificatesArgsForCall)]
+	fake.listClusterCertificatesArgsForCall = append(fake.listClusterCertificatesArgsForCall, struct {
+		arg1 client.ListClusterCertificatesOptions
+	}{arg1})
+	stub := fake.ListClusterCertificatesStub
+	fakeReturns := fake.listClusterCertificatesReturns
+	fake.recordInvocation("ListClusterCertificates", []interface{}{arg1})
+	fake.listClusterCertificatesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) ListClusterCertificatesCallCount() int {
+	fake.listClusterCertificatesMutex.RLock()
+	defer fake.listClusterCertificatesMutex.RUnlock()
+	return len(fake.listClusterCertificatesArgsForCall)
+}
+
+func (fake *Client) ListClusterCertificatesCalls(stub func(client.ListClusterCertificatesOptions) ([]client.ClusterCertificate, error)) {
+	fake.listClusterCertificatesMutex.Lock()
+	defer fake.listClusterCertificatesMutex.Unlock()
+	fake.ListClusterCertificatesStub = stub
+}
+
+func (fake *Client) ListClusterCertificatesArgsForCall(i int) client.ListClusterCertificatesOptions {
+	fake.listClusterCertificatesMutex.RLock()
+	defer fake.listClusterCertificatesMutex.RUnlock()
+	argsForCall := fake.listClusterCertificatesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) ListClusterCertificatesReturns(result1 []client.ClusterCertificate, result2 error) {
+	fake.listClusterCertificatesMutex.Lock()
+	defer fake.listClusterCertificatesMutex.Unlock()
+	fake.ListClusterCertificatesStub = nil
+	fake.listClusterCertificatesReturns = struct {
+		result1 []client.ClusterCertificate
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ListClusterCertificatesReturnsOnCall(i int, result1 []client.ClusterCertificate, result2 error) {
+	fake.listClusterCertificatesMutex.Lock()
+	defer fake.listClusterCertificatesMutex.Unlock()
+	fake.ListClusterCertificatesStub = nil
+	if fake.listClusterCertificatesReturnsOnCall == nil {
+		fake.listClusterCertificatesReturnsOnCall = make(map[int]struct {
+			result1 []client.ClusterCertificate
+			result2 error
+		})
+	}
+	fake.listClusterCertificatesReturnsOnCall[i] = struct {
+		result1 []client.ClusterCertificate
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Client) ListTKGClusters(arg1 client.ListTKGClustersOptions) ([]client.ClusterInfo, error) {
 	fake.listTKGClustersMutex.Lock()
 	ret, specificReturn := fake.listTKGClustersReturnsOnCall[len(fake.listTKGClustersArgsForCall)]
@@ -2520,6 +3251,134 @@ func (fake *Client) ListTKGClustersReturnsOnCall(i int, result1 []client.Cluster
 	}{result1, result2}
 }
 
+func (fake *Client) ListTKGClustersInAllRegions(arg1 client.ListTKGClustersInAllRegionsOptions) ([]client.ClusterInfo, []client.UnreachableRegion, error) {
+	fake.listTKGClustersInAllRegionsMutex.Lock()
+	ret, specificReturn := fake.listTKGClustersInAllRegionsReturnsOnCall[len(fake.listTKGClustersInAllRegionsArgsForCall)]
+	fake.listTKGClustersInAllRegionsArgsForCall = append(fake.listTKGClustersInAllRegionsArgsForCall, struct {
+		arg1 client.ListTKGClustersInAllRegionsOptions
+	}{arg1})
+	stub := fake.ListTKGClustersInAllRegionsStub
+	fakeReturns := fake.listTKGClustersInAllRegionsReturns
+	fake.recordInvocation("ListTKGClustersInAllRegions", []interface{}{arg1})
+	fake.listTKGClustersInAllRegionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *Client) ListTKGClustersInAllRegionsCallCount() int {
+	fake.listTKGClustersInAllRegionsMutex.RLock()
+	defer fake.listTKGClustersInAllRegionsMutex.RUnlock()
+	return len(fake.listTKGClustersInAllRegionsArgsForCall)
+}
+
+func (fake *Client) ListTKGClustersInAllRegionsCalls(stub func(client.ListTKGClustersInAllRegionsOptions) ([]client.ClusterInfo, []client.UnreachableRegion, error)) {
+	fake.listTKGClustersInAllRegionsMutex.Lock()
+	defer fake.listTKGClustersInAllRegionsMutex.Unlock()
+	fake.ListTKGClustersInAllRegionsStub = stub
+}
+
+func (fake *Client) ListTKGClustersInAllRegionsArgsForCall(i int) client.ListTKGClustersInAllRegionsOptions {
+	fake.listTKGClustersInAllRegionsMutex.RLock()
+	defer fake.listTKGClustersInAllRegionsMutex.RUnlock()
+	argsForCall := fake.listTKGClustersInAllRegionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) ListTKGClustersInAllRegionsReturns(result1 []client.ClusterInfo, result2 []client.UnreachableRegion, result3 error) {
+	fake.listTKGClustersInAllRegionsMutex.Lock()
+	defer fake.listTKGClustersInAllRegionsMutex.Unlock()
+	fake.ListTKGClustersInAllRegionsStub = nil
+	fake.listTKGClustersInAllRegionsReturns = struct {
+		result1 []client.ClusterInfo
+		result2 []client.UnreachableRegion
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *Client) ListTKGClustersInAllRegionsReturnsOnCall(i int, result1 []client.ClusterInfo, result2 []client.UnreachableRegion, result3 error) {
+	fake.listTKGClustersInAllRegionsMutex.Lock()
+	defer fake.listTKGClustersInAllRegionsMutex.Unlock()
+	fake.ListTKGClustersInAllRegionsStub = nil
+	if fake.listTKGClustersInAllRegionsReturnsOnCall == nil {
+		fake.listTKGClustersInAllRegionsReturnsOnCall = make(map[int]struct {
+			result1 []client.ClusterInfo
+			result2 []client.UnreachableRegion
+			result3 error
+		})
+	}
+	fake.listTKGClustersInAllRegionsReturnsOnCall[i] = struct {
+		result1 []client.ClusterInfo
+		result2 []client.UnreachableRegion
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *Client) MoveManagementCluster(arg1 client.MoveManagementClusterOptions) error {
+	fake.moveManagementClusterMutex.Lock()
+	ret, specificReturn := fake.moveManagementClusterReturnsOnCall[len(fake.moveManagementClusterArgsForCall)]
+	fake.moveManagementClusterArgsForCall = append(fake.moveManagementClusterArgsForCall, struct {
+		arg1 client.MoveManagementClusterOptions
+	}{arg1})
+	stub := fake.MoveManagementClusterStub
+	fakeReturns := fake.moveManagementClusterReturns
+	fake.recordInvocation("MoveManagementCluster", []interface{}{arg1})
+	fake.moveManagementClusterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) MoveManagementClusterCallCount() int {
+	fake.moveManagementClusterMutex.RLock()
+	defer fake.moveManagementClusterMutex.RUnlock()
+	return len(fake.moveManagementClusterArgsForCall)
+}
+
+func (fake *Client) MoveManagementClusterCalls(stub func(client.MoveManagementClusterOptions) error) {
+	fake.moveManagementClusterMutex.Lock()
+	defer fake.moveManagementClusterMutex.Unlock()
+	fake.MoveManagementClusterStub = stub
+}
+
+func (fake *Client) MoveManagementClusterArgsForCall(i int) client.MoveManagementClusterOptions {
+	fake.moveManagementClusterMutex.RLock()
+	defer fake.moveManagementClusterMutex.RUnlock()
+	argsForCall := fake.moveManagementClusterArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) MoveManagementClusterReturns(result1 error) {
+	fake.moveManagementClusterMutex.Lock()
+	defer fake.moveManagementClusterMutex.Unlock()
+	fake.MoveManagementClusterStub = nil
+	fake.moveManagementClusterReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) MoveManagementClusterReturnsOnCall(i int, result1 error) {
+	fake.moveManagementClusterMutex.Lock()
+	defer fake.moveManagementClusterMutex.Unlock()
+	fake.MoveManagementClusterStub = nil
+	if fake.moveManagementClusterReturnsOnCall == nil {
+		fake.moveManagementClusterReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.moveManagementClusterReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Client) ParseHiddenArgsAsFeatureFlags(arg1 *client.InitRegionOptions) {
 	fake.parseHiddenArgsAsFeatureFlagsMutex.Lock()
 	fake.parseHiddenArgsAsFeatureFlagsArgsForCall = append(fake.parseHiddenArgsAsFeatureFlagsArgsForCall, struct {
@@ -2552,6 +3411,134 @@ func (fake *Client) ParseHiddenArgsAsFeatureFlagsArgsForCall(i int) *client.Init
 	return argsForCall.arg1
 }
 
+func (fake *Client) PlanClusterUpgrade(arg1 *client.UpgradeClusterOptions) (*client.UpgradeClusterPlan, error) {
+	fake.planClusterUpgradeMutex.Lock()
+	ret, specificReturn := fake.planClusterUpgradeReturnsOnCall[len(fake.planClusterUpgradeArgsForCall)]
+	fake.planClusterUpgradeArgsForCall = append(fake.planClusterUpgradeArgsForCall, struct {
+		arg1 *client.UpgradeClusterOptions
+	}{arg1})
+	stub := fake.PlanClusterUpgradeStub
+	fakeReturns := fake.planClusterUpgradeReturns
+	fake.recordInvocation("PlanClusterUpgrade", []interface{}{arg1})
+	fake.planClusterUpgradeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) PlanClusterUpgradeCallCount() int {
+	fake.planClusterUpgradeMutex.RLock()
+	defer fake.planClusterUpgradeMutex.RUnlock()
+	return len(fake.planClusterUpgradeArgsForCall)
+}
+
+func (fake *Client) PlanClusterUpgradeCalls(stub func(*client.UpgradeClusterOptions) (*client.UpgradeClusterPlan, error)) {
+	fake.planClusterUpgradeMutex.Lock()
+	defer fake.planClusterUpgradeMutex.Unlock()
+	fake.PlanClusterUpgradeStub = stub
+}
+
+func (fake *Client) PlanClusterUpgradeArgsForCall(i int) *client.UpgradeClusterOptions {
+	fake.planClusterUpgradeMutex.RLock()
+	defer fake.planClusterUpgradeMutex.RUnlock()
+	argsForCall := fake.planClusterUpgradeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) PlanClusterUpgradeReturns(result1 *client.UpgradeClusterPlan, result2 error) {
+	fake.planClusterUpgradeMutex.Lock()
+	defer fake.planClusterUpgradeMutex.Unlock()
+	fake.PlanClusterUpgradeStub = nil
+	fake.planClusterUpgradeReturns = struct {
+		result1 *client.UpgradeClusterPlan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) PlanClusterUpgradeReturnsOnCall(i int, result1 *client.UpgradeClusterPlan, result2 error) {
+	fake.planClusterUpgradeMutex.Lock()
+	defer fake.planClusterUpgradeMutex.Unlock()
+	fake.PlanClusterUpgradeStub = nil
+	if fake.planClusterUpgradeReturnsOnCall == nil {
+		fake.planClusterUpgradeReturnsOnCall = make(map[int]struct {
+			result1 *client.UpgradeClusterPlan
+			result2 error
+		})
+	}
+	fake.planClusterUpgradeReturnsOnCall[i] = struct {
+		result1 *client.UpgradeClusterPlan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) PlanWorkloadClusterDeletion(arg1 client.DeleteWorkloadClusterOptions) (*client.DeleteClusterPlan, error) {
+	fake.planWorkloadClusterDeletionMutex.Lock()
+	ret, specificReturn := fake.planWorkloadClusterDeletionReturnsOnCall[len(fake.planWorkloadClusterDeletionArgsForCall)]
+	fake.planWorkloadClusterDeletionArgsForCall = append(fake.planWorkloadClusterDeletionArgsForCall, struct {
+		arg1 client.DeleteWorkloadClusterOptions
+	}{arg1})
+	stub := fake.PlanWorkloadClusterDeletionStub
+	fakeReturns := fake.planWorkloadClusterDeletionReturns
+	fake.recordInvocation("PlanWorkloadClusterDeletion", []interface{}{arg1})
+	fake.planWorkloadClusterDeletionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) PlanWorkloadClusterDeletionCallCount() int {
+	fake.planWorkloadClusterDeletionMutex.RLock()
+	defer fake.planWorkloadClusterDeletionMutex.RUnlock()
+	return len(fake.planWorkloadClusterDeletionArgsForCall)
+}
+
+func (fake *Client) PlanWorkloadClusterDeletionCalls(stub func(client.DeleteWorkloadClusterOptions) (*client.DeleteClusterPlan, error)) {
+	fake.planWorkloadClusterDeletionMutex.Lock()
+	defer fake.planWorkloadClusterDeletionMutex.Unlock()
+	fake.PlanWorkloadClusterDeletionStub = stub
+}
+
+func (fake *Client) PlanWorkloadClusterDeletionArgsForCall(i int) client.DeleteWorkloadClusterOptions {
+	fake.planWorkloadClusterDeletionMutex.RLock()
+	defer fake.planWorkloadClusterDeletionMutex.RUnlock()
+	argsForCall := fake.planWorkloadClusterDeletionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) PlanWorkloadClusterDeletionReturns(result1 *client.DeleteClusterPlan, result2 error) {
+	fake.planWorkloadClusterDeletionMutex.Lock()
+	defer fake.planWorkloadClusterDeletionMutex.Unlock()
+	fake.PlanWorkloadClusterDeletionStub = nil
+	fake.planWorkloadClusterDeletionReturns = struct {
+		result1 *client.DeleteClusterPlan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) PlanWorkloadClusterDeletionReturnsOnCall(i int, result1 *client.DeleteClusterPlan, result2 error) {
+	fake.planWorkloadClusterDeletionMutex.Lock()
+	defer fake.planWorkloadClusterDeletionMutex.Unlock()
+	fake.PlanWorkloadClusterDeletionStub = nil
+	if fake.planWorkloadClusterDeletionReturnsOnCall == nil {
+		fake.planWorkloadClusterDeletionReturnsOnCall = make(map[int]struct {
+			result1 *client.DeleteClusterPlan
+			result2 error
+		})
+	}
+	fake.planWorkloadClusterDeletionReturnsOnCall[i] = struct {
+		result1 *client.DeleteClusterPlan
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Client) RegisterManagementClusterToTmc(arg1 string, arg2 string) error {
 	fake.registerManagementClusterToTmcMutex.Lock()
 	ret, specificReturn := fake.registerManagementClusterToTmcReturnsOnCall[len(fake.registerManagementClusterToTmcArgsForCall)]
@@ -2614,6 +3601,329 @@ func (fake *Client) RegisterManagementClusterToTmcReturnsOnCall(i int, result1 e
 	}{result1}
 }
 
+func (fake *Client) RestoreManagementCluster(arg1 client.RestoreManagementClusterOptions) error {
+	fake.restoreManagementClusterMutex.Lock()
+	ret, specificReturn := fake.restoreManagementClusterReturnsOnCall[len(fake.restoreManagementClusterArgsForCall)]
+	fake.restoreManagementClusterArgsForCall = append(fake.restoreManagementClusterArgsForCall, struct {
+		arg1 client.RestoreManagementClusterOptions
+	}{arg1})
+	stub := fake.RestoreManagementClusterStub
+	fakeReturns := fake.restoreManagementClusterReturns
+	fake.recordInvocation("RestoreManagementCluster", []interface{}{arg1})
+	fake.restoreManagementClusterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) RestoreManagementClusterCallCount() int {
+	fake.restoreManagementClusterMutex.RLock()
+	defer fake.restoreManagementClusterMutex.RUnlock()
+	return len(fake.restoreManagementClusterArgsForCall)
+}
+
+func (fake *Client) RestoreManagementClusterCalls(stub func(client.RestoreManagementClusterOptions) error) {
+	fake.restoreManagementClusterMutex.Lock()
+	defer fake.restoreManagementClusterMutex.Unlock()
+	fake.RestoreManagementClusterStub = stub
+}
+
+func (fake *Client) RestoreManagementClusterArgsForCall(i int) client.RestoreManagementClusterOptions {
+	fake.restoreManagementClusterMutex.RLock()
+	defer fake.restoreManagementClusterMutex.RUnlock()
+	argsForCall := fake.restoreManagementClusterArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) RestoreManagementClusterReturns(result1 error) {
+	fake.restoreManagementClusterMutex.Lock()
+	defer fake.restoreManagementClusterMutex.Unlock()
+	fake.RestoreManagementClusterStub = nil
+	fake.restoreManagementClusterReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) RestoreManagementClusterReturnsOnCall(i int, result1 error) {
+	fake.restoreManagementClusterMutex.Lock()
+	defer fake.restoreManagementClusterMutex.Unlock()
+	fake.RestoreManagementClusterStub = nil
+	if fake.restoreManagementClusterReturnsOnCall == nil {
+		fake.restoreManagementClusterReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.restoreManagementClusterReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) ResumeCluster(arg1 client.ResumeClusterOptions) error {
+	fake.resumeClusterMutex.Lock()
+	ret, specificReturn := fake.resumeClusterReturnsOnCall[len(fake.resumeClusterArgsForCall)]
+	fake.resumeClusterArgsForCall = append(fake.resumeClusterArgsForCall, struct {
+		arg1 client.ResumeClusterOptions
+	}{arg1})
+	stub := fake.ResumeClusterStub
+	fakeReturns := fake.resumeClusterReturns
+	fake.recordInvocation("ResumeCluster", []interface{}{arg1})
+	fake.resumeClusterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) ResumeClusterCallCount() int {
+	fake.resumeClusterMutex.RLock()
+	defer fake.resumeClusterMutex.RUnlock()
+	return len(fake.resumeClusterArgsForCall)
+}
+
+func (fake *Client) ResumeClusterCalls(stub func(client.ResumeClusterOptions) error) {
+	fake.resumeClusterMutex.Lock()
+	defer fake.resumeClusterMutex.Unlock()
+	fake.ResumeClusterStub = stub
+}
+
+func (fake *Client) ResumeClusterArgsForCall(i int) client.ResumeClusterOptions {
+	fake.resumeClusterMutex.RLock()
+	defer fake.resumeClusterMutex.RUnlock()
+	argsForCall := fake.resumeClusterArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) ResumeClusterReturns(result1 error) {
+	fake.resumeClusterMutex.Lock()
+	defer fake.resumeClusterMutex.Unlock()
+	fake.ResumeClusterStub = nil
+	fake.resumeClusterReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) ResumeClusterReturnsOnCall(i int, result1 error) {
+	fake.resumeClusterMutex.Lock()
+	defer fake.resumeClusterMutex.Unlock()
+	fake.ResumeClusterStub = nil
+	if fake.resumeClusterReturnsOnCall == nil {
+		fake.resumeClusterReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resumeClusterReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) RotateClusterCertificates(arg1 client.RotateClusterCertificatesOptions) error {
+	fake.rotateClusterCertificatesMutex.Lock()
+	ret, specificReturn := fake.rotateClusterCertificatesReturnsOnCall[len(fake.rotateClusterCertificatesArgsForCall)]
+	fake.rotateClusterCertificatesArgsForCall = append(fake.rotateClusterCertificatesArgsForCall, struct {
+		arg1 client.RotateClusterCertificatesOptions
+	}{arg1})
+	stub := fake.RotateClusterCertificatesStub
+	fakeReturns := fake.rotateClusterCertificatesReturns
+	fake.recordInvocation("RotateClusterCertificates", []interface{}{arg1})
+	fake.rotateClusterCertificatesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) RotateClusterCertificatesCallCount() int {
+	fake.rotateClusterCertificatesMutex.RLock()
+	defer fake.rotateClusterCertificatesMutex.RUnlock()
+	return len(fake.rotateClusterCertificatesArgsForCall)
+}
+
+func (fake *Client) RotateClusterCertificatesCalls(stub func(client.RotateClusterCertificatesOptions) error) {
+	fake.rotateClusterCertificatesMutex.Lock()
+	defer fake.rotateClusterCertificatesMutex.Unlock()
+	fake.RotateClusterCertificatesStub = stub
+}
+
+func (fake *Client) RotateClusterCertificatesArgsForCall(i int) client.RotateClusterCertificatesOptions {
+	fake.rotateClusterCertificatesMutex.RLock()
+	defer fake.rotateClusterCertificatesMutex.RUnlock()
+	argsForCall := fake.rotateClusterCertificatesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) RotateClusterCertificatesReturns(result1 error) {
+	fake.rotateClusterCertificatesMutex.Lock()
+	defer fake.rotateClusterCertificatesMutex.Unlock()
+	fake.RotateClusterCertificatesStub = nil
+	fake.rotateClusterCertificatesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) RotateClusterCertificatesReturnsOnCall(i int, result1 error) {
+	fake.rotateClusterCertificatesMutex.Lock()
+	defer fake.rotateClusterCertificatesMutex.Unlock()
+	fake.RotateClusterCertificatesStub = nil
+	if fake.rotateClusterCertificatesReturnsOnCall == nil {
+		fake.rotateClusterCertificatesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.rotateClusterCertificatesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) RunClusterPreflight(arg1 *client.CreateClusterOptions, arg2 []string) (*client.PreflightReport, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.runClusterPreflightMutex.Lock()
+	ret, specificReturn := fake.runClusterPreflightReturnsOnCall[len(fake.runClusterPreflightArgsForCall)]
+	fake.runClusterPreflightArgsForCall = append(fake.runClusterPreflightArgsForCall, struct {
+		arg1 *client.CreateClusterOptions
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.RunClusterPreflightStub
+	fakeReturns := fake.runClusterPreflightReturns
+	fake.recordInvocation("RunClusterPreflight", []interface{}{arg1, arg2Copy})
+	fake.runClusterPreflightMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) RunClusterPreflightCallCount() int {
+	fake.runClusterPreflightMutex.RLock()
+	defer fake.runClusterPreflightMutex.RUnlock()
+	return len(fake.runClusterPreflightArgsForCall)
+}
+
+func (fake *Client) RunClusterPreflightCalls(stub func(*client.CreateClusterOptions, []string) (*client.PreflightReport, error)) {
+	fake.runClusterPreflightMutex.Lock()
+	defer fake.runClusterPreflightMutex.Unlock()
+	fake.RunClusterPreflightStub = stub
+}
+
+func (fake *Client) RunClusterPreflightArgsForCall(i int) (*client.CreateClusterOptions, []string) {
+	fake.runClusterPreflightMutex.RLock()
+	defer fake.runClusterPreflightMutex.RUnlock()
+	argsForCall := fake.runClusterPreflightArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) RunClusterPreflightReturns(result1 *client.PreflightReport, result2 error) {
+	fake.runClusterPreflightMutex.Lock()
+	defer fake.runClusterPreflightMutex.Unlock()
+	fake.RunClusterPreflightStub = nil
+	fake.runClusterPreflightReturns = struct {
+		result1 *client.PreflightReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) RunClusterPreflightReturnsOnCall(i int, result1 *client.PreflightReport, result2 error) {
+	fake.runClusterPreflightMutex.Lock()
+	defer fake.runClusterPreflightMutex.Unlock()
+	fake.RunClusterPreflightStub = nil
+	if fake.runClusterPreflightReturnsOnCall == nil {
+		fake.runClusterPreflightReturnsOnCall = make(map[int]struct {
+			result1 *client.PreflightReport
+			result2 error
+		})
+	}
+	fake.runClusterPreflightReturnsOnCall[i] = struct {
+		result1 *client.PreflightReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) RunManagementClusterPreflight(arg1 *client.InitRegionOptions, arg2 []string) (*client.PreflightReport, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.runManagementClusterPreflightMutex.Lock()
+	ret, specificReturn := fake.runManagementClusterPreflightReturnsOnCall[len(fake.runManagementClusterPreflightArgsForCall)]
+	fake.runManagementClusterPreflightArgsForCall = append(fake.runManagementClusterPreflightArgsForCall, struct {
+		arg1 *client.InitRegionOptions
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.RunManagementClusterPreflightStub
+	fakeReturns := fake.runManagementClusterPreflightReturns
+	fake.recordInvocation("RunManagementClusterPreflight", []interface{}{arg1, arg2Copy})
+	fake.runManagementClusterPreflightMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) RunManagementClusterPreflightCallCount() int {
+	fake.runManagementClusterPreflightMutex.RLock()
+	defer fake.runManagementClusterPreflightMutex.RUnlock()
+	return len(fake.runManagementClusterPreflightArgsForCall)
+}
+
+func (fake *Client) RunManagementClusterPreflightCalls(stub func(*client.InitRegionOptions, []string) (*client.PreflightReport, error)) {
+	fake.runManagementClusterPreflightMutex.Lock()
+	defer fake.runManagementClusterPreflightMutex.Unlock()
+	fake.RunManagementClusterPreflightStub = stub
+}
+
+func (fake *Client) RunManagementClusterPreflightArgsForCall(i int) (*client.InitRegionOptions, []string) {
+	fake.runManagementClusterPreflightMutex.RLock()
+	defer fake.runManagementClusterPreflightMutex.RUnlock()
+	argsForCall := fake.runManagementClusterPreflightArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) RunManagementClusterPreflightReturns(result1 *client.PreflightReport, result2 error) {
+	fake.runManagementClusterPreflightMutex.Lock()
+	defer fake.runManagementClusterPreflightMutex.Unlock()
+	fake.RunManagementClusterPreflightStub = nil
+	fake.runManagementClusterPreflightReturns = struct {
+		result1 *client.PreflightReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) RunManagementClusterPreflightReturnsOnCall(i int, result1 *client.PreflightReport, result2 error) {
+	fake.runManagementClusterPreflightMutex.Lock()
+	defer fake.runManagementClusterPreflightMutex.Unlock()
+	fake.RunManagementClusterPreflightStub = nil
+	if fake.runManagementClusterPreflightReturnsOnCall == nil {
+		fake.runManagementClusterPreflightReturnsOnCall = make(map[int]struct {
+			result1 *client.PreflightReport
+			result2 error
+		})
+	}
+	fake.runManagementClusterPreflightReturnsOnCall[i] = struct {
+		result1 *client.PreflightReport
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Client) SaveFeatureFlags(arg1 map[string]string) error {
 	fake.saveFeatureFlagsMutex.Lock()
 	ret, specificReturn := fake.saveFeatureFlagsReturnsOnCall[len(fake.saveFeatureFlagsArgsForCall)]
@@ -2799,6 +4109,67 @@ func (fake *Client) SetCEIPParticipationReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Client) SetClusterDeletionProtection(arg1 client.SetClusterDeletionProtectionOptions) error {
+	fake.setClusterDeletionProtectionMutex.Lock()
+	ret, specificReturn := fake.setClusterDeletionProtectionReturnsOnCall[len(fake.setClusterDeletionProtectionArgsForCall)]
+	fake.setClusterDeletionProtectionArgsForCall = append(fake.setClusterDeletionProtectionArgsForCall, struct {
+		arg1 client.SetClusterDeletionProtectionOptions
+	}{arg1})
+	stub := fake.SetClusterDeletionProtectionStub
+	fakeReturns := fake.setClusterDeletionProtectionReturns
+	fake.recordInvocation("SetClusterDeletionProtection", []interface{}{arg1})
+	fake.setClusterDeletionProtectionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) SetClusterDeletionProtectionCallCount() int {
+	fake.setClusterDeletionProtectionMutex.RLock()
+	defer fake.setClusterDeletionProtectionMutex.RUnlock()
+	return len(fake.setClusterDeletionProtectionArgsForCall)
+}
+
+func (fake *Client) SetClusterDeletionProtectionCalls(stub func(client.SetClusterDeletionProtectionOptions) error) {
+	fake.setClusterDeletionProtectionMutex.Lock()
+	defer fake.setClusterDeletionProtectionMutex.Unlock()
+	fake.SetClusterDeletionProtectionStub = stub
+}
+
+func (fake *Client) SetClusterDeletionProtectionArgsForCall(i int) client.SetClusterDeletionProtectionOptions {
+	fake.setClusterDeletionProtectionMutex.RLock()
+	defer fake.setClusterDeletionProtectionMutex.RUnlock()
+	argsForCall := fake.setClusterDeletionProtectionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) SetClusterDeletionProtectionReturns(result1 error) {
+	fake.setClusterDeletionProtectionMutex.Lock()
+	defer fake.setClusterDeletionProtectionMutex.Unlock()
+	fake.SetClusterDeletionProtectionStub = nil
+	fake.setClusterDeletionProtectionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) SetClusterDeletionProtectionReturnsOnCall(i int, result1 error) {
+	fake.setClusterDeletionProtectionMutex.Lock()
+	defer fake.setClusterDeletionProtectionMutex.Unlock()
+	fake.SetClusterDeletionProtectionStub = nil
+	if fake.setClusterDeletionProtectionReturnsOnCall == nil {
+		fake.setClusterDeletionProtectionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setClusterDeletionProtectionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Client) SetMachineDeployment(arg1 *client.SetMachineDeploymentOptions) error {
 	fake.setMachineDeploymentMutex.Lock()
 	ret, specificReturn := fake.setMachineDeploymentReturnsOnCall[len(fake.setMachineDeploymentArgsForCall)]
@@ -3406,6 +4777,53 @@ func (fake *Client) VerifyRegionReturnsOnCall(i int, result1 region.RegionContex
 	}{result1, result2}
 }
 
+func (fake *Client) VerifyWorkloadClusterDeletion(arg1 client.DeleteWorkloadClusterOptions) error {
+	fake.verifyWorkloadClusterDeletionMutex.Lock()
+	ret, specificReturn := fake.verifyWorkloadClusterDeletionReturnsOnCall[len(fake.verifyWorkloadClusterDeletionArgsForCall)]
+	fake.verifyWorkloadClusterDeletionArgsForCall = append(fake.verifyWorkloadClusterDeletionArgsForCall, struct {
+		arg1 client.DeleteWorkloadClusterOptions
+	}{arg1})
+	stub := fake.VerifyWorkloadClusterDeletionStub
+	fakeReturns := fake.verifyWorkloadClusterDeletionReturns
+	fake.recordInvocation("VerifyWorkloadClusterDeletion", []interface{}{arg1})
+	fake.verifyWorkloadClusterDeletionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) VerifyWorkloadClusterDeletionCallCount() int {
+	fake.verifyWorkloadClusterDeletionMutex.RLock()
+	defer fake.verifyWorkloadClusterDeletionMutex.RUnlock()
+	return len(fake.verifyWorkloadClusterDeletionArgsForCall)
+}
+
+func (fake *Client) VerifyWorkloadClusterDeletionCalls(stub func(client.DeleteWorkloadClusterOptions) error) {
+	fake.verifyWorkloadClusterDeletionMutex.Lock()
+	defer fake.verifyWorkloadClusterDeletionMutex.Unlock()
+	fake.VerifyWorkloadClusterDeletionStub = stub
+}
+
+func (fake *Client) VerifyWorkloadClusterDeletionArgsForCall(i int) client.DeleteWorkloadClusterOptions {
+	fake.verifyWorkloadClusterDeletionMutex.RLock()
+	defer fake.verifyWorkloadClusterDeletionMutex.RUnlock()
+	argsForCall := fake.verifyWorkloadClusterDeletionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) VerifyWorkloadClusterDeletionReturns(result1 error) {
+	fake.verifyWorkloadClusterDeletionMutex.Lock()
+	defer fake.verifyWorkloadClusterDeletionMutex.Unlock()
+	fake.VerifyWorkloadClusterDeletionStub = nil
+	fake.verifyWorkloadClusterDeletionReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Client) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -3413,6 +4831,8 @@ func (fake *Client) Invocations() map[string][][]interface{} {
 	defer fake.activateTanzuKubernetesReleasesMutex.RUnlock()
 	fake.addRegionContextMutex.RLock()
 	defer fake.addRegionContextMutex.RUnlock()
+	fake.backupManagementClusterMutex.RLock()
+	defer fake.backupManagementClusterMutex.RUnlock()
 	fake.configureAndValidateManagementClusterConfigurationMutex.RLock()
 	defer fake.configureAndValidateManagementClusterConfigurationMutex.RUnlock()
 	fake.configureAndValidateTkrVersionMutex.RLock()
@@ -3441,12 +4861,20 @@ func (fake *Client) Invocations() map[string][][]interface{} {
 	defer fake.describeProviderMutex.RUnlock()
 	fake.downloadBomFileMutex.RLock()
 	defer fake.downloadBomFileMutex.RUnlock()
+	fake.exportImageBundleMutex.RLock()
+	defer fake.exportImageBundleMutex.RUnlock()
 	fake.getCEIPParticipationMutex.RLock()
 	defer fake.getCEIPParticipationMutex.RUnlock()
 	fake.getClusterConfigurationMutex.RLock()
 	defer fake.getClusterConfigurationMutex.RUnlock()
+	fake.getClusterHealthMutex.RLock()
+	defer fake.getClusterHealthMutex.RUnlock()
+	fake.getClusterOperationHistoryMutex.RLock()
+	defer fake.getClusterOperationHistoryMutex.RUnlock()
 	fake.getClusterPinnipedInfoMutex.RLock()
 	defer fake.getClusterPinnipedInfoMutex.RUnlock()
+	fake.getClusterUpgradeStatusMutex.RLock()
+	defer fake.getClusterUpgradeStatusMutex.RUnlock()
 	fake.getCurrentRegionContextMutex.RLock()
 	defer fake.getCurrentRegionContextMutex.RUnlock()
 	fake.getKubernetesVersionsMutex.RLock()
@@ -3463,6 +4891,10 @@ func (fake *Client) Invocations() map[string][][]interface{} {
 	defer fake.getVSphereEndpointMutex.RUnlock()
 	fake.getWorkloadClusterCredentialsMutex.RLock()
 	defer fake.getWorkloadClusterCredentialsMutex.RUnlock()
+	fake.hibernateClusterMutex.RLock()
+	defer fake.hibernateClusterMutex.RUnlock()
+	fake.importImageBundleMutex.RLock()
+	defer fake.importImageBundleMutex.RUnlock()
 	fake.initRegionMutex.RLock()
 	defer fake.initRegionMutex.RUnlock()
 	fake.initRegionDryRunMutex.RLock()
@@ -3471,18 +4903,40 @@ func (fake *Client) Invocations() map[string][][]interface{} {
 	defer fake.isManagementClusterAKindClusterMutex.RUnlock()
 	fake.isPacificManagementClusterMutex.RLock()
 	defer fake.isPacificManagementClusterMutex.RUnlock()
+	fake.listClusterCertificatesMutex.RLock()
+	defer fake.listClusterCertificatesMutex.RUnlock()
 	fake.listTKGClustersMutex.RLock()
 	defer fake.listTKGClustersMutex.RUnlock()
+	fake.listTKGClustersInAllRegionsMutex.RLock()
+	defer fake.listTKGClustersInAllRegionsMutex.RUnlock()
+	fake.moveManagementClusterMutex.RLock()
+	defer fake.moveManagementClusterMutex.RUnlock()
 	fake.parseHiddenArgsAsFeatureFlagsMutex.RLock()
 	defer fake.parseHiddenArgsAsFeatureFlagsMutex.RUnlock()
+	fake.planClusterUpgradeMutex.RLock()
+	defer fake.planClusterUpgradeMutex.RUnlock()
+	fake.planWorkloadClusterDeletionMutex.RLock()
+	defer fake.planWorkloadClusterDeletionMutex.RUnlock()
 	fake.registerManagementClusterToTmcMutex.RLock()
 	defer fake.registerManagementClusterToTmcMutex.RUnlock()
+	fake.restoreManagementClusterMutex.RLock()
+	defer fake.restoreManagementClusterMutex.RUnlock()
+	fake.resumeClusterMutex.RLock()
+	defer fake.resumeClusterMutex.RUnlock()
+	fake.rotateClusterCertificatesMutex.RLock()
+	defer fake.rotateClusterCertificatesMutex.RUnlock()
+	fake.runClusterPreflightMutex.RLock()
+	defer fake.runClusterPreflightMutex.RUnlock()
+	fake.runManagementClusterPreflightMutex.RLock()
+	defer fake.runManagementClusterPreflightMutex.RUnlock()
 	fake.saveFeatureFlagsMutex.RLock()
 	defer fake.saveFeatureFlagsMutex.RUnlock()
 	fake.scaleClusterMutex.RLock()
 	defer fake.scaleClusterMutex.RUnlock()
 	fake.setCEIPParticipationMutex.RLock()
 	defer fake.setCEIPParticipationMutex.RUnlock()
+	fake.setClusterDeletionProtectionMutex.RLock()
+	defer fake.setClusterDeletionProtectionMutex.RUnlock()
 	fake.setMachineDeploymentMutex.RLock()
 	defer fake.setMachineDeploymentMutex.RUnlock()
 	fake.setMachineHealthCheckMutex.RLock()
@@ -3503,6 +4957,8 @@ func (fake *Client) Invocations() map[string][][]interface{} {
 	defer fake.validatePrerequisitesMutex.RUnlock()
 	fake.verifyRegionMutex.RLock()
 	defer fake.verifyRegionMutex.RUnlock()
+	fake.verifyWorkloadClusterDeletionMutex.RLock()
+	defer fake.verifyWorkloadClusterDeletionMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -228,6 +228,18 @@ type TKGConfigBomClient struct {
 		result1 registry.Registry
 		result2 error
 	}
+	InitBOMRegistryWithCredentialsStub        func() (registry.Registry, error)
+	initBOMRegistryWithCredentialsMutex       sync.RWMutex
+	initBOMRegistryWithCredentialsArgsForCall []struct {
+	}
+	initBOMRegistryWithCredentialsReturns struct {
+		result1 registry.Registry
+		result2 error
+	}
+	initBOMRegistryWithCredentialsReturnsOnCall map[int]struct {
+		result1 registry.Registry
+		result2 error
+	}
 	IsCustomRepositorySkipTLSVerifyStub        func() bool
 	isCustomRepositorySkipTLSVerifyMutex       sync.RWMutex
 	isCustomRepositorySkipTLSVerifyArgsForCall []struct {
@@ -1287,6 +1299,62 @@ func (fake *TKGConfigBomClient) InitBOMRegistryReturnsOnCall(i int, result1 regi
 	}{result1, result2}
 }
 
+func (fake *TKGConfigBomClient) InitBOMRegistryWithCredentials() (registry.Registry, error) {
+	fake.initBOMRegistryWithCredentialsMutex.Lock()
+	ret, specificReturn := fake.initBOMRegistryWithCredentialsReturnsOnCall[len(fake.initBOMRegistryWithCredentialsArgsForCall)]
+	fake.initBOMRegistryWithCredentialsArgsForCall = append(fake.initBOMRegistryWithCredentialsArgsForCall, struct {
+	}{})
+	stub := fake.InitBOMRegistryWithCredentialsStub
+	fakeReturns := fake.initBOMRegistryWithCredentialsReturns
+	fake.recordInvocation("InitBOMRegistryWithCredentials", []interface{}{})
+	fake.initBOMRegistryWithCredentialsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TKGConfigBomClient) InitBOMRegistryWithCredentialsCallCount() int {
+	fake.initBOMRegistryWithCredentialsMutex.RLock()
+	defer fake.initBOMRegistryWithCredentialsMutex.RUnlock()
+	return len(fake.initBOMRegistryWithCredentialsArgsForCall)
+}
+
+func (fake *TKGConfigBomClient) InitBOMRegistryWithCredentialsCalls(stub func() (registry.Registry, error)) {
+	fake.initBOMRegistryWithCredentialsMutex.Lock()
+	defer fake.initBOMRegistryWithCredentialsMutex.Unlock()
+	fake.InitBOMRegistryWithCredentialsStub = stub
+}
+
+func (fake *TKGConfigBomClient) InitBOMRegistryWithCredentialsReturns(result1 registry.Registry, result2 error) {
+	fake.initBOMRegistryWithCredentialsMutex.Lock()
+	defer fake.initBOMRegistryWithCredentialsMutex.Unlock()
+	fake.InitBOMRegistryWithCredentialsStub = nil
+	fake.initBOMRegistryWithCredentialsReturns = struct {
+		result1 registry.Registry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TKGConfigBomClient) InitBOMRegistryWithCredentialsReturnsOnCall(i int, result1 registry.Registry, result2 error) {
+	fake.initBOMRegistryWithCredentialsMutex.Lock()
+	defer fake.initBOMRegistryWithCredentialsMutex.Unlock()
+	fake.InitBOMRegistryWithCredentialsStub = nil
+	if fake.initBOMRegistryWithCredentialsReturnsOnCall == nil {
+		fake.initBOMRegistryWithCredentialsReturnsOnCall = make(map[int]struct {
+			result1 registry.Registry
+			result2 error
+		})
+	}
+	fake.initBOMRegistryWithCredentialsReturnsOnCall[i] = struct {
+		result1 registry.Registry
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *TKGConfigBomClient) IsCustomRepositorySkipTLSVerify() bool {
 	fake.isCustomRepositorySkipTLSVerifyMutex.Lock()
 	ret, specificReturn := fake.isCustomRepositorySkipTLSVerifyReturnsOnCall[len(fake.isCustomRepositorySkipTLSVerifyArgsForCall)]
@@ -1379,6 +1447,8 @@ func (fake *TKGConfigBomClient) Invocations() map[string][][]interface{} {
 	defer fake.getK8sVersionFromTkrVersionMutex.RUnlock()
 	fake.initBOMRegistryMutex.RLock()
 	defer fake.initBOMRegistryMutex.RUnlock()
+	fake.initBOMRegistryWithCredentialsMutex.RLock()
+	defer fake.initBOMRegistryWithCredentialsMutex.RUnlock()
 	fake.isCustomRepositorySkipTLSVerifyMutex.RLock()
 	defer fake.isCustomRepositorySkipTLSVerifyMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -0,0 +1,118 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Log formats
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// redactedValue replaces the values of the secret keys
+const redactedValue = "*****"
+
+// secretKeyPatterns are the substrings of the lowercased keys whose values are redacted
+var secretKeyPatterns = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"apikey",
+	"api_key",
+	"api-key",
+	"accesskey",
+	"access_key",
+	"privatekey",
+	"private_key",
+	"credential",
+}
+
+// Entry is a structured log entry, written to the logfile as a JSON object with the json log format
+type Entry struct {
+	Timestamp time.Time
+	LogType   string
+	Verbosity int32
+	Caller    string
+	Logger    string
+	Message   string
+	Error     error
+	Values    []interface{}
+}
+
+// reservedFields are the fields of the JSON log entries the key/values cannot override
+var reservedFields = map[string]bool{"ts": true, "level": true, "v": true, "caller": true, "logger": true, "msg": true, "error": true}
+
+// encodeJSON returns the entry as a newline terminated JSON object. The key/values are
+// added as fields, the ones conflicting with the entry fields are prefixed with "field.".
+func (e *Entry) encodeJSON() ([]byte, error) {
+	fields := map[string]interface{}{
+		"ts":    e.Timestamp.UTC().Format(time.RFC3339Nano),
+		"level": e.LogType,
+		"v":     e.Verbosity,
+		"msg":   strings.TrimSuffix(e.Message, "\n"),
+	}
+	if e.Caller != "" {
+		fields["caller"] = e.Caller
+	}
+	if e.Logger != "" {
+		fields["logger"] = e.Logger
+	}
+	if e.Error != nil {
+		fields["error"] = e.Error.Error()
+	}
+	for i := 0; i+1 < len(e.Values); i += 2 {
+		key := fmt.Sprint(e.Values[i])
+		if reservedFields[key] {
+			key = "field." + key
+		}
+		fields[key] = e.Values[i+1]
+	}
+
+	entryBytes, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	return append(entryBytes, '\n'), nil
+}
+
+// isSecretKey returns true if the values of the key must not be logged
+func isSecretKey(key string) bool {
+	lowerKey := strings.ToLower(key)
+	for _, pattern := range secretKeyPatterns {
+		if strings.Contains(lowerKey, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactValues returns a copy of the key/values with the values of the secret keys redacted
+func redactValues(kvs []interface{}) []interface{} {
+	redacted := copySlice(kvs)
+	for i := 0; i+1 < len(redacted); i += 2 {
+		if key, ok := redacted[i].(string); ok && isSecretKey(key) {
+			redacted[i+1] = redactedValue
+		}
+	}
+	return redacted
+}
+
+// SetLogFormat sets the format of the logfile
+// if the json format is set, each log entry is written to the logfile as a JSON object
+func SetLogFormat(format string) {
+	logWriter.SetLogFormat(format)
+}
+
+// SetFileRotation sets the size in bytes after which the logfile is rotated and the number
+// of rotated files to keep. The logfile is not rotated if maxSize is 0.
+func SetFileRotation(maxSize int64, maxBackups int) {
+	logWriter.SetFileRotation(maxSize, maxBackups)
+}
@@ -0,0 +1,70 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Encoder", func() {
+	Describe("encodeJSON", func() {
+		It("encodes the entry fields and the key/values as a JSON line", func() {
+			entry := &Entry{
+				Timestamp: time.Date(2021, 8, 1, 10, 0, 0, 5, time.FixedZone("PDT", -7*3600)),
+				LogType:   "INFO",
+				Verbosity: 3,
+				Caller:    "cluster.go:42",
+				Logger:    "upgrade",
+				Message:   "Upgrading cluster\n",
+				Error:     errors.New("timeout"),
+				Values:    []interface{}{"cluster", "my-cluster", "replicas", 3, "msg", "conflicting"},
+			}
+			entryBytes, err := entry.encodeJSON()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entryBytes).To(HaveSuffix("\n"))
+
+			fields := map[string]interface{}{}
+			Expect(json.Unmarshal(entryBytes, &fields)).To(Succeed())
+			Expect(fields).To(Equal(map[string]interface{}{
+				"ts":        "2021-08-01T17:00:00.000000005Z",
+				"level":     "INFO",
+				"v":         float64(3),
+				"caller":    "cluster.go:42",
+				"logger":    "upgrade",
+				"msg":       "Upgrading cluster",
+				"error":     "timeout",
+				"cluster":   "my-cluster",
+				"replicas":  float64(3),
+				"field.msg": "conflicting",
+			}))
+		})
+
+		It("omits the empty optional fields", func() {
+			entryBytes, err := (&Entry{LogType: "WARN", Message: "warning"}).encodeJSON()
+			Expect(err).NotTo(HaveOccurred())
+			fields := map[string]interface{}{}
+			Expect(json.Unmarshal(entryBytes, &fields)).To(Succeed())
+			Expect(fields).NotTo(HaveKey("caller"))
+			Expect(fields).NotTo(HaveKey("logger"))
+			Expect(fields).NotTo(HaveKey("error"))
+		})
+	})
+
+	Describe("redactValues", func() {
+		It("redacts the values of the secret keys", func() {
+			kvs := []interface{}{"user", "admin", "password", "p@ss", "VSPHERE_PASSWORD", "p@ss", "apiToken", "t0ken", 42, "password"}
+			Expect(redactValues(kvs)).To(Equal([]interface{}{"user", "admin", "password", redactedValue, "VSPHERE_PASSWORD", redactedValue, "apiToken", redactedValue, 42, "password"}))
+			Expect(kvs[3]).To(Equal("p@ss"))
+		})
+
+		It("leaves a trailing key without value", func() {
+			Expect(redactValues([]interface{}{"token"})).To(Equal([]interface{}{"token"}))
+		})
+	})
+})
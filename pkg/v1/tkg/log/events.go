@@ -0,0 +1,113 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Types of the progress events
+const (
+	EventTypePhaseStarted    = "phaseStarted"
+	EventTypePhaseFinished   = "phaseFinished"
+	EventTypeResourceWaiting = "resourceWaiting"
+	EventTypeWarning         = "warning"
+	EventTypeError           = "error"
+)
+
+// Codes of the error events
+const (
+	EventErrorCodeValidation = "ValidationFailed"
+	EventErrorCodeTimeout    = "Timeout"
+	EventErrorCodeOperation  = "OperationFailed"
+)
+
+// Progress formats
+const (
+	ProgressFormatText = "text"
+	ProgressFormatJSON = "json"
+)
+
+const msgTypeEvent = "event"
+
+const (
+	progressStatusRunning    = "running"
+	progressStatusSuccessful = "successful"
+	progressStatusFailed     = "failed"
+)
+
+// Event is a typed progress event of a long-running operation
+type Event struct {
+	Type        string         `json:"type"`
+	Timestamp   string         `json:"timestamp"`
+	Phase       string         `json:"phase,omitempty"`
+	TotalPhases []string       `json:"totalPhases,omitempty"`
+	Status      string         `json:"status,omitempty"`
+	Resource    *EventResource `json:"resource,omitempty"`
+	Message     string         `json:"message,omitempty"`
+	Code        string         `json:"code,omitempty"`
+}
+
+// EventResource is the resource a resource waiting event refers to
+type EventResource struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// eventUpdate is a structure to be used for sending events as websocket messages
+type eventUpdate struct {
+	Type string `json:"type"`
+	Data Event  `json:"data"`
+}
+
+// SetProgressFormat sets the format of the progress of long-running operations.
+// With the json format, the progress events are written to stdout as newline-delimited JSON.
+func SetProgressFormat(format string) {
+	logWriter.SetProgressFormat(format)
+}
+
+// SendWaitingEvent sends an event for a resource the operation is waiting for
+func SendWaitingEvent(kind, namespace, name, message string) {
+	logWriter.SendEvent(Event{
+		Type:     EventTypeResourceWaiting,
+		Resource: &EventResource{Kind: kind, Name: name, Namespace: namespace},
+		Message:  message,
+	})
+}
+
+// SendErrorEvent sends an event for the error the operation failed with
+func SendErrorEvent(code string, err error) {
+	if err == nil {
+		return
+	}
+	logWriter.SendEvent(Event{
+		Type:    EventTypeError,
+		Code:    code,
+		Message: err.Error(),
+	})
+}
+
+func convertEventToJSONBytes(event *Event) []byte {
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		ForceWriteToStdErr([]byte("unable marshal progress event"))
+		return []byte{}
+	}
+	return eventBytes
+}
+
+func convertEventToUpdateJSONBytes(event *Event) []byte {
+	updateBytes, err := json.Marshal(eventUpdate{Type: msgTypeEvent, Data: *event})
+	if err != nil {
+		ForceWriteToStdErr([]byte("unable marshal progress event"))
+		return []byte{}
+	}
+	return updateBytes
+}
+
+func eventTimestamp() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
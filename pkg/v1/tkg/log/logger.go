@@ -140,14 +140,27 @@ func (l *logger) CloneWithLevel(level int) LoggerImpl {
 }
 
 func (l *logger) Print(msg string, err error, logType string, kvs ...interface{}) {
-	values := copySlice(l.values)
-	values = append(values, kvs...)
+	now := time.Now()
+	file, line := l.caller(0)
+	kvValues := redactValues(append(copySlice(l.values), kvs...))
+	entry := &Entry{
+		Timestamp: now,
+		LogType:   logType,
+		Verbosity: l.level,
+		Caller:    fmt.Sprintf("%s:%d", file, line),
+		Logger:    l.prefix,
+		Message:   msg,
+		Error:     err,
+		Values:    kvValues,
+	}
+
+	values := copySlice(kvValues)
 	values = append(values, "msg", msg)
 	if err != nil {
 		values = append(values, "error", err)
 	}
-	header := []byte(l.header(logType, 0))
-	_, _ = logWriter.Write(header, []byte(l.getLogString(values)), l.Enabled(), l.level, logType)
+	header := []byte(l.header(logType, now, file, line))
+	_, _ = logWriter.Write(header, []byte(l.getLogString(values)), l.Enabled(), l.level, logType, entry)
 }
 
 func (l *logger) getLogString(values []interface{}) string {
@@ -158,7 +171,7 @@ func (l *logger) getLogString(values []interface{}) string {
 	}
 	f, err := flatten(entry)
 	if err != nil {
-		_, _ = logWriter.Write([]byte{}, []byte(err.Error()), l.Enabled(), 0, "WARN")
+		_, _ = logWriter.Write([]byte{}, []byte(err.Error()), l.Enabled(), 0, "WARN", nil)
 		return ""
 	}
 	return f
@@ -246,7 +259,8 @@ func pretty(value interface{}) (string, error) {
 	return string(jb), nil
 }
 
-func (l *logger) header(logType string, depth int) string {
+// caller returns the file name and line of the caller of the logging function, it is meant to be called from Print
+func (l *logger) caller(depth int) (string, int) {
 	_, file, line, ok := runtime.Caller(3 + depth)
 	if !ok {
 		file = "???"
@@ -255,8 +269,10 @@ func (l *logger) header(logType string, depth int) string {
 		path := file
 		file = path[slash+1:]
 	}
+	return file, line
+}
 
-	now := time.Now()
+func (l *logger) header(logType string, now time.Time, file string, line int) string {
 	_, month, day := now.Date()
 	hour, minute, second := now.Clock()
 
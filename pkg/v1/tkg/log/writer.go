@@ -4,9 +4,12 @@
 package log
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"path"
+	"strings"
+	"sync"
 )
 
 // Writer defines methods to write and configure tkg writer
@@ -19,13 +22,23 @@ type Writer interface {
 	// logEnabled is used to decide whether to write this message to stdout/stderr or not
 	// logVerbosity is used to decide which message to write for different output types
 	// logType used to decide should write to stdout or stderr
-	Write(header []byte, msg []byte, logEnabled bool, logVerbosity int32, logType string) (n int, err error)
+	// entry is the structured log entry written to the logfile with the json log format,
+	// the message is written as text when it is nil
+	Write(header []byte, msg []byte, logEnabled bool, logVerbosity int32, logType string, entry *Entry) (n int, err error)
 
 	// SetFile sets the logFile to writer
 	// if the non-empty file name is used, writer will also
 	// write the logs to this file
 	SetFile(fileName string)
 
+	// SetLogFormat sets the format of the logfile
+	// if the json format is set, writer will write each log entry to the logfile as a JSON object
+	SetLogFormat(format string)
+
+	// SetFileRotation sets the size in bytes after which the logfile is rotated and the
+	// number of rotated files to keep, the logfile is not rotated if maxSize is 0
+	SetFileRotation(maxSize int64, maxBackups int)
+
 	// SetChannel sets the channel to writer
 	// if channel is set, writer will forward log messages to this log channel
 	SetChannel(channel chan<- []byte)
@@ -38,7 +51,17 @@ type Writer interface {
 	SetVerbosity(verbosity int32)
 
 	// SendProgressUpdate sends the progress to the listening logChannel
+	// and the phase started and finished events
 	SendProgressUpdate(status string, step string, totalSteps []string)
+
+	// SetProgressFormat sets the format of the progress events
+	// if the json format is set, writer will write the events to stdout and the
+	// messages meant for stdout to stderr
+	SetProgressFormat(format string)
+
+	// SendEvent writes the progress event to stdout if the json progress format is set
+	// and sends it to the channel if channel is set
+	SendEvent(event Event)
 }
 
 var (
@@ -54,10 +77,21 @@ func NewWriter() Writer {
 }
 
 type writer struct {
-	logFile    string
-	logChannel chan<- []byte
-	verbosity  int32
-	quiet      bool
+	logFile        string
+	logChannel     chan<- []byte
+	verbosity      int32
+	quiet          bool
+	progressFormat string
+	logFormat      string
+
+	// fileLock guards the writes and the rotation of the logfile
+	fileLock       sync.Mutex
+	fileMaxSize    int64
+	fileMaxBackups int
+
+	// eventLock guards the current phase and the writes of the events
+	eventLock    sync.Mutex
+	currentPhase string
 }
 
 // SetFile sets the logFile to writer
@@ -67,6 +101,19 @@ func (w *writer) SetFile(fileName string) {
 	w.logFile = fileName
 }
 
+// SetLogFormat sets the format of the logfile
+// if the json format is set, writer will write each log entry to the logfile as a JSON object
+func (w *writer) SetLogFormat(format string) {
+	w.logFormat = format
+}
+
+// SetFileRotation sets the size in bytes after which the logfile is rotated and the
+// number of rotated files to keep, the logfile is not rotated if maxSize is 0
+func (w *writer) SetFileRotation(maxSize int64, maxBackups int) {
+	w.fileMaxSize = maxSize
+	w.fileMaxBackups = maxBackups
+}
+
 // SetChannel sets the channel to writer
 // if channel is set, writer will forward log messages to this log channel
 func (w *writer) SetChannel(channel chan<- []byte) {
@@ -87,6 +134,13 @@ func (w *writer) SetVerbosity(verbosity int32) {
 	}
 }
 
+// SetProgressFormat sets the format of the progress events
+// if the json format is set, writer will write the events to stdout and the
+// messages meant for stdout to stderr
+func (w *writer) SetProgressFormat(format string) {
+	w.progressFormat = format
+}
+
 // Write writes message to stdout/stderr, logfile and sends to the channel if channel is set
 //
 // header is message header to append as a message prefix
@@ -94,13 +148,15 @@ func (w *writer) SetVerbosity(verbosity int32) {
 // logEnabled is used to decide whether to write this message to stdout/stderr or not
 // logVerbosity is used to decide which message to write for different output types
 // logType used to decide should write to stdout or stderr
-func (w *writer) Write(header, msg []byte, logEnabled bool, logVerbosity int32, logType string) (n int, err error) {
+// entry is the structured log entry written to the logfile with the json log format,
+// the message is written as text when it is nil
+func (w *writer) Write(header, msg []byte, logEnabled bool, logVerbosity int32, logType string, entry *Entry) (n int, err error) {
 	fullMsg := append(header, msg...)
 
 	// write to logfile, channel only if verbosityLevel is <= default VerbosityLevel
 	if logVerbosity <= defaultVerbosity {
 		if w.logFile != "" {
-			fileWriter(w.logFile, fullMsg)
+			w.writeToFile(fullMsg, entry)
 		}
 		if w.logChannel != nil {
 			w.logChannel <- convertLogMsgToJSONBytes(fullMsg)
@@ -109,13 +165,18 @@ func (w *writer) Write(header, msg []byte, logEnabled bool, logVerbosity int32,
 
 	// write to stdout/stderr if quiet mode is not set and logEnabled is true
 	if !w.quiet && logEnabled {
-		if logType == "OUTPUT" {
+		// stdout is reserved to the events with the json progress format
+		if logType == "OUTPUT" && w.progressFormat != ProgressFormatJSON {
 			stdoutWriter(msg)
 		} else {
 			stderrWriter(msg)
 		}
 	}
 
+	if logType == logTypeWARN && logEnabled {
+		w.SendEvent(Event{Type: EventTypeWarning, Message: strings.TrimSpace(string(msg))})
+	}
+
 	return len(msg), nil
 }
 
@@ -128,6 +189,54 @@ func (w *writer) SendProgressUpdate(status, currentPhase string, totalPhases []s
 	if w.logChannel != nil {
 		w.logChannel <- convertProgressMsgToJSONBytes(&msgData)
 	}
+
+	for _, event := range w.phaseEvents(status, currentPhase, totalPhases) {
+		w.SendEvent(event)
+	}
+}
+
+// phaseEvents returns the phase finished and started events of the transition to the current phase.
+// Reaching a new phase finishes the previous one successfully.
+func (w *writer) phaseEvents(status, currentPhase string, totalPhases []string) []Event {
+	w.eventLock.Lock()
+	defer w.eventLock.Unlock()
+
+	events := []Event{}
+	switch status {
+	case progressStatusRunning:
+		if currentPhase == "" || currentPhase == w.currentPhase {
+			return events
+		}
+		if w.currentPhase != "" {
+			events = append(events, Event{Type: EventTypePhaseFinished, Phase: w.currentPhase, Status: progressStatusSuccessful})
+		}
+		events = append(events, Event{Type: EventTypePhaseStarted, Phase: currentPhase, TotalPhases: totalPhases})
+		w.currentPhase = currentPhase
+	case progressStatusSuccessful, progressStatusFailed:
+		if w.currentPhase != "" {
+			events = append(events, Event{Type: EventTypePhaseFinished, Phase: w.currentPhase, Status: status})
+		}
+		w.currentPhase = ""
+	}
+	return events
+}
+
+// SendEvent writes the progress event to stdout if the json progress format is set
+// and sends it to the channel if channel is set
+func (w *writer) SendEvent(event Event) {
+	if event.Timestamp == "" {
+		event.Timestamp = eventTimestamp()
+	}
+
+	w.eventLock.Lock()
+	defer w.eventLock.Unlock()
+
+	if w.progressFormat == ProgressFormatJSON {
+		stdoutWriter(append(convertEventToJSONBytes(&event), '\n'))
+	}
+	if w.logChannel != nil {
+		w.logChannel <- convertEventToUpdateJSONBytes(&event)
+	}
 }
 
 // UnsetStdoutStderr intercept the actual stdout and stderr
@@ -144,6 +253,52 @@ func UnsetStdoutStderr() {
 	log.SetOutput(nil)
 }
 
+// writeToFile writes the message to the logfile, encoding the entry with the json log format,
+// and rotates the logfile first if the message would make it exceed its maximum size
+func (w *writer) writeToFile(msg []byte, entry *Entry) {
+	if w.logFormat == LogFormatJSON && entry != nil {
+		entryBytes, err := entry.encodeJSON()
+		if err == nil {
+			msg = entryBytes
+		}
+	}
+
+	w.fileLock.Lock()
+	defer w.fileLock.Unlock()
+
+	if w.fileMaxSize > 0 {
+		if info, err := os.Stat(w.logFile); err == nil && info.Size() > 0 && info.Size()+int64(len(msg)) > w.fileMaxSize {
+			rotateFile(w.logFile, w.fileMaxBackups)
+		}
+	}
+	fileWriter(w.logFile, msg)
+}
+
+// rotateFile renames the logfile to <logfile>.1, shifting the rotated files by one and
+// dropping the ones over maxBackups. The logfile is removed if maxBackups is 0.
+func rotateFile(logFileName string, maxBackups int) {
+	if maxBackups <= 0 {
+		if err := os.Remove(logFileName); err != nil {
+			stderrWriter([]byte("Unable to rotate log file: " + err.Error() + "\n"))
+		}
+		return
+	}
+
+	_ = os.Remove(backupFileName(logFileName, maxBackups))
+	for i := maxBackups - 1; i >= 1; i-- {
+		if _, err := os.Stat(backupFileName(logFileName, i)); err == nil {
+			_ = os.Rename(backupFileName(logFileName, i), backupFileName(logFileName, i+1))
+		}
+	}
+	if err := os.Rename(logFileName, backupFileName(logFileName, 1)); err != nil {
+		stderrWriter([]byte("Unable to rotate log file: " + err.Error() + "\n"))
+	}
+}
+
+func backupFileName(logFileName string, index int) string {
+	return fmt.Sprintf("%s.%d", logFileName, index)
+}
+
 func fileWriter(logFileName string, msg []byte) {
 	basePath := path.Dir(logFileName)
 	filePath := path.Base(logFileName)
@@ -0,0 +1,103 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Writer", func() {
+	var (
+		dir     string
+		logFile string
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "log-writer-test")
+		Expect(err).NotTo(HaveOccurred())
+		logFile = filepath.Join(dir, "tanzu.log")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	readFile := func(name string) string {
+		content, err := os.ReadFile(name)
+		Expect(err).NotTo(HaveOccurred())
+		return string(content)
+	}
+
+	Context("with the json log format", func() {
+		It("writes the entries to the logfile as JSON objects with the secret values redacted", func() {
+			w := &writer{logFile: logFile, logFormat: LogFormatJSON}
+			entry := &Entry{
+				Timestamp: time.Now(),
+				LogType:   "INFO",
+				Message:   "Creating cluster",
+				Values:    redactValues([]interface{}{"cluster", "my-cluster", "AZURE_CLIENT_SECRET", "s3cr3t"}),
+			}
+			_, err := w.Write([]byte("I0801 "), []byte("Creating cluster"), false, 0, "INFO", entry)
+			Expect(err).NotTo(HaveOccurred())
+
+			content := readFile(logFile)
+			Expect(content).NotTo(ContainSubstring("s3cr3t"))
+			fields := map[string]interface{}{}
+			Expect(json.Unmarshal([]byte(content), &fields)).To(Succeed())
+			Expect(fields).To(HaveKeyWithValue("msg", "Creating cluster"))
+			Expect(fields).To(HaveKeyWithValue("cluster", "my-cluster"))
+			Expect(fields).To(HaveKeyWithValue("AZURE_CLIENT_SECRET", redactedValue))
+		})
+
+		It("writes the message as text without an entry", func() {
+			w := &writer{logFile: logFile, logFormat: LogFormatJSON}
+			_, err := w.Write([]byte("I0801 "), []byte("plain message\n"), false, 0, "INFO", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(readFile(logFile)).To(Equal("I0801 plain message\n"))
+		})
+	})
+
+	Context("with the file rotation", func() {
+		write := func(w *writer, line string) {
+			_, err := w.Write(nil, []byte(line), false, 0, "INFO", nil)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		It("rotates the logfile at the size threshold and keeps maxBackups files", func() {
+			w := &writer{logFile: logFile, fileMaxSize: 100, fileMaxBackups: 2}
+			for _, c := range []string{"a", "b", "c", "d", "e"} {
+				write(w, strings.Repeat(c, 59)+"\n")
+			}
+
+			Expect(readFile(logFile)).To(Equal(strings.Repeat("e", 59) + "\n"))
+			Expect(readFile(logFile + ".1")).To(Equal(strings.Repeat("d", 59) + "\n"))
+			Expect(readFile(logFile + ".2")).To(Equal(strings.Repeat("c", 59) + "\n"))
+			Expect(logFile + ".3").NotTo(BeAnExistingFile())
+		})
+
+		It("does not rotate the logfile below the size threshold", func() {
+			w := &writer{logFile: logFile, fileMaxSize: 100, fileMaxBackups: 2}
+			write(w, strings.Repeat("a", 49)+"\n")
+			write(w, strings.Repeat("b", 49)+"\n")
+			Expect(readFile(logFile)).To(HaveLen(100))
+			Expect(logFile + ".1").NotTo(BeAnExistingFile())
+		})
+
+		It("removes the logfile when no backup is kept", func() {
+			w := &writer{logFile: logFile, fileMaxSize: 100}
+			write(w, strings.Repeat("a", 59)+"\n")
+			write(w, strings.Repeat("b", 59)+"\n")
+			Expect(readFile(logFile)).To(Equal(strings.Repeat("b", 59) + "\n"))
+			Expect(logFile + ".1").NotTo(BeAnExistingFile())
+		})
+	})
+})
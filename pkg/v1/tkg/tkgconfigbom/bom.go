@@ -518,6 +518,16 @@ func (c *client) DownloadTKGCompatibilityFileFromRegistry(bomRegistry registry.R
 }
 
 func (c *client) InitBOMRegistry() (registry.Registry, error) {
+	return c.initRegistry(true)
+}
+
+// InitBOMRegistryWithCredentials initializes a registry client authenticating with the credentials of the docker
+// config file, e.g. to push images to a private registry
+func (c *client) InitBOMRegistryWithCredentials() (registry.Registry, error) {
+	return c.initRegistry(false)
+}
+
+func (c *client) initRegistry(anonymous bool) (registry.Registry, error) {
 	verifyCerts := true
 	skipVerifyCerts, err := c.tkgConfigReaderWriter.Get(constants.ConfigVariableCustomImageRepositorySkipTLSVerify)
 	if err == nil && strings.EqualFold(skipVerifyCerts, "true") {
@@ -526,7 +536,7 @@ func (c *client) InitBOMRegistry() (registry.Registry, error) {
 
 	registryOpts := &ctlimg.RegistryOpts{
 		VerifyCerts: verifyCerts,
-		Anon:        true,
+		Anon:        anonymous,
 	}
 
 	if runtime.GOOS == "windows" {
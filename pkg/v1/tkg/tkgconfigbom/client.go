@@ -59,6 +59,8 @@ type Client interface {
 	DownloadTKGCompatibilityFileFromRegistry(registry.Registry) error
 	// Initializes the registry for downloading the bom files
 	InitBOMRegistry() (registry.Registry, error)
+	// Initializes a registry authenticating with the docker credentials, e.g. for pushing images to a private registry
+	InitBOMRegistryWithCredentials() (registry.Registry, error)
 	// GetDefaultTKRVersion return default TKr version from default TKG BOM file
 	GetDefaultTKRVersion() (string, error)
 	// GetDefaultBoMFilePath returns path of default BoM file
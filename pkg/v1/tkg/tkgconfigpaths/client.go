@@ -30,6 +30,9 @@ type Client interface {
 	// GetTKGBoMDirectory returns path to tkg config directory "$HOME/.tkg/bom"
 	GetTKGBoMDirectory() (string, error)
 
+	// GetTKGClusterProfilesDirectory returns path to tkg cluster profiles directory "<TKGConfigDirectory>/profiles"
+	GetTKGClusterProfilesDirectory() (string, error)
+
 	// GetTKGConfigDirectories returns tkg config directories in below order
 	// (tkgDir, bomDir, providersDir, error)
 	GetTKGConfigDirectories() (string, string, string, error)
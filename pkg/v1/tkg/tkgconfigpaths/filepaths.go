@@ -48,6 +48,15 @@ func (c *client) GetTKGCompatibilityDirectory() (string, error) {
 	return filepath.Join(tkgDir, constants.LocalCompatibilityFolderName), nil
 }
 
+// GetTKGClusterProfilesDirectory returns path to tkg cluster profiles directory "<TKGConfigDirectory>/profiles"
+func (c *client) GetTKGClusterProfilesDirectory() (string, error) {
+	tkgDir, err := c.GetTKGDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(tkgDir, constants.LocalClusterProfilesFolder), nil
+}
+
 // GetTKGConfigDirectories returns tkg config directories in below order
 // (tkgDir, bomDir, providersDir, error)
 func (c *client) GetTKGConfigDirectories() (string, string, string, error) {
@@ -0,0 +1,89 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgconfigschema
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/yamlprocessor"
+)
+
+const (
+	configDefaultFileName        = "config_default.yaml"
+	infrastructureProviderPrefix = "infrastructure-"
+	templateDefinitionPrefix     = "cluster-template-definition-"
+	windowsPlanSuffix            = "-windows"
+)
+
+// Load derives the schema of the config variables from the providers in the tkg directory.
+// The variables are the ytt data values of the shared and infrastructure provider ytt
+// libraries, including user customizations, and of config_default.yaml.
+func Load(tkgDir string) (*Schema, error) {
+	// the template definition parser only accepts absolute paths
+	tkgDir, err := filepath.Abs(tkgDir)
+	if err != nil {
+		return nil, err
+	}
+	providersDir := filepath.Join(tkgDir, constants.LocalProvidersFolderName)
+	configDefault, err := os.ReadFile(filepath.Join(providersDir, configDefaultFileName))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the default cluster configuration of the providers")
+	}
+
+	providerDirs, err := filepath.Glob(filepath.Join(providersDir, infrastructureProviderPrefix+"*"))
+	if err != nil {
+		return nil, err
+	}
+	sources := &Sources{ConfigDefault: configDefault}
+	yttPaths := []string{path.Join(constants.LocalProvidersFolderName, "ytt")}
+	plans := map[string]bool{}
+	for _, providerDir := range providerDirs {
+		providerName := strings.TrimPrefix(filepath.Base(providerDir), infrastructureProviderPrefix)
+		sources.Providers = append(sources.Providers, providerName)
+		if info, err := os.Stat(filepath.Join(providerDir, "ytt")); err == nil && info.IsDir() {
+			yttPaths = append(yttPaths, path.Join(constants.LocalProvidersFolderName, filepath.Base(providerDir), "ytt"))
+		}
+
+		definitions, err := filepath.Glob(filepath.Join(providerDir, "*", templateDefinitionPrefix+"*.yaml"))
+		if err != nil {
+			return nil, err
+		}
+		for _, definition := range definitions {
+			plan := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(definition), templateDefinitionPrefix), ".yaml")
+			// the windows templates are selected with IS_WINDOWS_WORKLOAD_CLUSTER rather than the plan
+			if !strings.HasSuffix(plan, windowsPlanSuffix) {
+				plans[plan] = true
+			}
+		}
+	}
+	for plan := range plans {
+		sources.Plans = append(sources.Plans, plan)
+	}
+	sort.Strings(sources.Plans)
+	yttPaths = append(yttPaths, path.Join(constants.LocalProvidersFolderName, configDefaultFileName))
+
+	sources.Variables, err = yamlprocessor.NewYttProcessorWithConfigDir(tkgDir).GetVariables(templateDefinition(yttPaths))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get the config variables of the provider templates")
+	}
+	return Generate(sources)
+}
+
+// templateDefinition returns a template definition of the ytt libraries, the paths are relative to the tkg directory
+func templateDefinition(paths []string) []byte {
+	var definition strings.Builder
+	definition.WriteString("apiVersion: providers.tanzu.vmware.com/v1alpha1\nkind: TemplateDefinition\nspec:\n  paths:\n")
+	for _, p := range paths {
+		fmt.Fprintf(&definition, "  - path: %s\n", p)
+	}
+	return []byte(definition.String())
+}
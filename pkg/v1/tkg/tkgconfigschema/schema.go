@@ -0,0 +1,254 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tkgconfigschema derives the schema of the cluster configuration variables
+// from the provider templates and validates cluster configuration files against it.
+package tkgconfigschema
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+)
+
+// VariableType is the type of the value of a config variable
+type VariableType string
+
+// Config variable types
+const (
+	TypeString   VariableType = "string"
+	TypeBoolean  VariableType = "boolean"
+	TypeInteger  VariableType = "integer"
+	TypeDuration VariableType = "duration"
+)
+
+// providerTKGServiceVSphere is the infrastructure provider of clusters created on vSphere with Tanzu
+const providerTKGServiceVSphere = "tkg-service-vsphere"
+
+// Schema describes the config variables supported in cluster configuration files
+type Schema struct {
+	Variables []Variable `json:"variables" yaml:"variables"`
+}
+
+// Variable describes a config variable
+type Variable struct {
+	Name    string       `json:"name" yaml:"name"`
+	Type    VariableType `json:"type" yaml:"type"`
+	Default string       `json:"default,omitempty" yaml:"default,omitempty"`
+	Enum    []string     `json:"enum,omitempty" yaml:"enum,omitempty"`
+	// Providers are the infrastructure providers the variable applies to, it applies to all providers if empty
+	Providers []string `json:"providers,omitempty" yaml:"providers,omitempty"`
+	// ReplacedBy lists the variables to use instead of a deprecated variable
+	ReplacedBy []string `json:"replacedBy,omitempty" yaml:"replacedBy,omitempty"`
+	Deprecated bool     `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+}
+
+// Sources are the provider template data the schema is derived from
+type Sources struct {
+	// ConfigDefault is the content of the config_default.yaml file of the providers
+	ConfigDefault []byte
+	// Variables are the ytt data values of the cluster templates
+	Variables []string
+	// Providers are the names of the infrastructure providers
+	Providers []string
+	// Plans are the names of the cluster plans
+	Plans []string
+}
+
+type annotation struct {
+	varType    VariableType
+	enum       []string
+	providers  []string
+	replacedBy []string
+}
+
+// variableAnnotations completes the schema with what cannot be inferred from the default values
+var variableAnnotations = map[string]annotation{
+	constants.ConfigVariableCNI:                      {enum: []string{"antrea", "calico", "none"}},
+	constants.ConfigVariableIPFamily:                 {enum: []string{constants.IPv4Family, constants.IPv6Family, constants.DualStackPrimaryIPv4Family, constants.DualStackPrimaryIPv6Family}},
+	constants.ConfigVariableBuildEdition:             {enum: []string{"tce", "tkg"}},
+	"IDENTITY_MANAGEMENT_TYPE":                       {enum: []string{"oidc", "ldap", "none"}},
+	"VSPHERE_CLONE_MODE":                             {enum: []string{"fullClone", "linkedClone"}},
+	constants.ConfigVariableAzureEnvironment:         {enum: []string{"AzurePublicCloud", "AzureChinaCloud", "AzureGermanCloud", "AzureUSGovernmentCloud"}},
+	"AVI_AKO_IMAGE_PULL_POLICY":                      {enum: []string{"Always", "IfNotPresent", "Never"}},
+	constants.ConfigVariableControlPlaneMachineCount: {varType: TypeInteger},
+	constants.ConfigVariableWorkerMachineCount:       {varType: TypeInteger},
+	constants.ConfigVariableWorkerMachineCount0:      {varType: TypeInteger},
+	constants.ConfigVariableWorkerMachineCount1:      {varType: TypeInteger},
+	constants.ConfigVariableWorkerMachineCount2:      {varType: TypeInteger},
+	"AUTOSCALER_MIN_SIZE_0":                          {varType: TypeInteger},
+	"AUTOSCALER_MAX_SIZE_0":                          {varType: TypeInteger},
+	"AUTOSCALER_MIN_SIZE_1":                          {varType: TypeInteger},
+	"AUTOSCALER_MAX_SIZE_1":                          {varType: TypeInteger},
+	"AUTOSCALER_MIN_SIZE_2":                          {varType: TypeInteger},
+	"AUTOSCALER_MAX_SIZE_2":                          {varType: TypeInteger},
+	constants.ConfigVariableEnableMHC:                {varType: TypeBoolean},
+	constants.ConfigVariableEnableCEIPParticipation:  {varType: TypeBoolean},
+	constants.ConfigVariableDeployTKGOnVsphere7:      {varType: TypeBoolean},
+	constants.ConfigVariableEnableTKGSonVsphere7:     {varType: TypeBoolean},
+	// the vSphere template is discovered from the OS selection
+	constants.ConfigVariableVsphereTemplate: {replacedBy: []string{constants.ConfigVariableOSName, constants.ConfigVariableOSVersion, constants.ConfigVariableOSArch}},
+}
+
+// cliVariables are read by the CLI rather than the cluster templates
+var cliVariables = map[string]annotation{
+	constants.ConfigVariableAWSAccessKeyID:               {providers: []string{constants.InfrastructureProviderAWS}},
+	constants.ConfigVariableAWSSecretAccessKey:           {providers: []string{constants.InfrastructureProviderAWS}},
+	constants.ConfigVariableAWSSessionToken:              {providers: []string{constants.InfrastructureProviderAWS}},
+	constants.ConfigVariableAWSProfile:                   {providers: []string{constants.InfrastructureProviderAWS}},
+	constants.ConfigVariableAWSB64Credentials:            {providers: []string{constants.InfrastructureProviderAWS}},
+	constants.ConfigVariableAzureTenantIDB64:             {providers: []string{constants.InfrastructureProviderAzure}},
+	constants.ConfigVariableAzureSubscriptionIDB64:       {providers: []string{constants.InfrastructureProviderAzure}},
+	constants.ConfigVariableAzureClientIDB64:             {providers: []string{constants.InfrastructureProviderAzure}},
+	constants.ConfigVariableAzureClientSecretB64:         {providers: []string{constants.InfrastructureProviderAzure}},
+	constants.ConfigVariableEnableClusterOptions:         {},
+	constants.TKGHTTPProxyEnabled:                        {varType: TypeBoolean},
+	constants.ConfigVariablePackageInstallTimeout:        {varType: TypeDuration},
+	constants.ConfigVariableDevImageRepository:           {},
+	constants.ConfigVariableCompatibilityCustomImagePath: {},
+}
+
+var (
+	sectionTitleRegex    = regexp.MustCompile(`(?m)^#! -{3,}\n#! (.+)\n(?:#! .*\n)*?#! -{3,}`)
+	providerSectionRegex = regexp.MustCompile(`^Settings for (\S+) infrastructure provider$`)
+	durationRegex        = regexp.MustCompile(`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`)
+)
+
+// Generate derives the schema of the config variables from the provider template data.
+// The type of a variable is inferred from its default value and the providers it applies to
+// from the section of config_default.yaml it is declared in.
+func Generate(sources *Sources) (*Schema, error) {
+	defaults, err := parseConfigDefault(sources.ConfigDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[string]*Variable{}
+	for _, name := range sources.Variables {
+		variable := &Variable{Name: name, Type: TypeString}
+		if d, exists := defaults[name]; exists {
+			variable = d
+		}
+		variables[name] = variable
+	}
+	for name, a := range cliVariables {
+		if _, exists := variables[name]; !exists {
+			variables[name] = &Variable{Name: name, Type: TypeString, Providers: a.providers}
+		}
+		if a.varType != "" {
+			variables[name].Type = a.varType
+		}
+	}
+	for name, a := range variableAnnotations {
+		variable, exists := variables[name]
+		if !exists {
+			continue
+		}
+		if a.varType != "" {
+			variable.Type = a.varType
+		}
+		variable.Enum = a.enum
+		if len(a.replacedBy) != 0 {
+			variable.Deprecated = true
+			variable.ReplacedBy = a.replacedBy
+		}
+	}
+	if variable, exists := variables[constants.ConfigVariableInfraProvider]; exists && len(sources.Providers) != 0 {
+		variable.Enum = sortedCopy(sources.Providers)
+	}
+	if variable, exists := variables[constants.ConfigVariableClusterPlan]; exists && len(sources.Plans) != 0 {
+		variable.Enum = sortedCopy(sources.Plans)
+	}
+
+	schema := &Schema{Variables: make([]Variable, 0, len(variables))}
+	for _, variable := range variables {
+		schema.Variables = append(schema.Variables, *variable)
+	}
+	sort.Slice(schema.Variables, func(i, j int) bool {
+		return schema.Variables[i].Name < schema.Variables[j].Name
+	})
+	return schema, nil
+}
+
+// parseConfigDefault returns the variables declared in config_default.yaml with their default value
+func parseConfigDefault(configDefault []byte) (map[string]*Variable, error) {
+	variables := map[string]*Variable{}
+	decoder := yaml.NewDecoder(bytes.NewReader(configDefault))
+	for {
+		document := yaml.Node{}
+		if err := decoder.Decode(&document); err != nil {
+			if err == io.EOF {
+				return variables, nil
+			}
+			return nil, errors.Wrap(err, "unable to parse config_default.yaml")
+		}
+		if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+			continue
+		}
+
+		var providers []string
+		items := document.Content[0].Content
+		for i := 0; i+1 < len(items); i += 2 {
+			key, value := items[i], items[i+1]
+			if titles := sectionTitleRegex.FindAllStringSubmatch(key.HeadComment, -1); len(titles) != 0 {
+				providers = sectionProviders(titles[len(titles)-1][1])
+			}
+			variable := &Variable{Name: key.Value, Type: TypeString, Providers: providers}
+			if value.Kind == yaml.ScalarNode && value.Tag != "!!null" {
+				variable.Default = value.Value
+				variable.Type = inferType(value)
+			}
+			variables[key.Value] = variable
+		}
+	}
+}
+
+// sectionProviders returns the providers the variables of a config_default.yaml section apply to
+func sectionProviders(title string) []string {
+	if strings.Contains(title, "vSphere with Tanzu") {
+		return []string{providerTKGServiceVSphere}
+	}
+	if match := providerSectionRegex.FindStringSubmatch(title); match != nil {
+		return []string{strings.ToLower(match[1])}
+	}
+	return nil
+}
+
+func inferType(value *yaml.Node) VariableType {
+	switch value.Tag {
+	case "!!bool":
+		return TypeBoolean
+	case "!!int":
+		return TypeInteger
+	}
+	if value.Value == "" {
+		return TypeString
+	}
+	if _, err := strconv.ParseBool(value.Value); err == nil && strings.ToLower(value.Value) == value.Value {
+		return TypeBoolean
+	}
+	if _, err := strconv.Atoi(value.Value); err == nil {
+		return TypeInteger
+	}
+	if durationRegex.MatchString(value.Value) {
+		if _, err := time.ParseDuration(value.Value); err == nil {
+			return TypeDuration
+		}
+	}
+	return TypeString
+}
+
+func sortedCopy(values []string) []string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return sorted
+}
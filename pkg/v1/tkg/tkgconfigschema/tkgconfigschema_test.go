@@ -0,0 +1,118 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgconfigschema_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgconfigschema"
+)
+
+func TestTKGConfigSchema(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Tkg config schema Suite")
+}
+
+// providersTKGDir is the directory containing the providers of the repository
+const providersTKGDir = "../../"
+
+func findVariable(schema *Schema, name string) *Variable {
+	for i := range schema.Variables {
+		if schema.Variables[i].Name == name {
+			return &schema.Variables[i]
+		}
+	}
+	return nil
+}
+
+var _ = Describe("Schema", func() {
+	var (
+		schema *Schema
+		err    error
+	)
+
+	BeforeEach(func() {
+		schema, err = Load(providersTKGDir)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("Load", func() {
+		It("derives the types from the default values", func() {
+			Expect(findVariable(schema, "VSPHERE_NUM_CPUS").Type).To(Equal(TypeInteger))
+			Expect(findVariable(schema, "ENABLE_AUDIT_LOGGING").Type).To(Equal(TypeBoolean))
+			Expect(findVariable(schema, "NSXT_REMOTE_AUTH").Type).To(Equal(TypeBoolean))
+			Expect(findVariable(schema, "MHC_FALSE_STATUS_TIMEOUT").Type).To(Equal(TypeDuration))
+			Expect(findVariable(schema, "MHC_FALSE_STATUS_TIMEOUT").Default).To(Equal("12m"))
+			Expect(findVariable(schema, "WORKER_MACHINE_COUNT").Type).To(Equal(TypeInteger))
+			Expect(findVariable(schema, "CLUSTER_NAME").Type).To(Equal(TypeString))
+		})
+
+		It("derives the providers from the config_default.yaml sections", func() {
+			Expect(findVariable(schema, "VSPHERE_SERVER").Providers).To(Equal([]string{"vsphere"}))
+			Expect(findVariable(schema, "AWS_REGION").Providers).To(Equal([]string{"aws"}))
+			Expect(findVariable(schema, "AZURE_LOCATION").Providers).To(Equal([]string{"azure"}))
+			Expect(findVariable(schema, "WORKER_VM_CLASS").Providers).To(Equal([]string{"tkg-service-vsphere"}))
+			Expect(findVariable(schema, "ENABLE_OIDC").Providers).To(BeEmpty())
+			Expect(findVariable(schema, "CNI").Providers).To(BeEmpty())
+		})
+
+		It("derives the infrastructure providers and plans from the providers", func() {
+			Expect(findVariable(schema, "INFRASTRUCTURE_PROVIDER").Enum).To(ContainElements("aws", "azure", "docker", "vsphere"))
+			Expect(findVariable(schema, "CLUSTER_PLAN").Enum).To(Equal([]string{"dev", "prod"}))
+		})
+
+		It("includes the variables read by the CLI and the deprecated variables", func() {
+			Expect(findVariable(schema, "AWS_ACCESS_KEY_ID")).NotTo(BeNil())
+			Expect(findVariable(schema, "VSPHERE_TEMPLATE").Deprecated).To(BeTrue())
+		})
+	})
+
+	Describe("Validate", func() {
+		It("reports all the issues at once", func() {
+			issues := schema.Validate(map[string]string{
+				"INFRASTRUCTURE_PROVIDER":    "aws:v0.6.4",
+				"CLUSTER_PLAN":               "dev",
+				"CLUSTER_NAME":               "my-cluster",
+				"WORKER_MACHNE_COUNT":        "3",
+				"ENABLE_AUDIT_LOGGING":       "yes",
+				"CNI":                        "flannel",
+				"MHC_UNKNOWN_STATUS_TIMEOUT": "5 minutes",
+				"VSPHERE_SERVER":             "vc.example.com",
+				"VSPHERE_TEMPLATE":           "photon",
+				"AWS_REGION":                 "",
+			})
+			Expect(issues).To(Equal([]Issue{
+				{Variable: "CNI", Severity: SeverityError, Message: "invalid value 'flannel', expected one of antrea, calico, none"},
+				{Variable: "ENABLE_AUDIT_LOGGING", Severity: SeverityError, Message: "invalid value 'yes', expected true or false"},
+				{Variable: "MHC_UNKNOWN_STATUS_TIMEOUT", Severity: SeverityError, Message: "invalid value '5 minutes', expected a duration such as 5m or 1h30m"},
+				{Variable: "VSPHERE_SERVER", Severity: SeverityWarning, Message: "only applies to the vsphere infrastructure provider, ignored for 'aws'"},
+				{Variable: "VSPHERE_TEMPLATE", Severity: SeverityWarning, Message: "deprecated config variable, use OS_NAME, OS_VERSION, OS_ARCH instead"},
+				{Variable: "VSPHERE_TEMPLATE", Severity: SeverityWarning, Message: "only applies to the vsphere infrastructure provider, ignored for 'aws'"},
+				{Variable: "WORKER_MACHNE_COUNT", Severity: SeverityWarning, Message: "unknown config variable, did you mean 'WORKER_MACHINE_COUNT'?"},
+			}))
+		})
+
+		It("only warns about the variables of custom overlays", func() {
+			Expect(schema.Validate(map[string]string{
+				"INFRASTRUCTURE_PROVIDER": "vsphere",
+				"MY_OVERLAY_NTP_SERVERS":  "time.example.com",
+			})).To(Equal([]Issue{
+				{Variable: "MY_OVERLAY_NTP_SERVERS", Severity: SeverityWarning, Message: "unknown config variable"},
+			}))
+		})
+
+		It("accepts a valid configuration", func() {
+			Expect(schema.Validate(map[string]string{
+				"INFRASTRUCTURE_PROVIDER": "vsphere",
+				"CLUSTER_PLAN":            "prod",
+				"VSPHERE_SERVER":          "vc.example.com",
+				"WORKER_MACHINE_COUNT":    "3",
+				"TKG_IP_FAMILY":           "ipv4,ipv6",
+			})).To(BeEmpty())
+		})
+	})
+})
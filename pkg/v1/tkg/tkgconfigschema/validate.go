@@ -0,0 +1,162 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgconfigschema
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+)
+
+// Severity is the severity of a validation issue
+type Severity string
+
+// Validation issue severities
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// maxSuggestionDistance is the maximum edit distance of a variable name suggested for an unknown variable
+const maxSuggestionDistance = 3
+
+// Issue is a problem found while validating config variables
+type Issue struct {
+	Variable string   `json:"variable" yaml:"variable"`
+	Severity Severity `json:"severity" yaml:"severity"`
+	Message  string   `json:"message" yaml:"message"`
+}
+
+// Validate checks the config variables against the schema and returns all the issues found,
+// sorted by variable name. Variables without a value are ignored.
+func (s *Schema) Validate(variables map[string]string) []Issue {
+	schemaVariables := make(map[string]*Variable, len(s.Variables))
+	for i := range s.Variables {
+		schemaVariables[s.Variables[i].Name] = &s.Variables[i]
+	}
+	provider := providerName(variables[constants.ConfigVariableInfraProvider])
+
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	issues := []Issue{}
+	for _, name := range names {
+		value := variables[name]
+		if value == "" {
+			continue
+		}
+		variable, exists := schemaVariables[name]
+		if !exists {
+			// custom ytt overlays and provider extras may read variables the schema does not know about
+			message := "unknown config variable"
+			if suggestion := s.closestVariable(name); suggestion != "" {
+				message = fmt.Sprintf("%s, did you mean '%s'?", message, suggestion)
+			}
+			issues = append(issues, Issue{Variable: name, Severity: SeverityWarning, Message: message})
+			continue
+		}
+		if message := variable.validateValue(value); message != "" {
+			issues = append(issues, Issue{Variable: name, Severity: SeverityError, Message: message})
+		}
+		if variable.Deprecated {
+			issues = append(issues, Issue{Variable: name, Severity: SeverityWarning,
+				Message: fmt.Sprintf("deprecated config variable, use %s instead", strings.Join(variable.ReplacedBy, ", "))})
+		}
+		if provider != "" && len(variable.Providers) != 0 && !contains(variable.Providers, provider) {
+			issues = append(issues, Issue{Variable: name, Severity: SeverityWarning,
+				Message: fmt.Sprintf("only applies to the %s infrastructure provider, ignored for '%s'", strings.Join(variable.Providers, ", "), provider)})
+		}
+	}
+	return issues
+}
+
+// validateValue returns the reason the value is invalid, or an empty string if it is valid
+func (v *Variable) validateValue(value string) string {
+	switch v.Type {
+	case TypeBoolean:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Sprintf("invalid value '%s', expected true or false", value)
+		}
+	case TypeInteger:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Sprintf("invalid value '%s', expected an integer", value)
+		}
+	case TypeDuration:
+		if _, err := time.ParseDuration(value); err != nil {
+			return fmt.Sprintf("invalid value '%s', expected a duration such as 5m or 1h30m", value)
+		}
+	}
+
+	if len(v.Enum) != 0 {
+		if v.Name == constants.ConfigVariableInfraProvider {
+			value = providerName(value)
+		}
+		if !contains(v.Enum, value) {
+			return fmt.Sprintf("invalid value '%s', expected one of %s", value, strings.Join(v.Enum, ", "))
+		}
+	}
+	return ""
+}
+
+// closestVariable returns the known variable closest to the name, if close enough to be a typo
+func (s *Schema) closestVariable(name string) string {
+	closest := ""
+	closestDistance := maxSuggestionDistance + 1
+	for i := range s.Variables {
+		if distance := editDistance(name, s.Variables[i].Name); distance < closestDistance {
+			closest = s.Variables[i].Name
+			closestDistance = distance
+		}
+	}
+	return closest
+}
+
+// providerName strips the version from an infrastructure provider, e.g. vsphere:v0.7.10
+func providerName(provider string) string {
+	return strings.SplitN(provider, ":", 2)[0]
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// editDistance returns the Levenshtein distance between the two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(minInt(previous[j]+1, current[j-1]+1), previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,57 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+)
+
+// BackupRegionOptions options for backing up a management cluster
+type BackupRegionOptions struct {
+	File string
+}
+
+// RestoreRegionOptions options for restoring a management cluster backup
+type RestoreRegionOptions struct {
+	File       string
+	SkipPrompt bool
+}
+
+// BackupRegion exports the objects of the workload clusters of the current management cluster to an archive
+func (t *tkgctl) BackupRegion(options BackupRegionOptions) error {
+	if options.File == "" {
+		return errors.New("the file to back up the management cluster to is required")
+	}
+	return t.tkgClient.BackupManagementCluster(client.BackupManagementClusterOptions{
+		File: options.File,
+	})
+}
+
+// RestoreRegion creates the objects of a management cluster backup on the current management cluster
+func (t *tkgctl) RestoreRegion(options RestoreRegionOptions) error {
+	if options.File == "" {
+		return errors.New("the file to restore the management cluster from is required")
+	}
+
+	if !options.SkipPrompt {
+		if err := askForConfirmation(fmt.Sprintf("Restoring '%s' takes over the management of the workload clusters it holds. "+
+			"Make sure the management cluster they were backed up from is deleted or does not manage them anymore. Are you sure?", options.File)); err != nil {
+			return err
+		}
+	}
+
+	if err := t.tkgClient.RestoreManagementCluster(client.RestoreManagementClusterOptions{
+		File: options.File,
+	}); err != nil {
+		return err
+	}
+
+	log.Infof("Management cluster backup '%s' restored\n", options.File)
+	return nil
+}
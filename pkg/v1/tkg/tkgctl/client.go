@@ -38,6 +38,9 @@ type tkgctl struct {
 	tkgConfigPathsClient     tkgconfigpaths.Client
 	providerGetter           providerinterface.ProviderInterface
 	tkgConfigReaderWriter    tkgconfigreaderwriter.TKGConfigReaderWriter
+	// tkgClientFactory creates a tkg client with its own configuration reader writer, used when several
+	// operations run concurrently and must not see the configuration values set by each other
+	tkgClientFactory func() (client.Client, error)
 }
 
 // LoggingOptions options to configure logging with tkgctl client
@@ -50,7 +53,18 @@ type LoggingOptions struct {
 	Verbosity int32
 	// LogChannel if channel is set, writer will forward log messages to this log channel
 	// The result of this will be in the format of 'LogData' struct mentioned in `pkg/log/type.go`
+	// or of the progress 'Event' struct mentioned in `pkg/log/events.go`
 	LogChannel chan<- []byte
+	// ProgressFormat if set to 'json', the progress events of the long-running operations
+	// are written to stdout as newline-delimited JSON
+	ProgressFormat string
+	// LogFormat if set to 'json', each log entry is written to the log file as a JSON object
+	// with the timestamp, verbosity, caller and key/values as fields
+	LogFormat string
+	// LogFileMaxSize size in megabytes after which the log file is rotated, 0 disables the rotation
+	LogFileMaxSize int
+	// LogFileMaxBackups number of rotated log files to keep
+	LogFileMaxBackups int
 }
 
 // Options options to create tkgctl client
@@ -108,11 +122,6 @@ func New(options Options) (TKGClient, error) { //nolint:gocritic
 		return nil, err
 	}
 
-	allClients, err := clientcreator.CreateAllClients(appConfig, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	var clusterKubeConfig *types.ClusterKubeConfig
 	if options.KubeConfig != "" {
 		clusterKubeConfig = &types.ClusterKubeConfig{
@@ -121,35 +130,26 @@ func New(options Options) (TKGClient, error) { //nolint:gocritic
 		}
 	}
 
-	tkgClient, err := client.New(client.Options{
-		ClusterCtlClient:         allClients.ClusterCtlClient,
-		ReaderWriterConfigClient: allClients.ConfigClient,
-		RegionManager:            allClients.RegionManager,
-		TKGConfigDir:             options.ConfigDir,
-		Timeout:                  constants.DefaultOperationTimeout,
-		FeaturesClient:           allClients.FeaturesClient,
-		TKGConfigProvidersClient: allClients.TKGConfigProvidersClient,
-		TKGBomClient:             allClients.TKGBomClient,
-		TKGConfigUpdater:         allClients.TKGConfigUpdaterClient,
-		TKGPathsClient:           allClients.TKGConfigPathsClient,
-		ClusterKubeConfig:        clusterKubeConfig,
-		ClusterClientFactory:     clusterclient.NewClusterClientFactory(),
-	})
+	tkgClient, allClients, err := newTKGClient(appConfig, clusterKubeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// log format and log file rotation settings not given as options are read from the tkg config
+	logOptions, err := loggingOptionsFromConfig(options.LogOptions, allClients.ConfigClient.TKGConfigReaderWriter())
 	if err != nil {
 		return nil, err
 	}
+	configureLogFileFormat(logOptions)
 
 	// ensure BoM and Providers prerequisite files are extracted if missing
 	err = ensureBoMandProvidersPrerequisite(options.ConfigDir, allClients.TKGConfigUpdaterClient, options.ForceUpdateTKGCompatibilityImage)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to ensure prerequisites")
 	}
-	// Set default BOM name to the config variables to use during template generation
-	defaultBoMFileName, err := allClients.TKGBomClient.GetDefaultBoMFileName()
-	if err != nil {
-		return nil, errors.Wrap(err, "unable to get default BOM file name")
+	if err := setDefaultBoMFile(allClients); err != nil {
+		return nil, err
 	}
-	allClients.ConfigClient.TKGConfigReaderWriter().Set(constants.ConfigVariableDefaultBomFile, defaultBoMFileName)
 
 	return &tkgctl{
 		configDir:                options.ConfigDir,
@@ -163,9 +163,56 @@ func New(options Options) (TKGClient, error) { //nolint:gocritic
 		tkgClient:                tkgClient,
 		providerGetter:           options.ProviderGetter,
 		tkgConfigReaderWriter:    allClients.ConfigClient.TKGConfigReaderWriter(),
+		tkgClientFactory: func() (client.Client, error) {
+			tkgClient, allClients, err := newTKGClient(appConfig, clusterKubeConfig)
+			if err != nil {
+				return nil, err
+			}
+			if err := setDefaultBoMFile(allClients); err != nil {
+				return nil, err
+			}
+			return tkgClient, nil
+		},
 	}, nil
 }
 
+// newTKGClient creates a tkg client and the clients it uses, all sharing a new configuration reader writer
+func newTKGClient(appConfig types.AppConfig, clusterKubeConfig *types.ClusterKubeConfig) (*client.TkgClient, clientcreator.Clients, error) { //nolint:gocritic
+	allClients, err := clientcreator.CreateAllClients(appConfig, nil)
+	if err != nil {
+		return nil, clientcreator.Clients{}, err
+	}
+
+	tkgClient, err := client.New(client.Options{
+		ClusterCtlClient:         allClients.ClusterCtlClient,
+		ReaderWriterConfigClient: allClients.ConfigClient,
+		RegionManager:            allClients.RegionManager,
+		TKGConfigDir:             appConfig.TKGConfigDir,
+		Timeout:                  constants.DefaultOperationTimeout,
+		FeaturesClient:           allClients.FeaturesClient,
+		TKGConfigProvidersClient: allClients.TKGConfigProvidersClient,
+		TKGBomClient:             allClients.TKGBomClient,
+		TKGConfigUpdater:         allClients.TKGConfigUpdaterClient,
+		TKGPathsClient:           allClients.TKGConfigPathsClient,
+		ClusterKubeConfig:        clusterKubeConfig,
+		ClusterClientFactory:     clusterclient.NewClusterClientFactory(),
+	})
+	if err != nil {
+		return nil, clientcreator.Clients{}, err
+	}
+	return tkgClient, allClients, nil
+}
+
+// setDefaultBoMFile sets default BOM name to the config variables to use during template generation
+func setDefaultBoMFile(allClients clientcreator.Clients) error { //nolint:gocritic
+	defaultBoMFileName, err := allClients.TKGBomClient.GetDefaultBoMFileName()
+	if err != nil {
+		return errors.Wrap(err, "unable to get default BOM file name")
+	}
+	allClients.ConfigClient.TKGConfigReaderWriter().Set(constants.ConfigVariableDefaultBomFile, defaultBoMFileName)
+	return nil
+}
+
 func ensureTKGConfigFile(configDir string, providerGetter providerinterface.ProviderInterface) error {
 	var err error
 
@@ -227,6 +274,20 @@ func configureLogging(logOptions LoggingOptions) {
 	}
 	log.QuietMode(logOptions.Quietly)
 	log.SetVerbosity(logOptions.Verbosity)
+	if logOptions.ProgressFormat != "" {
+		log.SetProgressFormat(logOptions.ProgressFormat)
+	}
+	configureLogFileFormat(logOptions)
+}
+
+// configureLogFileFormat sets the format and the rotation of the logfile
+func configureLogFileFormat(logOptions LoggingOptions) { //nolint:gocritic
+	if logOptions.LogFormat != "" {
+		log.SetLogFormat(logOptions.LogFormat)
+	}
+	if logOptions.LogFileMaxSize > 0 {
+		log.SetFileRotation(int64(logOptions.LogFileMaxSize)*bytesPerMegabyte, logOptions.LogFileMaxBackups)
+	}
 }
 
 func getDefaultProviderGetter() providerinterface.ProviderInterface {
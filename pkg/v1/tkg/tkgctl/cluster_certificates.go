@@ -0,0 +1,67 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+)
+
+// ListClusterCertificatesOptions options for listing the certificates of a cluster
+type ListClusterCertificatesOptions struct {
+	ClusterName string
+	Namespace   string
+}
+
+// RotateClusterCertificatesOptions options for rotating the certificates of a cluster
+type RotateClusterCertificatesOptions struct {
+	ClusterName string
+	Namespace   string
+	SkipPrompt  bool
+	Timeout     time.Duration
+}
+
+// ListClusterCertificates returns the certificates of a cluster with their expiry
+func (t *tkgctl) ListClusterCertificates(options ListClusterCertificatesOptions) ([]client.ClusterCertificate, error) {
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+
+	return t.tkgClient.ListClusterCertificates(client.ListClusterCertificatesOptions{
+		ClusterName: options.ClusterName,
+		Namespace:   options.Namespace,
+	})
+}
+
+// RotateClusterCertificates renews the certificates of the control plane nodes of a cluster by rolling out its control plane
+func (t *tkgctl) RotateClusterCertificates(options RotateClusterCertificatesOptions) error {
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+	if options.Timeout == 0 {
+		options.Timeout = constants.DefaultLongRunningOperationTimeout
+	}
+	defer t.restoreAfterSettingTimeout(options.Timeout)()
+
+	if !options.SkipPrompt {
+		if err := askForConfirmation(fmt.Sprintf("Rotating the certificates of cluster '%s' replaces all its control plane machines. Are you sure?", options.ClusterName)); err != nil {
+			return err
+		}
+	}
+
+	err := t.tkgClient.RotateClusterCertificates(client.RotateClusterCertificatesOptions{
+		ClusterName: options.ClusterName,
+		Namespace:   options.Namespace,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Certificates of cluster '%s' rotated\n", options.ClusterName)
+	return nil
+}
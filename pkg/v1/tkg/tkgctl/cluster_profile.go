@@ -0,0 +1,99 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterprofile"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgconfigpaths"
+)
+
+// SaveClusterProfileOptions options for saving a cluster profile
+type SaveClusterProfileOptions struct {
+	Name              string
+	ClusterConfigFile string
+	Overwrite         bool
+}
+
+// SaveClusterProfile saves a cluster configuration file or cluster spec as a named cluster profile
+func (t *tkgctl) SaveClusterProfile(options SaveClusterProfileOptions) (*clusterprofile.Profile, error) {
+	data, err := os.ReadFile(options.ClusterConfigFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read cluster config file")
+	}
+	store, err := t.clusterProfileStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.Save(options.Name, data, options.Overwrite)
+}
+
+// ListClusterProfiles lists the saved cluster profiles
+func (t *tkgctl) ListClusterProfiles() ([]clusterprofile.Profile, error) {
+	store, err := t.clusterProfileStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.List()
+}
+
+// GetClusterProfile returns the content of a saved cluster profile
+func (t *tkgctl) GetClusterProfile(name string) ([]byte, error) {
+	store, err := t.clusterProfileStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.Get(name)
+}
+
+// DeleteClusterProfile deletes a saved cluster profile
+func (t *tkgctl) DeleteClusterProfile(name string) error {
+	store, err := t.clusterProfileStore()
+	if err != nil {
+		return err
+	}
+	return store.Delete(name)
+}
+
+func (t *tkgctl) clusterProfileStore() (*clusterprofile.Store, error) {
+	profilesDir, err := tkgconfigpaths.New(t.configDir).GetTKGClusterProfilesDirectory()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get the cluster profiles directory")
+	}
+	return clusterprofile.NewStore(profilesDir), nil
+}
+
+// ensureCreateClusterConfig merges the configuration of the cluster to create. The config
+// overrides take precedence over the cluster profile or cluster config file, which take
+// precedence over the defaults of the providers.
+func (t *tkgctl) ensureCreateClusterConfig(cc *CreateClusterOptions) error {
+	clusterConfigFile := cc.ClusterConfigFile
+	if cc.Profile != "" {
+		if cc.ClusterConfigFile != "" {
+			return errors.New("a cluster profile and a cluster config file cannot be used together")
+		}
+		store, err := t.clusterProfileStore()
+		if err != nil {
+			return err
+		}
+		if clusterConfigFile, err = store.Path(cc.Profile); err != nil {
+			return err
+		}
+		log.V(3).Infof("using cluster profile '%s' at '%v'", cc.Profile, clusterConfigFile)
+	}
+
+	var err error
+	cc.ClusterConfigFile, err = t.ensureClusterConfigFile(clusterConfigFile)
+	if err != nil {
+		return err
+	}
+	for name, value := range cc.ConfigOverrides {
+		t.TKGConfigReaderWriter().Set(name, value)
+	}
+	return nil
+}
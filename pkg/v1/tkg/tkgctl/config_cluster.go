@@ -17,7 +17,7 @@ import (
 func (t *tkgctl) ConfigCluster(configClusterOption CreateClusterOptions) error {
 	var err error
 
-	configClusterOption.ClusterConfigFile, err = t.ensureClusterConfigFile(configClusterOption.ClusterConfigFile)
+	err = t.ensureCreateClusterConfig(&configClusterOption)
 	if err != nil {
 		return err
 	}
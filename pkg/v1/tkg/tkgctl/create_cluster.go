@@ -45,18 +45,24 @@ type CreateClusterOptions struct {
 	Timeout                     time.Duration
 	// Tanzu edition (either tce or tkg)
 	Edition string
+	// Name of a saved cluster profile to use instead of ClusterConfigFile
+	Profile string
+	// Config variables taking precedence over the cluster config file or profile
+	ConfigOverrides map[string]string
 }
 
 //nolint:gocritic
 // CreateCluster create tkg cluster
-func (t *tkgctl) CreateCluster(cc CreateClusterOptions) error {
+func (t *tkgctl) CreateCluster(cc CreateClusterOptions) (err error) {
+	defer func() {
+		sendErrorEvent(err)
+	}()
+
 	if cc.GenerateOnly {
 		return t.ConfigCluster(cc)
 	}
 
-	var err error
-	cc.ClusterConfigFile, err = t.ensureClusterConfigFile(cc.ClusterConfigFile)
-	if err != nil {
+	if err := t.ensureCreateClusterConfig(&cc); err != nil {
 		return err
 	}
 
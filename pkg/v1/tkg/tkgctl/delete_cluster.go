@@ -20,13 +20,6 @@ type DeleteClustersOptions struct {
 
 // DeleteCluster deletes workload cluster
 func (t *tkgctl) DeleteCluster(options DeleteClustersOptions) error {
-	// if --yes is set, kick off the delete process without waiting for confirmation
-	if !options.SkipPrompt {
-		if err := askForConfirmation(fmt.Sprintf("Deleting workload cluster '%s'. Are you sure?", options.ClusterName)); err != nil {
-			return err
-		}
-	}
-
 	if options.Namespace == "" {
 		options.Namespace = constants.DefaultNamespace
 	}
@@ -36,6 +29,18 @@ func (t *tkgctl) DeleteCluster(options DeleteClustersOptions) error {
 		Namespace:   options.Namespace,
 	}
 
+	// a protected cluster is refused before asking for the confirmation of its deletion
+	if err := t.tkgClient.VerifyWorkloadClusterDeletion(deleteWcOptions); err != nil {
+		return err
+	}
+
+	// if --yes is set, kick off the delete process without waiting for confirmation
+	if !options.SkipPrompt {
+		if err := askForConfirmation(fmt.Sprintf("Deleting workload cluster '%s'. Are you sure?", options.ClusterName)); err != nil {
+			return err
+		}
+	}
+
 	err := t.tkgClient.DeleteWorkloadCluster(deleteWcOptions)
 	if err != nil {
 		return err
@@ -45,3 +50,15 @@ func (t *tkgctl) DeleteCluster(options DeleteClustersOptions) error {
 
 	return nil
 }
+
+// PlanClusterDeletion lists the objects the deletion of the workload cluster would remove, without deleting anything
+func (t *tkgctl) PlanClusterDeletion(options DeleteClustersOptions) (*client.DeleteClusterPlan, error) {
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+
+	return t.tkgClient.PlanWorkloadClusterDeletion(client.DeleteWorkloadClusterOptions{
+		ClusterName: options.ClusterName,
+		Namespace:   options.Namespace,
+	})
+}
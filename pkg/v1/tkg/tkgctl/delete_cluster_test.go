@@ -16,13 +16,19 @@ import (
 
 var _ = Describe("Unit tests for delete cluster", func() {
 	var (
-		ctl       *tkgctl
-		tkgClient = &fakes.Client{}
-		err       error
-		configDir string
-		dcOps     DeleteClustersOptions
+		ctl        *tkgctl
+		tkgClient  = &fakes.Client{}
+		err        error
+		configDir  string
+		dcOps      DeleteClustersOptions
+		skipPrompt bool
 	)
 
+	BeforeEach(func() {
+		skipPrompt = true
+		tkgClient.VerifyWorkloadClusterDeletionReturns(nil)
+	})
+
 	JustBeforeEach(func() {
 		configDir, err = os.MkdirTemp("", "test")
 		err = os.MkdirAll(testingDir, 0o700)
@@ -37,7 +43,7 @@ var _ = Describe("Unit tests for delete cluster", func() {
 		ctl, _ = c.(*tkgctl)
 		ctl.tkgClient = tkgClient
 		dcOps = DeleteClustersOptions{
-			SkipPrompt:  true,
+			SkipPrompt:  skipPrompt,
 			ClusterName: "my-cluster",
 		}
 		err = ctl.DeleteCluster(dcOps)
@@ -59,6 +65,20 @@ var _ = Describe("Unit tests for delete cluster", func() {
 		})
 	})
 
+	Context("When the cluster is protected from deletion", func() {
+		BeforeEach(func() {
+			skipPrompt = false
+			tkgClient.VerifyWorkloadClusterDeletionReturns(errors.New("cluster 'my-cluster' in namespace 'default' is protected from deletion"))
+		})
+		It("should refuse the deletion without asking for confirmation", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is protected from deletion"))
+			options := tkgClient.VerifyWorkloadClusterDeletionArgsForCall(tkgClient.VerifyWorkloadClusterDeletionCallCount() - 1)
+			Expect(options.ClusterName).To(Equal("my-cluster"))
+			Expect(options.Namespace).To(Equal("default"))
+		})
+	})
+
 	AfterEach(func() {
 		os.Remove(configDir)
 	})
@@ -5,8 +5,10 @@ package tkgctl
 
 import (
 	"sort"
+	"time"
 
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/region"
 )
 
 // ListTKGClustersOptions ptions passed while getting a list of TKG Clusters
@@ -16,6 +18,17 @@ type ListTKGClustersOptions struct {
 	IncludeMC   bool
 }
 
+// ListAllTKGClustersOptions options passed while getting a list of TKG Clusters of all the management clusters
+type ListAllTKGClustersOptions struct {
+	Namespace string
+	IncludeMC bool
+	// ManagementClusters are the management clusters to list the clusters of,
+	// all the management clusters of the tkg config are listed if empty
+	ManagementClusters []region.RegionContext
+	// Timeout is the time to wait for the clusters of each management cluster
+	Timeout time.Duration
+}
+
 // GetClusters returns list of cluster
 func (t *tkgctl) GetClusters(options ListTKGClustersOptions) ([]client.ClusterInfo, error) {
 	listTKGClustersOptions := client.ListTKGClustersOptions{
@@ -28,7 +41,37 @@ func (t *tkgctl) GetClusters(options ListTKGClustersOptions) ([]client.ClusterIn
 		return nil, err
 	}
 
+	sortClusters(clusters)
+	return clusters, nil
+}
+
+// GetClustersInAllManagementClusters returns list of cluster of all the management clusters, and the management
+// clusters whose clusters could not be listed
+func (t *tkgctl) GetClustersInAllManagementClusters(options ListAllTKGClustersOptions) ([]client.ClusterInfo, []client.UnreachableRegion, error) {
+	listOptions := client.ListTKGClustersInAllRegionsOptions{
+		ListTKGClustersOptions: client.ListTKGClustersOptions{
+			Namespace: options.Namespace,
+			IncludeMC: options.IncludeMC,
+		},
+		Regions: options.ManagementClusters,
+		Timeout: options.Timeout,
+	}
+
+	clusters, unreachable, err := t.tkgClient.ListTKGClustersInAllRegions(listOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sortClusters(clusters)
+	return clusters, unreachable, nil
+}
+
+// sortClusters sorts the clusters by management cluster, namespace and name
+func sortClusters(clusters []client.ClusterInfo) {
 	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].ManagementCluster != clusters[j].ManagementCluster {
+			return clusters[i].ManagementCluster < clusters[j].ManagementCluster
+		}
 		if clusters[i].Namespace < clusters[j].Namespace {
 			return true
 		}
@@ -37,6 +80,4 @@ func (t *tkgctl) GetClusters(options ListTKGClustersOptions) ([]client.ClusterIn
 		}
 		return clusters[i].Name < clusters[j].Name
 	})
-
-	return clusters, nil
 }
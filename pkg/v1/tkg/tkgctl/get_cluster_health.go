@@ -0,0 +1,27 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+)
+
+// GetClusterHealthOptions options for checking the health of a cluster
+type GetClusterHealthOptions struct {
+	ClusterName string
+	Namespace   string
+}
+
+// GetClusterHealth returns the health report of a workload cluster
+func (t *tkgctl) GetClusterHealth(options GetClusterHealthOptions) (*client.ClusterHealthReport, error) {
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+
+	return t.tkgClient.GetClusterHealth(client.GetClusterHealthOptions{
+		ClusterName: options.ClusterName,
+		Namespace:   options.Namespace,
+	})
+}
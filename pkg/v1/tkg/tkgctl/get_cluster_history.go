@@ -0,0 +1,27 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+)
+
+// GetClusterHistoryOptions options for getting the operation history of a cluster
+type GetClusterHistoryOptions struct {
+	ClusterName string
+	Namespace   string
+}
+
+// GetClusterHistory returns the lifecycle operations recorded on a workload or management cluster, oldest first
+func (t *tkgctl) GetClusterHistory(options GetClusterHistoryOptions) ([]client.OperationRecord, error) {
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+
+	return t.tkgClient.GetClusterOperationHistory(client.GetClusterOperationHistoryOptions{
+		ClusterName: options.ClusterName,
+		Namespace:   options.Namespace,
+	})
+}
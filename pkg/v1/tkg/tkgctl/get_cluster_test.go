@@ -48,3 +48,50 @@ var _ = Describe("Unit test for get clusters", func() {
 		})
 	})
 })
+
+var _ = Describe("Unit test for get clusters of all the management clusters", func() {
+	var (
+		ctl         tkgctl
+		tkgClient   *fakes.Client
+		clusters    []client.ClusterInfo
+		unreachable []client.UnreachableRegion
+		err         error
+	)
+
+	BeforeEach(func() {
+		tkgClient = &fakes.Client{}
+	})
+
+	JustBeforeEach(func() {
+		ctl = tkgctl{
+			configDir:  testingDir,
+			tkgClient:  tkgClient,
+			kubeconfig: "./kube",
+		}
+		clusters, unreachable, err = ctl.GetClustersInAllManagementClusters(ListAllTKGClustersOptions{Namespace: "default"})
+	})
+
+	Context("when failed to get the management clusters", func() {
+		BeforeEach(func() {
+			tkgClient.ListTKGClustersInAllRegionsReturns(nil, nil, errors.New("failed to list management clusters"))
+		})
+		It("should return an error", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	Context("when some management clusters are unreachable", func() {
+		BeforeEach(func() {
+			tkgClient.ListTKGClustersInAllRegionsReturns([]client.ClusterInfo{
+				{Name: "my-cluster", Namespace: "default", ManagementCluster: "mc-2"},
+				{Name: "my-cluster", Namespace: "default", ManagementCluster: "mc-1"},
+			}, []client.UnreachableRegion{{ManagementCluster: "mc-3", Error: "connection refused"}}, nil)
+		})
+		It("should return the clusters sorted by management cluster and the unreachable management clusters", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(clusters[0].ManagementCluster).To(Equal("mc-1"))
+			Expect(clusters[1].ManagementCluster).To(Equal("mc-2"))
+			Expect(unreachable).To(HaveLen(1))
+			Expect(tkgClient.ListTKGClustersInAllRegionsArgsForCall(0).Namespace).To(Equal("default"))
+		})
+	})
+})
@@ -9,6 +9,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterspec"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgconfigpaths"
@@ -89,9 +90,8 @@ func (t *tkgctl) ensureClusterConfigFile(clusterConfigFile string) (string, erro
 		}
 	}
 
-	// read cluster config file with tkgConfigReaderWriter and merge it into existing configuration
-	if err := t.TKGConfigReaderWriter().MergeInConfig(clusterConfigFile); err != nil {
-		return "", errors.Wrap(err, "error initializing cluster config")
+	if err := t.mergeClusterConfigFile(clusterConfigFile); err != nil {
+		return "", err
 	}
 
 	// decode credentials in viper after reading config file
@@ -101,3 +101,28 @@ func (t *tkgctl) ensureClusterConfigFile(clusterConfigFile string) (string, erro
 	}
 	return clusterConfigFile, nil
 }
+
+// mergeClusterConfigFile merges the cluster config file into the existing configuration.
+// A cluster spec file is translated into the config variables used by the cluster templates.
+func (t *tkgctl) mergeClusterConfigFile(clusterConfigFile string) error {
+	data, err := os.ReadFile(clusterConfigFile)
+	if err != nil {
+		return errors.Wrap(err, "unable to read cluster config file")
+	}
+	if !clusterspec.IsClusterSpec(data) {
+		// read cluster config file with tkgConfigReaderWriter and merge it into existing configuration
+		if err := t.TKGConfigReaderWriter().MergeInConfig(clusterConfigFile); err != nil {
+			return errors.Wrap(err, "error initializing cluster config")
+		}
+		return nil
+	}
+
+	spec, err := clusterspec.Parse(data)
+	if err != nil {
+		return errors.Wrapf(err, "invalid cluster spec file '%s'", clusterConfigFile)
+	}
+	for name, value := range spec.ToConfigVariables() {
+		t.TKGConfigReaderWriter().Set(name, value)
+	}
+	return nil
+}
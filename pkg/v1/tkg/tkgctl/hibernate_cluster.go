@@ -0,0 +1,73 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+)
+
+// HibernateClusterOptions options for hibernating a cluster
+type HibernateClusterOptions struct {
+	ClusterName string
+	Namespace   string
+	SkipPrompt  bool
+}
+
+// ResumeClusterOptions options for resuming a hibernated cluster
+type ResumeClusterOptions struct {
+	ClusterName string
+	Namespace   string
+	Timeout     time.Duration
+}
+
+// HibernateCluster scales the workers of a cluster to zero and pauses its cluster autoscaler
+func (t *tkgctl) HibernateCluster(options HibernateClusterOptions) error {
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+
+	if !options.SkipPrompt {
+		if err := askForConfirmation(fmt.Sprintf("Hibernating cluster '%s' deletes all its worker machines until it is resumed. Are you sure?", options.ClusterName)); err != nil {
+			return err
+		}
+	}
+
+	err := t.tkgClient.HibernateCluster(client.HibernateClusterOptions{
+		ClusterName: options.ClusterName,
+		Namespace:   options.Namespace,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Workload cluster '%s' is being hibernated\n", options.ClusterName)
+	return nil
+}
+
+// ResumeCluster restores the workers and the cluster autoscaler of a hibernated cluster and waits for its nodes to be ready
+func (t *tkgctl) ResumeCluster(options ResumeClusterOptions) error {
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+	if options.Timeout == 0 {
+		options.Timeout = constants.DefaultLongRunningOperationTimeout
+	}
+	defer t.restoreAfterSettingTimeout(options.Timeout)()
+
+	err := t.tkgClient.ResumeCluster(client.ResumeClusterOptions{
+		ClusterName: options.ClusterName,
+		Namespace:   options.Namespace,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Workload cluster '%s' resumed\n", options.ClusterName)
+	return nil
+}
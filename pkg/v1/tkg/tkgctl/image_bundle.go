@@ -0,0 +1,60 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+)
+
+// ExportImageBundleOptions options for exporting the images of a TKr to an image bundle
+type ExportImageBundleOptions struct {
+	TkrVersion string
+	File       string
+}
+
+// ImportImageBundleOptions options for importing an image bundle to a private registry
+type ImportImageBundleOptions struct {
+	File       string
+	Repository string
+	SkipPrompt bool
+}
+
+// ExportImageBundle writes the images of the TKG BOM and of the BOM of the TKr, along with the BOM, compatibility
+// and provider files, to an image bundle
+func (t *tkgctl) ExportImageBundle(options ExportImageBundleOptions) error {
+	if options.File == "" {
+		return errors.New("the file to export the image bundle to is required")
+	}
+	return t.tkgClient.ExportImageBundle(client.ExportImageBundleOptions{
+		TKRVersion: options.TkrVersion,
+		File:       options.File,
+	})
+}
+
+// ImportImageBundle pushes the images of an image bundle to a private registry, and writes its BOM files, with
+// their image references rewritten to the registry, and its compatibility and provider files to the config directory
+func (t *tkgctl) ImportImageBundle(options ImportImageBundleOptions) error {
+	if options.File == "" {
+		return errors.New("the file to import the image bundle from is required")
+	}
+	if options.Repository == "" {
+		return errors.New("the image repository to push the images to is required")
+	}
+
+	if !options.SkipPrompt {
+		if err := askForConfirmation(fmt.Sprintf("Importing '%s' overwrites the BOM, compatibility and provider files it holds in the TKG "+
+			"configuration directory. Are you sure?", options.File)); err != nil {
+			return err
+		}
+	}
+
+	return t.tkgClient.ImportImageBundle(client.ImportImageBundleOptions{
+		File:       options.File,
+		Repository: options.Repository,
+	})
+}
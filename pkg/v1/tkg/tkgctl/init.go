@@ -51,8 +51,11 @@ type InitRegionOptions struct {
 
 //nolint:gocritic,gocyclo,funlen
 // Init initializes tkg management cluster
-func (t *tkgctl) Init(options InitRegionOptions) error {
-	var err error
+func (t *tkgctl) Init(options InitRegionOptions) (err error) {
+	defer func() {
+		sendErrorEvent(err)
+	}()
+
 	options.ClusterConfigFile, err = t.ensureClusterConfigFile(options.ClusterConfigFile)
 	if err != nil {
 		return err
@@ -9,7 +9,9 @@ import (
 
 	runv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/run/v1alpha1"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterprofile"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/region"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgconfigschema"
 )
 
 // TKGClient implements TKG client
@@ -18,12 +20,38 @@ type TKGClient interface {
 	AddRegion(options AddRegionOptions) error
 	// ConfigCluster prints cluster template to stdout
 	ConfigCluster(configClusterOption CreateClusterOptions) error
+	// ValidateClusterConfig validates a cluster configuration file against the config variable schema
+	ValidateClusterConfig(options ValidateClusterConfigOptions) ([]tkgconfigschema.Issue, error)
+	// GetClusterConfigSchema returns the schema of the config variables supported by the providers
+	GetClusterConfigSchema() (*tkgconfigschema.Schema, error)
+	// SaveClusterProfile saves a cluster configuration file as a named cluster profile
+	SaveClusterProfile(options SaveClusterProfileOptions) (*clusterprofile.Profile, error)
+	// ListClusterProfiles lists the saved cluster profiles
+	ListClusterProfiles() ([]clusterprofile.Profile, error)
+	// GetClusterProfile returns the content of a saved cluster profile
+	GetClusterProfile(name string) ([]byte, error)
+	// DeleteClusterProfile deletes a saved cluster profile
+	DeleteClusterProfile(name string) error
+	// BackupRegion exports the objects of the workload clusters of the management cluster to an archive
+	BackupRegion(options BackupRegionOptions) error
 	// CreateAWSCloudFormationStack create aws cloud formation stack
 	CreateAWSCloudFormationStack(clusterConfigFile string) error
 	// CreateCluster create tkg cluster
 	CreateCluster(cc CreateClusterOptions) error
 	// DeleteCluster deletes workload cluster
 	DeleteCluster(options DeleteClustersOptions) error
+	// PlanClusterDeletion lists the objects the deletion of the workload cluster would remove
+	PlanClusterDeletion(options DeleteClustersOptions) (*client.DeleteClusterPlan, error)
+	// SetClusterDeletionProtection enables or disables the deletion protection of a cluster
+	SetClusterDeletionProtection(options SetClusterDeletionProtectionOptions) error
+	// GetClusterHistory returns the lifecycle operations recorded on a cluster
+	GetClusterHistory(options GetClusterHistoryOptions) ([]client.OperationRecord, error)
+	// GetClusterHealth returns the health report of a workload cluster
+	GetClusterHealth(options GetClusterHealthOptions) (*client.ClusterHealthReport, error)
+	// ListClusterCertificates returns the certificates of a cluster with their expiry
+	ListClusterCertificates(options ListClusterCertificatesOptions) ([]client.ClusterCertificate, error)
+	// RotateClusterCertificates renews the certificates of the control plane nodes of a cluster
+	RotateClusterCertificates(options RotateClusterCertificatesOptions) error
 	// DeleteMachineHealthCheck deletes MHC on cluster
 	DeleteMachineHealthCheck(options DeleteMachineHealthCheckOptions) error
 	// DeleteRegion deletes management cluster
@@ -34,6 +62,9 @@ type TKGClient interface {
 	GetCEIP() (client.ClusterCeipInfo, error)
 	// GetClusters returns list of cluster
 	GetClusters(options ListTKGClustersOptions) ([]client.ClusterInfo, error)
+	// GetClustersInAllManagementClusters returns list of cluster of all the management clusters,
+	// and the management clusters whose clusters could not be listed
+	GetClustersInAllManagementClusters(options ListAllTKGClustersOptions) ([]client.ClusterInfo, []client.UnreachableRegion, error)
 	// DescribeCluster describes all the objects in the Cluster
 	DescribeCluster(options DescribeTKGClustersOptions) (DescribeClusterResult, error)
 	// DescribeProviders describes all the installed providers
@@ -52,6 +83,10 @@ type TKGClient interface {
 	RegisterWithTmc(options RegisterOptions) error
 	// ScaleCluster scales cluster
 	ScaleCluster(options ScaleClusterOptions) error
+	// HibernateCluster scales the workers of a cluster to zero and pauses its cluster autoscaler
+	HibernateCluster(options HibernateClusterOptions) error
+	// ResumeCluster restores the workers and the cluster autoscaler of a hibernated cluster
+	ResumeCluster(options ResumeClusterOptions) error
 	// SetCeip sets CEIP to the management cluster
 	SetCeip(ceipOptIn, isProd, labels string) error
 	// SetMachineHealthCheck apply machine health check to the cluster
@@ -66,6 +101,24 @@ type TKGClient interface {
 	SetRegion(options SetRegionOptions) error
 	// UpgradeCluster upgrade tkg workload cluster
 	UpgradeCluster(options UpgradeClusterOptions) error
+	// UpgradeClusters upgrades the tkg workload clusters matching a label selector in waves
+	UpgradeClusters(options UpgradeClustersOptions) ([]ClusterUpgradeResult, error)
+	// PlanClusterUpgrade computes the changes an upgrade of the tkg workload cluster would make
+	PlanClusterUpgrade(options UpgradeClusterOptions) (*client.UpgradeClusterPlan, error)
+	// GetClusterUpgradeStatus returns the status of the last upgrade of the tkg workload cluster
+	GetClusterUpgradeStatus(options UpgradeClusterOptions) (*client.UpgradeStatus, error)
+	// MoveRegion moves the workload clusters of the management cluster to an existing cluster which becomes the management cluster
+	MoveRegion(options MoveRegionOptions) error
+	// ExportImageBundle writes the images of a TKr and the BOM, compatibility and provider files to an image bundle
+	ExportImageBundle(options ExportImageBundleOptions) error
+	// ImportImageBundle pushes the images of an image bundle to a private registry and rewrites the image references of its BOM files
+	ImportImageBundle(options ImportImageBundleOptions) error
+	// PreflightRegion runs the preflight checks of a management cluster and reports their results
+	PreflightRegion(options PreflightRegionOptions) (*client.PreflightReport, error)
+	// PreflightCluster runs the preflight checks of a workload cluster and reports their results
+	PreflightCluster(options PreflightClusterOptions) (*client.PreflightReport, error)
+	// RestoreRegion creates the objects of a management cluster backup on the management cluster
+	RestoreRegion(options RestoreRegionOptions) error
 	// UpgradeRegion upgrades management cluster
 	UpgradeRegion(options UpgradeRegionOptions) error
 	// Updates management cluster
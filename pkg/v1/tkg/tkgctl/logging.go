@@ -0,0 +1,71 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgconfigreaderwriter"
+)
+
+const bytesPerMegabyte = 1024 * 1024
+
+// ValidateLoggingOptions returns an error if the log format or the log file rotation settings are not supported
+func ValidateLoggingOptions(options LoggingOptions) error {
+	switch options.LogFormat {
+	case "", log.LogFormatText, log.LogFormatJSON:
+	default:
+		return errors.Errorf("unsupported log format '%s', supported formats are '%s' and '%s'", options.LogFormat, log.LogFormatText, log.LogFormatJSON)
+	}
+	if options.LogFileMaxSize < 0 {
+		return errors.Errorf("invalid log file max size %d, the size must be 0 or more megabytes", options.LogFileMaxSize)
+	}
+	if options.LogFileMaxBackups < 0 {
+		return errors.Errorf("invalid log file max backups %d, the number of backups must be 0 or more", options.LogFileMaxBackups)
+	}
+	return nil
+}
+
+// loggingOptionsFromConfig returns the logging options with the log format and the log file rotation
+// settings that are not set read from the TKG_LOG_FORMAT, TKG_LOG_FILE_MAX_SIZE and TKG_LOG_FILE_MAX_BACKUPS
+// config variables, so the options given by flag take precedence over the config
+func loggingOptionsFromConfig(options LoggingOptions, readerWriter tkgconfigreaderwriter.TKGConfigReaderWriter) (LoggingOptions, error) { //nolint:gocritic
+	if options.LogFormat == "" {
+		if format, err := readerWriter.Get(constants.ConfigVariableLogFormat); err == nil {
+			options.LogFormat = format
+		}
+	}
+	if options.LogFileMaxSize == 0 {
+		maxSize, err := getIntConfigVariable(readerWriter, constants.ConfigVariableLogFileMaxSize)
+		if err != nil {
+			return options, err
+		}
+		options.LogFileMaxSize = maxSize
+	}
+	if options.LogFileMaxBackups == 0 {
+		maxBackups, err := getIntConfigVariable(readerWriter, constants.ConfigVariableLogFileMaxBackups)
+		if err != nil {
+			return options, err
+		}
+		options.LogFileMaxBackups = maxBackups
+	}
+	return options, ValidateLoggingOptions(options)
+}
+
+// getIntConfigVariable returns the integer value of the config variable, or 0 if it is not set
+func getIntConfigVariable(readerWriter tkgconfigreaderwriter.TKGConfigReaderWriter, name string) (int, error) {
+	value, err := readerWriter.Get(name)
+	if err != nil || value == "" {
+		return 0, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.Errorf("invalid value '%s' for %s, the value must be an integer", value, name)
+	}
+	return i, nil
+}
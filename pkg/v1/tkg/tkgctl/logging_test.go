@@ -0,0 +1,72 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgconfigreaderwriter"
+)
+
+var _ = Describe("ValidateLoggingOptions", func() {
+	It("accepts the text and json log formats", func() {
+		Expect(ValidateLoggingOptions(LoggingOptions{})).To(Succeed())
+		Expect(ValidateLoggingOptions(LoggingOptions{LogFormat: log.LogFormatText})).To(Succeed())
+		Expect(ValidateLoggingOptions(LoggingOptions{LogFormat: log.LogFormatJSON, LogFileMaxSize: 10, LogFileMaxBackups: 3})).To(Succeed())
+	})
+	It("rejects other log formats", func() {
+		Expect(ValidateLoggingOptions(LoggingOptions{LogFormat: "yaml"})).To(MatchError("unsupported log format 'yaml', supported formats are 'text' and 'json'"))
+	})
+	It("rejects negative rotation settings", func() {
+		Expect(ValidateLoggingOptions(LoggingOptions{LogFileMaxSize: -1})).To(MatchError("invalid log file max size -1, the size must be 0 or more megabytes"))
+		Expect(ValidateLoggingOptions(LoggingOptions{LogFileMaxSize: 10, LogFileMaxBackups: -1})).To(MatchError("invalid log file max backups -1, the number of backups must be 0 or more"))
+	})
+})
+
+var _ = Describe("loggingOptionsFromConfig", func() {
+	var readerWriter tkgconfigreaderwriter.TKGConfigReaderWriter
+
+	BeforeEach(func() {
+		var err error
+		readerWriter, err = tkgconfigreaderwriter.NewReaderWriterFromConfigFile("../fakes/config/config.yaml", "../fakes/config/config.yaml")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("keeps the options when the config variables are not set", func() {
+		options, err := loggingOptionsFromConfig(LoggingOptions{File: "tkg.log", LogFormat: log.LogFormatJSON}, readerWriter)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(options).To(Equal(LoggingOptions{File: "tkg.log", LogFormat: log.LogFormatJSON}))
+	})
+	It("reads the options that are not set from the config variables", func() {
+		readerWriter.Set(constants.ConfigVariableLogFormat, log.LogFormatJSON)
+		readerWriter.Set(constants.ConfigVariableLogFileMaxSize, "10")
+		readerWriter.Set(constants.ConfigVariableLogFileMaxBackups, "3")
+
+		options, err := loggingOptionsFromConfig(LoggingOptions{File: "tkg.log"}, readerWriter)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(options).To(Equal(LoggingOptions{File: "tkg.log", LogFormat: log.LogFormatJSON, LogFileMaxSize: 10, LogFileMaxBackups: 3}))
+	})
+	It("gives precedence to the options over the config variables", func() {
+		readerWriter.Set(constants.ConfigVariableLogFormat, log.LogFormatJSON)
+		readerWriter.Set(constants.ConfigVariableLogFileMaxSize, "10")
+		readerWriter.Set(constants.ConfigVariableLogFileMaxBackups, "3")
+
+		options, err := loggingOptionsFromConfig(LoggingOptions{LogFormat: log.LogFormatText, LogFileMaxSize: 20, LogFileMaxBackups: 5}, readerWriter)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(options).To(Equal(LoggingOptions{LogFormat: log.LogFormatText, LogFileMaxSize: 20, LogFileMaxBackups: 5}))
+	})
+	It("rejects invalid config variables", func() {
+		readerWriter.Set(constants.ConfigVariableLogFileMaxSize, "ten")
+		_, err := loggingOptionsFromConfig(LoggingOptions{}, readerWriter)
+		Expect(err).To(MatchError("invalid value 'ten' for TKG_LOG_FILE_MAX_SIZE, the value must be an integer"))
+
+		readerWriter.Set(constants.ConfigVariableLogFileMaxSize, "10")
+		readerWriter.Set(constants.ConfigVariableLogFormat, "yaml")
+		_, err = loggingOptionsFromConfig(LoggingOptions{}, readerWriter)
+		Expect(err).To(MatchError("unsupported log format 'yaml', supported formats are 'text' and 'json'"))
+	})
+})
@@ -0,0 +1,56 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+)
+
+// MoveRegionOptions options for moving a management cluster to an existing cluster
+type MoveRegionOptions struct {
+	ClusterName   string
+	ToKubeconfig  string
+	ToContext     string
+	ToClusterName string
+	SkipPrompt    bool
+	Timeout       time.Duration
+}
+
+// MoveRegion moves the workload clusters of the current management cluster to an existing cluster,
+// which becomes the management cluster
+func (t *tkgctl) MoveRegion(options MoveRegionOptions) error {
+	if options.ToKubeconfig == "" {
+		return errors.New("the kubeconfig of the cluster to move the management cluster to is required")
+	}
+	if options.Timeout == 0 {
+		options.Timeout = constants.DefaultLongRunningOperationTimeout
+	}
+	defer t.restoreAfterSettingTimeout(options.Timeout)()
+
+	if !options.SkipPrompt {
+		if err := askForConfirmation(fmt.Sprintf("Moving management cluster '%s' installs Cluster API providers on the target cluster "+
+			"and moves all its workload clusters to it. Are you sure?", options.ClusterName)); err != nil {
+			return err
+		}
+	}
+
+	err := t.tkgClient.MoveManagementCluster(client.MoveManagementClusterOptions{
+		Kubeconfig:  options.ToKubeconfig,
+		Context:     options.ToContext,
+		ClusterName: options.ToClusterName,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Management cluster '%s' moved\n", options.ClusterName)
+	return nil
+}
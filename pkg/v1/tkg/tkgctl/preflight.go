@@ -0,0 +1,95 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"strconv"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+)
+
+// PreflightRegionOptions options for running the preflight checks of a management cluster
+type PreflightRegionOptions struct {
+	ClusterConfigFile  string
+	UseExistingCluster bool
+	Edition            string
+	SkipChecks         []string
+}
+
+// PreflightClusterOptions options for running the preflight checks of a workload cluster
+type PreflightClusterOptions struct {
+	ClusterConfigFile string
+	Profile           string
+	ConfigOverrides   map[string]string
+	ClusterName       string
+	Namespace         string
+	TkrVersion        string
+	Edition           string
+	SkipChecks        []string
+}
+
+// PreflightRegion runs the preflight checks of the management cluster described by the cluster config file
+func (t *tkgctl) PreflightRegion(options PreflightRegionOptions) (*client.PreflightReport, error) {
+	iro := InitRegionOptions{
+		ClusterConfigFile:  options.ClusterConfigFile,
+		UseExistingCluster: options.UseExistingCluster,
+		Edition:            options.Edition,
+	}
+
+	var err error
+	iro.ClusterConfigFile, err = t.ensureClusterConfigFile(iro.ClusterConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureConfigImages(t.configDir, t.tkgConfigUpdaterClient); err != nil {
+		return nil, err
+	}
+	iro.CoreProvider, iro.BootstrapProvider, iro.ControlPlaneProvider, err = t.tkgBomClient.GetDefaultClusterAPIProviders()
+	if err != nil {
+		return nil, err
+	}
+	if err := t.configureInitManagementClusterOptionsFromConfigFile(&iro); err != nil {
+		return nil, err
+	}
+
+	ceipOptIn, err := strconv.ParseBool(iro.CeipOptIn)
+	if err != nil {
+		ceipOptIn = false
+	}
+	nodeSizeOptions := client.NodeSizeOptions{
+		Size:             iro.Size,
+		ControlPlaneSize: iro.ControlPlaneSize,
+		WorkerSize:       iro.WorkerSize,
+	}
+	optionsIR := t.populateClientInitRegionOptions(&iro, nodeSizeOptions, ceipOptIn)
+
+	return t.tkgClient.RunManagementClusterPreflight(&optionsIR, options.SkipChecks)
+}
+
+// PreflightCluster runs the preflight checks of the workload cluster described by the cluster config file or profile
+func (t *tkgctl) PreflightCluster(options PreflightClusterOptions) (*client.PreflightReport, error) {
+	cc := CreateClusterOptions{
+		ClusterConfigFile: options.ClusterConfigFile,
+		Profile:           options.Profile,
+		ConfigOverrides:   options.ConfigOverrides,
+		ClusterName:       options.ClusterName,
+		Namespace:         options.Namespace,
+		TkrVersion:        options.TkrVersion,
+		Edition:           options.Edition,
+	}
+	if err := t.ensureCreateClusterConfig(&cc); err != nil {
+		return nil, err
+	}
+	if err := t.configureCreateClusterOptionsFromConfigFile(&cc); err != nil {
+		return nil, err
+	}
+
+	clusterOptions, err := t.getCreateClusterOptions(cc.ClusterName, &cc)
+	if err != nil {
+		return nil, err
+	}
+	clusterOptions.TKRVersion = cc.TkrVersion
+
+	return t.tkgClient.RunClusterPreflight(&clusterOptions, options.SkipChecks)
+}
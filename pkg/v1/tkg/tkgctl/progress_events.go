@@ -0,0 +1,43 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+)
+
+// ValidateProgressFormat returns an error if the progress format is not supported
+func ValidateProgressFormat(format string) error {
+	switch format {
+	case "", log.ProgressFormatText, log.ProgressFormatJSON:
+		return nil
+	default:
+		return errors.Errorf("unsupported progress format '%s', supported formats are '%s' and '%s'", format, log.ProgressFormatText, log.ProgressFormatJSON)
+	}
+}
+
+// sendErrorEvent sends the error event of the failed operation, classified by its code
+func sendErrorEvent(err error) {
+	if err != nil {
+		log.SendErrorEvent(errorEventCode(err), err)
+	}
+}
+
+func errorEventCode(err error) string {
+	var validationErr *client.ValidationError
+	if errors.As(err, &validationErr) {
+		return log.EventErrorCodeValidation
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, wait.ErrWaitTimeout) || strings.Contains(err.Error(), "timed out") {
+		return log.EventErrorCodeTimeout
+	}
+	return log.EventErrorCodeOperation
+}
@@ -0,0 +1,41 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+)
+
+var _ = Describe("Progress events", func() {
+	Describe("ValidateProgressFormat", func() {
+		It("accepts the text and json formats", func() {
+			Expect(ValidateProgressFormat("")).To(Succeed())
+			Expect(ValidateProgressFormat(log.ProgressFormatText)).To(Succeed())
+			Expect(ValidateProgressFormat(log.ProgressFormatJSON)).To(Succeed())
+		})
+		It("rejects other formats", func() {
+			Expect(ValidateProgressFormat("yaml")).To(MatchError("unsupported progress format 'yaml', supported formats are 'text' and 'json'"))
+		})
+	})
+
+	Describe("errorEventCode", func() {
+		It("classifies validation errors", func() {
+			err := errors.Wrap(client.NewValidationError(client.ValidationErrorCode, "invalid cluster name"), "validation failed")
+			Expect(errorEventCode(err)).To(Equal(log.EventErrorCodeValidation))
+		})
+		It("classifies timeouts", func() {
+			Expect(errorEventCode(errors.Wrap(wait.ErrWaitTimeout, "unable to wait for cluster"))).To(Equal(log.EventErrorCodeTimeout))
+			Expect(errorEventCode(errors.New("timed out waiting for upgrade to complete"))).To(Equal(log.EventErrorCodeTimeout))
+		})
+		It("classifies other errors as operation failures", func() {
+			Expect(errorEventCode(errors.New("unable to apply cluster configuration"))).To(Equal(log.EventErrorCodeOperation))
+		})
+	})
+})
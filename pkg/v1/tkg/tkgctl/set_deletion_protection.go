@@ -0,0 +1,40 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+)
+
+// SetClusterDeletionProtectionOptions options for setting the deletion protection of a cluster
+type SetClusterDeletionProtectionOptions struct {
+	ClusterName string
+	Namespace   string
+	Enabled     bool
+}
+
+// SetClusterDeletionProtection enables or disables the deletion protection of a workload or management cluster
+func (t *tkgctl) SetClusterDeletionProtection(options SetClusterDeletionProtectionOptions) error {
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+
+	err := t.tkgClient.SetClusterDeletionProtection(client.SetClusterDeletionProtectionOptions{
+		ClusterName: options.ClusterName,
+		Namespace:   options.Namespace,
+		Enabled:     options.Enabled,
+	})
+	if err != nil {
+		return err
+	}
+
+	if options.Enabled {
+		log.Infof("Deletion protection of cluster '%s' enabled\n", options.ClusterName)
+	} else {
+		log.Infof("Deletion protection of cluster '%s' disabled\n", options.ClusterName)
+	}
+	return nil
+}
@@ -35,9 +35,10 @@ type UpgradeClusterOptions struct {
 
 //nolint:gocritic
 // UpgradeCluster upgrade tkg workload cluster
-func (t *tkgctl) UpgradeCluster(options UpgradeClusterOptions) error {
-	var err error
-	var k8sVersion string
+func (t *tkgctl) UpgradeCluster(options UpgradeClusterOptions) (err error) {
+	defer func() {
+		sendErrorEvent(err)
+	}()
 
 	// upgrade requires minimum 15 minutes timeout
 	minTimeoutReq := 15 * time.Minute
@@ -47,9 +48,60 @@ func (t *tkgctl) UpgradeCluster(options UpgradeClusterOptions) error {
 	}
 	defer t.restoreAfterSettingTimeout(options.Timeout)()
 
+	upgradeClusterOption, err := t.getUpgradeClusterOptions(options)
+	if err != nil {
+		return err
+	}
+
+	// if --yes is set, kick off the upgrade process without waiting for confirmation
+	if !options.SkipPrompt {
+		if err := askForConfirmation(fmt.Sprintf("Upgrading workload cluster '%s' to kubernetes version '%s'. Are you sure?", options.ClusterName, upgradeClusterOption.KubernetesVersion)); err != nil {
+			return err
+		}
+	}
+
+	err = t.tkgClient.UpgradeCluster(upgradeClusterOption)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Cluster '%s' successfully upgraded to kubernetes version '%s'\n", upgradeClusterOption.ClusterName, upgradeClusterOption.KubernetesVersion)
+	return nil
+}
+
+//nolint:gocritic
+// PlanClusterUpgrade computes the changes the upgrade of a tkg workload cluster would make without changing anything
+func (t *tkgctl) PlanClusterUpgrade(options UpgradeClusterOptions) (*client.UpgradeClusterPlan, error) {
+	upgradeClusterOption, err := t.getUpgradeClusterOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	return t.tkgClient.PlanClusterUpgrade(upgradeClusterOption)
+}
+
+//nolint:gocritic
+// GetClusterUpgradeStatus returns the status of the last upgrade of a tkg workload cluster,
+// or nil if the cluster was never upgraded
+func (t *tkgctl) GetClusterUpgradeStatus(options UpgradeClusterOptions) (*client.UpgradeStatus, error) {
+	if options.Namespace == "" {
+		options.Namespace = constants.DefaultNamespace
+	}
+	return t.tkgClient.GetClusterUpgradeStatus(&client.UpgradeClusterOptions{
+		ClusterName: options.ClusterName,
+		Namespace:   options.Namespace,
+		Kubeconfig:  t.kubeconfig,
+	})
+}
+
+//nolint:gocritic
+// getUpgradeClusterOptions resolves the TKr and kubernetes version of the upgrade, downloading the TKr BOM if needed
+func (t *tkgctl) getUpgradeClusterOptions(options UpgradeClusterOptions) (*client.UpgradeClusterOptions, error) {
+	var err error
+	var k8sVersion string
+
 	isPacific, err := t.tkgClient.IsPacificManagementCluster()
 	if err != nil {
-		return errors.Wrap(err, "unable to determine if management cluster is on vSphere with Tanzu")
+		return nil, errors.Wrap(err, "unable to determine if management cluster is on vSphere with Tanzu")
 	}
 
 	if isPacific {
@@ -58,21 +110,14 @@ func (t *tkgctl) UpgradeCluster(options UpgradeClusterOptions) error {
 	} else {
 		options.TkrVersion, k8sVersion, err = t.getAndDownloadTkrIfNeeded(options.TkrVersion)
 		if err != nil {
-			return errors.Wrapf(err, "unable to determine the TKr version and kubernetes version based on '%v'", options.TkrVersion)
-		}
-	}
-
-	// if --yes is set, kick off the upgrade process without waiting for confirmation
-	if !options.SkipPrompt {
-		if err := askForConfirmation(fmt.Sprintf("Upgrading workload cluster '%s' to kubernetes version '%s'. Are you sure?", options.ClusterName, k8sVersion)); err != nil {
-			return err
+			return nil, errors.Wrapf(err, "unable to determine the TKr version and kubernetes version based on '%v'", options.TkrVersion)
 		}
 	}
 
 	if options.Namespace == "" {
 		options.Namespace = constants.DefaultNamespace
 	}
-	upgradeClusterOption := client.UpgradeClusterOptions{
+	return &client.UpgradeClusterOptions{
 		ClusterName:         options.ClusterName,
 		Namespace:           options.Namespace,
 		KubernetesVersion:   k8sVersion,
@@ -84,12 +129,5 @@ func (t *tkgctl) UpgradeCluster(options UpgradeClusterOptions) error {
 		OSVersion:           options.OSVersion,
 		OSArch:              options.OSArch,
 		Edition:             options.Edition,
-	}
-	err = t.tkgClient.UpgradeCluster(&upgradeClusterOption)
-	if err != nil {
-		return err
-	}
-
-	log.Infof("Cluster '%s' successfully upgraded to kubernetes version '%s'\n", upgradeClusterOption.ClusterName, upgradeClusterOption.KubernetesVersion)
-	return nil
+	}, nil
 }
@@ -0,0 +1,192 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/log"
+)
+
+// Cluster upgrade result status constants
+const (
+	ClusterUpgradeStatusUpgraded = "Upgraded"
+	ClusterUpgradeStatusFailed   = "Failed"
+	ClusterUpgradeStatusSkipped  = "Skipped"
+)
+
+// UpgradeClustersOptions options for upgrading the workload clusters matching a label selector in waves
+type UpgradeClustersOptions struct {
+	// UpgradeClusterOptions are the options used to upgrade each cluster, the cluster name is ignored
+	UpgradeClusterOptions
+	// Selector is the label selector the workload clusters to upgrade must match
+	Selector string
+	// MaxConcurrent is the number of clusters upgraded at the same time in a wave
+	MaxConcurrent int
+	// FailureThreshold is the number of failed cluster upgrades after which no further wave is started
+	FailureThreshold int
+	// ResolveTkrVersion returns the TKr version to upgrade the cluster to, TkrVersion is used if not set
+	ResolveTkrVersion func(cluster client.ClusterInfo) (string, error)
+}
+
+// ClusterUpgradeResult describes the outcome of the upgrade of a cluster in a batch upgrade
+type ClusterUpgradeResult struct {
+	Name              string `json:"name" yaml:"name"`
+	Namespace         string `json:"namespace" yaml:"namespace"`
+	Wave              int    `json:"wave" yaml:"wave"`
+	Status            string `json:"status" yaml:"status"`
+	KubernetesVersion string `json:"kubernetes,omitempty" yaml:"kubernetes,omitempty"`
+	Duration          string `json:"duration,omitempty" yaml:"duration,omitempty"`
+	Error             string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+//nolint:gocritic
+// UpgradeClusters upgrades the tkg workload clusters matching the label selector in waves of
+// at most MaxConcurrent clusters. No further wave is started once FailureThreshold upgrades failed.
+// The result of every matching cluster is returned, along with an error if any upgrade failed.
+func (t *tkgctl) UpgradeClusters(options UpgradeClustersOptions) ([]ClusterUpgradeResult, error) {
+	selector, err := labels.Parse(options.Selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid label selector '%s'", options.Selector)
+	}
+	if options.MaxConcurrent < 1 {
+		options.MaxConcurrent = 1
+	}
+	if options.FailureThreshold < 1 {
+		options.FailureThreshold = 1
+	}
+
+	clusters, err := t.GetClusters(ListTKGClustersOptions{Namespace: options.Namespace})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list workload clusters")
+	}
+	matchingClusters := []client.ClusterInfo{}
+	for i := range clusters {
+		if selector.Matches(labels.Set(clusters[i].Labels)) {
+			matchingClusters = append(matchingClusters, clusters[i])
+		}
+	}
+	if len(matchingClusters) == 0 {
+		return nil, errors.Errorf("no workload clusters match the selector '%s'", options.Selector)
+	}
+
+	if !options.SkipPrompt {
+		names := make([]string, 0, len(matchingClusters))
+		for i := range matchingClusters {
+			names = append(names, matchingClusters[i].Namespace+"/"+matchingClusters[i].Name)
+		}
+		msg := fmt.Sprintf("Upgrading %d workload clusters (%s), %d at a time. Are you sure?", len(names), strings.Join(names, ", "), options.MaxConcurrent)
+		if err := askForConfirmation(msg); err != nil {
+			return nil, err
+		}
+	}
+
+	// upgrade requires minimum 15 minutes timeout
+	minTimeoutReq := 15 * time.Minute
+	if options.Timeout < minTimeoutReq {
+		log.V(6).Infof("timeout duration of at least 15 minutes is required, using default timeout %v", constants.DefaultLongRunningOperationTimeout)
+		options.Timeout = constants.DefaultLongRunningOperationTimeout
+	}
+	defer t.restoreAfterSettingTimeout(options.Timeout)()
+
+	results := make([]ClusterUpgradeResult, len(matchingClusters))
+	for i := range matchingClusters {
+		results[i] = ClusterUpgradeResult{
+			Name:      matchingClusters[i].Name,
+			Namespace: matchingClusters[i].Namespace,
+			Wave:      i/options.MaxConcurrent + 1,
+			Status:    ClusterUpgradeStatusSkipped,
+		}
+	}
+
+	failures := 0
+	for start := 0; start < len(matchingClusters); start += options.MaxConcurrent {
+		if failures >= options.FailureThreshold {
+			log.Warningf("Stopping the upgrade rollout as %d cluster upgrades failed", failures)
+			break
+		}
+		end := start + options.MaxConcurrent
+		if end > len(matchingClusters) {
+			end = len(matchingClusters)
+		}
+		log.Infof("Upgrading wave %d of %d (%d clusters)...", results[start].Wave, results[len(results)-1].Wave, end-start)
+		t.upgradeClustersWave(matchingClusters[start:end], results[start:end], &options)
+		for i := start; i < end; i++ {
+			if results[i].Status == ClusterUpgradeStatusFailed {
+				failures++
+			}
+		}
+	}
+
+	if failures > 0 {
+		return results, errors.Errorf("%d of %d cluster upgrades failed", failures, len(results))
+	}
+	return results, nil
+}
+
+// upgradeClustersWave upgrades the clusters concurrently and records the outcome of each upgrade in results.
+// The upgrade options are resolved one cluster at a time, as resolving them may download the TKr BOM.
+// Each upgrade uses its own tkg client, as the upgrade sets cluster specific values in the tkg configuration.
+func (t *tkgctl) upgradeClustersWave(clusters []client.ClusterInfo, results []ClusterUpgradeResult, options *UpgradeClustersOptions) {
+	var wg sync.WaitGroup
+	for i := range clusters {
+		upgradeClusterOptions, err := t.getBatchUpgradeClusterOptions(&clusters[i], options)
+		if err != nil {
+			results[i].Status = ClusterUpgradeStatusFailed
+			results[i].Error = err.Error()
+			log.Warningf("Unable to upgrade cluster '%s' in namespace '%s': %v", clusters[i].Name, clusters[i].Namespace, err)
+			continue
+		}
+		results[i].KubernetesVersion = upgradeClusterOptions.KubernetesVersion
+
+		tkgClient, err := t.tkgClientFactory()
+		if err != nil {
+			results[i].Status = ClusterUpgradeStatusFailed
+			results[i].Error = err.Error()
+			log.Warningf("Unable to create the client to upgrade cluster '%s' in namespace '%s': %v", clusters[i].Name, clusters[i].Namespace, err)
+			continue
+		}
+		tkgClient.ConfigureTimeout(options.Timeout)
+
+		wg.Add(1)
+		go func(tkgClient client.Client, result *ClusterUpgradeResult, upgradeClusterOptions *client.UpgradeClusterOptions) {
+			defer wg.Done()
+			log.Infof("Upgrading cluster '%s' in namespace '%s' to kubernetes version '%s'...", result.Name, result.Namespace, upgradeClusterOptions.KubernetesVersion)
+			startTime := time.Now()
+			err := tkgClient.UpgradeCluster(upgradeClusterOptions)
+			result.Duration = time.Since(startTime).Round(time.Second).String()
+			if err != nil {
+				result.Status = ClusterUpgradeStatusFailed
+				result.Error = err.Error()
+				log.Warningf("Upgrade of cluster '%s' in namespace '%s' failed: %v", result.Name, result.Namespace, err)
+				return
+			}
+			result.Status = ClusterUpgradeStatusUpgraded
+			log.Infof("Cluster '%s' in namespace '%s' successfully upgraded to kubernetes version '%s'", result.Name, result.Namespace, upgradeClusterOptions.KubernetesVersion)
+		}(tkgClient, &results[i], upgradeClusterOptions)
+	}
+	wg.Wait()
+}
+
+func (t *tkgctl) getBatchUpgradeClusterOptions(cluster *client.ClusterInfo, options *UpgradeClustersOptions) (*client.UpgradeClusterOptions, error) {
+	clusterOptions := options.UpgradeClusterOptions
+	clusterOptions.ClusterName = cluster.Name
+	clusterOptions.Namespace = cluster.Namespace
+	if options.ResolveTkrVersion != nil {
+		tkrVersion, err := options.ResolveTkrVersion(*cluster)
+		if err != nil {
+			return nil, err
+		}
+		clusterOptions.TkrVersion = tkrVersion
+	}
+	return t.getUpgradeClusterOptions(clusterOptions)
+}
@@ -0,0 +1,199 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1alpha3"
+	capibootstrapkubeadmtypesv1beta1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/v1beta1"
+	capikubeadmv1alpha3 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha3"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/client"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/constants"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/fakes"
+	fakeproviders "github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/fakes/providers"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/types"
+)
+
+var _ = Describe("Unit tests for batch upgrade of clusters", func() {
+	var (
+		ctl       tkgctl
+		tkgClient *fakes.Client
+		ops       UpgradeClustersOptions
+		results   []ClusterUpgradeResult
+		err       error
+	)
+
+	BeforeEach(func() {
+		tkgClient = &fakes.Client{}
+		tkgClient.IsPacificManagementClusterReturns(true, nil)
+		tkgClient.ListTKGClustersReturns([]client.ClusterInfo{
+			{Name: "wc-1", Namespace: "default", Labels: map[string]string{"env": "staging"}},
+			{Name: "wc-2", Namespace: "default", Labels: map[string]string{"env": "staging"}},
+			{Name: "wc-3", Namespace: "default", Labels: map[string]string{"env": "staging"}},
+			{Name: "wc-4", Namespace: "default", Labels: map[string]string{"env": "prod"}},
+		}, nil)
+		ops = UpgradeClustersOptions{
+			UpgradeClusterOptions: UpgradeClusterOptions{
+				TkrVersion: "v1.21.2---vmware.1-tkg.1",
+				SkipPrompt: true,
+			},
+			Selector:      "env=staging",
+			MaxConcurrent: 2,
+		}
+	})
+
+	JustBeforeEach(func() {
+		ctl = tkgctl{
+			configDir:  testingDir,
+			tkgClient:  tkgClient,
+			kubeconfig: "./kube",
+			tkgClientFactory: func() (client.Client, error) {
+				return tkgClient, nil
+			},
+		}
+		results, err = ctl.UpgradeClusters(ops)
+	})
+
+	Context("when the selector is invalid", func() {
+		BeforeEach(func() {
+			ops.Selector = "env in (staging"
+		})
+		It("should return an error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid label selector"))
+		})
+	})
+	Context("when no clusters match the selector", func() {
+		BeforeEach(func() {
+			ops.Selector = "env=dev"
+		})
+		It("should return an error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no workload clusters match the selector"))
+		})
+	})
+	Context("when all upgrades succeed", func() {
+		It("should upgrade the matching clusters in waves", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tkgClient.UpgradeClusterCallCount()).To(Equal(3))
+			Expect(results).To(HaveLen(3))
+			for i, wave := range []int{1, 1, 2} {
+				Expect(results[i].Wave).To(Equal(wave))
+				Expect(results[i].Status).To(Equal(ClusterUpgradeStatusUpgraded))
+			}
+		})
+	})
+	Context("when the failure threshold is reached", func() {
+		BeforeEach(func() {
+			tkgClient.UpgradeClusterStub = func(options *client.UpgradeClusterOptions) error {
+				if options.ClusterName == "wc-1" {
+					return errors.New("fake-error")
+				}
+				return nil
+			}
+		})
+		It("should not start the next wave", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("1 of 3 cluster upgrades failed"))
+			Expect(tkgClient.UpgradeClusterCallCount()).To(Equal(2))
+			Expect(results[0].Status).To(Equal(ClusterUpgradeStatusFailed))
+			Expect(results[0].Error).To(Equal("fake-error"))
+			Expect(results[1].Status).To(Equal(ClusterUpgradeStatusUpgraded))
+			Expect(results[2].Status).To(Equal(ClusterUpgradeStatusSkipped))
+		})
+	})
+})
+
+var _ = Describe("Unit tests for the tkg clients of a batch upgrade", func() {
+	var (
+		configDir string
+		ctl       *tkgctl
+	)
+
+	BeforeEach(func() {
+		var err error
+		configDir, err = os.MkdirTemp("", "batch_upgrade_test")
+		Expect(err).ToNot(HaveOccurred())
+		prepareConfiDir(configDir)
+		tkgctlClient, err := New(Options{
+			ConfigDir:      configDir,
+			ProviderGetter: fakeproviders.FakeProviderGetter(),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		ctl = tkgctlClient.(*tkgctl)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(configDir)
+	})
+
+	It("should not share the configuration set by concurrent upgrades", func() {
+		osNames := []string{"photon", "ubuntu"}
+		tkgClients := make([]*client.TkgClient, len(osNames))
+		errs := make([]error, len(osNames))
+		var wg sync.WaitGroup
+		for i := range osNames {
+			tkgClient, err := ctl.tkgClientFactory()
+			Expect(err).ToNot(HaveOccurred())
+			tkgClients[i] = tkgClient.(*client.TkgClient)
+
+			regionalClusterClient, currentClusterClient := getBatchUpgradeClusterClients()
+			options := &client.UpgradeClusterOptions{
+				ClusterName:       fmt.Sprintf("wc-%d", i),
+				Namespace:         "default",
+				KubernetesVersion: "v1.18.0+vmware.1",
+				TkrVersion:        "v1.18.0+vmware.1-tkg.2",
+				OSName:            osNames[i],
+				OSVersion:         "20.04",
+				OSArch:            "amd64",
+				SkipAddonUpgrade:  true,
+			}
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = tkgClients[i].DoClusterUpgrade(regionalClusterClient, currentClusterClient, options)
+			}(i)
+		}
+		wg.Wait()
+
+		for i := range osNames {
+			Expect(errs[i]).ToNot(HaveOccurred())
+			osName, err := tkgClients[i].TKGConfigReaderWriter().Get(constants.ConfigVariableOSName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(osName).To(Equal(osNames[i]))
+		}
+	})
+})
+
+func getBatchUpgradeClusterClients() (*fakes.ClusterClient, *fakes.ClusterClient) {
+	kcp := &capikubeadmv1alpha3.KubeadmControlPlane{}
+	kcp.Name = "fake-kcp"
+	kcp.Namespace = "default"
+	kcp.Spec.Version = "v1.17.3+vmware.2"
+	kcp.Spec.InfrastructureTemplate = corev1.ObjectReference{Name: "fake-template", Namespace: "default", Kind: constants.VSphereMachineTemplate}
+	kcp.Spec.KubeadmConfigSpec.ClusterConfiguration = &capibootstrapkubeadmtypesv1beta1.ClusterConfiguration{
+		Etcd: capibootstrapkubeadmtypesv1beta1.Etcd{Local: &capibootstrapkubeadmtypesv1beta1.LocalEtcd{}},
+	}
+	md := capi.MachineDeployment{}
+	md.Name = "fake-md"
+	md.Namespace = "default"
+
+	vcClient := &fakes.VCClient{}
+	vcClient.GetAndValidateVirtualMachineTemplateReturns(&types.VSphereVirtualMachine{}, nil)
+	regionalClusterClient := &fakes.ClusterClient{}
+	regionalClusterClient.GetVCClientAndDataCenterReturns(vcClient, "", nil)
+	regionalClusterClient.GetKCPObjectForClusterReturns(kcp, nil)
+	regionalClusterClient.GetMDObjectForClusterReturns([]capi.MachineDeployment{md}, nil)
+	currentClusterClient := &fakes.ClusterClient{}
+	currentClusterClient.GetKubernetesVersionReturns("v1.17.3+vmware.2", nil)
+	return regionalClusterClient, currentClusterClient
+}
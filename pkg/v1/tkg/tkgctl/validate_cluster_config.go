@@ -0,0 +1,47 @@
+// Copyright 2021 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package tkgctl
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/clusterspec"
+	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkg/tkgconfigschema"
+)
+
+// ValidateClusterConfigOptions options for validating a cluster configuration file
+type ValidateClusterConfigOptions struct {
+	ClusterConfigFile string
+}
+
+// ValidateClusterConfig validates the config variables of a cluster configuration file, or of
+// the variables a cluster spec file translates into, and returns all the issues found
+func (t *tkgctl) ValidateClusterConfig(options ValidateClusterConfigOptions) ([]tkgconfigschema.Issue, error) {
+	data, err := os.ReadFile(options.ClusterConfigFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read cluster config file")
+	}
+
+	variables, err := clusterspec.ReadConfigVariables(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cluster config file '%s'", options.ClusterConfigFile)
+	}
+
+	schema, err := t.GetClusterConfigSchema()
+	if err != nil {
+		return nil, err
+	}
+	return schema.Validate(variables), nil
+}
+
+// GetClusterConfigSchema returns the schema of the config variables supported by the providers
+func (t *tkgctl) GetClusterConfigSchema() (*tkgconfigschema.Schema, error) {
+	schema, err := tkgconfigschema.Load(t.configDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to generate the cluster config schema")
+	}
+	return schema, nil
+}
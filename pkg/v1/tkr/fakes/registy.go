@@ -4,6 +4,7 @@ package fakes
 import (
 	"sync"
 
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/vmware-tanzu/tanzu-framework/pkg/v1/tkr/pkg/registry"
 )
 
@@ -37,6 +38,20 @@ type Registry struct {
 		result1 map[string][]byte
 		result2 error
 	}
+	GetImageStub        func(string, string) (v1.Image, error)
+	getImageMutex       sync.RWMutex
+	getImageArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	getImageReturns struct {
+		result1 v1.Image
+		result2 error
+	}
+	getImageReturnsOnCall map[int]struct {
+		result1 v1.Image
+		result2 error
+	}
 	ListImageTagsStub        func(string) ([]string, error)
 	listImageTagsMutex       sync.RWMutex
 	listImageTagsArgsForCall []struct {
@@ -50,6 +65,19 @@ type Registry struct {
 		result1 []string
 		result2 error
 	}
+	PushImageStub        func(string, string, v1.Image) error
+	pushImageMutex       sync.RWMutex
+	pushImageArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 v1.Image
+	}
+	pushImageReturns struct {
+		result1 error
+	}
+	pushImageReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -185,6 +213,71 @@ func (fake *Registry) GetFilesReturnsOnCall(i int, result1 map[string][]byte, re
 	}{result1, result2}
 }
 
+func (fake *Registry) GetImage(arg1 string, arg2 string) (v1.Image, error) {
+	fake.getImageMutex.Lock()
+	ret, specificReturn := fake.getImageReturnsOnCall[len(fake.getImageArgsForCall)]
+	fake.getImageArgsForCall = append(fake.getImageArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetImageStub
+	fakeReturns := fake.getImageReturns
+	fake.recordInvocation("GetImage", []interface{}{arg1, arg2})
+	fake.getImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Registry) GetImageCallCount() int {
+	fake.getImageMutex.RLock()
+	defer fake.getImageMutex.RUnlock()
+	return len(fake.getImageArgsForCall)
+}
+
+func (fake *Registry) GetImageCalls(stub func(string, string) (v1.Image, error)) {
+	fake.getImageMutex.Lock()
+	defer fake.getImageMutex.Unlock()
+	fake.GetImageStub = stub
+}
+
+func (fake *Registry) GetImageArgsForCall(i int) (string, string) {
+	fake.getImageMutex.RLock()
+	defer fake.getImageMutex.RUnlock()
+	argsForCall := fake.getImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Registry) GetImageReturns(result1 v1.Image, result2 error) {
+	fake.getImageMutex.Lock()
+	defer fake.getImageMutex.Unlock()
+	fake.GetImageStub = nil
+	fake.getImageReturns = struct {
+		result1 v1.Image
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Registry) GetImageReturnsOnCall(i int, result1 v1.Image, result2 error) {
+	fake.getImageMutex.Lock()
+	defer fake.getImageMutex.Unlock()
+	fake.GetImageStub = nil
+	if fake.getImageReturnsOnCall == nil {
+		fake.getImageReturnsOnCall = make(map[int]struct {
+			result1 v1.Image
+			result2 error
+		})
+	}
+	fake.getImageReturnsOnCall[i] = struct {
+		result1 v1.Image
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Registry) ListImageTags(arg1 string) ([]string, error) {
 	fake.listImageTagsMutex.Lock()
 	ret, specificReturn := fake.listImageTagsReturnsOnCall[len(fake.listImageTagsArgsForCall)]
@@ -249,6 +342,69 @@ func (fake *Registry) ListImageTagsReturnsOnCall(i int, result1 []string, result
 	}{result1, result2}
 }
 
+func (fake *Registry) PushImage(arg1 string, arg2 string, arg3 v1.Image) error {
+	fake.pushImageMutex.Lock()
+	ret, specificReturn := fake.pushImageReturnsOnCall[len(fake.pushImageArgsForCall)]
+	fake.pushImageArgsForCall = append(fake.pushImageArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 v1.Image
+	}{arg1, arg2, arg3})
+	stub := fake.PushImageStub
+	fakeReturns := fake.pushImageReturns
+	fake.recordInvocation("PushImage", []interface{}{arg1, arg2, arg3})
+	fake.pushImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Registry) PushImageCallCount() int {
+	fake.pushImageMutex.RLock()
+	defer fake.pushImageMutex.RUnlock()
+	return len(fake.pushImageArgsForCall)
+}
+
+func (fake *Registry) PushImageCalls(stub func(string, string, v1.Image) error) {
+	fake.pushImageMutex.Lock()
+	defer fake.pushImageMutex.Unlock()
+	fake.PushImageStub = stub
+}
+
+func (fake *Registry) PushImageArgsForCall(i int) (string, string, v1.Image) {
+	fake.pushImageMutex.RLock()
+	defer fake.pushImageMutex.RUnlock()
+	argsForCall := fake.pushImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Registry) PushImageReturns(result1 error) {
+	fake.pushImageMutex.Lock()
+	defer fake.pushImageMutex.Unlock()
+	fake.PushImageStub = nil
+	fake.pushImageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Registry) PushImageReturnsOnCall(i int, result1 error) {
+	fake.pushImageMutex.Lock()
+	defer fake.pushImageMutex.Unlock()
+	fake.PushImageStub = nil
+	if fake.pushImageReturnsOnCall == nil {
+		fake.pushImageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pushImageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Registry) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -256,8 +412,12 @@ func (fake *Registry) Invocations() map[string][][]interface{} {
 	defer fake.getFileMutex.RUnlock()
 	fake.getFilesMutex.RLock()
 	defer fake.getFilesMutex.RUnlock()
+	fake.getImageMutex.RLock()
+	defer fake.getImageMutex.RUnlock()
 	fake.listImageTagsMutex.RLock()
 	defer fake.listImageTagsMutex.RUnlock()
+	fake.pushImageMutex.RLock()
+	defer fake.pushImageMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -165,3 +165,21 @@ func getAllFilesContentFromImage(image regv1.Image) (map[string][]byte, error) {
 
 	return nil, errors.New("cannot find file from the image")
 }
+
+// GetImage gets the given image:tag.
+func (r *registry) GetImage(image, tag string) (regv1.Image, error) {
+	ref, err := regname.ParseReference(fmt.Sprintf("%s:%s", image, tag), regname.WeakValidation)
+	if err != nil {
+		return nil, err
+	}
+	return r.registry.Image(ref)
+}
+
+// PushImage pushes the given image as image:tag.
+func (r *registry) PushImage(image, tag string, img regv1.Image) error {
+	ref, err := regname.ParseReference(fmt.Sprintf("%s:%s", image, tag), regname.WeakValidation)
+	if err != nil {
+		return err
+	}
+	return r.registry.WriteImage(ref, img)
+}
@@ -3,6 +3,10 @@
 
 package registry
 
+import (
+	regv1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
 //go:generate counterfeiter -o ../../fakes/registy.go --fake-name Registry . Registry
 
 // Registry defines the Registry interface
@@ -14,4 +18,8 @@ type Registry interface {
 	GetFile(image string, tag string, filename string) ([]byte, error)
 	// GetFiles get all the files content bundled in the given image:tag.
 	GetFiles(image string, tag string) (map[string][]byte, error)
+	// GetImage gets the given image:tag.
+	GetImage(image string, tag string) (regv1.Image, error)
+	// PushImage pushes the given image as image:tag.
+	PushImage(image string, tag string, img regv1.Image) error
 }